@@ -163,6 +163,17 @@ func IsErrNoMetadata(err error) bool {
 	return strings.Contains(err.Error(), status.Convert(ErrNoMetadata).Message())
 }
 
+// S3CredentialSecrets are the secrets of an S3 credential. They are only
+// returned when the credential is created, and inside pachd to the S3
+// gateway.
+type S3CredentialSecrets struct {
+	// SecretAccessKey signs the requests made with the credential.
+	SecretAccessKey string
+	// SessionToken is the auth token the S3 gateway uses to act as the
+	// principal of the credential.
+	SessionToken string
+}
+
 // IsErrBadToken returns true if 'err' is a ErrBadToken
 func IsErrBadToken(err error) bool {
 	if err == nil {
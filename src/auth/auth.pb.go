@@ -8,8 +8,8 @@ import (
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
-	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	types "github.com/gogo/protobuf/types"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -70,6 +70,7 @@ const (
 	Permission_CLUSTER_IDENTITY_GET_OIDC_CLIENT           Permission = 128
 	Permission_CLUSTER_IDENTITY_DELETE_OIDC_CLIENT        Permission = 129
 	Permission_CLUSTER_DEBUG_DUMP                         Permission = 131
+	Permission_CLUSTER_DEBUG_SET_STORAGE_BANDWIDTH        Permission = 149
 	Permission_CLUSTER_LICENSE_ACTIVATE                   Permission = 132
 	Permission_CLUSTER_LICENSE_GET_CODE                   Permission = 133
 	Permission_CLUSTER_LICENSE_ADD_CLUSTER                Permission = 134
@@ -136,6 +137,7 @@ var Permission_name = map[int32]string{
 	128: "CLUSTER_IDENTITY_GET_OIDC_CLIENT",
 	129: "CLUSTER_IDENTITY_DELETE_OIDC_CLIENT",
 	131: "CLUSTER_DEBUG_DUMP",
+	149: "CLUSTER_DEBUG_SET_STORAGE_BANDWIDTH",
 	132: "CLUSTER_LICENSE_ACTIVATE",
 	133: "CLUSTER_LICENSE_GET_CODE",
 	134: "CLUSTER_LICENSE_ADD_CLUSTER",
@@ -201,6 +203,7 @@ var Permission_value = map[string]int32{
 	"CLUSTER_IDENTITY_GET_OIDC_CLIENT":           128,
 	"CLUSTER_IDENTITY_DELETE_OIDC_CLIENT":        129,
 	"CLUSTER_DEBUG_DUMP":                         131,
+	"CLUSTER_DEBUG_SET_STORAGE_BANDWIDTH":        149,
 	"CLUSTER_LICENSE_ACTIVATE":                   132,
 	"CLUSTER_LICENSE_GET_CODE":                   133,
 	"CLUSTER_LICENSE_ADD_CLUSTER":                134,
@@ -3053,6 +3056,411 @@ func (m *RevokeAuthTokensForUserResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_RevokeAuthTokensForUserResponse proto.InternalMessageInfo
 
+// S3Credential describes an S3 access key issued to a principal for the S3
+// gateway. The secret access key is only returned when it is created.
+type S3Credential struct {
+	AccessKeyID string `protobuf:"bytes,1,opt,name=access_key_id,json=accessKeyId,proto3" json:"access_key_id,omitempty"`
+	// principal is the subject whose permissions the credential grants.
+	Principal string `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	// read_only restricts the credential to reading objects and listing buckets.
+	ReadOnly bool `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// buckets restricts the credential to these buckets, all buckets are
+	// accessible if it is empty.
+	Buckets              []string         `protobuf:"bytes,4,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Expiration           *types.Timestamp `protobuf:"bytes,5,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Created              *types.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *S3Credential) Reset()         { *m = S3Credential{} }
+func (m *S3Credential) String() string { return proto.CompactTextString(m) }
+func (*S3Credential) ProtoMessage()    {}
+func (*S3Credential) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{55}
+}
+func (m *S3Credential) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *S3Credential) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_S3Credential.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *S3Credential) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_S3Credential.Merge(m, src)
+}
+func (m *S3Credential) XXX_Size() int {
+	return m.Size()
+}
+func (m *S3Credential) XXX_DiscardUnknown() {
+	xxx_messageInfo_S3Credential.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_S3Credential proto.InternalMessageInfo
+
+func (m *S3Credential) GetAccessKeyID() string {
+	if m != nil {
+		return m.AccessKeyID
+	}
+	return ""
+}
+
+func (m *S3Credential) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+func (m *S3Credential) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *S3Credential) GetBuckets() []string {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *S3Credential) GetExpiration() *types.Timestamp {
+	if m != nil {
+		return m.Expiration
+	}
+	return nil
+}
+
+func (m *S3Credential) GetCreated() *types.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+type CreateS3CredentialsRequest struct {
+	// principal defaults to the caller. Creating credentials for another
+	// principal requires the same permission as GetRobotToken.
+	Principal string   `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+	ReadOnly  bool     `protobuf:"varint,2,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	Buckets   []string `protobuf:"bytes,3,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	// ttl is the lifetime of the credential in seconds. A credential never
+	// outlives the token of the caller which created it for itself.
+	TTL                  int64    `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateS3CredentialsRequest) Reset()         { *m = CreateS3CredentialsRequest{} }
+func (m *CreateS3CredentialsRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateS3CredentialsRequest) ProtoMessage()    {}
+func (*CreateS3CredentialsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{56}
+}
+func (m *CreateS3CredentialsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateS3CredentialsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateS3CredentialsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CreateS3CredentialsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateS3CredentialsRequest.Merge(m, src)
+}
+func (m *CreateS3CredentialsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateS3CredentialsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateS3CredentialsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateS3CredentialsRequest proto.InternalMessageInfo
+
+func (m *CreateS3CredentialsRequest) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+func (m *CreateS3CredentialsRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *CreateS3CredentialsRequest) GetBuckets() []string {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *CreateS3CredentialsRequest) GetTTL() int64 {
+	if m != nil {
+		return m.TTL
+	}
+	return 0
+}
+
+type CreateS3CredentialsResponse struct {
+	Credential           *S3Credential `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	SecretAccessKey      string        `protobuf:"bytes,2,opt,name=secret_access_key,json=secretAccessKey,proto3" json:"secret_access_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CreateS3CredentialsResponse) Reset()         { *m = CreateS3CredentialsResponse{} }
+func (m *CreateS3CredentialsResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateS3CredentialsResponse) ProtoMessage()    {}
+func (*CreateS3CredentialsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{57}
+}
+func (m *CreateS3CredentialsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateS3CredentialsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateS3CredentialsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CreateS3CredentialsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateS3CredentialsResponse.Merge(m, src)
+}
+func (m *CreateS3CredentialsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateS3CredentialsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateS3CredentialsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateS3CredentialsResponse proto.InternalMessageInfo
+
+func (m *CreateS3CredentialsResponse) GetCredential() *S3Credential {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *CreateS3CredentialsResponse) GetSecretAccessKey() string {
+	if m != nil {
+		return m.SecretAccessKey
+	}
+	return ""
+}
+
+type ListS3CredentialsRequest struct {
+	// principal defaults to the caller. Listing the credentials of another
+	// principal requires the same permission as GetRobotToken.
+	Principal            string   `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListS3CredentialsRequest) Reset()         { *m = ListS3CredentialsRequest{} }
+func (m *ListS3CredentialsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListS3CredentialsRequest) ProtoMessage()    {}
+func (*ListS3CredentialsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{58}
+}
+func (m *ListS3CredentialsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListS3CredentialsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListS3CredentialsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListS3CredentialsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListS3CredentialsRequest.Merge(m, src)
+}
+func (m *ListS3CredentialsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListS3CredentialsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListS3CredentialsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListS3CredentialsRequest proto.InternalMessageInfo
+
+func (m *ListS3CredentialsRequest) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+type ListS3CredentialsResponse struct {
+	Credentials          []*S3Credential `protobuf:"bytes,1,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListS3CredentialsResponse) Reset()         { *m = ListS3CredentialsResponse{} }
+func (m *ListS3CredentialsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListS3CredentialsResponse) ProtoMessage()    {}
+func (*ListS3CredentialsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{59}
+}
+func (m *ListS3CredentialsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListS3CredentialsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListS3CredentialsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListS3CredentialsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListS3CredentialsResponse.Merge(m, src)
+}
+func (m *ListS3CredentialsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListS3CredentialsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListS3CredentialsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListS3CredentialsResponse proto.InternalMessageInfo
+
+func (m *ListS3CredentialsResponse) GetCredentials() []*S3Credential {
+	if m != nil {
+		return m.Credentials
+	}
+	return nil
+}
+
+type RevokeS3CredentialsRequest struct {
+	AccessKeyID          string   `protobuf:"bytes,1,opt,name=access_key_id,json=accessKeyId,proto3" json:"access_key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeS3CredentialsRequest) Reset()         { *m = RevokeS3CredentialsRequest{} }
+func (m *RevokeS3CredentialsRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeS3CredentialsRequest) ProtoMessage()    {}
+func (*RevokeS3CredentialsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{60}
+}
+func (m *RevokeS3CredentialsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevokeS3CredentialsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevokeS3CredentialsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RevokeS3CredentialsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeS3CredentialsRequest.Merge(m, src)
+}
+func (m *RevokeS3CredentialsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevokeS3CredentialsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeS3CredentialsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeS3CredentialsRequest proto.InternalMessageInfo
+
+func (m *RevokeS3CredentialsRequest) GetAccessKeyID() string {
+	if m != nil {
+		return m.AccessKeyID
+	}
+	return ""
+}
+
+type RevokeS3CredentialsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeS3CredentialsResponse) Reset()         { *m = RevokeS3CredentialsResponse{} }
+func (m *RevokeS3CredentialsResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeS3CredentialsResponse) ProtoMessage()    {}
+func (*RevokeS3CredentialsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_712ec48c1eaf43a2, []int{61}
+}
+func (m *RevokeS3CredentialsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevokeS3CredentialsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevokeS3CredentialsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RevokeS3CredentialsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeS3CredentialsResponse.Merge(m, src)
+}
+func (m *RevokeS3CredentialsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevokeS3CredentialsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeS3CredentialsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeS3CredentialsResponse proto.InternalMessageInfo
+
 type DeleteExpiredAuthTokensRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -3063,7 +3471,7 @@ func (m *DeleteExpiredAuthTokensRequest) Reset()         { *m = DeleteExpiredAut
 func (m *DeleteExpiredAuthTokensRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteExpiredAuthTokensRequest) ProtoMessage()    {}
 func (*DeleteExpiredAuthTokensRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_712ec48c1eaf43a2, []int{55}
+	return fileDescriptor_712ec48c1eaf43a2, []int{62}
 }
 func (m *DeleteExpiredAuthTokensRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3102,7 +3510,7 @@ func (m *DeleteExpiredAuthTokensResponse) Reset()         { *m = DeleteExpiredAu
 func (m *DeleteExpiredAuthTokensResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteExpiredAuthTokensResponse) ProtoMessage()    {}
 func (*DeleteExpiredAuthTokensResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_712ec48c1eaf43a2, []int{56}
+	return fileDescriptor_712ec48c1eaf43a2, []int{63}
 }
 func (m *DeleteExpiredAuthTokensResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3193,6 +3601,13 @@ func init() {
 	proto.RegisterType((*RestoreAuthTokenResponse)(nil), "auth_v2.RestoreAuthTokenResponse")
 	proto.RegisterType((*RevokeAuthTokensForUserRequest)(nil), "auth_v2.RevokeAuthTokensForUserRequest")
 	proto.RegisterType((*RevokeAuthTokensForUserResponse)(nil), "auth_v2.RevokeAuthTokensForUserResponse")
+	proto.RegisterType((*S3Credential)(nil), "auth_v2.S3Credential")
+	proto.RegisterType((*CreateS3CredentialsRequest)(nil), "auth_v2.CreateS3CredentialsRequest")
+	proto.RegisterType((*CreateS3CredentialsResponse)(nil), "auth_v2.CreateS3CredentialsResponse")
+	proto.RegisterType((*ListS3CredentialsRequest)(nil), "auth_v2.ListS3CredentialsRequest")
+	proto.RegisterType((*ListS3CredentialsResponse)(nil), "auth_v2.ListS3CredentialsResponse")
+	proto.RegisterType((*RevokeS3CredentialsRequest)(nil), "auth_v2.RevokeS3CredentialsRequest")
+	proto.RegisterType((*RevokeS3CredentialsResponse)(nil), "auth_v2.RevokeS3CredentialsResponse")
 	proto.RegisterType((*DeleteExpiredAuthTokensRequest)(nil), "auth_v2.DeleteExpiredAuthTokensRequest")
 	proto.RegisterType((*DeleteExpiredAuthTokensResponse)(nil), "auth_v2.DeleteExpiredAuthTokensResponse")
 }
@@ -3200,183 +3615,200 @@ func init() {
 func init() { proto.RegisterFile("auth/auth.proto", fileDescriptor_712ec48c1eaf43a2) }
 
 var fileDescriptor_712ec48c1eaf43a2 = []byte{
-	// 2809 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0xe9, 0x73, 0xdb, 0xc6,
-	0x15, 0x37, 0x24, 0x5b, 0x22, 0x9f, 0x2e, 0x78, 0x45, 0x49, 0x14, 0x64, 0x89, 0x12, 0x1c, 0xc7,
-	0x47, 0x1b, 0x29, 0x51, 0x9a, 0xd6, 0x49, 0xdc, 0x0f, 0x3c, 0x20, 0x1a, 0x09, 0x45, 0x72, 0x00,
-	0xd0, 0x8e, 0x3b, 0x9d, 0xa2, 0x14, 0xb9, 0x96, 0x50, 0x4b, 0x04, 0x03, 0x80, 0xaa, 0x95, 0x36,
-	0x6d, 0xd3, 0xfb, 0x4e, 0x7a, 0x4c, 0xff, 0x8b, 0x7e, 0x69, 0xff, 0x89, 0xf4, 0x4e, 0xcf, 0x8f,
-	0x6e, 0x46, 0x7f, 0x42, 0xa7, 0xd3, 0xcf, 0x9d, 0x5d, 0x2c, 0x80, 0x05, 0x08, 0xca, 0x4e, 0x32,
-	0xf9, 0x22, 0x61, 0xdf, 0xfb, 0xbd, 0x63, 0xdf, 0xbe, 0x5d, 0xbc, 0x7d, 0x20, 0xcc, 0xb5, 0x07,
-	0xde, 0xc1, 0x16, 0xf9, 0xb3, 0xd9, 0x77, 0x6c, 0xcf, 0x46, 0x93, 0xe4, 0xd9, 0x3c, 0xde, 0x96,
-	0x72, 0xfb, 0xf6, 0xbe, 0x4d, 0x69, 0x5b, 0xe4, 0xc9, 0x67, 0x4b, 0x85, 0x7d, 0xdb, 0xde, 0x3f,
-	0xc4, 0x5b, 0x74, 0xb4, 0x37, 0xb8, 0xbf, 0xe5, 0x59, 0x47, 0xd8, 0xf5, 0xda, 0x47, 0x7d, 0x1f,
-	0x20, 0x3f, 0x0b, 0x73, 0xc5, 0x8e, 0x67, 0x1d, 0xb7, 0x3d, 0xac, 0xe1, 0xd7, 0x07, 0xd8, 0xf5,
-	0xd0, 0x2a, 0x80, 0x63, 0xdb, 0x9e, 0xe9, 0xd9, 0x0f, 0x70, 0x2f, 0x2f, 0xac, 0x0b, 0xd7, 0xb2,
-	0x5a, 0x96, 0x50, 0x0c, 0x42, 0x90, 0x9f, 0x03, 0x31, 0x92, 0x70, 0xfb, 0x76, 0xcf, 0xc5, 0x44,
-	0xa4, 0xdf, 0xee, 0x1c, 0xc4, 0x45, 0x08, 0xc5, 0x17, 0x99, 0x87, 0x8b, 0x15, 0xdc, 0x8e, 0x9b,
-	0x91, 0x73, 0x80, 0x78, 0xa2, 0xaf, 0x49, 0xfe, 0x0c, 0x2c, 0x6a, 0xb6, 0x47, 0x28, 0x81, 0xc1,
-	0x27, 0x74, 0xeb, 0x26, 0x2c, 0x0d, 0x09, 0x46, 0xde, 0x9d, 0x25, 0xf9, 0xfe, 0x18, 0x40, 0x43,
-	0xad, 0x94, 0xcb, 0x76, 0xef, 0xbe, 0xb5, 0x8f, 0x16, 0x61, 0xc2, 0x72, 0xdd, 0x01, 0x76, 0x18,
-	0x92, 0x8d, 0xd0, 0x75, 0xc8, 0x76, 0x0e, 0x2d, 0xdc, 0xf3, 0x4c, 0xab, 0x9b, 0x1f, 0x23, 0xac,
-	0xd2, 0xf4, 0xe9, 0xa3, 0x42, 0xa6, 0x4c, 0x89, 0x6a, 0x45, 0xcb, 0xf8, 0x6c, 0xb5, 0x8b, 0x2e,
-	0xc3, 0x0c, 0x83, 0xba, 0xb8, 0xe3, 0x60, 0x2f, 0x3f, 0x4e, 0x35, 0x4d, 0xfb, 0x44, 0x9d, 0xd2,
-	0xd0, 0x36, 0x4c, 0x3b, 0xb8, 0x6b, 0x39, 0xb8, 0xe3, 0x99, 0x03, 0xc7, 0xca, 0x9f, 0xa7, 0x2a,
-	0xe7, 0x4e, 0x1f, 0x15, 0xa6, 0x34, 0x46, 0x6f, 0x69, 0xaa, 0x36, 0x15, 0x80, 0x5a, 0x8e, 0x45,
-	0x7c, 0x73, 0x3b, 0x76, 0x1f, 0xbb, 0xf9, 0x0b, 0xeb, 0xe3, 0xc4, 0x37, 0x7f, 0x84, 0x3e, 0x05,
-	0x8b, 0x0e, 0x7e, 0x7d, 0x60, 0x39, 0xd8, 0xc4, 0x47, 0x6d, 0xeb, 0xd0, 0x3c, 0xc6, 0x8e, 0x75,
-	0xdf, 0xc2, 0xdd, 0xfc, 0xc4, 0xba, 0x70, 0x2d, 0xa3, 0xe5, 0x18, 0x57, 0x21, 0xcc, 0x3b, 0x8c,
-	0x87, 0xae, 0x83, 0x78, 0x68, 0x77, 0xda, 0x87, 0x07, 0xb6, 0xeb, 0x99, 0x6c, 0xce, 0x93, 0x14,
-	0x3f, 0x17, 0xd2, 0x55, 0x7f, 0xf2, 0x9f, 0x85, 0x95, 0x81, 0x8b, 0x1d, 0xb3, 0xdd, 0xe9, 0x60,
-	0xd7, 0xb5, 0xf6, 0x0e, 0x31, 0x13, 0x30, 0x09, 0x28, 0x9f, 0xa1, 0xf3, 0xcb, 0x13, 0x48, 0x31,
-	0x44, 0xf8, 0xa2, 0xb7, 0x6d, 0xd7, 0x93, 0x97, 0x61, 0xa9, 0x8a, 0x3d, 0x3f, 0xc0, 0x03, 0xa7,
-	0xed, 0x59, 0x76, 0xb0, 0xac, 0x72, 0x0b, 0xf2, 0xc3, 0x2c, 0xb6, 0x70, 0x2f, 0xc2, 0x4c, 0x87,
-	0x67, 0xd0, 0x15, 0x99, 0xda, 0x9e, 0xdf, 0x64, 0x49, 0xbf, 0x19, 0x2d, 0x9b, 0x16, 0x47, 0xca,
-	0x06, 0x2c, 0xe9, 0xe9, 0x16, 0x3f, 0x8a, 0x56, 0x09, 0xf2, 0xfa, 0x08, 0x67, 0xe5, 0xdf, 0x08,
-	0x90, 0xa5, 0x09, 0xa5, 0xf6, 0xee, 0xdb, 0x28, 0x0f, 0x93, 0xee, 0x60, 0xef, 0x4b, 0xb8, 0xe3,
-	0xb1, 0x34, 0x0a, 0x86, 0x48, 0x07, 0xc0, 0x0f, 0xfb, 0x16, 0xb3, 0x3d, 0x46, 0x6d, 0x4b, 0x9b,
-	0xfe, 0x3e, 0xdd, 0x0c, 0xf6, 0xe9, 0xa6, 0x11, 0xec, 0xd3, 0xd2, 0xd2, 0x7f, 0x1e, 0x15, 0xe6,
-	0xba, 0x7b, 0x2f, 0xc9, 0x91, 0x94, 0xfc, 0xce, 0xbf, 0x0b, 0x82, 0xc6, 0xa9, 0x41, 0x9f, 0x86,
-	0xe9, 0x83, 0xb6, 0x7b, 0x80, 0xbb, 0x2c, 0xc9, 0x69, 0xc2, 0x95, 0xe6, 0x03, 0x51, 0x4a, 0x34,
-	0x09, 0x42, 0xd6, 0xa6, 0x7c, 0xa0, 0x9f, 0xfb, 0x5f, 0x80, 0xf9, 0xe2, 0xc0, 0x3b, 0xc0, 0x3d,
-	0xcf, 0xea, 0x70, 0x47, 0xc0, 0x27, 0x01, 0x6c, 0xab, 0xdb, 0x31, 0x5d, 0xb2, 0xa1, 0xfc, 0x09,
-	0x94, 0x66, 0x4e, 0x1f, 0x15, 0xb2, 0x24, 0x34, 0x3a, 0xdd, 0x65, 0x59, 0x02, 0xa0, 0x8f, 0x68,
-	0x19, 0x32, 0x56, 0x60, 0x78, 0xcc, 0x9f, 0xac, 0xc5, 0xf4, 0xbf, 0x00, 0xb9, 0xb8, 0xfe, 0x27,
-	0x3b, 0x30, 0xe6, 0x60, 0xe6, 0xee, 0x81, 0x5d, 0x3c, 0x52, 0x83, 0x2c, 0x79, 0x4b, 0x80, 0xd9,
-	0x80, 0xc2, 0x54, 0x48, 0x90, 0x21, 0xf9, 0xd6, 0x6b, 0x1f, 0x31, 0x0f, 0xb5, 0x70, 0xfc, 0xb1,
-	0xc4, 0x58, 0xd6, 0xe1, 0x52, 0x15, 0x7b, 0x9a, 0x7d, 0x88, 0xdd, 0x1d, 0xdb, 0x69, 0x62, 0xe7,
-	0xc8, 0x72, 0x5d, 0x2e, 0xaf, 0x9e, 0x07, 0xe8, 0x87, 0x44, 0xea, 0xd2, 0x2c, 0x97, 0x54, 0x1c,
-	0x9e, 0x83, 0xc9, 0x15, 0x58, 0x1d, 0xa1, 0x94, 0x4d, 0xf3, 0x32, 0x5c, 0x70, 0x08, 0x37, 0x2f,
-	0xac, 0x8f, 0x5f, 0x9b, 0xda, 0x9e, 0x09, 0x15, 0x12, 0x19, 0xcd, 0xe7, 0xc9, 0x0e, 0x5c, 0xa0,
-	0x2a, 0xd0, 0x56, 0x1c, 0xbd, 0x1c, 0x43, 0xbb, 0xfe, 0x5f, 0xa5, 0xe7, 0x39, 0x27, 0x4c, 0x52,
-	0xba, 0x09, 0x10, 0x11, 0x91, 0x08, 0xe3, 0x0f, 0xf0, 0x09, 0x0b, 0x27, 0x79, 0x44, 0x39, 0xb8,
-	0x70, 0xdc, 0x3e, 0x1c, 0x60, 0x1a, 0xc4, 0x8c, 0xe6, 0x0f, 0x5e, 0x1a, 0xbb, 0x29, 0xc8, 0xbf,
-	0x12, 0x60, 0x8a, 0x88, 0x96, 0xac, 0x5e, 0xd7, 0xea, 0xed, 0xa3, 0x97, 0x61, 0x12, 0xf7, 0x3c,
-	0xc7, 0x0a, 0x8d, 0x6f, 0xc4, 0x8c, 0x33, 0xd8, 0xa6, 0xe2, 0x63, 0x7c, 0x27, 0x02, 0x09, 0xe9,
-	0x15, 0x98, 0xe6, 0x19, 0x29, 0x8e, 0x3c, 0xc5, 0x3b, 0x32, 0xb5, 0x3d, 0x1b, 0x9f, 0x19, 0xef,
-	0x98, 0x0a, 0x19, 0x0d, 0xbb, 0xf6, 0xc0, 0xe9, 0x60, 0x74, 0x1d, 0xce, 0x7b, 0x27, 0x7d, 0xcc,
-	0x56, 0x63, 0x21, 0x12, 0x62, 0x00, 0xe3, 0xa4, 0x8f, 0x35, 0x0a, 0x41, 0x08, 0xce, 0xd3, 0x5c,
-	0xf2, 0x33, 0x98, 0x3e, 0xcb, 0xdf, 0x14, 0xe0, 0x42, 0xcb, 0xc5, 0x8e, 0x8b, 0x5e, 0x86, 0x6c,
-	0x90, 0x5d, 0xc1, 0xfc, 0x56, 0x43, 0x6d, 0x14, 0x42, 0xff, 0x52, 0xbe, 0x3f, 0xb7, 0x08, 0x2f,
-	0xdd, 0x82, 0xd9, 0x38, 0xf3, 0x03, 0x05, 0xfa, 0x21, 0x4c, 0x54, 0x1d, 0x7b, 0xd0, 0x77, 0xd1,
-	0xf3, 0x30, 0xb1, 0x4f, 0x9f, 0x98, 0x07, 0x2b, 0xa1, 0x07, 0x3e, 0x80, 0xfd, 0xf3, 0xed, 0x33,
-	0xa8, 0xf4, 0x22, 0x4c, 0x71, 0xe4, 0x0f, 0x64, 0xf9, 0x6d, 0x01, 0xce, 0x93, 0xf0, 0x86, 0xb1,
-	0x11, 0xa2, 0xd8, 0xa0, 0x17, 0x60, 0x2a, 0xca, 0x63, 0x37, 0x3f, 0xb6, 0x3e, 0x3e, 0x2a, 0xdf,
-	0x79, 0x1c, 0xba, 0x05, 0xb3, 0x0e, 0x0b, 0xbe, 0x49, 0xe2, 0xee, 0xe6, 0xc7, 0xa9, 0xe4, 0x88,
-	0xb5, 0x99, 0x71, 0xb8, 0x91, 0x2b, 0x3f, 0x04, 0x91, 0x9c, 0x27, 0xb6, 0x63, 0xbd, 0x11, 0x1e,
-	0x56, 0xcf, 0x40, 0x26, 0x00, 0xb1, 0xa3, 0xfc, 0xe2, 0x90, 0x2e, 0x2d, 0x84, 0x7c, 0x48, 0xbf,
-	0xe5, 0xdf, 0x0a, 0x70, 0x91, 0x33, 0xcd, 0x76, 0xe7, 0x1a, 0x40, 0x3b, 0x20, 0x76, 0xa9, 0xf5,
-	0x8c, 0xc6, 0x51, 0xd0, 0x73, 0x90, 0x75, 0xdb, 0x9e, 0xe5, 0xd2, 0x77, 0xf1, 0x19, 0xa6, 0x22,
-	0x14, 0x7a, 0x06, 0x26, 0x29, 0xb5, 0xb7, 0xcf, 0x22, 0x93, 0x2a, 0x10, 0x60, 0xd0, 0x25, 0xc8,
-	0xf6, 0x1d, 0xab, 0xd7, 0xb1, 0xfa, 0xed, 0x43, 0xbf, 0x86, 0xd0, 0x22, 0x82, 0xbc, 0x03, 0x0b,
-	0x55, 0xec, 0x45, 0x72, 0xee, 0x87, 0x0b, 0x9a, 0xdc, 0x87, 0x8d, 0xb8, 0x1e, 0x72, 0x58, 0x05,
-	0x56, 0x3e, 0xe4, 0x42, 0xc4, 0x3c, 0x1f, 0x4b, 0x7a, 0x8e, 0x61, 0x31, 0xe9, 0x39, 0x8b, 0x79,
-	0x62, 0x01, 0x85, 0x27, 0x4c, 0xbc, 0x5c, 0x70, 0x34, 0x8e, 0xd1, 0xd2, 0x89, 0x9d, 0x9c, 0x6f,
-	0x42, 0x7e, 0xd7, 0xee, 0x5a, 0xf7, 0x4f, 0xb8, 0x33, 0xea, 0xe3, 0x98, 0x4f, 0x64, 0x7e, 0x9c,
-	0x37, 0xbf, 0x02, 0xcb, 0x29, 0xe6, 0x59, 0x45, 0xe1, 0x2f, 0xde, 0x47, 0x76, 0x4c, 0xbe, 0x4d,
-	0x43, 0x99, 0x62, 0x01, 0x6d, 0xc2, 0xe4, 0x9e, 0x4f, 0x62, 0x7a, 0x72, 0x69, 0x67, 0xb6, 0x16,
-	0x80, 0xe4, 0x2f, 0xc2, 0x94, 0x8e, 0x69, 0x3c, 0x69, 0x91, 0x93, 0x83, 0x0b, 0x3d, 0xbb, 0xd7,
-	0x09, 0xce, 0x05, 0x7f, 0x40, 0xa8, 0xb4, 0x08, 0x65, 0x31, 0xf0, 0x07, 0xe8, 0x0a, 0xcc, 0x76,
-	0xec, 0xde, 0x31, 0x76, 0x88, 0xb4, 0x89, 0x1d, 0x87, 0xd6, 0x28, 0x19, 0x5a, 0x61, 0x31, 0xaa,
-	0xe2, 0x38, 0xf2, 0x02, 0xcc, 0x57, 0xb1, 0x47, 0xca, 0x8c, 0x9a, 0xbd, 0x6f, 0x85, 0x55, 0xe2,
-	0x5d, 0xc8, 0xc5, 0xc9, 0x6c, 0x02, 0xd7, 0x21, 0x7b, 0x48, 0x08, 0xe6, 0xc0, 0x39, 0x64, 0x75,
-	0x0a, 0x2d, 0xca, 0x29, 0xaa, 0xa5, 0xd5, 0xb4, 0x0c, 0x65, 0xb7, 0x1c, 0xba, 0x00, 0x7e, 0x39,
-	0xc3, 0xdc, 0xa2, 0x03, 0xb9, 0x4a, 0x15, 0x6b, 0xf6, 0x5e, 0xe2, 0xb6, 0x41, 0x97, 0x6b, 0xcf,
-	0x0e, 0xaa, 0x37, 0x7f, 0x80, 0x96, 0x61, 0xdc, 0xf3, 0xfc, 0x89, 0x8d, 0x97, 0x26, 0x4f, 0x1f,
-	0x15, 0xc6, 0x0d, 0xa3, 0xa6, 0x11, 0x9a, 0xfc, 0x0c, 0x5b, 0xac, 0xbd, 0xe4, 0xed, 0x23, 0x07,
-	0x17, 0xf8, 0x2a, 0xc7, 0x1f, 0xc8, 0x9b, 0xb0, 0xa8, 0xe1, 0x63, 0xfb, 0x01, 0x26, 0x67, 0x4a,
-	0xd2, 0x72, 0x0a, 0x7e, 0x19, 0x96, 0x86, 0xf0, 0x2c, 0x4d, 0x76, 0x69, 0xa9, 0xeb, 0x9f, 0xf1,
-	0x3b, 0xb6, 0x43, 0xde, 0x34, 0x81, 0xae, 0xb3, 0x6a, 0xa4, 0xc5, 0xf0, 0x65, 0xe2, 0x6f, 0x08,
-	0x36, 0x62, 0x35, 0x6e, 0x42, 0x1d, 0x33, 0x75, 0x07, 0x72, 0x7e, 0xba, 0xee, 0xe2, 0xa3, 0x3d,
-	0xec, 0xb8, 0x9c, 0xcf, 0x54, 0x3a, 0xf0, 0x99, 0x0e, 0xc8, 0xab, 0xa6, 0xdd, 0xed, 0x32, 0xf5,
-	0xe4, 0x91, 0xd8, 0x74, 0xf0, 0x91, 0x7d, 0x8c, 0xd9, 0x2e, 0x60, 0x23, 0x79, 0x09, 0x16, 0x12,
-	0x7a, 0x99, 0x41, 0x04, 0x62, 0x35, 0x70, 0x26, 0xc8, 0x85, 0x5b, 0xb4, 0x0e, 0x0b, 0x1d, 0x1c,
-	0x3a, 0x86, 0x62, 0xfb, 0x50, 0x48, 0x9e, 0x2b, 0x9f, 0x80, 0x8b, 0x9c, 0x46, 0xb6, 0x46, 0x8b,
-	0xb1, 0x17, 0x6b, 0x14, 0x8b, 0xab, 0x30, 0x57, 0xc5, 0x1e, 0x7d, 0xbd, 0x9f, 0x39, 0x55, 0xf9,
-	0x59, 0xea, 0x27, 0x03, 0x32, 0xa5, 0x97, 0x92, 0x25, 0x43, 0x96, 0xab, 0x09, 0x48, 0x98, 0x95,
-	0x87, 0x9e, 0xd3, 0xee, 0x78, 0xe1, 0x8a, 0x86, 0x33, 0xac, 0xc2, 0x72, 0x0a, 0x8f, 0xa9, 0xbd,
-	0x01, 0x13, 0x34, 0x25, 0x82, 0x22, 0x00, 0x85, 0x5b, 0x36, 0xbc, 0x7d, 0x68, 0x0c, 0x21, 0x97,
-	0x49, 0xd6, 0xb8, 0x9e, 0xed, 0x0c, 0xa7, 0xd9, 0x35, 0x3e, 0xcd, 0xd2, 0xb5, 0xb0, 0xd4, 0x93,
-	0x20, 0x3f, 0xac, 0x84, 0xad, 0xcf, 0x2d, 0x58, 0x4b, 0xa4, 0xe5, 0x07, 0x48, 0x41, 0x79, 0x03,
-	0x0a, 0x23, 0xa5, 0x99, 0x81, 0x75, 0x58, 0xab, 0xe0, 0x43, 0xec, 0x61, 0x85, 0x14, 0xe2, 0xb8,
-	0x3b, 0x1c, 0xac, 0x0d, 0x28, 0x8c, 0x44, 0xf8, 0x4a, 0x6e, 0xfc, 0x77, 0x16, 0x20, 0x7a, 0x2d,
-	0xa0, 0x45, 0x40, 0x4d, 0x45, 0xdb, 0x55, 0x75, 0x5d, 0x6d, 0xd4, 0xcd, 0x56, 0xfd, 0xd5, 0x7a,
-	0xe3, 0x6e, 0x5d, 0x3c, 0x87, 0x56, 0x60, 0xa9, 0x5c, 0x6b, 0xe9, 0x86, 0xa2, 0x99, 0xbb, 0x8d,
-	0x8a, 0xba, 0x73, 0xcf, 0x2c, 0xa9, 0xf5, 0x8a, 0x5a, 0xaf, 0xea, 0x62, 0x17, 0xe5, 0x21, 0x17,
-	0x30, 0xab, 0x8a, 0x11, 0x71, 0x30, 0x5a, 0x81, 0x45, 0x9e, 0xd3, 0x2c, 0x96, 0x6f, 0x57, 0xcc,
-	0x5a, 0xa3, 0xaa, 0x8b, 0xbf, 0x14, 0xd0, 0x32, 0x2c, 0x04, 0xcc, 0x62, 0xcb, 0xb8, 0x6d, 0x16,
-	0xcb, 0x86, 0x7a, 0xa7, 0x68, 0x28, 0xe2, 0x7d, 0xde, 0x1c, 0x65, 0x55, 0x94, 0x90, 0xb9, 0x3f,
-	0xc4, 0x24, 0x9a, 0xcb, 0x8d, 0xfa, 0x8e, 0x5a, 0x15, 0x0f, 0x86, 0x98, 0x7a, 0xc4, 0xb4, 0xd0,
-	0x06, 0x5c, 0x1a, 0x92, 0xd4, 0x1a, 0xa5, 0x86, 0x61, 0x1a, 0x8d, 0x57, 0x95, 0xba, 0xf8, 0x23,
-	0x01, 0x5d, 0x81, 0x8d, 0x18, 0x84, 0xcd, 0xb6, 0xaa, 0x35, 0x5a, 0x4d, 0x73, 0x57, 0xd9, 0x2d,
-	0x29, 0x9a, 0x2e, 0x1e, 0xa5, 0xfa, 0x40, 0x31, 0xba, 0xd8, 0x43, 0xeb, 0x29, 0x66, 0x7c, 0x05,
-	0x2d, 0x9d, 0x88, 0xdb, 0xa8, 0x00, 0x2b, 0x31, 0x84, 0xf2, 0x9a, 0xa1, 0x15, 0xcb, 0xcc, 0x0d,
-	0x5d, 0xec, 0xa3, 0x35, 0x90, 0x62, 0x00, 0x4d, 0xd1, 0x8d, 0x86, 0xa6, 0x30, 0x3f, 0x5f, 0x47,
-	0x5b, 0x70, 0x63, 0xc8, 0x44, 0xb4, 0x70, 0xba, 0xb9, 0xd3, 0xd0, 0xcc, 0xa6, 0xa6, 0xd6, 0xcb,
-	0x6a, 0xb3, 0x58, 0x13, 0x7f, 0x22, 0xa0, 0xab, 0x20, 0x27, 0x22, 0x5a, 0x53, 0x0c, 0xc5, 0x54,
-	0x5e, 0x6b, 0xaa, 0x9a, 0x52, 0x09, 0x0c, 0xff, 0x58, 0x40, 0x4f, 0x41, 0x21, 0x61, 0xf9, 0x4e,
-	0xe3, 0x55, 0x85, 0x7a, 0x1e, 0xa0, 0x7e, 0x2a, 0xa0, 0xcb, 0xb0, 0x16, 0x47, 0x35, 0x8c, 0xa2,
-	0xa1, 0x98, 0x5a, 0x23, 0x8c, 0xe5, 0x2f, 0x04, 0x7e, 0x96, 0x4a, 0xdd, 0x50, 0xb4, 0xa6, 0xa6,
-	0xea, 0x4a, 0xb4, 0xcc, 0x0e, 0x1f, 0x28, 0x0e, 0x70, 0x5b, 0x29, 0x6a, 0x46, 0x49, 0x29, 0x1a,
-	0xa2, 0x3b, 0x42, 0x85, 0xbf, 0xe2, 0x15, 0x45, 0xf4, 0xd0, 0x06, 0xac, 0xa6, 0x00, 0xb8, 0x7c,
-	0x19, 0xf0, 0x3a, 0xd4, 0x8a, 0x52, 0x37, 0x54, 0xe3, 0x1e, 0x9f, 0x16, 0xc7, 0xa9, 0x00, 0x2e,
-	0xa9, 0xbe, 0x9c, 0x0a, 0x28, 0x6b, 0x0a, 0x99, 0xb1, 0x5a, 0x69, 0x8a, 0x0f, 0x53, 0x01, 0xad,
-	0x66, 0x25, 0x00, 0x9c, 0xf0, 0xeb, 0x19, 0x02, 0x6a, 0xaa, 0x6e, 0x10, 0xb6, 0x2e, 0xbe, 0x81,
-	0x2e, 0x41, 0x3e, 0xd5, 0x05, 0x22, 0xfd, 0x95, 0x54, 0xf5, 0x6c, 0x01, 0x09, 0xe0, 0xab, 0xe8,
-	0x2a, 0x5c, 0x1e, 0xe5, 0x20, 0x29, 0x0c, 0xcc, 0x72, 0x4d, 0x55, 0xea, 0x86, 0xf8, 0x66, 0x2a,
-	0x90, 0x39, 0xca, 0x03, 0xbf, 0x86, 0x9e, 0x8e, 0xf2, 0x25, 0xee, 0x30, 0x07, 0xd3, 0xc5, 0xaf,
-	0xa3, 0x2b, 0xb0, 0x9e, 0xea, 0x38, 0xaf, 0xed, 0x1b, 0x02, 0xba, 0x96, 0x62, 0x97, 0xcd, 0x80,
-	0x47, 0xbe, 0x25, 0xa0, 0x25, 0x40, 0x01, 0xb2, 0xa2, 0x94, 0x5a, 0x55, 0xb3, 0xd2, 0xda, 0x6d,
-	0x8a, 0xdf, 0x12, 0xd0, 0x6a, 0x14, 0xa2, 0x9a, 0x5a, 0x56, 0xea, 0x7c, 0x2a, 0x7d, 0x3b, 0x95,
-	0x1d, 0xa6, 0xc9, 0x77, 0x04, 0xb4, 0x1e, 0x85, 0x30, 0x94, 0xae, 0x54, 0x4c, 0x46, 0x13, 0xbf,
-	0x1b, 0x4b, 0xe9, 0x00, 0xc1, 0x22, 0x13, 0x80, 0xbe, 0x97, 0x0a, 0x62, 0xd3, 0x08, 0x40, 0xdf,
-	0x17, 0x90, 0x1c, 0xe5, 0x64, 0x00, 0xa2, 0xa1, 0x63, 0x44, 0x5d, 0xfc, 0x81, 0x80, 0xa4, 0xe8,
-	0xf0, 0x63, 0x0b, 0xa5, 0x2b, 0x65, 0x4d, 0x31, 0xc4, 0xb7, 0xc9, 0xc1, 0x98, 0x8b, 0xe4, 0x75,
-	0x83, 0x71, 0x74, 0xf1, 0x1d, 0x01, 0x21, 0x98, 0xf1, 0x47, 0xcc, 0xac, 0xf8, 0x33, 0x01, 0xcd,
-	0xc3, 0x2c, 0xa3, 0xa9, 0x75, 0xbd, 0xa9, 0x94, 0x0d, 0xf1, 0xe7, 0x89, 0x30, 0x52, 0x07, 0x8b,
-	0xb5, 0x9a, 0xf8, 0x43, 0x01, 0xcd, 0x42, 0x56, 0x53, 0x9a, 0x0d, 0x53, 0x53, 0x8a, 0x15, 0xf1,
-	0x5d, 0x01, 0xcd, 0x01, 0xd0, 0xf1, 0x5d, 0x4d, 0x35, 0x14, 0xf1, 0x77, 0xd4, 0x3a, 0x25, 0x24,
-	0xcf, 0xf9, 0xdf, 0x0b, 0x48, 0x84, 0x29, 0xca, 0x62, 0xb6, 0xff, 0x20, 0xa0, 0x3c, 0xcc, 0x53,
-	0x0a, 0xb3, 0x6c, 0x96, 0x1b, 0xbb, 0xbb, 0xaa, 0x21, 0xfe, 0x51, 0x40, 0x0b, 0x20, 0x52, 0x8e,
-	0x3f, 0x73, 0x9f, 0xfc, 0x27, 0xea, 0x17, 0xa7, 0x22, 0x60, 0xfc, 0x39, 0x62, 0xb0, 0x68, 0x94,
-	0xb4, 0x62, 0xbd, 0x7c, 0x5b, 0xfc, 0x4b, 0x42, 0x11, 0x23, 0xbf, 0x37, 0xa4, 0x88, 0x31, 0xfe,
-	0x2a, 0xa0, 0x45, 0xb8, 0x18, 0x73, 0x69, 0x47, 0xad, 0x29, 0xe2, 0xdf, 0x68, 0x98, 0x22, 0x3d,
-	0x94, 0xf8, 0x77, 0x9a, 0x35, 0x94, 0x48, 0x72, 0xa1, 0xa9, 0x36, 0x95, 0x9a, 0x5a, 0x57, 0x68,
-	0x68, 0x14, 0x4d, 0xfc, 0x07, 0xcd, 0x1a, 0x16, 0xac, 0xdd, 0xc6, 0x1d, 0x65, 0x08, 0xf1, 0xcf,
-	0x11, 0x0a, 0x68, 0x2c, 0x35, 0xf1, 0x5f, 0xd4, 0x99, 0x90, 0x4a, 0x0d, 0xbf, 0xd2, 0x28, 0x89,
-	0xbf, 0x1e, 0xbb, 0xd1, 0x80, 0x69, 0xfe, 0x2e, 0x4f, 0xde, 0x85, 0x9a, 0xa2, 0x37, 0x5a, 0x5a,
-	0x59, 0x31, 0x8d, 0x7b, 0x4d, 0x85, 0x7b, 0xf5, 0x4e, 0xc1, 0x64, 0x90, 0x5b, 0x02, 0xca, 0xc0,
-	0x79, 0x62, 0x4e, 0x1c, 0x43, 0x33, 0x90, 0x25, 0xf3, 0x33, 0xe9, 0x70, 0x7c, 0xfb, 0x7f, 0x22,
-	0x8c, 0x17, 0x9b, 0x2a, 0x2a, 0x42, 0x26, 0xf8, 0x04, 0x81, 0xf2, 0x61, 0xe1, 0x92, 0xf8, 0x8e,
-	0x21, 0x2d, 0xa7, 0x70, 0x58, 0x55, 0x71, 0x0e, 0x55, 0x01, 0xa2, 0xaf, 0x0f, 0x48, 0x0a, 0xa1,
-	0x43, 0xdf, 0x29, 0xa4, 0x95, 0x54, 0x5e, 0xa8, 0xe8, 0x1e, 0xad, 0xfc, 0x62, 0x2d, 0x61, 0xb4,
-	0x1e, 0xf5, 0x65, 0xd2, 0x7b, 0xd0, 0xd2, 0xc6, 0x19, 0x08, 0x5e, 0xb5, 0x3e, 0x5a, 0xb5, 0xfe,
-	0x58, 0xd5, 0xfa, 0x68, 0xd5, 0xbb, 0x30, 0xcd, 0xf7, 0x65, 0xd1, 0xa5, 0x28, 0x56, 0xc3, 0xed,
-	0x60, 0x69, 0x75, 0x04, 0x37, 0x54, 0x57, 0x81, 0x6c, 0xd8, 0x1b, 0x41, 0xcb, 0x31, 0x34, 0xdf,
-	0xaa, 0x91, 0xa4, 0x34, 0x56, 0xa8, 0x45, 0x87, 0xd9, 0xf8, 0x95, 0x1f, 0xad, 0xf1, 0x61, 0x1a,
-	0xee, 0x62, 0x48, 0x85, 0x91, 0xfc, 0x50, 0xe9, 0x03, 0x90, 0x46, 0x77, 0x2e, 0xd0, 0x8d, 0x11,
-	0x0a, 0x52, 0xee, 0x15, 0x4f, 0x62, 0xec, 0x65, 0x98, 0xf0, 0xbb, 0xd4, 0x68, 0x31, 0x04, 0xc7,
-	0x1a, 0xd9, 0xd2, 0xd2, 0x10, 0x3d, 0x14, 0x3e, 0x08, 0xaf, 0xfb, 0xf1, 0x56, 0x30, 0xba, 0xc2,
-	0x1b, 0x1e, 0xd9, 0x7f, 0x96, 0x9e, 0x7e, 0x1c, 0x2c, 0xb4, 0xf4, 0x79, 0xb8, 0x38, 0xd4, 0x75,
-	0x40, 0x51, 0xde, 0x8c, 0x6a, 0x88, 0x48, 0xf2, 0x59, 0x90, 0xc4, 0x32, 0xf2, 0xaa, 0xd7, 0x92,
-	0x9e, 0x25, 0xf4, 0x16, 0x46, 0xf2, 0xf9, 0x84, 0xe5, 0x1b, 0x00, 0x5c, 0xc2, 0xa6, 0xb4, 0x0b,
-	0xb8, 0x84, 0x4d, 0xeb, 0x1a, 0xc8, 0xe7, 0x50, 0x13, 0x66, 0x62, 0xb7, 0x75, 0xb4, 0x1a, 0x77,
-	0x21, 0xd1, 0x0e, 0x90, 0xd6, 0x46, 0xb1, 0x43, 0x8d, 0x77, 0x60, 0x2e, 0x71, 0x97, 0x41, 0x05,
-	0xae, 0x29, 0x93, 0x76, 0xd5, 0x97, 0xd6, 0x47, 0x03, 0x42, 0xbd, 0xbd, 0xa1, 0x8b, 0x7f, 0x70,
-	0x47, 0x42, 0x57, 0x47, 0x89, 0x27, 0xee, 0x60, 0xd2, 0xb5, 0xc7, 0x03, 0x13, 0x87, 0x4e, 0xec,
-	0xfa, 0x1f, 0x3f, 0x74, 0xd2, 0x1a, 0x0d, 0xf1, 0x43, 0x27, 0xbd, 0x77, 0x40, 0x83, 0x1e, 0xbb,
-	0xe5, 0x73, 0x41, 0x4f, 0xeb, 0x2a, 0x70, 0x41, 0x4f, 0x6f, 0x0e, 0xd0, 0x73, 0x27, 0xbc, 0xcc,
-	0x73, 0xe7, 0x4e, 0xb2, 0x65, 0xc0, 0x9d, 0x3b, 0x43, 0x77, 0x7f, 0xba, 0x1d, 0x16, 0x52, 0x1b,
-	0x0a, 0xf1, 0x8d, 0x37, 0xb2, 0xe1, 0xf0, 0x18, 0xed, 0x45, 0xc8, 0x04, 0xad, 0x01, 0xee, 0x65,
-	0x95, 0x68, 0x2b, 0x48, 0xcb, 0x29, 0x1c, 0x7e, 0xbf, 0x0e, 0xf5, 0x03, 0xb8, 0xfd, 0x3a, 0xaa,
-	0x8f, 0xc0, 0xed, 0xd7, 0x91, 0xed, 0x04, 0x7f, 0xc5, 0x93, 0xf7, 0x7b, 0xc4, 0x67, 0x66, 0x6a,
-	0xff, 0x80, 0x5b, 0xf1, 0x91, 0xcd, 0x01, 0x9a, 0xbc, 0x23, 0xee, 0xe6, 0x5c, 0xf2, 0x9e, 0x7d,
-	0xbf, 0xe7, 0x92, 0xf7, 0x31, 0xd7, 0x7c, 0xb6, 0x09, 0xe3, 0x3f, 0x02, 0xe0, 0x37, 0x61, 0xea,
-	0xef, 0x0a, 0xf8, 0x4d, 0x98, 0xfe, 0xfb, 0x01, 0xf9, 0x5c, 0xe9, 0xe6, 0xbb, 0xa7, 0x6b, 0xc2,
-	0x7b, 0xa7, 0x6b, 0xc2, 0xfb, 0xa7, 0x6b, 0xc2, 0xe7, 0x6e, 0xec, 0x5b, 0xde, 0xc1, 0x60, 0x6f,
-	0xb3, 0x63, 0x1f, 0x6d, 0xf5, 0xdb, 0x9d, 0x83, 0x93, 0x2e, 0x76, 0xf8, 0xa7, 0xe3, 0xed, 0x2d,
-	0xd7, 0xe9, 0xd0, 0x5f, 0x69, 0xec, 0x4d, 0xd0, 0xaf, 0x8d, 0xcf, 0xff, 0x3f, 0x00, 0x00, 0xff,
-	0xff, 0xec, 0xaa, 0x28, 0xb5, 0xb9, 0x21, 0x00, 0x00,
+	// 3074 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0x59, 0x77, 0xdb, 0x46,
+	0x96, 0x0e, 0x24, 0xcb, 0x22, 0xaf, 0x36, 0xb8, 0xb4, 0x51, 0xd0, 0x0e, 0xdb, 0xf1, 0x32, 0x13,
+	0x29, 0xb1, 0x93, 0x89, 0x93, 0x78, 0x1e, 0xb8, 0xc0, 0x34, 0x62, 0x8a, 0xe4, 0x00, 0xa0, 0x1d,
+	0xcf, 0x99, 0x33, 0x18, 0x8a, 0x2c, 0x4b, 0x18, 0x53, 0x84, 0x02, 0x80, 0x1a, 0x2b, 0x33, 0x99,
+	0x4e, 0x7a, 0xef, 0x74, 0xba, 0x93, 0x5e, 0x9f, 0xfa, 0xf4, 0x3f, 0xe8, 0x97, 0xee, 0x3f, 0x91,
+	0xde, 0xd3, 0xeb, 0xa3, 0x3b, 0xc7, 0x3f, 0xa1, 0x7f, 0x41, 0x9f, 0x2a, 0x14, 0x80, 0x02, 0x08,
+	0xc8, 0x4b, 0x4e, 0x5e, 0x24, 0xd4, 0xbd, 0xdf, 0x5d, 0xea, 0xd6, 0xad, 0x8b, 0xaa, 0x0b, 0xc2,
+	0x4c, 0x7b, 0xe0, 0xed, 0x6f, 0x93, 0x3f, 0x5b, 0x87, 0x8e, 0xed, 0xd9, 0x68, 0x9c, 0x3c, 0x9b,
+	0x47, 0x57, 0xa4, 0xb9, 0x3d, 0x7b, 0xcf, 0xa6, 0xb4, 0x6d, 0xf2, 0xe4, 0xb3, 0xa5, 0xf5, 0x3d,
+	0xdb, 0xde, 0xeb, 0xe1, 0x6d, 0x3a, 0xda, 0x1d, 0xdc, 0xdb, 0xf6, 0xac, 0x03, 0xec, 0x7a, 0xed,
+	0x83, 0x43, 0x1f, 0x20, 0xbf, 0x08, 0x33, 0xc5, 0x8e, 0x67, 0x1d, 0xb5, 0x3d, 0xac, 0xe1, 0xb7,
+	0x07, 0xd8, 0xf5, 0xd0, 0x2a, 0x80, 0x63, 0xdb, 0x9e, 0xe9, 0xd9, 0xf7, 0x71, 0xbf, 0x20, 0x6c,
+	0x08, 0x17, 0xf3, 0x5a, 0x9e, 0x50, 0x0c, 0x42, 0x90, 0x5f, 0x02, 0x31, 0x92, 0x70, 0x0f, 0xed,
+	0xbe, 0x8b, 0x89, 0xc8, 0x61, 0xbb, 0xb3, 0x1f, 0x17, 0x21, 0x14, 0x5f, 0x64, 0x16, 0xce, 0x54,
+	0x70, 0x3b, 0x6e, 0x46, 0x9e, 0x03, 0xc4, 0x13, 0x7d, 0x4d, 0xf2, 0xab, 0xb0, 0xa0, 0xd9, 0x1e,
+	0xa1, 0x04, 0x06, 0x9f, 0xd0, 0xad, 0x6b, 0xb0, 0x38, 0x24, 0x18, 0x79, 0x77, 0x92, 0xe4, 0x67,
+	0x23, 0x00, 0x0d, 0xb5, 0x52, 0x2e, 0xdb, 0xfd, 0x7b, 0xd6, 0x1e, 0x5a, 0x80, 0xd3, 0x96, 0xeb,
+	0x0e, 0xb0, 0xc3, 0x90, 0x6c, 0x84, 0x2e, 0x41, 0xbe, 0xd3, 0xb3, 0x70, 0xdf, 0x33, 0xad, 0x6e,
+	0x61, 0x84, 0xb0, 0x4a, 0x93, 0x8f, 0x1e, 0xae, 0xe7, 0xca, 0x94, 0xa8, 0x56, 0xb4, 0x9c, 0xcf,
+	0x56, 0xbb, 0xe8, 0x2c, 0x4c, 0x31, 0xa8, 0x8b, 0x3b, 0x0e, 0xf6, 0x0a, 0xa3, 0x54, 0xd3, 0xa4,
+	0x4f, 0xd4, 0x29, 0x0d, 0x5d, 0x81, 0x49, 0x07, 0x77, 0x2d, 0x07, 0x77, 0x3c, 0x73, 0xe0, 0x58,
+	0x85, 0x53, 0x54, 0xe5, 0xcc, 0xa3, 0x87, 0xeb, 0x13, 0x1a, 0xa3, 0xb7, 0x34, 0x55, 0x9b, 0x08,
+	0x40, 0x2d, 0xc7, 0x22, 0xbe, 0xb9, 0x1d, 0xfb, 0x10, 0xbb, 0x85, 0xb1, 0x8d, 0x51, 0xe2, 0x9b,
+	0x3f, 0x42, 0x2f, 0xc3, 0x82, 0x83, 0xdf, 0x1e, 0x58, 0x0e, 0x36, 0xf1, 0x41, 0xdb, 0xea, 0x99,
+	0x47, 0xd8, 0xb1, 0xee, 0x59, 0xb8, 0x5b, 0x38, 0xbd, 0x21, 0x5c, 0xcc, 0x69, 0x73, 0x8c, 0xab,
+	0x10, 0xe6, 0x6d, 0xc6, 0x43, 0x97, 0x40, 0xec, 0xd9, 0x9d, 0x76, 0x6f, 0xdf, 0x76, 0x3d, 0x93,
+	0xcd, 0x79, 0x9c, 0xe2, 0x67, 0x42, 0xba, 0xea, 0x4f, 0xfe, 0x5f, 0x61, 0x79, 0xe0, 0x62, 0xc7,
+	0x6c, 0x77, 0x3a, 0xd8, 0x75, 0xad, 0xdd, 0x1e, 0x66, 0x02, 0x26, 0x01, 0x15, 0x72, 0x74, 0x7e,
+	0x05, 0x02, 0x29, 0x86, 0x08, 0x5f, 0xf4, 0xa6, 0xed, 0x7a, 0xf2, 0x12, 0x2c, 0x56, 0xb1, 0xe7,
+	0x07, 0x78, 0xe0, 0xb4, 0x3d, 0xcb, 0x0e, 0x96, 0x55, 0x6e, 0x41, 0x61, 0x98, 0xc5, 0x16, 0xee,
+	0x35, 0x98, 0xea, 0xf0, 0x0c, 0xba, 0x22, 0x13, 0x57, 0x66, 0xb7, 0x58, 0xd2, 0x6f, 0x45, 0xcb,
+	0xa6, 0xc5, 0x91, 0xb2, 0x01, 0x8b, 0x7a, 0xba, 0xc5, 0xcf, 0xa3, 0x55, 0x82, 0x82, 0x9e, 0xe1,
+	0xac, 0xfc, 0x73, 0x01, 0xf2, 0x34, 0xa1, 0xd4, 0xfe, 0x3d, 0x1b, 0x15, 0x60, 0xdc, 0x1d, 0xec,
+	0xfe, 0x37, 0xee, 0x78, 0x2c, 0x8d, 0x82, 0x21, 0xd2, 0x01, 0xf0, 0x83, 0x43, 0x8b, 0xd9, 0x1e,
+	0xa1, 0xb6, 0xa5, 0x2d, 0x7f, 0x9f, 0x6e, 0x05, 0xfb, 0x74, 0xcb, 0x08, 0xf6, 0x69, 0x69, 0xf1,
+	0xef, 0x0f, 0xd7, 0x67, 0xba, 0xbb, 0xaf, 0xcb, 0x91, 0x94, 0xfc, 0xf1, 0xdf, 0xd6, 0x05, 0x8d,
+	0x53, 0x83, 0xfe, 0x05, 0x26, 0xf7, 0xdb, 0xee, 0x3e, 0xee, 0xb2, 0x24, 0xa7, 0x09, 0x57, 0x9a,
+	0x0d, 0x44, 0x29, 0xd1, 0x24, 0x08, 0x59, 0x9b, 0xf0, 0x81, 0x7e, 0xee, 0xff, 0x27, 0xcc, 0x16,
+	0x07, 0xde, 0x3e, 0xee, 0x7b, 0x56, 0x87, 0x2b, 0x01, 0xff, 0x0c, 0x60, 0x5b, 0xdd, 0x8e, 0xe9,
+	0x92, 0x0d, 0xe5, 0x4f, 0xa0, 0x34, 0xf5, 0xe8, 0xe1, 0x7a, 0x9e, 0x84, 0x46, 0x27, 0x44, 0x2d,
+	0x4f, 0x00, 0xf4, 0x11, 0x2d, 0x41, 0xce, 0x0a, 0x0c, 0x8f, 0xf8, 0x93, 0xb5, 0x98, 0xfe, 0x57,
+	0x60, 0x2e, 0xae, 0xff, 0xc9, 0x0a, 0xc6, 0x0c, 0x4c, 0xdd, 0xd9, 0xb7, 0x8b, 0x07, 0x6a, 0x90,
+	0x25, 0xef, 0x0b, 0x30, 0x1d, 0x50, 0x98, 0x0a, 0x09, 0x72, 0x24, 0xdf, 0xfa, 0xed, 0x03, 0xe6,
+	0xa1, 0x16, 0x8e, 0xbf, 0x90, 0x18, 0xcb, 0x3a, 0xac, 0x54, 0xb1, 0xa7, 0xd9, 0x3d, 0xec, 0xde,
+	0xb0, 0x9d, 0x26, 0x76, 0x0e, 0x2c, 0xd7, 0xe5, 0xf2, 0xea, 0x2a, 0xc0, 0x61, 0x48, 0xa4, 0x2e,
+	0x4d, 0x73, 0x49, 0xc5, 0xe1, 0x39, 0x98, 0x5c, 0x81, 0xd5, 0x0c, 0xa5, 0x6c, 0x9a, 0x67, 0x61,
+	0xcc, 0x21, 0xdc, 0x82, 0xb0, 0x31, 0x7a, 0x71, 0xe2, 0xca, 0x54, 0xa8, 0x90, 0xc8, 0x68, 0x3e,
+	0x4f, 0x76, 0x60, 0x8c, 0xaa, 0x40, 0xdb, 0x71, 0xf4, 0x52, 0x0c, 0xed, 0xfa, 0x7f, 0x95, 0xbe,
+	0xe7, 0x1c, 0x33, 0x49, 0xe9, 0x1a, 0x40, 0x44, 0x44, 0x22, 0x8c, 0xde, 0xc7, 0xc7, 0x2c, 0x9c,
+	0xe4, 0x11, 0xcd, 0xc1, 0xd8, 0x51, 0xbb, 0x37, 0xc0, 0x34, 0x88, 0x39, 0xcd, 0x1f, 0xbc, 0x3e,
+	0x72, 0x4d, 0x90, 0x7f, 0x2c, 0xc0, 0x04, 0x11, 0x2d, 0x59, 0xfd, 0xae, 0xd5, 0xdf, 0x43, 0x6f,
+	0xc0, 0x38, 0xee, 0x7b, 0x8e, 0x15, 0x1a, 0xdf, 0x8c, 0x19, 0x67, 0xb0, 0x2d, 0xc5, 0xc7, 0xf8,
+	0x4e, 0x04, 0x12, 0xd2, 0x9b, 0x30, 0xc9, 0x33, 0x52, 0x1c, 0x39, 0xc7, 0x3b, 0x32, 0x71, 0x65,
+	0x3a, 0x3e, 0x33, 0xde, 0x31, 0x15, 0x72, 0x1a, 0x76, 0xed, 0x81, 0xd3, 0xc1, 0xe8, 0x12, 0x9c,
+	0xf2, 0x8e, 0x0f, 0x31, 0x5b, 0x8d, 0xf9, 0x48, 0x88, 0x01, 0x8c, 0xe3, 0x43, 0xac, 0x51, 0x08,
+	0x42, 0x70, 0x8a, 0xe6, 0x92, 0x9f, 0xc1, 0xf4, 0x59, 0xfe, 0xb2, 0x00, 0x63, 0x2d, 0x17, 0x3b,
+	0x2e, 0x7a, 0x03, 0xf2, 0x41, 0x76, 0x05, 0xf3, 0x5b, 0x0d, 0xb5, 0x51, 0xc8, 0x56, 0x2b, 0xe0,
+	0xfb, 0x73, 0x8b, 0xf0, 0xd2, 0x75, 0x98, 0x8e, 0x33, 0x9f, 0x2a, 0xd0, 0x0f, 0xe0, 0x74, 0xd5,
+	0xb1, 0x07, 0x87, 0x2e, 0xba, 0x0a, 0xa7, 0xf7, 0xe8, 0x13, 0xf3, 0x60, 0x39, 0xf4, 0xc0, 0x07,
+	0xb0, 0x7f, 0xbe, 0x7d, 0x06, 0x95, 0x5e, 0x83, 0x09, 0x8e, 0xfc, 0x54, 0x96, 0x3f, 0x12, 0xe0,
+	0x14, 0x09, 0x6f, 0x18, 0x1b, 0x21, 0x8a, 0x0d, 0x7a, 0x05, 0x26, 0xa2, 0x3c, 0x76, 0x0b, 0x23,
+	0x1b, 0xa3, 0x59, 0xf9, 0xce, 0xe3, 0xd0, 0x75, 0x98, 0x76, 0x58, 0xf0, 0x4d, 0x12, 0x77, 0xb7,
+	0x30, 0xba, 0x31, 0x9a, 0xbd, 0x36, 0x53, 0x0e, 0x37, 0x72, 0xe5, 0x07, 0x20, 0x92, 0x7a, 0x62,
+	0x3b, 0xd6, 0x3b, 0x61, 0xb1, 0x7a, 0x01, 0x72, 0x01, 0x88, 0x95, 0xf2, 0x33, 0x43, 0xba, 0xb4,
+	0x10, 0xf2, 0x8c, 0x7e, 0xcb, 0xbf, 0x10, 0xe0, 0x0c, 0x67, 0x9a, 0xed, 0xce, 0x35, 0x80, 0x76,
+	0x40, 0xec, 0x52, 0xeb, 0x39, 0x8d, 0xa3, 0xa0, 0x97, 0x20, 0xef, 0xb6, 0x3d, 0xcb, 0xa5, 0xef,
+	0xe2, 0x13, 0x4c, 0x45, 0x28, 0xf4, 0x02, 0x8c, 0x53, 0x6a, 0x7f, 0xaf, 0x30, 0x9a, 0x2d, 0x10,
+	0x60, 0xd0, 0x0a, 0xe4, 0x0f, 0x1d, 0xab, 0xdf, 0xb1, 0x0e, 0xdb, 0x3d, 0xff, 0x0c, 0xa1, 0x45,
+	0x04, 0xf9, 0x06, 0xcc, 0x57, 0xb1, 0x17, 0xc9, 0xb9, 0xcf, 0x16, 0x34, 0xf9, 0x10, 0x36, 0xe3,
+	0x7a, 0x48, 0xb1, 0x0a, 0xac, 0x3c, 0xe3, 0x42, 0xc4, 0x3c, 0x1f, 0x49, 0x7a, 0x8e, 0x61, 0x21,
+	0xe9, 0x39, 0x8b, 0x79, 0x62, 0x01, 0x85, 0x27, 0x4c, 0xbc, 0xb9, 0xa0, 0x34, 0x8e, 0xd0, 0xa3,
+	0x93, 0x3f, 0x90, 0xdf, 0x85, 0xc2, 0x8e, 0xdd, 0xb5, 0xee, 0x1d, 0x73, 0x35, 0xea, 0x8b, 0x98,
+	0x4f, 0x64, 0x7e, 0x94, 0x37, 0xbf, 0x0c, 0x4b, 0x29, 0xe6, 0xd9, 0x89, 0xc2, 0x5f, 0xbc, 0xcf,
+	0xed, 0x98, 0x7c, 0x13, 0x16, 0x92, 0x7a, 0x58, 0x28, 0xb7, 0x60, 0x7c, 0xd7, 0x27, 0x31, 0x3d,
+	0x73, 0x69, 0x35, 0x5b, 0x0b, 0x40, 0xf2, 0x7f, 0xc1, 0x84, 0x8e, 0x69, 0x3c, 0xe9, 0x21, 0x67,
+	0x0e, 0xc6, 0xfa, 0x76, 0xbf, 0x13, 0xd4, 0x05, 0x7f, 0x40, 0xa8, 0xf4, 0x10, 0xca, 0x62, 0xe0,
+	0x0f, 0xd0, 0x79, 0x98, 0xee, 0xd8, 0xfd, 0x23, 0xec, 0x10, 0x69, 0x13, 0x3b, 0x0e, 0x3d, 0xa3,
+	0xe4, 0xb4, 0xa9, 0x88, 0xaa, 0x38, 0x8e, 0x3c, 0x0f, 0xb3, 0x55, 0xec, 0x91, 0x63, 0x46, 0xcd,
+	0xde, 0xb3, 0xc2, 0x53, 0xe2, 0x1d, 0x98, 0x8b, 0x93, 0xd9, 0x04, 0x2e, 0x41, 0xbe, 0x47, 0x08,
+	0xe6, 0xc0, 0xe9, 0x15, 0x84, 0xe8, 0x50, 0x4e, 0x51, 0x2d, 0xad, 0xa6, 0xe5, 0x28, 0xbb, 0xe5,
+	0xd0, 0x05, 0xf0, 0x8f, 0x33, 0xcc, 0x2d, 0x3a, 0x90, 0xab, 0x54, 0xb1, 0x66, 0xef, 0x26, 0x6e,
+	0x1b, 0x74, 0xb9, 0x76, 0xed, 0xe0, 0xf4, 0xe6, 0x0f, 0xd0, 0x12, 0x8c, 0x7a, 0x9e, 0x3f, 0xb1,
+	0xd1, 0xd2, 0xf8, 0xa3, 0x87, 0xeb, 0xa3, 0x86, 0x51, 0xd3, 0x08, 0x4d, 0x7e, 0x01, 0xe6, 0x13,
+	0x8a, 0x98, 0x8b, 0x73, 0x30, 0xc6, 0x9f, 0x72, 0xfc, 0x81, 0xbc, 0x05, 0x0b, 0x1a, 0x3e, 0xb2,
+	0xef, 0x63, 0x52, 0x53, 0x92, 0x96, 0x53, 0xf0, 0x4b, 0xb0, 0x38, 0x84, 0x67, 0x69, 0xb2, 0x43,
+	0x8f, 0xba, 0x7e, 0x8d, 0xbf, 0x61, 0x3b, 0xe4, 0x4d, 0x13, 0xe8, 0x3a, 0xe9, 0x8c, 0xb4, 0x10,
+	0xbe, 0x4c, 0xfc, 0x0d, 0xc1, 0x46, 0xec, 0x8c, 0x9b, 0x50, 0xc7, 0x4c, 0xdd, 0x86, 0x39, 0x3f,
+	0x5d, 0x77, 0xf0, 0xc1, 0x2e, 0x76, 0x5c, 0xce, 0x67, 0x2a, 0x1d, 0xf8, 0x4c, 0x07, 0xe4, 0x55,
+	0xd3, 0xee, 0x76, 0x99, 0x7a, 0xf2, 0x48, 0x6c, 0x3a, 0xf8, 0xc0, 0x3e, 0xc2, 0x6c, 0x17, 0xb0,
+	0x91, 0xbc, 0x08, 0xf3, 0x09, 0xbd, 0xcc, 0x20, 0x02, 0xb1, 0x1a, 0x38, 0x13, 0xe4, 0xc2, 0x75,
+	0x58, 0x09, 0x69, 0x69, 0x65, 0x28, 0xb6, 0x0f, 0x85, 0x64, 0x5d, 0xf9, 0x27, 0x38, 0xc3, 0x69,
+	0x64, 0x6b, 0xb4, 0x10, 0x7b, 0xb1, 0x46, 0xb1, 0xb8, 0x00, 0x33, 0x55, 0xec, 0xd1, 0xd7, 0xfb,
+	0x89, 0x53, 0x95, 0x5f, 0x04, 0x31, 0x02, 0x32, 0xa5, 0x2b, 0xc9, 0x23, 0x43, 0x9e, 0x3b, 0x13,
+	0x90, 0x30, 0x2b, 0x0f, 0x3c, 0xa7, 0xdd, 0xf1, 0xc2, 0x15, 0x0d, 0x67, 0x58, 0x85, 0xa5, 0x14,
+	0x1e, 0x53, 0x7b, 0x19, 0x4e, 0xd3, 0x94, 0x08, 0x0e, 0x01, 0x28, 0xdc, 0xb2, 0xe1, 0xed, 0x43,
+	0x63, 0x08, 0xb9, 0x4c, 0xb2, 0xc6, 0xf5, 0x6c, 0x67, 0x38, 0xcd, 0x2e, 0xf2, 0x69, 0x96, 0xae,
+	0x85, 0xa5, 0x9e, 0x04, 0x85, 0x61, 0x25, 0x6c, 0x7d, 0xae, 0xc3, 0x5a, 0x22, 0x2d, 0x9f, 0x22,
+	0x05, 0xe5, 0x4d, 0x58, 0xcf, 0x94, 0x66, 0x06, 0x3e, 0x18, 0x81, 0x49, 0xfd, 0x6a, 0xd9, 0xc1,
+	0x5d, 0x72, 0x87, 0x68, 0xf7, 0xd0, 0x55, 0x98, 0xf2, 0x2f, 0xa1, 0xe6, 0x7d, 0x7c, 0x4c, 0xae,
+	0xe2, 0x42, 0x74, 0x6f, 0xf6, 0xef, 0x9e, 0xb7, 0xf0, 0xb1, 0x5a, 0xd1, 0x26, 0xda, 0xe1, 0xa0,
+	0xfb, 0x98, 0xd2, 0xbc, 0x0c, 0x79, 0x07, 0xb7, 0xbb, 0xa6, 0xdd, 0xef, 0x1d, 0xb3, 0xaa, 0x94,
+	0x23, 0x84, 0x46, 0xbf, 0x77, 0x4c, 0x2e, 0x72, 0xbb, 0x83, 0xce, 0x7d, 0xec, 0xb9, 0x85, 0x53,
+	0x74, 0x0d, 0x83, 0x21, 0x7a, 0x3d, 0x76, 0xc9, 0x18, 0x7b, 0xdc, 0x25, 0x23, 0x76, 0x5f, 0x7b,
+	0x19, 0xc6, 0x3b, 0x0e, 0x6e, 0x7b, 0xec, 0x86, 0x7e, 0xb2, 0x60, 0x00, 0x95, 0x3f, 0x14, 0x40,
+	0x2a, 0xd3, 0x67, 0x3e, 0x24, 0xee, 0x13, 0x25, 0x7e, 0x7c, 0x96, 0x23, 0xd9, 0xb3, 0x1c, 0x8d,
+	0xcf, 0x92, 0x95, 0xbc, 0x53, 0x29, 0x25, 0xef, 0x3d, 0x01, 0x96, 0x53, 0xdd, 0x09, 0x5f, 0xd4,
+	0xd0, 0x09, 0xc9, 0x2c, 0xcf, 0xa2, 0x63, 0x1e, 0x2f, 0xa3, 0x71, 0x40, 0x74, 0x19, 0xce, 0xf8,
+	0x6d, 0x13, 0x33, 0x5a, 0x68, 0xb6, 0x68, 0x33, 0x3e, 0x23, 0x5c, 0x67, 0xf9, 0x1a, 0x14, 0x6a,
+	0x96, 0xeb, 0x3d, 0x7d, 0x38, 0x64, 0x03, 0x96, 0x52, 0x24, 0x99, 0xe7, 0xaf, 0xc2, 0x44, 0xe4,
+	0x50, 0xb0, 0xd1, 0x32, 0x5c, 0xe7, 0x91, 0xf2, 0xbf, 0x81, 0xe4, 0x67, 0x74, 0xaa, 0x47, 0xcf,
+	0x92, 0xbb, 0xf2, 0x2a, 0x2c, 0xa7, 0xaa, 0x64, 0x1b, 0x64, 0x03, 0xd6, 0x2a, 0xb8, 0x87, 0x3d,
+	0xac, 0x90, 0xec, 0xc2, 0xdd, 0xe1, 0x6a, 0xb2, 0x09, 0xeb, 0x99, 0x08, 0x5f, 0xc9, 0xe5, 0x9f,
+	0xce, 0x00, 0x44, 0xe7, 0x26, 0xb4, 0x00, 0xa8, 0xa9, 0x68, 0x3b, 0xaa, 0xae, 0xab, 0x8d, 0xba,
+	0xd9, 0xaa, 0xdf, 0xaa, 0x37, 0xee, 0xd4, 0xc5, 0xe7, 0xd0, 0x32, 0x2c, 0x96, 0x6b, 0x2d, 0xdd,
+	0x50, 0x34, 0x73, 0xa7, 0x51, 0x51, 0x6f, 0xdc, 0x35, 0x4b, 0x6a, 0xbd, 0xa2, 0xd6, 0xab, 0xba,
+	0xd8, 0x45, 0x05, 0x98, 0x0b, 0x98, 0x55, 0xc5, 0x88, 0x38, 0x18, 0x2d, 0xc3, 0x02, 0xcf, 0x69,
+	0x16, 0xcb, 0x37, 0x2b, 0x66, 0xad, 0x51, 0xd5, 0xc5, 0x1f, 0x0a, 0x68, 0x09, 0xe6, 0x03, 0x66,
+	0xb1, 0x65, 0xdc, 0x34, 0x8b, 0x65, 0x43, 0xbd, 0x5d, 0x34, 0x14, 0xf1, 0x1e, 0x6f, 0x8e, 0xb2,
+	0x2a, 0x4a, 0xc8, 0xdc, 0x1b, 0x62, 0x12, 0xcd, 0xe5, 0x46, 0xfd, 0x86, 0x5a, 0x15, 0xf7, 0x87,
+	0x98, 0x7a, 0xc4, 0xb4, 0xd0, 0x26, 0xac, 0x0c, 0x49, 0x6a, 0x8d, 0x52, 0xc3, 0x30, 0x8d, 0xc6,
+	0x2d, 0xa5, 0x2e, 0x7e, 0x5b, 0x40, 0xe7, 0x61, 0x33, 0x06, 0x61, 0xb3, 0xad, 0x6a, 0x8d, 0x56,
+	0xd3, 0xdc, 0x51, 0x76, 0x4a, 0x8a, 0xa6, 0x8b, 0x07, 0xa9, 0x3e, 0x50, 0x8c, 0x2e, 0xf6, 0xd1,
+	0x06, 0xac, 0xa4, 0x33, 0xcd, 0x96, 0x4e, 0xc4, 0x6d, 0xb4, 0x0e, 0xcb, 0x31, 0x84, 0xf2, 0x96,
+	0xa1, 0x15, 0xcb, 0xcc, 0x0d, 0x5d, 0x3c, 0x44, 0x6b, 0x20, 0xc5, 0x00, 0x9a, 0xa2, 0x1b, 0x0d,
+	0x4d, 0x61, 0x7e, 0xbe, 0x8d, 0xb6, 0xe1, 0xf2, 0x90, 0x89, 0x68, 0xe1, 0x74, 0xf3, 0x46, 0x43,
+	0x33, 0x9b, 0x9a, 0x5a, 0x2f, 0xab, 0xcd, 0x62, 0x4d, 0xfc, 0x8e, 0x80, 0x2e, 0x80, 0x9c, 0x88,
+	0x68, 0x4d, 0x31, 0x14, 0x53, 0x79, 0xab, 0xa9, 0x6a, 0x4a, 0x25, 0x30, 0xfc, 0xa1, 0x80, 0xce,
+	0xc1, 0x7a, 0xc2, 0xf2, 0xed, 0xc6, 0x2d, 0x85, 0x7a, 0x1e, 0xa0, 0xbe, 0x2b, 0xa0, 0xb3, 0xb0,
+	0x16, 0x47, 0x35, 0x8c, 0xa2, 0xa1, 0x98, 0x5a, 0x23, 0x8c, 0xe5, 0x0f, 0x04, 0x7e, 0x96, 0x4a,
+	0xdd, 0x50, 0xb4, 0xa6, 0xa6, 0xea, 0x4a, 0xb4, 0xcc, 0x0e, 0x1f, 0x28, 0x0e, 0x70, 0x53, 0x29,
+	0x6a, 0x46, 0x49, 0x29, 0x1a, 0xa2, 0x9b, 0xa1, 0xc2, 0x5f, 0xf1, 0x8a, 0x22, 0x7a, 0x68, 0x13,
+	0x56, 0x53, 0x00, 0x5c, 0xbe, 0x0c, 0x78, 0x1d, 0x6a, 0x45, 0xa9, 0x1b, 0xaa, 0x71, 0x97, 0x4f,
+	0x8b, 0xa3, 0x54, 0x00, 0x97, 0x54, 0xff, 0x93, 0x0a, 0x28, 0x6b, 0x0a, 0x99, 0xb1, 0x5a, 0x69,
+	0x8a, 0x0f, 0x52, 0x01, 0xad, 0x66, 0x25, 0x00, 0x1c, 0xf3, 0xeb, 0x19, 0x02, 0x6a, 0xaa, 0x6e,
+	0x10, 0xb6, 0x2e, 0xbe, 0x83, 0x56, 0xa0, 0x90, 0xea, 0x02, 0x91, 0xfe, 0xdf, 0x54, 0xf5, 0x6c,
+	0x01, 0x09, 0xe0, 0xff, 0xd0, 0x05, 0x38, 0x9b, 0xe5, 0x20, 0x39, 0x39, 0x9b, 0xe5, 0x9a, 0xaa,
+	0xd4, 0x0d, 0xf1, 0xdd, 0x54, 0x20, 0x73, 0x94, 0x07, 0xfe, 0x3f, 0x7a, 0x1e, 0xe4, 0x21, 0x20,
+	0x75, 0x98, 0x83, 0xe9, 0xe2, 0x97, 0xd0, 0x79, 0xd8, 0x48, 0x75, 0x9c, 0xd7, 0xf6, 0x9e, 0x80,
+	0x2e, 0xc2, 0xd9, 0xac, 0x19, 0xf0, 0xc8, 0xf7, 0x05, 0xb4, 0x08, 0x28, 0x40, 0x56, 0x94, 0x52,
+	0xab, 0x6a, 0x56, 0x5a, 0x3b, 0x4d, 0xf1, 0x2b, 0x31, 0x15, 0x3e, 0x83, 0xac, 0x21, 0xd9, 0x15,
+	0xc5, 0xaa, 0x62, 0x96, 0x8a, 0xf5, 0xca, 0x1d, 0xb5, 0x62, 0xdc, 0x14, 0x7f, 0x24, 0xa0, 0xd5,
+	0x28, 0x98, 0x35, 0xb5, 0xac, 0xd4, 0xf9, 0xa4, 0xfb, 0x6a, 0x2a, 0x3b, 0x4c, 0xa8, 0xaf, 0x09,
+	0x68, 0x03, 0x96, 0x93, 0xec, 0x62, 0xa5, 0x62, 0x32, 0x9a, 0xf8, 0xf5, 0x58, 0xf2, 0x07, 0x08,
+	0x16, 0xc3, 0x00, 0xf4, 0x8d, 0x54, 0x10, 0x9b, 0x70, 0x00, 0xfa, 0xa6, 0x80, 0x64, 0x58, 0x4d,
+	0x82, 0x68, 0x90, 0x19, 0x51, 0x17, 0xbf, 0x25, 0x20, 0x29, 0x2a, 0x93, 0x6c, 0x49, 0x75, 0xa5,
+	0xac, 0x29, 0x86, 0xf8, 0x11, 0x29, 0xa1, 0x73, 0x91, 0xbc, 0x6e, 0x30, 0x8e, 0x2e, 0x7e, 0x2c,
+	0x20, 0x04, 0x53, 0xfe, 0x88, 0x99, 0x15, 0xbf, 0x27, 0xa0, 0x59, 0x98, 0x66, 0x34, 0xb5, 0xae,
+	0x37, 0x95, 0xb2, 0x21, 0x7e, 0x3f, 0x11, 0x70, 0xea, 0x60, 0xb1, 0x56, 0x13, 0x3f, 0x10, 0xd0,
+	0x34, 0xe4, 0x35, 0xa5, 0xd9, 0x30, 0x35, 0xa5, 0x58, 0x11, 0x3f, 0x11, 0xd0, 0x0c, 0x00, 0x1d,
+	0xdf, 0xd1, 0x54, 0x43, 0x11, 0x7f, 0x49, 0xad, 0x53, 0x42, 0xf2, 0x8d, 0xf0, 0x2b, 0x01, 0x89,
+	0x30, 0x41, 0x59, 0xcc, 0xf6, 0xaf, 0x05, 0x54, 0x80, 0x59, 0x4a, 0x61, 0x96, 0xcd, 0x72, 0x63,
+	0x67, 0x47, 0x35, 0xc4, 0xdf, 0x08, 0x68, 0x1e, 0x44, 0xca, 0xf1, 0x67, 0xee, 0x93, 0x7f, 0x4b,
+	0xfd, 0xe2, 0x54, 0x04, 0x8c, 0xdf, 0x45, 0x0c, 0x16, 0x8d, 0x92, 0x56, 0xac, 0x97, 0x6f, 0x8a,
+	0xbf, 0x4f, 0x28, 0x62, 0xe4, 0x4f, 0x87, 0x14, 0x31, 0xc6, 0x1f, 0x04, 0xb4, 0x00, 0x67, 0x62,
+	0x2e, 0xdd, 0x50, 0x6b, 0x8a, 0xf8, 0x47, 0x1a, 0xa6, 0x48, 0x0f, 0x25, 0xfe, 0x89, 0x66, 0x0d,
+	0x25, 0x92, 0x5c, 0x68, 0xaa, 0x4d, 0xa5, 0xa6, 0xd6, 0x15, 0x1a, 0x1a, 0x45, 0x13, 0xff, 0x4c,
+	0xb3, 0x86, 0x05, 0x6b, 0xa7, 0x71, 0x5b, 0x19, 0x42, 0xfc, 0x25, 0x43, 0x01, 0x8d, 0xa5, 0x26,
+	0xfe, 0x95, 0x3a, 0x13, 0x52, 0xa9, 0xe1, 0x37, 0x1b, 0x25, 0xf1, 0x67, 0x23, 0x97, 0x1b, 0x30,
+	0xc9, 0xb7, 0xc5, 0xc8, 0x5b, 0x53, 0x53, 0xf4, 0x46, 0x4b, 0x2b, 0x2b, 0xa6, 0x71, 0xb7, 0xa9,
+	0x70, 0x2f, 0xe9, 0x09, 0x18, 0x0f, 0x72, 0x4b, 0x40, 0x39, 0x38, 0x45, 0xcc, 0x89, 0x23, 0x68,
+	0x0a, 0xf2, 0x64, 0x7e, 0x26, 0x1d, 0x8e, 0x5e, 0xf9, 0xc9, 0x2c, 0x8c, 0x16, 0x9b, 0x2a, 0x2a,
+	0x42, 0x2e, 0xf8, 0x9a, 0x87, 0x0a, 0xe1, 0x01, 0x27, 0xf1, 0x49, 0x50, 0x5a, 0x4a, 0xe1, 0xb0,
+	0xf3, 0xc7, 0x73, 0xa8, 0x0a, 0x10, 0x7d, 0xc8, 0x43, 0x52, 0x08, 0x1d, 0xfa, 0xe4, 0x27, 0x2d,
+	0xa7, 0xf2, 0x42, 0x45, 0x77, 0xe9, 0x25, 0x2a, 0xf6, 0x75, 0x05, 0x6d, 0x84, 0x22, 0x19, 0x1f,
+	0x90, 0xa4, 0xcd, 0x13, 0x10, 0xbc, 0x6a, 0x3d, 0x5b, 0xb5, 0xfe, 0x58, 0xd5, 0x7a, 0xb6, 0xea,
+	0x1d, 0x98, 0xe4, 0x3f, 0x71, 0xa0, 0x95, 0x28, 0x56, 0xc3, 0x5f, 0x56, 0xa4, 0xd5, 0x0c, 0x6e,
+	0xa8, 0xae, 0x02, 0xf9, 0xb0, 0xcd, 0x88, 0x96, 0x62, 0x68, 0xbe, 0xeb, 0x29, 0x49, 0x69, 0xac,
+	0x50, 0x8b, 0x0e, 0xd3, 0xf1, 0xee, 0x19, 0x5a, 0xe3, 0xc3, 0x34, 0xdc, 0x10, 0x94, 0xd6, 0x33,
+	0xf9, 0xa1, 0xd2, 0xfb, 0x20, 0x65, 0x37, 0x01, 0xd1, 0xe5, 0x0c, 0x05, 0x29, 0x57, 0xf4, 0x27,
+	0x31, 0xf6, 0x06, 0x9c, 0xf6, 0x3f, 0xf8, 0xa0, 0x85, 0x10, 0x1c, 0xfb, 0x26, 0x24, 0x2d, 0x0e,
+	0xd1, 0x43, 0xe1, 0xfd, 0xb0, 0x73, 0x16, 0xff, 0xaa, 0x82, 0xce, 0xf3, 0x86, 0x33, 0x3f, 0xe5,
+	0x48, 0xcf, 0x3f, 0x0e, 0x16, 0x5a, 0xfa, 0x0f, 0x38, 0x33, 0xd4, 0xc0, 0x43, 0x51, 0xde, 0x64,
+	0xf5, 0x16, 0x25, 0xf9, 0x24, 0x48, 0x62, 0x19, 0x79, 0xd5, 0x6b, 0x49, 0xcf, 0x12, 0x7a, 0xd7,
+	0x33, 0xf9, 0x7c, 0xc2, 0xf2, 0xbd, 0x34, 0x2e, 0x61, 0x53, 0x3a, 0x6f, 0xd2, 0x6a, 0x06, 0x37,
+	0x54, 0xd7, 0x84, 0xa9, 0x58, 0xe3, 0x0b, 0xad, 0xc6, 0x5d, 0x48, 0x74, 0xd6, 0xa4, 0xb5, 0x2c,
+	0x76, 0xa8, 0xf1, 0x36, 0xcc, 0x24, 0xda, 0x02, 0x68, 0x9d, 0xeb, 0x6f, 0xa6, 0x75, 0xcd, 0xa4,
+	0x8d, 0x6c, 0x40, 0xa8, 0xb7, 0x3f, 0xd4, 0x43, 0x0b, 0xda, 0x0d, 0xe8, 0x42, 0x96, 0x78, 0xa2,
+	0x9d, 0x21, 0x5d, 0x7c, 0x3c, 0x30, 0xb4, 0xb7, 0x0b, 0xb3, 0x29, 0xd7, 0x63, 0x74, 0x36, 0x54,
+	0x91, 0x7d, 0x97, 0x97, 0xce, 0x9d, 0x0c, 0xe2, 0xf3, 0x6f, 0xe8, 0x1a, 0xcb, 0xe5, 0x5f, 0xd6,
+	0xe5, 0x58, 0x92, 0x4f, 0x82, 0xf0, 0x33, 0x48, 0xb9, 0x7b, 0x72, 0x33, 0xc8, 0xbe, 0xec, 0x4a,
+	0xe7, 0x4e, 0x06, 0x25, 0x4a, 0x73, 0xac, 0xdf, 0x18, 0x2f, 0xcd, 0x69, 0x9d, 0x4d, 0x69, 0xf3,
+	0x04, 0x04, 0x9f, 0x9a, 0xb1, 0xb6, 0x22, 0x97, 0x9a, 0x69, 0x6d, 0x4c, 0x69, 0x2d, 0x8b, 0xcd,
+	0x57, 0xe7, 0xb0, 0x7b, 0xc8, 0x55, 0xe7, 0x64, 0x8f, 0x52, 0x92, 0xd2, 0x58, 0xdc, 0xa2, 0xcd,
+	0xa7, 0x76, 0x30, 0xe3, 0xe5, 0x29, 0xb3, 0xc3, 0xf9, 0x18, 0xed, 0x45, 0xc8, 0x05, 0xbd, 0x48,
+	0xee, 0x95, 0x9e, 0xe8, 0x63, 0x4a, 0x4b, 0x29, 0x1c, 0x3e, 0xab, 0x86, 0x1a, 0x90, 0x5c, 0x56,
+	0x65, 0x35, 0x2e, 0x25, 0xf9, 0x24, 0x08, 0xbf, 0xe2, 0xc9, 0x86, 0x22, 0xe2, 0xf7, 0x6f, 0x6a,
+	0xc3, 0x52, 0xda, 0x3c, 0x01, 0xc1, 0x6f, 0xf1, 0x8c, 0x5e, 0x07, 0xb7, 0xc5, 0x4f, 0xee, 0x97,
+	0x48, 0x17, 0x1f, 0x0f, 0x8c, 0x95, 0xaa, 0xf8, 0xaf, 0x8e, 0xf8, 0x52, 0x95, 0xfa, 0x43, 0x26,
+	0x69, 0x23, 0x1b, 0x10, 0xe8, 0x2d, 0x5d, 0xfb, 0xe4, 0xd1, 0x9a, 0xf0, 0xe9, 0xa3, 0x35, 0xe1,
+	0xb3, 0x47, 0x6b, 0xc2, 0xbf, 0x5f, 0xde, 0xb3, 0xbc, 0xfd, 0xc1, 0xee, 0x56, 0xc7, 0x3e, 0xd8,
+	0x26, 0x3f, 0x92, 0x38, 0xee, 0x62, 0x87, 0x7f, 0x3a, 0xba, 0xb2, 0xed, 0x3a, 0x1d, 0xfa, 0xb3,
+	0xb0, 0xdd, 0xd3, 0xb4, 0x81, 0x78, 0xf5, 0x1f, 0x03, 0x00, 0x4f, 0x54, 0x22, 0x64, 0x2a, 0x26,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3410,6 +3842,9 @@ type APIClient interface {
 	GetRobotToken(ctx context.Context, in *GetRobotTokenRequest, opts ...grpc.CallOption) (*GetRobotTokenResponse, error)
 	RevokeAuthToken(ctx context.Context, in *RevokeAuthTokenRequest, opts ...grpc.CallOption) (*RevokeAuthTokenResponse, error)
 	RevokeAuthTokensForUser(ctx context.Context, in *RevokeAuthTokensForUserRequest, opts ...grpc.CallOption) (*RevokeAuthTokensForUserResponse, error)
+	CreateS3Credentials(ctx context.Context, in *CreateS3CredentialsRequest, opts ...grpc.CallOption) (*CreateS3CredentialsResponse, error)
+	ListS3Credentials(ctx context.Context, in *ListS3CredentialsRequest, opts ...grpc.CallOption) (*ListS3CredentialsResponse, error)
+	RevokeS3Credentials(ctx context.Context, in *RevokeS3CredentialsRequest, opts ...grpc.CallOption) (*RevokeS3CredentialsResponse, error)
 	SetGroupsForUser(ctx context.Context, in *SetGroupsForUserRequest, opts ...grpc.CallOption) (*SetGroupsForUserResponse, error)
 	ModifyMembers(ctx context.Context, in *ModifyMembersRequest, opts ...grpc.CallOption) (*ModifyMembersResponse, error)
 	GetGroups(ctx context.Context, in *GetGroupsRequest, opts ...grpc.CallOption) (*GetGroupsResponse, error)
@@ -3573,34 +4008,61 @@ func (c *aPIClient) RevokeAuthTokensForUser(ctx context.Context, in *RevokeAuthT
 	return out, nil
 }
 
-func (c *aPIClient) SetGroupsForUser(ctx context.Context, in *SetGroupsForUserRequest, opts ...grpc.CallOption) (*SetGroupsForUserResponse, error) {
-	out := new(SetGroupsForUserResponse)
-	err := c.cc.Invoke(ctx, "/auth_v2.API/SetGroupsForUser", in, out, opts...)
+func (c *aPIClient) CreateS3Credentials(ctx context.Context, in *CreateS3CredentialsRequest, opts ...grpc.CallOption) (*CreateS3CredentialsResponse, error) {
+	out := new(CreateS3CredentialsResponse)
+	err := c.cc.Invoke(ctx, "/auth_v2.API/CreateS3Credentials", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *aPIClient) ModifyMembers(ctx context.Context, in *ModifyMembersRequest, opts ...grpc.CallOption) (*ModifyMembersResponse, error) {
-	out := new(ModifyMembersResponse)
-	err := c.cc.Invoke(ctx, "/auth_v2.API/ModifyMembers", in, out, opts...)
+func (c *aPIClient) ListS3Credentials(ctx context.Context, in *ListS3CredentialsRequest, opts ...grpc.CallOption) (*ListS3CredentialsResponse, error) {
+	out := new(ListS3CredentialsResponse)
+	err := c.cc.Invoke(ctx, "/auth_v2.API/ListS3Credentials", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *aPIClient) GetGroups(ctx context.Context, in *GetGroupsRequest, opts ...grpc.CallOption) (*GetGroupsResponse, error) {
-	out := new(GetGroupsResponse)
-	err := c.cc.Invoke(ctx, "/auth_v2.API/GetGroups", in, out, opts...)
+func (c *aPIClient) RevokeS3Credentials(ctx context.Context, in *RevokeS3CredentialsRequest, opts ...grpc.CallOption) (*RevokeS3CredentialsResponse, error) {
+	out := new(RevokeS3CredentialsResponse)
+	err := c.cc.Invoke(ctx, "/auth_v2.API/RevokeS3Credentials", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *aPIClient) GetGroupsForPrincipal(ctx context.Context, in *GetGroupsForPrincipalRequest, opts ...grpc.CallOption) (*GetGroupsResponse, error) {
+func (c *aPIClient) SetGroupsForUser(ctx context.Context, in *SetGroupsForUserRequest, opts ...grpc.CallOption) (*SetGroupsForUserResponse, error) {
+	out := new(SetGroupsForUserResponse)
+	err := c.cc.Invoke(ctx, "/auth_v2.API/SetGroupsForUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ModifyMembers(ctx context.Context, in *ModifyMembersRequest, opts ...grpc.CallOption) (*ModifyMembersResponse, error) {
+	out := new(ModifyMembersResponse)
+	err := c.cc.Invoke(ctx, "/auth_v2.API/ModifyMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetGroups(ctx context.Context, in *GetGroupsRequest, opts ...grpc.CallOption) (*GetGroupsResponse, error) {
+	out := new(GetGroupsResponse)
+	err := c.cc.Invoke(ctx, "/auth_v2.API/GetGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetGroupsForPrincipal(ctx context.Context, in *GetGroupsForPrincipalRequest, opts ...grpc.CallOption) (*GetGroupsResponse, error) {
 	out := new(GetGroupsResponse)
 	err := c.cc.Invoke(ctx, "/auth_v2.API/GetGroupsForPrincipal", in, out, opts...)
 	if err != nil {
@@ -3675,6 +4137,9 @@ type APIServer interface {
 	GetRobotToken(context.Context, *GetRobotTokenRequest) (*GetRobotTokenResponse, error)
 	RevokeAuthToken(context.Context, *RevokeAuthTokenRequest) (*RevokeAuthTokenResponse, error)
 	RevokeAuthTokensForUser(context.Context, *RevokeAuthTokensForUserRequest) (*RevokeAuthTokensForUserResponse, error)
+	CreateS3Credentials(context.Context, *CreateS3CredentialsRequest) (*CreateS3CredentialsResponse, error)
+	ListS3Credentials(context.Context, *ListS3CredentialsRequest) (*ListS3CredentialsResponse, error)
+	RevokeS3Credentials(context.Context, *RevokeS3CredentialsRequest) (*RevokeS3CredentialsResponse, error)
 	SetGroupsForUser(context.Context, *SetGroupsForUserRequest) (*SetGroupsForUserResponse, error)
 	ModifyMembers(context.Context, *ModifyMembersRequest) (*ModifyMembersResponse, error)
 	GetGroups(context.Context, *GetGroupsRequest) (*GetGroupsResponse, error)
@@ -3738,6 +4203,15 @@ func (*UnimplementedAPIServer) RevokeAuthToken(ctx context.Context, req *RevokeA
 func (*UnimplementedAPIServer) RevokeAuthTokensForUser(ctx context.Context, req *RevokeAuthTokensForUserRequest) (*RevokeAuthTokensForUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RevokeAuthTokensForUser not implemented")
 }
+func (*UnimplementedAPIServer) CreateS3Credentials(ctx context.Context, req *CreateS3CredentialsRequest) (*CreateS3CredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateS3Credentials not implemented")
+}
+func (*UnimplementedAPIServer) ListS3Credentials(ctx context.Context, req *ListS3CredentialsRequest) (*ListS3CredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListS3Credentials not implemented")
+}
+func (*UnimplementedAPIServer) RevokeS3Credentials(ctx context.Context, req *RevokeS3CredentialsRequest) (*RevokeS3CredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeS3Credentials not implemented")
+}
 func (*UnimplementedAPIServer) SetGroupsForUser(ctx context.Context, req *SetGroupsForUserRequest) (*SetGroupsForUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetGroupsForUser not implemented")
 }
@@ -4058,6 +4532,60 @@ func _API_RevokeAuthTokensForUser_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_CreateS3Credentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateS3CredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateS3Credentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_v2.API/CreateS3Credentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateS3Credentials(ctx, req.(*CreateS3CredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListS3Credentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListS3CredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListS3Credentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_v2.API/ListS3Credentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListS3Credentials(ctx, req.(*ListS3CredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RevokeS3Credentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeS3CredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RevokeS3Credentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_v2.API/RevokeS3Credentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RevokeS3Credentials(ctx, req.(*RevokeS3CredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_SetGroupsForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetGroupsForUserRequest)
 	if err := dec(in); err != nil {
@@ -4288,6 +4816,18 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeAuthTokensForUser",
 			Handler:    _API_RevokeAuthTokensForUser_Handler,
 		},
+		{
+			MethodName: "CreateS3Credentials",
+			Handler:    _API_CreateS3Credentials_Handler,
+		},
+		{
+			MethodName: "ListS3Credentials",
+			Handler:    _API_ListS3Credentials_Handler,
+		},
+		{
+			MethodName: "RevokeS3Credentials",
+			Handler:    _API_RevokeS3Credentials_Handler,
+		},
 		{
 			MethodName: "SetGroupsForUser",
 			Handler:    _API_SetGroupsForUser_Handler,
@@ -6521,7 +7061,7 @@ func (m *RevokeAuthTokensForUserResponse) MarshalToSizedBuffer(dAtA []byte) (int
 	return len(dAtA) - i, nil
 }
 
-func (m *DeleteExpiredAuthTokensRequest) Marshal() (dAtA []byte, err error) {
+func (m *S3Credential) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6531,12 +7071,12 @@ func (m *DeleteExpiredAuthTokensRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteExpiredAuthTokensRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *S3Credential) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteExpiredAuthTokensRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *S3Credential) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -6545,10 +7085,67 @@ func (m *DeleteExpiredAuthTokensRequest) MarshalToSizedBuffer(dAtA []byte) (int,
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Created != nil {
+		{
+			size, err := m.Created.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAuth(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Expiration != nil {
+		{
+			size, err := m.Expiration.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAuth(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Buckets) > 0 {
+		for iNdEx := len(m.Buckets) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Buckets[iNdEx])
+			copy(dAtA[i:], m.Buckets[iNdEx])
+			i = encodeVarintAuth(dAtA, i, uint64(len(m.Buckets[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.ReadOnly {
+		i--
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Principal) > 0 {
+		i -= len(m.Principal)
+		copy(dAtA[i:], m.Principal)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Principal)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AccessKeyID) > 0 {
+		i -= len(m.AccessKeyID)
+		copy(dAtA[i:], m.AccessKeyID)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.AccessKeyID)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DeleteExpiredAuthTokensResponse) Marshal() (dAtA []byte, err error) {
+func (m *CreateS3CredentialsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6558,12 +7155,12 @@ func (m *DeleteExpiredAuthTokensResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteExpiredAuthTokensResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateS3CredentialsRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteExpiredAuthTokensResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CreateS3CredentialsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -6572,75 +7169,342 @@ func (m *DeleteExpiredAuthTokensResponse) MarshalToSizedBuffer(dAtA []byte) (int
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return len(dAtA) - i, nil
-}
-
-func encodeVarintAuth(dAtA []byte, offset int, v uint64) int {
-	offset -= sovAuth(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	if m.TTL != 0 {
+		i = encodeVarintAuth(dAtA, i, uint64(m.TTL))
+		i--
+		dAtA[i] = 0x20
 	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *ActivateRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Buckets) > 0 {
+		for iNdEx := len(m.Buckets) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Buckets[iNdEx])
+			copy(dAtA[i:], m.Buckets[iNdEx])
+			i = encodeVarintAuth(dAtA, i, uint64(len(m.Buckets[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	var l int
-	_ = l
-	l = len(m.RootToken)
-	if l > 0 {
-		n += 1 + l + sovAuth(uint64(l))
+	if m.ReadOnly {
+		i--
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Principal) > 0 {
+		i -= len(m.Principal)
+		copy(dAtA[i:], m.Principal)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Principal)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ActivateResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CreateS3CredentialsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateS3CredentialsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateS3CredentialsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.PachToken)
-	if l > 0 {
-		n += 1 + l + sovAuth(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.SecretAccessKey) > 0 {
+		i -= len(m.SecretAccessKey)
+		copy(dAtA[i:], m.SecretAccessKey)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.SecretAccessKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Credential != nil {
+		{
+			size, err := m.Credential.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAuth(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *DeactivateRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListS3CredentialsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ListS3CredentialsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListS3CredentialsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Principal) > 0 {
+		i -= len(m.Principal)
+		copy(dAtA[i:], m.Principal)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Principal)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *DeactivateResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListS3CredentialsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ListS3CredentialsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListS3CredentialsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
+	if len(m.Credentials) > 0 {
+		for iNdEx := len(m.Credentials) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Credentials[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAuth(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RevokeS3CredentialsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeS3CredentialsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RevokeS3CredentialsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.AccessKeyID) > 0 {
+		i -= len(m.AccessKeyID)
+		copy(dAtA[i:], m.AccessKeyID)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.AccessKeyID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RevokeS3CredentialsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeS3CredentialsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RevokeS3CredentialsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteExpiredAuthTokensRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteExpiredAuthTokensRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteExpiredAuthTokensRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteExpiredAuthTokensResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteExpiredAuthTokensResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteExpiredAuthTokensResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAuth(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAuth(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ActivateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RootToken)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ActivateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PachToken)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeactivateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeactivateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
 
 func (m *RotateRootTokenRequest) Size() (n int) {
 	if m == nil {
@@ -7578,42 +8442,189 @@ func (m *RevokeAuthTokensForUserResponse) Size() (n int) {
 	return n
 }
 
-func (m *DeleteExpiredAuthTokensRequest) Size() (n int) {
+func (m *S3Credential) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = len(m.AccessKeyID)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.Principal)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.ReadOnly {
+		n += 2
+	}
+	if len(m.Buckets) > 0 {
+		for _, s := range m.Buckets {
+			l = len(s)
+			n += 1 + l + sovAuth(uint64(l))
+		}
+	}
+	if m.Expiration != nil {
+		l = m.Expiration.Size()
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.Created != nil {
+		l = m.Created.Size()
+		n += 1 + l + sovAuth(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *DeleteExpiredAuthTokensResponse) Size() (n int) {
+func (m *CreateS3CredentialsRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = len(m.Principal)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.ReadOnly {
+		n += 2
+	}
+	if len(m.Buckets) > 0 {
+		for _, s := range m.Buckets {
+			l = len(s)
+			n += 1 + l + sovAuth(uint64(l))
+		}
+	}
+	if m.TTL != 0 {
+		n += 1 + sovAuth(uint64(m.TTL))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func sovAuth(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *CreateS3CredentialsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Credential != nil {
+		l = m.Credential.Size()
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.SecretAccessKey)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func sozAuth(x uint64) (n int) {
-	return sovAuth(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *ListS3CredentialsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Principal)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
+
+func (m *ListS3CredentialsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Credentials) > 0 {
+		for _, e := range m.Credentials {
+			l = e.Size()
+			n += 1 + l + sovAuth(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RevokeS3CredentialsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AccessKeyID)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RevokeS3CredentialsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteExpiredAuthTokensRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteExpiredAuthTokensResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovAuth(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAuth(x uint64) (n int) {
+	return sovAuth(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
 		for shift := uint(0); ; shift += 7 {
 			if shift >= 64 {
 				return ErrIntOverflowAuth
@@ -12556,9 +13567,818 @@ func (m *GetGroupsResponse) Unmarshal(dAtA []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Groups = append(m.Groups, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetUsersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetUsersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetUsersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Group = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetUsersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetUsersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetUsersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usernames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Usernames = append(m.Usernames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExtractAuthTokensRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExtractAuthTokensRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExtractAuthTokensRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExtractAuthTokensResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExtractAuthTokensResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExtractAuthTokensResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tokens", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tokens = append(m.Tokens, &TokenInfo{})
+			if err := m.Tokens[len(m.Tokens)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RestoreAuthTokenRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RestoreAuthTokenRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RestoreAuthTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Token == nil {
+				m.Token = &TokenInfo{}
+			}
+			if err := m.Token.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RestoreAuthTokenResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RestoreAuthTokenResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RestoreAuthTokenResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RevokeAuthTokensForUserRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RevokeAuthTokensForUserRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RevokeAuthTokensForUserRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Username", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Username = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RevokeAuthTokensForUserResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RevokeAuthTokensForUserResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RevokeAuthTokensForUserResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *S3Credential) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: S3Credential: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: S3Credential: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessKeyID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccessKeyID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Principal", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Principal = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Buckets", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Buckets = append(m.Buckets, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expiration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expiration == nil {
+				m.Expiration = &types.Timestamp{}
+			}
+			if err := m.Expiration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAuth
@@ -12568,23 +14388,27 @@ func (m *GetGroupsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAuth
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAuth
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Groups = append(m.Groups, string(dAtA[iNdEx:postIndex]))
+			if m.Created == nil {
+				m.Created = &types.Timestamp{}
+			}
+			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12608,7 +14432,7 @@ func (m *GetGroupsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetUsersRequest) Unmarshal(dAtA []byte) error {
+func (m *CreateS3CredentialsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12631,15 +14455,15 @@ func (m *GetUsersRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetUsersRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateS3CredentialsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetUsersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateS3CredentialsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Principal", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12667,62 +14491,31 @@ func (m *GetUsersRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Group = string(dAtA[iNdEx:postIndex])
+			m.Principal = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAuth(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAuth
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetUsersResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAuth
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetUsersResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetUsersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.ReadOnly = bool(v != 0)
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Usernames", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Buckets", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12750,59 +14543,27 @@ func (m *GetUsersResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Usernames = append(m.Usernames, string(dAtA[iNdEx:postIndex]))
+			m.Buckets = append(m.Buckets, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAuth(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAuth
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ExtractAuthTokensRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAuth
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.TTL = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TTL |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ExtractAuthTokensRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExtractAuthTokensRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -12825,7 +14586,7 @@ func (m *ExtractAuthTokensRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExtractAuthTokensResponse) Unmarshal(dAtA []byte) error {
+func (m *CreateS3CredentialsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12848,15 +14609,15 @@ func (m *ExtractAuthTokensResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExtractAuthTokensResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateS3CredentialsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExtractAuthTokensResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateS3CredentialsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tokens", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Credential", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12883,11 +14644,45 @@ func (m *ExtractAuthTokensResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tokens = append(m.Tokens, &TokenInfo{})
-			if err := m.Tokens[len(m.Tokens)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Credential == nil {
+				m.Credential = &S3Credential{}
+			}
+			if err := m.Credential.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecretAccessKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecretAccessKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -12910,7 +14705,7 @@ func (m *ExtractAuthTokensResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RestoreAuthTokenRequest) Unmarshal(dAtA []byte) error {
+func (m *ListS3CredentialsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12933,17 +14728,17 @@ func (m *RestoreAuthTokenRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RestoreAuthTokenRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListS3CredentialsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RestoreAuthTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListS3CredentialsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Principal", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAuth
@@ -12953,27 +14748,23 @@ func (m *RestoreAuthTokenRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAuth
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAuth
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Token == nil {
-				m.Token = &TokenInfo{}
-			}
-			if err := m.Token.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Principal = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12997,7 +14788,7 @@ func (m *RestoreAuthTokenRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RestoreAuthTokenResponse) Unmarshal(dAtA []byte) error {
+func (m *ListS3CredentialsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13020,12 +14811,46 @@ func (m *RestoreAuthTokenResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RestoreAuthTokenResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListS3CredentialsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RestoreAuthTokenResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListS3CredentialsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Credentials", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Credentials = append(m.Credentials, &S3Credential{})
+			if err := m.Credentials[len(m.Credentials)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -13048,7 +14873,7 @@ func (m *RestoreAuthTokenResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RevokeAuthTokensForUserRequest) Unmarshal(dAtA []byte) error {
+func (m *RevokeS3CredentialsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13071,15 +14896,15 @@ func (m *RevokeAuthTokensForUserRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RevokeAuthTokensForUserRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RevokeS3CredentialsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RevokeAuthTokensForUserRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RevokeS3CredentialsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Username", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessKeyID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13107,7 +14932,7 @@ func (m *RevokeAuthTokensForUserRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Username = string(dAtA[iNdEx:postIndex])
+			m.AccessKeyID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13131,7 +14956,7 @@ func (m *RevokeAuthTokensForUserRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RevokeAuthTokensForUserResponse) Unmarshal(dAtA []byte) error {
+func (m *RevokeS3CredentialsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13154,10 +14979,10 @@ func (m *RevokeAuthTokensForUserResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RevokeAuthTokensForUserResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: RevokeS3CredentialsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RevokeAuthTokensForUserResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RevokeS3CredentialsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -690,6 +690,14 @@ func (c *APIClient) Close() error {
 // Use with caution, there is no undo.
 // TODO: rewrite this to use transactions
 func (c APIClient) DeleteAll() error {
+	// Check that no repo is under a retention lock before deleting the state
+	// of any service, since PFS can't delete a locked repo.
+	if _, err := c.PfsAPIClient.CheckDeleteAll(
+		c.Ctx(),
+		&types.Empty{},
+	); err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
 	if _, err := c.IdentityAPIClient.DeleteAll(
 		c.Ctx(),
 		&identity.DeleteAllRequest{},
@@ -51,3 +51,18 @@ func (c APIClient) Dump(filter *debug.Filter, limit int64, w io.Writer) (retErr
 	}
 	return grpcutil.WriteFromStreamingBytesClient(dumpC, w)
 }
+
+// SetStorageBandwidth sets the object storage bandwidth limits, in bytes per
+// second, of pachd and the workers selected by filter. A limit of 0 means
+// unlimited. Only the pachd replica which serves the call is limited, so
+// clusters with several pachd replicas should limit them through their
+// configuration instead. It requires the clusterAdmin role.
+func (c APIClient) SetStorageBandwidth(filter *debug.Filter, uploadBytesPerSecond, downloadBytesPerSecond, burstBytes int64) error {
+	_, err := c.DebugClient.SetStorageBandwidth(c.Ctx(), &debug.SetStorageBandwidthRequest{
+		Filter:                 filter,
+		UploadBytesPerSecond:   uploadBytesPerSecond,
+		DownloadBytesPerSecond: downloadBytesPerSecond,
+		BurstBytes:             burstBytes,
+	})
+	return grpcutil.ScrubGRPC(err)
+}
@@ -3,8 +3,9 @@ package client
 import "github.com/pachyderm/pachyderm/v2/src/pfs"
 
 type putFileConfig struct {
-	datum  string
-	append bool
+	datum       string
+	append      bool
+	byReference bool
 }
 
 // PutFileOption configures a PutFile call.
@@ -24,6 +25,16 @@ func WithDatumPutFile(datum string) PutFileOption {
 	}
 }
 
+// WithByReferencePutFile configures a PutFileURL call to reference the
+// objects at the URL rather than copying their content. Only object storage
+// URLs can be added by reference, and files added by reference cannot be
+// appended to.
+func WithByReferencePutFile() PutFileOption {
+	return func(pf *putFileConfig) {
+		pf.byReference = true
+	}
+}
+
 type deleteFileConfig struct {
 	datum     string
 	recursive bool
@@ -63,6 +74,17 @@ func WithDatumCopyFile(datum string) CopyFileOption {
 	}
 }
 
+// SetFileMetadataOption configures a SetFileMetadata call.
+type SetFileMetadataOption func(*pfs.SetFileMetadata)
+
+// WithDatumSetFileMetadata configures the SetFileMetadata call to apply to a
+// particular datum.
+func WithDatumSetFileMetadata(datum string) SetFileMetadataOption {
+	return func(sfm *pfs.SetFileMetadata) {
+		sfm.Datum = datum
+	}
+}
+
 // GetFileOption configures a GetFile call
 type GetFileOption func(*pfs.GetFileRequest)
 
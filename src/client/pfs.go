@@ -3,7 +3,9 @@ package client
 import (
 	"context"
 	"io"
+	"time"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/v2/src/internal/clientsdk"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
@@ -64,6 +66,40 @@ func (c APIClient) CreateRepo(repoName string) error {
 	return grpcutil.ScrubGRPC(err)
 }
 
+// CreateRepoWithRetention creates a new Repo object in PFS whose finished
+// commits cannot be squashed or dropped, and whose branches cannot be deleted,
+// until `retention` has passed since they were finished.
+func (c APIClient) CreateRepoWithRetention(repoName string, retention time.Duration) error {
+	_, err := c.PfsAPIClient.CreateRepo(
+		c.Ctx(),
+		&pfs.CreateRepoRequest{
+			Repo:      NewRepo(repoName),
+			Retention: types.DurationProto(retention),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// ExtendRepoRetention extends the retention period of a repo to
+// `retention`. The retention period of a repo cannot be shortened.
+func (c APIClient) ExtendRepoRetention(repoName string, retention time.Duration) (retErr error) {
+	defer func() { retErr = grpcutil.ScrubGRPC(retErr) }()
+	repoInfo, err := c.PfsAPIClient.InspectRepo(c.Ctx(), &pfs.InspectRepoRequest{Repo: NewRepo(repoName)})
+	if err != nil {
+		return err
+	}
+	_, err = c.PfsAPIClient.CreateRepo(
+		c.Ctx(),
+		&pfs.CreateRepoRequest{
+			Repo:        repoInfo.Repo,
+			Description: repoInfo.Description,
+			Update:      true,
+			Retention:   types.DurationProto(retention),
+		},
+	)
+	return err
+}
+
 // UpdateRepo upserts a repo with the given name.
 func (c APIClient) UpdateRepo(repoName string) error {
 	_, err := c.PfsAPIClient.CreateRepo(
@@ -89,6 +125,20 @@ func (c APIClient) InspectRepo(repoName string) (_ *pfs.RepoInfo, retErr error)
 	)
 }
 
+// RepoDiskUsage compares the logical size of the history of a repo with the
+// size of the distinct chunks it references.
+func (c APIClient) RepoDiskUsage(repoName string) (_ *pfs.RepoDiskUsageResponse, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	return c.PfsAPIClient.RepoDiskUsage(
+		c.Ctx(),
+		&pfs.RepoDiskUsageRequest{
+			Repo: NewRepo(repoName),
+		},
+	)
+}
+
 // ListRepo returns info about user Repos
 func (c APIClient) ListRepo() ([]*pfs.RepoInfo, error) {
 	return c.ListRepoByType(pfs.UserRepoType)
@@ -110,6 +160,39 @@ func (c APIClient) ListRepoByType(repoType string) (_ []*pfs.RepoInfo, retErr er
 	return clientsdk.ListRepoInfo(client)
 }
 
+// ListRepoPage lists one page of the repos described by req, calling cb with
+// each RepoInfo. It returns the token for the next page, which is empty when
+// there are no more repos or cb stopped the listing early.
+func (c APIClient) ListRepoPage(req *pfs.ListRepoRequest, cb func(*pfs.RepoInfo) error) (_ string, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.ListRepo(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	// the last result of a page carries the token for the next page
+	var token string
+	for {
+		ri, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return token, nil
+			}
+			return "", err
+		}
+		token = ri.NextPageToken
+		if err := cb(ri); err != nil {
+			if errors.Is(err, errutil.ErrBreak) {
+				return "", nil
+			}
+			return "", err
+		}
+	}
+}
+
 // DeleteRepo deletes a repo and reclaims the storage space it was using. Note
 // that as of 1.0 we do not reclaim the blocks that the Repo was referencing,
 // this is because they may also be referenced by other Repos and deleting them
@@ -198,6 +281,43 @@ func (c APIClient) InspectCommit(repoName string, branchName string, commitID st
 	return c.inspectCommit(repoName, branchName, commitID, pfs.CommitState_STARTED)
 }
 
+// VerifyCommit recomputes the hash of a commit and compares it with the hash
+// computed when the commit was finished. If ancestors is true the ancestors
+// of the commit are verified as well. A commit which fails verification has
+// an error in its response.
+func (c APIClient) VerifyCommit(repoName string, branchName string, commitID string, ancestors bool) (_ []*pfs.VerifyCommitResponse, retErr error) {
+	defer func() { retErr = grpcutil.ScrubGRPC(retErr) }()
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.VerifyCommit(ctx, &pfs.VerifyCommitRequest{
+		Commit:    NewCommit(repoName, branchName, commitID),
+		Ancestors: ancestors,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var resps []*pfs.VerifyCommitResponse
+	for {
+		resp, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return resps, nil
+			}
+			return nil, err
+		}
+		resps = append(resps, resp)
+	}
+}
+
+// AttestCommit verifies a commit and returns an attestation of its hash
+// signed by the cluster, which can be checked with its Verify method.
+func (c APIClient) AttestCommit(repoName string, branchName string, commitID string) (_ *pfs.CommitAttestation, retErr error) {
+	defer func() { retErr = grpcutil.ScrubGRPC(retErr) }()
+	return c.PfsAPIClient.AttestCommit(c.Ctx(), &pfs.AttestCommitRequest{
+		Commit: NewCommit(repoName, branchName, commitID),
+	})
+}
+
 // WaitCommit returns info about a specific Commit, but blocks until that
 // commit has been finished.
 func (c APIClient) WaitCommit(repoName string, branchName string, commitID string) (_ *pfs.CommitInfo, retErr error) {
@@ -280,6 +400,39 @@ func (c APIClient) ListCommitF(repo *pfs.Repo, to, from *pfs.Commit, number int6
 	return nil
 }
 
+// ListCommitPage lists one page of the commits described by req, calling cb
+// with each CommitInfo. It returns the token for the next page, which is empty
+// when there are no more commits or cb stopped the listing early.
+func (c APIClient) ListCommitPage(req *pfs.ListCommitRequest, cb func(*pfs.CommitInfo) error) (_ string, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	stream, err := c.PfsAPIClient.ListCommit(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	// the last result of a page carries the token for the next page
+	var token string
+	for {
+		ci, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return token, nil
+			}
+			return "", err
+		}
+		token = ci.NextPageToken
+		if err := cb(ci); err != nil {
+			if errors.Is(err, errutil.ErrBreak) {
+				return "", nil
+			}
+			return "", err
+		}
+	}
+}
+
 // ListCommitByRepo lists all commits in a repo.
 func (c APIClient) ListCommitByRepo(repo *pfs.Repo) ([]*pfs.CommitInfo, error) {
 	return c.ListCommit(repo, nil, nil, 0)
@@ -366,6 +519,47 @@ func (c APIClient) DeleteBranch(repoName string, branchName string, force bool)
 	return grpcutil.ScrubGRPC(err)
 }
 
+// ListReflog returns the moves of a branch head, from newest to oldest.
+// `number` limits the number of entries returned, 0 returns all of them.
+func (c APIClient) ListReflog(repoName string, branchName string, number int64) (_ []*pfs.ReflogEntry, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.ListReflog(ctx, &pfs.ListReflogRequest{
+		Branch: NewBranch(repoName, branchName),
+		Number: number,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var entries []*pfs.ReflogEntry
+	for {
+		entry, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// ResetBranch moves a branch head back to the new head of the reflog entry
+// at `index`, where 0 is the most recent move.
+func (c APIClient) ResetBranch(repoName string, branchName string, index int64) error {
+	_, err := c.PfsAPIClient.ResetBranch(
+		c.Ctx(),
+		&pfs.ResetBranchRequest{
+			Branch:      NewBranch(repoName, branchName),
+			ReflogIndex: index,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
 func (c APIClient) inspectCommitSet(id string, wait bool, cb func(*pfs.CommitInfo) error) error {
 	req := &pfs.InspectCommitSetRequest{
 		CommitSet: NewCommitSet(id),
@@ -489,6 +683,39 @@ func (c APIClient) SubscribeCommit(repo *pfs.Repo, branchName string, from strin
 	}
 }
 
+// SubscribeFile calls cb for each finished commit on a branch which changed
+// files that match a glob pattern, with the changes to those files. If from is
+// set, only the commits created after it are considered, so a subscription
+// can be resumed from the last commit it processed.
+func (c APIClient) SubscribeFile(branch *pfs.Branch, pattern string, from *pfs.Commit, cb func(*pfs.SubscribeFileEvent) error) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	client, err := c.PfsAPIClient.SubscribeFile(c.Ctx(), &pfs.SubscribeFileRequest{
+		Branch:  branch,
+		Pattern: pattern,
+		From:    from,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := cb(event); err != nil {
+			if errors.Is(err, errutil.ErrBreak) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
 // ClearCommit clears the state of an open commit.
 func (c APIClient) ClearCommit(repoName string, branchName string, commitID string) (retErr error) {
 	defer func() {
@@ -503,12 +730,75 @@ func (c APIClient) ClearCommit(repoName string, branchName string, commitID stri
 	return err
 }
 
+// CompactCommit fully compacts the file set layers of a commit.
+func (c APIClient) CompactCommit(repoName string, branchName string, commitID string) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	_, err := c.PfsAPIClient.CompactCommit(
+		c.Ctx(),
+		&pfs.CompactCommitRequest{
+			Commit: NewCommit(repoName, branchName, commitID),
+		},
+	)
+	return err
+}
+
+// MaterializeCommit copies the content of the external objects referenced by
+// a finished commit into the commit. The hashes of the materialized files
+// change.
+func (c APIClient) MaterializeCommit(repoName string, branchName string, commitID string) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	_, err := c.PfsAPIClient.MaterializeCommit(
+		c.Ctx(),
+		&pfs.MaterializeCommitRequest{
+			Commit: NewCommit(repoName, branchName, commitID),
+		},
+	)
+	return err
+}
+
+// MaterializeCommitInBackground queues a commit to be materialized by pachd
+// once it is finished, and returns without waiting for it.
+func (c APIClient) MaterializeCommitInBackground(repoName string, branchName string, commitID string) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	_, err := c.PfsAPIClient.MaterializeCommit(
+		c.Ctx(),
+		&pfs.MaterializeCommitRequest{
+			Commit:     NewCommit(repoName, branchName, commitID),
+			Background: true,
+		},
+	)
+	return err
+}
+
 // Fsck performs checks on pfs. Errors that are encountered will be passed
 // onError. These aren't errors in the traditional sense, in that they don't
 // prevent the completion of fsck. Errors that do prevent completion will be
 // returned from the function.
 func (c APIClient) Fsck(fix bool, cb func(*pfs.FsckResponse) error) error {
-	fsckClient, err := c.PfsAPIClient.Fsck(c.Ctx(), &pfs.FsckRequest{Fix: fix})
+	return c.fsck(&pfs.FsckRequest{Fix: fix}, cb)
+}
+
+// FsckDeep performs the same checks as Fsck, and also checks that the chunks
+// referenced by every commit exist in object storage. If readChunkData is
+// true, the chunks are also read and verified. If resume is true, the check
+// continues from the checkpoint of a previous interrupted deep check.
+func (c APIClient) FsckDeep(fix, readChunkData, resume bool, cb func(*pfs.FsckResponse) error) error {
+	return c.fsck(&pfs.FsckRequest{
+		Fix:           fix,
+		Deep:          true,
+		ReadChunkData: readChunkData,
+		Resume:        resume,
+	}, cb)
+}
+
+func (c APIClient) fsck(req *pfs.FsckRequest, cb func(*pfs.FsckResponse) error) error {
+	fsckClient, err := c.PfsAPIClient.Fsck(c.Ctx(), req)
 	if err != nil {
 		return grpcutil.ScrubGRPC(err)
 	}
@@ -530,6 +820,34 @@ func (c APIClient) Fsck(fix bool, cb func(*pfs.FsckResponse) error) error {
 	return nil
 }
 
+// ReclaimOrphanedObjects finds the chunk objects in object storage which are
+// not referenced by the chunk metadata, calling cb with each of them. Orphans
+// which are at least minAge old are reclaimed, unless dryRun is true.
+func (c APIClient) ReclaimOrphanedObjects(minAge time.Duration, dryRun bool, cb func(*pfs.OrphanedObject) error) error {
+	client, err := c.PfsAPIClient.ReclaimOrphanedObjects(c.Ctx(), &pfs.ReclaimOrphanedObjectsRequest{
+		MinAge: types.DurationProto(minAge),
+		DryRun: dryRun,
+	})
+	if err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	for {
+		o, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return grpcutil.ScrubGRPC(err)
+		}
+		if err := cb(o); err != nil {
+			if errors.Is(err, errutil.ErrBreak) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
 // FsckFastExit performs checks on pfs, similar to Fsck, except that it returns the
 // first fsck error it encounters and exits.
 func (c APIClient) FsckFastExit() error {
@@ -54,6 +54,15 @@ func (c APIClient) CopyFile(dstCommit *pfs.Commit, dstPath string, srcCommit *pf
 	})
 }
 
+// SetFileMetadata replaces the key-value metadata of a file in PFS. The
+// metadata is kept when the file is appended to, and dropped when it is
+// overwritten.
+func (c APIClient) SetFileMetadata(commit *pfs.Commit, path string, metadata map[string]string, opts ...SetFileMetadataOption) error {
+	return c.WithModifyFileClient(commit, func(mf ModifyFile) error {
+		return mf.SetFileMetadata(path, metadata, opts...)
+	})
+}
+
 // ModifyFile is used for performing a stream of file modifications.
 // The modifications are not persisted until the ModifyFileClient is closed.
 // ModifyFileClient is not thread safe. Multiple ModifyFileClients
@@ -70,6 +79,8 @@ type ModifyFile interface {
 	DeleteFile(path string, opts ...DeleteFileOption) error
 	// CopyFile copies a file from src to dst.
 	CopyFile(dst string, src *pfs.File, opts ...CopyFileOption) error
+	// SetFileMetadata replaces the metadata of a file.
+	SetFileMetadata(path string, metadata map[string]string, opts ...SetFileMetadataOption) error
 }
 
 // WithModifyFileClient creates a new ModifyFileClient that is scoped to the passed in callback.
@@ -235,6 +246,9 @@ func (mfc *modifyFileCore) PutFileURL(path, url string, recursive bool, opts ...
 		opt(config)
 	}
 	return mfc.maybeError(func() error {
+		if config.append && config.byReference {
+			return errors.Errorf("cannot append to a file by reference")
+		}
 		if !config.append {
 			if err := mfc.sendDeleteFile(&pfs.DeleteFile{
 				Path:  path,
@@ -248,8 +262,9 @@ func (mfc *modifyFileCore) PutFileURL(path, url string, recursive bool, opts ...
 			Datum: config.datum,
 			Source: &pfs.AddFile_Url{
 				Url: &pfs.AddFile_URLSource{
-					URL:       url,
-					Recursive: recursive,
+					URL:         url,
+					Recursive:   recursive,
+					ByReference: config.byReference,
 				},
 			},
 		}
@@ -303,6 +318,23 @@ func (mfc *modifyFileCore) sendCopyFile(req *pfs.CopyFile) error {
 	})
 }
 
+func (mfc *modifyFileCore) SetFileMetadata(path string, metadata map[string]string, opts ...SetFileMetadataOption) error {
+	return mfc.maybeError(func() error {
+		sfm := &pfs.SetFileMetadata{
+			Path:     path,
+			Metadata: metadata,
+		}
+		for _, opt := range opts {
+			opt(sfm)
+		}
+		return mfc.client.Send(&pfs.ModifyFileRequest{
+			Body: &pfs.ModifyFileRequest_SetFileMetadata{
+				SetFileMetadata: sfm,
+			},
+		})
+	})
+}
+
 // Close closes the ModifyFileClient.
 func (mfc *ModifyFileClient) Close() error {
 	return mfc.maybeError(func() error {
@@ -695,6 +727,72 @@ func (c APIClient) GlobFile(commit *pfs.Commit, pattern string, cb func(fi *pfs.
 	}
 }
 
+// ListFilePage lists one page of the files described by req, calling cb with
+// each FileInfo. It returns the token for the next page, which is empty when
+// there are no more files or cb stopped the listing early.
+func (c APIClient) ListFilePage(req *pfs.ListFileRequest, cb func(fi *pfs.FileInfo) error) (_ string, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.ListFile(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	// the last result of a page carries the token for the next page
+	var token string
+	for {
+		fi, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return token, nil
+			}
+			return "", err
+		}
+		token = fi.NextPageToken
+		if err := cb(fi); err != nil {
+			if errors.Is(err, errutil.ErrBreak) {
+				return "", nil
+			}
+			return "", err
+		}
+	}
+}
+
+// GlobFilePage returns one page of the files described by req, calling cb
+// with each FileInfo. It returns the token for the next page, which is empty
+// when there are no more files or cb stopped the listing early.
+func (c APIClient) GlobFilePage(req *pfs.GlobFileRequest, cb func(fi *pfs.FileInfo) error) (_ string, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	ctx, cf := context.WithCancel(c.Ctx())
+	defer cf()
+	client, err := c.PfsAPIClient.GlobFile(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	// the last result of a page carries the token for the next page
+	var token string
+	for {
+		fi, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return token, nil
+			}
+			return "", err
+		}
+		token = fi.NextPageToken
+		if err := cb(fi); err != nil {
+			if errors.Is(err, errutil.ErrBreak) {
+				return "", nil
+			}
+			return "", err
+		}
+	}
+}
+
 // GlobFileAll returns files that match a given glob pattern in a given commit.
 // The pattern is documented here: https://golang.org/pkg/path/filepath/#Match
 func (c APIClient) GlobFileAll(commit *pfs.Commit, pattern string) (_ []*pfs.FileInfo, retErr error) {
@@ -792,3 +890,35 @@ func (c APIClient) WalkFile(commit *pfs.Commit, path string, cb func(*pfs.FileIn
 		}
 	}
 }
+
+// DiskUsage calls cb with the aggregated sizes and file counts of the
+// directories under path, down to depth directory levels below path.
+func (c APIClient) DiskUsage(commit *pfs.Commit, path string, depth int64, cb func(*pfs.DiskUsageInfo) error) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	client, err := c.PfsAPIClient.DiskUsage(
+		c.Ctx(),
+		&pfs.DiskUsageRequest{
+			File:  commit.NewFile(path),
+			Depth: depth,
+		})
+	if err != nil {
+		return err
+	}
+	for {
+		info, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := cb(info); err != nil {
+			if errors.Is(err, errutil.ErrBreak) {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package client
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+var (
+	// globMetaRe matches the characters which are special in either PFS globs
+	// or fs.Glob patterns.
+	globMetaRe = regexp.MustCompile(`[*?[\]{}!()@+^\\]`)
+)
+
+// FS returns a read-only fs.FS of the files in a commit, which also
+// implements fs.ReadDirFS, fs.StatFS and fs.GlobFS. Its files implement
+// io.Seeker, and the Sys method of their fs.FileInfo returns their
+// *pfs.FileInfo.
+//
+// Each call reads the commit as it is at the time, so an FS of a branch head
+// sees the new commits made to the branch. Pass a commit with an ID for a
+// consistent view.
+func (c APIClient) FS(commit *pfs.Commit) fs.FS {
+	return &commitFS{c: c, commit: commit}
+}
+
+type commitFS struct {
+	c      APIClient
+	commit *pfs.Commit
+}
+
+func (fsys *commitFS) Open(name string) (fs.File, error) {
+	fi, info, err := fsys.stat("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return &commitDir{fsys: fsys, name: name, info: info}, nil
+	}
+	return &commitFile{c: fsys.c, file: fi.File, name: name, info: info}, nil
+}
+
+func (fsys *commitFS) Stat(name string) (fs.FileInfo, error) {
+	_, info, err := fsys.stat("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (fsys *commitFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	_, info, err := fsys.stat("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return fsys.readDir(name)
+}
+
+// Glob matches a pattern with the semantics of path.Match. Each element of
+// the pattern with special characters is globbed in PFS with "*", and the
+// results are matched against the pattern, since PFS globs have a different
+// syntax.
+func (fsys *commitFS) Glob(pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	elems := strings.Split(pattern, "/")
+	for i, elem := range elems {
+		if globMetaRe.MatchString(elem) {
+			elems[i] = "*"
+		}
+	}
+	var names []string
+	if err := fsys.c.GlobFile(fsys.commit, "/"+strings.Join(elems, "/"), func(fi *pfs.FileInfo) error {
+		name := strings.Trim(fi.File.Path, "/")
+		if name == "" {
+			return nil
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			names = append(names, name)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// stat returns the info of a file, which is nil for the root directory.
+func (fsys *commitFS) stat(op, name string) (*pfs.FileInfo, *fileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return nil, &fileInfo{name: ".", dir: true}, nil
+	}
+	fi, err := fsys.c.InspectFile(fsys.commit, "/"+name)
+	if err != nil {
+		return nil, nil, pathError(op, name, err)
+	}
+	return fi, newFileInfo(fi), nil
+}
+
+func (fsys *commitFS) readDir(name string) ([]fs.DirEntry, error) {
+	p := "/"
+	if name != "." {
+		p += name + "/"
+	}
+	var entries []fs.DirEntry
+	if err := fsys.c.ListFile(fsys.commit, p, func(fi *pfs.FileInfo) error {
+		entries = append(entries, fs.FileInfoToDirEntry(newFileInfo(fi)))
+		return nil
+	}); err != nil {
+		return nil, pathError("readdir", name, err)
+	}
+	// PFS sorts directories by their path with a trailing slash.
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
+// pathError converts the errors of an operation on a file to the errors
+// expected from an fs.FS. A missing file, commit or repo doesn't exist.
+func pathError(op, name string, err error) error {
+	if errutil.IsNotFoundError(err) {
+		err = fs.ErrNotExist
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// fileInfo is the fs.FileInfo of a PFS file.
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+	fi      *pfs.FileInfo
+}
+
+func newFileInfo(fi *pfs.FileInfo) *fileInfo {
+	info := &fileInfo{
+		name: path.Base(fi.File.Path),
+		size: fi.SizeBytes,
+		dir:  fi.FileType == pfs.FileType_DIR,
+		fi:   fi,
+	}
+	if fi.Committed != nil {
+		info.modTime, _ = types.TimestampFromProto(fi.Committed)
+	}
+	return info
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *fileInfo) IsDir() bool        { return fi.dir }
+func (fi *fileInfo) Sys() interface{}   { return fi.fi }
+
+func (fi *fileInfo) Mode() fs.FileMode {
+	if fi.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+// commitDir is an open directory, which is listed when it is first read.
+type commitDir struct {
+	fsys    *commitFS
+	name    string
+	info    *fileInfo
+	entries []fs.DirEntry
+	listed  bool
+}
+
+func (d *commitDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.listed {
+		entries, err := d.fsys.readDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries = entries
+		d.listed = true
+	}
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+func (d *commitDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *commitDir) Close() error               { return nil }
+
+func (d *commitDir) Read(p []byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+// commitFile is an open file. It is read from its offset when it is first
+// read after being opened or seeked, so that seeking doesn't read the file up
+// to the offset.
+type commitFile struct {
+	c      APIClient
+	file   *pfs.File
+	name   string
+	info   *fileInfo
+	offset int64
+	r      io.ReadCloser
+	closed bool
+}
+
+func (f *commitFile) Read(p []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.offset >= f.info.size {
+		return 0, io.EOF
+	}
+	if f.r == nil {
+		ctx, cancel := context.WithCancel(f.c.Ctx())
+		getFileClient, err := f.c.PfsAPIClient.GetFile(ctx, &pfs.GetFileRequest{
+			File:   f.file,
+			Offset: f.offset,
+		})
+		if err != nil {
+			cancel()
+			return 0, pathError("read", f.name, grpcutil.ScrubGRPC(err))
+		}
+		f.r = grpcutil.NewStreamingBytesReader(getFileClient, cancel)
+	}
+	n, err := f.r.Read(p)
+	f.offset += int64(n)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, pathError("read", f.name, grpcutil.ScrubGRPC(err))
+	}
+	return n, err
+}
+
+func (f *commitFile) Seek(offset int64, whence int) (int64, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrClosed}
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.info.size
+	default:
+		return f.offset, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+	if offset < 0 {
+		return f.offset, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+	if offset != f.offset {
+		if err := f.closeReader(); err != nil {
+			return f.offset, err
+		}
+		f.offset = offset
+	}
+	return f.offset, nil
+}
+
+func (f *commitFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *commitFile) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	return f.closeReader()
+}
+
+func (f *commitFile) closeReader() error {
+	if f.r == nil {
+		return nil
+	}
+	err := f.r.Close()
+	f.r = nil
+	return errors.EnsureStack(err)
+}
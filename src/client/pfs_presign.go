@@ -0,0 +1,93 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/sigv4"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// anonymousS3AccessKey signs presigned URLs when auth is not active, in which
+// case the S3 gateway accepts any access key as its own secret.
+const anonymousS3AccessKey = "anonymous"
+
+// PresignFileURL returns a URL through which a file can be read (with method
+// GET) or written (with method PUT), via the S3 gateway at endpoint (e.g.
+// "http://localhost:30600"), without any other credentials until expiry has
+// passed.
+//
+// When auth is active the URL is signed with S3 credentials created for the
+// caller, which are limited to the bucket of the file (and to reads for GET)
+// and expire with the URL, after which the auth service deletes them along
+// with their expired auth tokens. They are also revoked along with the
+// caller's session.
+func (c APIClient) PresignFileURL(endpoint string, file *pfs.File, method string, expiry time.Duration) (_ string, retErr error) {
+	defer func() { retErr = grpcutil.ScrubGRPC(retErr) }()
+	if method != http.MethodGet && method != http.MethodPut {
+		return "", errors.Errorf("method must be %s or %s, not %q", http.MethodGet, http.MethodPut, method)
+	}
+	if expiry <= 0 || expiry > sigv4.MaxExpiry {
+		return "", errors.Errorf("expiry must be between 1s and %v", sigv4.MaxExpiry)
+	}
+	commit := file.Commit
+	if commit.Branch.Name == "" && commit.ID == "" {
+		return "", errors.Errorf("a branch or commit must be specified")
+	}
+	if commit.ID != "" {
+		if method == http.MethodPut {
+			return "", errors.Errorf("files can only be written to the head of a branch, not to commit %s", commit.ID)
+		}
+		commitInfo, err := c.PfsAPIClient.InspectCommit(c.Ctx(), &pfs.InspectCommitRequest{Commit: commit})
+		if err != nil {
+			return "", err
+		}
+		commit = commitInfo.Commit
+	}
+	bucket := s3BucketName(commit)
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	u.Path = path.Join("/", u.Path, bucket, file.Path)
+
+	now := time.Now()
+	accessKeyID, secretAccessKey := anonymousS3AccessKey, anonymousS3AccessKey
+	active, err := c.IsAuthActive()
+	if err != nil {
+		return "", err
+	}
+	if active {
+		resp, err := c.CreateS3Credentials(c.Ctx(), &auth.CreateS3CredentialsRequest{
+			ReadOnly: method == http.MethodGet,
+			Buckets:  []string{bucket},
+			TTL:      int64(math.Ceil(expiry.Seconds())),
+		})
+		if err != nil {
+			return "", err
+		}
+		accessKeyID, secretAccessKey = resp.Credential.AccessKeyID, resp.SecretAccessKey
+	}
+	return sigv4.Presign(method, u.String(), accessKeyID, secretAccessKey, sigv4.DefaultRegion, now, expiry)
+}
+
+// s3BucketName returns the name of the S3 gateway bucket which serves a
+// commit, which is [commitID.]branch.[type.]repo.
+func s3BucketName(commit *pfs.Commit) string {
+	repo := commit.Branch.Repo
+	name := fmt.Sprintf("%s.%s", commit.Branch.Name, repo.Name)
+	if repo.Type != pfs.UserRepoType {
+		name = fmt.Sprintf("%s.%s.%s", commit.Branch.Name, repo.Type, repo.Name)
+	}
+	if commit.ID != "" {
+		name = commit.ID + "." + name
+	}
+	return name
+}
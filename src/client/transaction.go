@@ -422,6 +422,39 @@ func (c *pfsBuilderClient) RunLoadTestDefault(ctx context.Context, req *types.Em
 func (c *pfsBuilderClient) CheckStorage(ctx context.Context, req *pfs.CheckStorageRequest, opts ...grpc.CallOption) (*pfs.CheckStorageResponse, error) {
 	return nil, unsupportedError("CheckStorage")
 }
+func (c *pfsBuilderClient) ReclaimOrphanedObjects(ctx context.Context, req *pfs.ReclaimOrphanedObjectsRequest, opts ...grpc.CallOption) (pfs.API_ReclaimOrphanedObjectsClient, error) {
+	return nil, unsupportedError("ReclaimOrphanedObjects")
+}
+func (c *pfsBuilderClient) MaterializeCommit(ctx context.Context, req *pfs.MaterializeCommitRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("MaterializeCommit")
+}
+func (c *pfsBuilderClient) CompactCommit(ctx context.Context, req *pfs.CompactCommitRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CompactCommit")
+}
+func (c *pfsBuilderClient) DiskUsage(ctx context.Context, req *pfs.DiskUsageRequest, opts ...grpc.CallOption) (pfs.API_DiskUsageClient, error) {
+	return nil, unsupportedError("DiskUsage")
+}
+func (c *pfsBuilderClient) RepoDiskUsage(ctx context.Context, req *pfs.RepoDiskUsageRequest, opts ...grpc.CallOption) (*pfs.RepoDiskUsageResponse, error) {
+	return nil, unsupportedError("RepoDiskUsage")
+}
+func (c *pfsBuilderClient) SubscribeFile(ctx context.Context, req *pfs.SubscribeFileRequest, opts ...grpc.CallOption) (pfs.API_SubscribeFileClient, error) {
+	return nil, unsupportedError("SubscribeFile")
+}
+func (c *pfsBuilderClient) ListReflog(ctx context.Context, req *pfs.ListReflogRequest, opts ...grpc.CallOption) (pfs.API_ListReflogClient, error) {
+	return nil, unsupportedError("ListReflog")
+}
+func (c *pfsBuilderClient) ResetBranch(ctx context.Context, req *pfs.ResetBranchRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("ResetBranch")
+}
+func (c *pfsBuilderClient) VerifyCommit(ctx context.Context, req *pfs.VerifyCommitRequest, opts ...grpc.CallOption) (pfs.API_VerifyCommitClient, error) {
+	return nil, unsupportedError("VerifyCommit")
+}
+func (c *pfsBuilderClient) AttestCommit(ctx context.Context, req *pfs.AttestCommitRequest, opts ...grpc.CallOption) (*pfs.CommitAttestation, error) {
+	return nil, unsupportedError("AttestCommit")
+}
+func (c *pfsBuilderClient) CheckDeleteAll(ctx context.Context, req *types.Empty, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CheckDeleteAll")
+}
 
 func (c *ppsBuilderClient) InspectJobSet(ctx context.Context, req *pps.InspectJobSetRequest, opts ...grpc.CallOption) (pps.API_InspectJobSetClient, error) {
 	return nil, unsupportedError("InspectJobSet")
@@ -627,6 +660,9 @@ func (c *debugBuilderClient) Binary(ctx context.Context, req *debug.BinaryReques
 func (c *debugBuilderClient) Dump(ctx context.Context, req *debug.DumpRequest, opts ...grpc.CallOption) (debug.Debug_DumpClient, error) {
 	return nil, unsupportedError("Dump")
 }
+func (c *debugBuilderClient) SetStorageBandwidth(ctx context.Context, req *debug.SetStorageBandwidthRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("SetStorageBandwidth")
+}
 
 func (c *authBuilderClient) DeleteExpiredAuthTokens(ctx context.Context, req *auth.DeleteExpiredAuthTokensRequest, opts ...grpc.CallOption) (*auth.DeleteExpiredAuthTokensResponse, error) {
 	return nil, unsupportedError("DeleteExpiredAuthTokens")
@@ -634,3 +670,12 @@ func (c *authBuilderClient) DeleteExpiredAuthTokens(ctx context.Context, req *au
 func (c *authBuilderClient) RotateRootToken(ctx context.Context, req *auth.RotateRootTokenRequest, opts ...grpc.CallOption) (*auth.RotateRootTokenResponse, error) {
 	return nil, unsupportedError("RotateRootToken")
 }
+func (c *authBuilderClient) CreateS3Credentials(ctx context.Context, req *auth.CreateS3CredentialsRequest, opts ...grpc.CallOption) (*auth.CreateS3CredentialsResponse, error) {
+	return nil, unsupportedError("CreateS3Credentials")
+}
+func (c *authBuilderClient) ListS3Credentials(ctx context.Context, req *auth.ListS3CredentialsRequest, opts ...grpc.CallOption) (*auth.ListS3CredentialsResponse, error) {
+	return nil, unsupportedError("ListS3Credentials")
+}
+func (c *authBuilderClient) RevokeS3Credentials(ctx context.Context, req *auth.RevokeS3CredentialsRequest, opts ...grpc.CallOption) (*auth.RevokeS3CredentialsResponse, error) {
+	return nil, unsupportedError("RevokeS3Credentials")
+}
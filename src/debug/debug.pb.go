@@ -397,6 +397,83 @@ func (m *DumpRequest) GetLimit() int64 {
 	return 0
 }
 
+// SetStorageBandwidthRequest sets the object storage bandwidth limits of the
+// pods selected by the filter. Only the pachd replica which serves the request
+// is limited.
+type SetStorageBandwidthRequest struct {
+	Filter *Filter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Limits in bytes per second, 0 means unlimited.
+	UploadBytesPerSecond   int64 `protobuf:"varint,2,opt,name=upload_bytes_per_second,json=uploadBytesPerSecond,proto3" json:"upload_bytes_per_second,omitempty"`
+	DownloadBytesPerSecond int64 `protobuf:"varint,3,opt,name=download_bytes_per_second,json=downloadBytesPerSecond,proto3" json:"download_bytes_per_second,omitempty"`
+	// The number of bytes which can be transferred at once above the limits,
+	// 0 means the default.
+	BurstBytes           int64    `protobuf:"varint,4,opt,name=burst_bytes,json=burstBytes,proto3" json:"burst_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetStorageBandwidthRequest) Reset()         { *m = SetStorageBandwidthRequest{} }
+func (m *SetStorageBandwidthRequest) String() string { return proto.CompactTextString(m) }
+func (*SetStorageBandwidthRequest) ProtoMessage()    {}
+func (*SetStorageBandwidthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ae24eab94cb53d5, []int{6}
+}
+func (m *SetStorageBandwidthRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetStorageBandwidthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetStorageBandwidthRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetStorageBandwidthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetStorageBandwidthRequest.Merge(m, src)
+}
+func (m *SetStorageBandwidthRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetStorageBandwidthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetStorageBandwidthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetStorageBandwidthRequest proto.InternalMessageInfo
+
+func (m *SetStorageBandwidthRequest) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *SetStorageBandwidthRequest) GetUploadBytesPerSecond() int64 {
+	if m != nil {
+		return m.UploadBytesPerSecond
+	}
+	return 0
+}
+
+func (m *SetStorageBandwidthRequest) GetDownloadBytesPerSecond() int64 {
+	if m != nil {
+		return m.DownloadBytesPerSecond
+	}
+	return 0
+}
+
+func (m *SetStorageBandwidthRequest) GetBurstBytes() int64 {
+	if m != nil {
+		return m.BurstBytes
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ProfileRequest)(nil), "debug_v2.ProfileRequest")
 	proto.RegisterType((*Profile)(nil), "debug_v2.Profile")
@@ -404,40 +481,48 @@ func init() {
 	proto.RegisterType((*Worker)(nil), "debug_v2.Worker")
 	proto.RegisterType((*BinaryRequest)(nil), "debug_v2.BinaryRequest")
 	proto.RegisterType((*DumpRequest)(nil), "debug_v2.DumpRequest")
+	proto.RegisterType((*SetStorageBandwidthRequest)(nil), "debug_v2.SetStorageBandwidthRequest")
 }
 
 func init() { proto.RegisterFile("debug/debug.proto", fileDescriptor_5ae24eab94cb53d5) }
 
 var fileDescriptor_5ae24eab94cb53d5 = []byte{
-	// 447 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xce, 0x92, 0xc6, 0x35, 0x53, 0x15, 0xb5, 0x23, 0x7e, 0x42, 0x91, 0x2c, 0xe4, 0x53, 0x45,
-	0x25, 0x1b, 0x05, 0x71, 0x28, 0x07, 0x0e, 0x56, 0x84, 0x72, 0x41, 0x42, 0x2b, 0x04, 0x12, 0x17,
-	0xe4, 0xc4, 0x13, 0x77, 0x85, 0x93, 0x5d, 0xd6, 0x76, 0xa3, 0xbc, 0x00, 0xcf, 0xc6, 0x11, 0xde,
-	0x00, 0xe5, 0x49, 0x90, 0x77, 0xd7, 0x71, 0x20, 0x87, 0xa8, 0x97, 0x68, 0xe6, 0x9b, 0x6f, 0xbe,
-	0x99, 0x6f, 0x27, 0x86, 0xf3, 0x8c, 0xa6, 0x75, 0x1e, 0x9b, 0xdf, 0x48, 0x69, 0x59, 0x49, 0xf4,
-	0x4d, 0xf2, 0xf5, 0x76, 0x74, 0x11, 0xe4, 0x52, 0xe6, 0x05, 0xc5, 0x06, 0x9f, 0xd6, 0xf3, 0x78,
-	0xa5, 0x53, 0xa5, 0x48, 0x97, 0x96, 0xb9, 0x5f, 0xcf, 0x6a, 0x9d, 0x56, 0x42, 0x2e, 0x5d, 0xfd,
-	0x54, 0xa9, 0x32, 0x56, 0xca, 0xd1, 0xc3, 0x1c, 0x1e, 0x7c, 0xd0, 0x72, 0x2e, 0x0a, 0xe2, 0xf4,
-	0xbd, 0xa6, 0xb2, 0xc2, 0x2b, 0x38, 0x56, 0x16, 0x19, 0xb2, 0xe7, 0xec, 0xf2, 0x64, 0x74, 0x1e,
-	0xb5, 0xc3, 0xa3, 0x96, 0xda, 0x32, 0xf0, 0x12, 0xbc, 0xb9, 0x28, 0x2a, 0xd2, 0xc3, 0x7b, 0x86,
-	0x7b, 0xd6, 0x71, 0xdf, 0x19, 0x9c, 0xbb, 0x7a, 0xf8, 0x11, 0x8e, 0x5d, 0x37, 0x22, 0x1c, 0x2d,
-	0xd3, 0x85, 0x95, 0xbf, 0xcf, 0x4d, 0x8c, 0xaf, 0xc1, 0x6f, 0x17, 0x75, 0x52, 0x4f, 0x23, 0xeb,
-	0x24, 0x6a, 0x9d, 0x44, 0x63, 0x47, 0xe0, 0x5b, 0x6a, 0xf8, 0x83, 0x81, 0x67, 0x07, 0xe1, 0x63,
-	0x18, 0xa8, 0x74, 0x76, 0x93, 0x19, 0x59, 0x7f, 0xd2, 0xe3, 0x36, 0xc5, 0x08, 0x7c, 0x25, 0x14,
-	0x15, 0x62, 0x49, 0xdb, 0x25, 0x95, 0x2a, 0x8d, 0x1d, 0x87, 0x4f, 0x7a, 0x7c, 0xcb, 0xc1, 0x17,
-	0xe0, 0xad, 0xa4, 0xfe, 0x46, 0x7a, 0xd8, 0xff, 0xdf, 0xd2, 0x67, 0x83, 0x4f, 0x7a, 0xdc, 0x31,
-	0x12, 0xbf, 0xb5, 0x1f, 0xbe, 0x01, 0xcf, 0x56, 0xf1, 0x0c, 0xfa, 0x4a, 0x66, 0xce, 0x5c, 0x13,
-	0x62, 0x00, 0xa0, 0x29, 0x13, 0x9a, 0x66, 0x15, 0x65, 0x66, 0x07, 0x9f, 0xef, 0x20, 0xe1, 0x35,
-	0x9c, 0x26, 0x62, 0x99, 0xea, 0x75, 0x7b, 0x82, 0xee, 0x55, 0xd9, 0x81, 0x57, 0x7d, 0x0f, 0x27,
-	0xe3, 0x7a, 0xa1, 0xee, 0xdc, 0x88, 0x0f, 0x61, 0x50, 0x88, 0x85, 0xa8, 0xcc, 0x3a, 0x7d, 0x6e,
-	0x93, 0xd1, 0x6f, 0x06, 0x83, 0x71, 0xd3, 0x81, 0xe3, 0xee, 0x5c, 0xc3, 0xfd, 0xfb, 0xdb, 0x71,
-	0x17, 0xcf, 0xf6, 0x4e, 0x94, 0xac, 0x2b, 0x2a, 0x3f, 0xa5, 0x45, 0x4d, 0x61, 0xef, 0x25, 0xc3,
-	0x04, 0x3c, 0xeb, 0x0c, 0x9f, 0x74, 0x22, 0xff, 0x78, 0x3d, 0xac, 0xf1, 0x16, 0x8e, 0x1a, 0x8b,
-	0xf8, 0xa8, 0x53, 0xd8, 0xb1, 0x7c, 0xb0, 0x3f, 0xb9, 0xfe, 0xb9, 0x09, 0xd8, 0xaf, 0x4d, 0xc0,
-	0xfe, 0x6c, 0x02, 0xf6, 0xe5, 0x2a, 0x17, 0xd5, 0x4d, 0x3d, 0x8d, 0x66, 0x72, 0x11, 0x37, 0xff,
-	0x8f, 0x75, 0x46, 0x7a, 0x37, 0xba, 0x1d, 0xc5, 0xa5, 0x9e, 0xd9, 0x6f, 0x6f, 0xea, 0x19, 0xcd,
-	0x57, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x2c, 0x84, 0xe8, 0x49, 0x91, 0x03, 0x00, 0x00,
+	// 560 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xda, 0x4c,
+	0x14, 0xc5, 0x21, 0x38, 0xfe, 0x2e, 0xca, 0xa7, 0x64, 0x9a, 0x12, 0x42, 0x24, 0x5a, 0x59, 0x5d,
+	0x44, 0x8d, 0x64, 0x57, 0x54, 0x59, 0xd0, 0x45, 0x17, 0x16, 0xad, 0xd8, 0x54, 0x8a, 0x4c, 0x7f,
+	0xa4, 0x6e, 0x90, 0xcd, 0x0c, 0xc6, 0xaa, 0xf1, 0x4c, 0xc7, 0xe3, 0x20, 0x5e, 0xa0, 0xcf, 0xd2,
+	0x47, 0xe9, 0xb2, 0xdb, 0xee, 0x2a, 0x9e, 0xa4, 0xf2, 0xcc, 0x18, 0x53, 0x48, 0x85, 0xb2, 0x41,
+	0x33, 0xf7, 0x9c, 0x7b, 0x7c, 0xcf, 0x9c, 0x61, 0xe0, 0x14, 0x93, 0x30, 0x8f, 0x5c, 0xf9, 0xeb,
+	0x30, 0x4e, 0x05, 0x45, 0x96, 0xdc, 0x8c, 0xef, 0x7a, 0x9d, 0x6e, 0x44, 0x69, 0x94, 0x10, 0x57,
+	0xd6, 0xc3, 0x7c, 0xea, 0x2e, 0x78, 0xc0, 0x18, 0xe1, 0x99, 0x62, 0xee, 0xe2, 0x38, 0xe7, 0x81,
+	0x88, 0x69, 0xaa, 0xf1, 0xcb, 0x6d, 0x9c, 0xcc, 0x99, 0x58, 0x6a, 0xf0, 0x98, 0xb1, 0xcc, 0x65,
+	0x4c, 0x6b, 0xd9, 0x11, 0xfc, 0x7f, 0xcb, 0xe9, 0x34, 0x4e, 0x88, 0x4f, 0xbe, 0xe6, 0x24, 0x13,
+	0xe8, 0x1a, 0x8e, 0x98, 0xaa, 0xb4, 0x8d, 0xa7, 0xc6, 0x55, 0xb3, 0x77, 0xea, 0x94, 0x93, 0x39,
+	0x25, 0xb5, 0x64, 0xa0, 0x2b, 0x30, 0xa7, 0x71, 0x22, 0x08, 0x6f, 0x1f, 0x48, 0xee, 0x49, 0xc5,
+	0x7d, 0x2b, 0xeb, 0xbe, 0xc6, 0xed, 0xf7, 0x70, 0xa4, 0xbb, 0x11, 0x82, 0xc3, 0x34, 0x98, 0x2b,
+	0xf9, 0xff, 0x7c, 0xb9, 0x46, 0x37, 0x60, 0x95, 0x2e, 0xb4, 0xd4, 0x85, 0xa3, 0x6c, 0x38, 0xa5,
+	0x0d, 0x67, 0xa0, 0x09, 0xfe, 0x9a, 0x6a, 0x7f, 0x33, 0xc0, 0x54, 0x1f, 0x42, 0x2d, 0x68, 0xb0,
+	0x60, 0x32, 0xc3, 0x52, 0xd6, 0x1a, 0xd6, 0x7c, 0xb5, 0x45, 0x0e, 0x58, 0x2c, 0x66, 0x24, 0x89,
+	0x53, 0xb2, 0x1e, 0x92, 0xb1, 0x4c, 0xda, 0xd1, 0xf5, 0x61, 0xcd, 0x5f, 0x73, 0xd0, 0x73, 0x30,
+	0x17, 0x94, 0x7f, 0x21, 0xbc, 0x5d, 0xdf, 0xb6, 0xf4, 0x49, 0xd6, 0x87, 0x35, 0x5f, 0x33, 0x3c,
+	0xab, 0xb4, 0x6f, 0xbf, 0x02, 0x53, 0xa1, 0xe8, 0x04, 0xea, 0x8c, 0x62, 0x6d, 0xae, 0x58, 0xa2,
+	0x2e, 0x00, 0x27, 0x38, 0xe6, 0x64, 0x22, 0x08, 0x96, 0x33, 0x58, 0xfe, 0x46, 0xc5, 0xee, 0xc3,
+	0xb1, 0x17, 0xa7, 0x01, 0x5f, 0x96, 0x11, 0x54, 0xa7, 0x6a, 0xec, 0x39, 0xd5, 0x77, 0xd0, 0x1c,
+	0xe4, 0x73, 0xf6, 0xe0, 0x46, 0x74, 0x06, 0x8d, 0x24, 0x9e, 0xc7, 0x42, 0x8e, 0x53, 0xf7, 0xd5,
+	0xc6, 0xfe, 0x65, 0x40, 0x67, 0x44, 0xc4, 0x48, 0x50, 0x1e, 0x44, 0xc4, 0x0b, 0x52, 0xbc, 0x88,
+	0xb1, 0x98, 0x3d, 0x5c, 0xfe, 0x06, 0xce, 0x73, 0x96, 0xd0, 0x00, 0x8f, 0xc3, 0xa5, 0x20, 0xd9,
+	0x98, 0x11, 0x3e, 0xce, 0xc8, 0x84, 0xa6, 0x58, 0x7f, 0xf0, 0x4c, 0xc1, 0x5e, 0x81, 0xde, 0x12,
+	0x3e, 0x92, 0x18, 0xea, 0xc3, 0x05, 0xa6, 0x8b, 0xf4, 0xfe, 0xc6, 0xba, 0x6c, 0x6c, 0x95, 0x84,
+	0xad, 0xd6, 0x27, 0xd0, 0x0c, 0x73, 0x9e, 0x09, 0xd5, 0xd7, 0x3e, 0x94, 0x64, 0x90, 0x25, 0xc9,
+	0xec, 0x7d, 0x3f, 0x80, 0xc6, 0xa0, 0x18, 0x17, 0x0d, 0xaa, 0xab, 0xd8, 0xde, 0xbd, 0xdb, 0xca,
+	0x6b, 0xe7, 0x72, 0xe7, 0xfa, 0x49, 0x99, 0x8f, 0x41, 0x92, 0x13, 0xbb, 0xf6, 0xc2, 0x40, 0x1e,
+	0x98, 0x2a, 0x35, 0x74, 0x5e, 0x89, 0xfc, 0x95, 0xe3, 0x7e, 0x8d, 0xd7, 0x70, 0x58, 0xc4, 0x87,
+	0x1e, 0x57, 0x0a, 0x1b, 0x71, 0xee, 0xef, 0xff, 0x00, 0x8f, 0xee, 0x89, 0x0b, 0x3d, 0xab, 0xe4,
+	0xfe, 0x9d, 0x66, 0xa7, 0xb5, 0xa3, 0xfe, 0xa6, 0x78, 0x27, 0xec, 0x9a, 0xd7, 0xff, 0xb1, 0xea,
+	0x1a, 0x3f, 0x57, 0x5d, 0xe3, 0xf7, 0xaa, 0x6b, 0x7c, 0xbe, 0x8e, 0x62, 0x31, 0xcb, 0x43, 0x67,
+	0x42, 0xe7, 0x6e, 0xf1, 0x97, 0x5a, 0x62, 0xc2, 0x37, 0x57, 0x77, 0x3d, 0x37, 0xe3, 0x13, 0xf5,
+	0x96, 0x85, 0xa6, 0x14, 0x7b, 0xf9, 0x67, 0x00, 0x5d, 0x22, 0xec, 0x1a, 0xe1, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -455,6 +540,7 @@ type DebugClient interface {
 	Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (Debug_ProfileClient, error)
 	Binary(ctx context.Context, in *BinaryRequest, opts ...grpc.CallOption) (Debug_BinaryClient, error)
 	Dump(ctx context.Context, in *DumpRequest, opts ...grpc.CallOption) (Debug_DumpClient, error)
+	SetStorageBandwidth(ctx context.Context, in *SetStorageBandwidthRequest, opts ...grpc.CallOption) (*types.Empty, error)
 }
 
 type debugClient struct {
@@ -561,11 +647,21 @@ func (x *debugDumpClient) Recv() (*types.BytesValue, error) {
 	return m, nil
 }
 
+func (c *debugClient) SetStorageBandwidth(ctx context.Context, in *SetStorageBandwidthRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/debug_v2.Debug/SetStorageBandwidth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DebugServer is the server API for Debug service.
 type DebugServer interface {
 	Profile(*ProfileRequest, Debug_ProfileServer) error
 	Binary(*BinaryRequest, Debug_BinaryServer) error
 	Dump(*DumpRequest, Debug_DumpServer) error
+	SetStorageBandwidth(context.Context, *SetStorageBandwidthRequest) (*types.Empty, error)
 }
 
 // UnimplementedDebugServer can be embedded to have forward compatible implementations.
@@ -581,6 +677,9 @@ func (*UnimplementedDebugServer) Binary(req *BinaryRequest, srv Debug_BinaryServ
 func (*UnimplementedDebugServer) Dump(req *DumpRequest, srv Debug_DumpServer) error {
 	return status.Errorf(codes.Unimplemented, "method Dump not implemented")
 }
+func (*UnimplementedDebugServer) SetStorageBandwidth(ctx context.Context, req *SetStorageBandwidthRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStorageBandwidth not implemented")
+}
 
 func RegisterDebugServer(s *grpc.Server, srv DebugServer) {
 	s.RegisterService(&_Debug_serviceDesc, srv)
@@ -649,10 +748,33 @@ func (x *debugDumpServer) Send(m *types.BytesValue) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Debug_SetStorageBandwidth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStorageBandwidthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).SetStorageBandwidth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug_v2.Debug/SetStorageBandwidth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).SetStorageBandwidth(ctx, req.(*SetStorageBandwidthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Debug_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "debug_v2.Debug",
 	HandlerType: (*DebugServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetStorageBandwidth",
+			Handler:    _Debug_SetStorageBandwidth_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Profile",
@@ -992,6 +1114,60 @@ func (m *DumpRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SetStorageBandwidthRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetStorageBandwidthRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetStorageBandwidthRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.BurstBytes != 0 {
+		i = encodeVarintDebug(dAtA, i, uint64(m.BurstBytes))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.DownloadBytesPerSecond != 0 {
+		i = encodeVarintDebug(dAtA, i, uint64(m.DownloadBytesPerSecond))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.UploadBytesPerSecond != 0 {
+		i = encodeVarintDebug(dAtA, i, uint64(m.UploadBytesPerSecond))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Filter != nil {
+		{
+			size, err := m.Filter.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDebug(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintDebug(dAtA []byte, offset int, v uint64) int {
 	offset -= sovDebug(v)
 	base := offset
@@ -1145,6 +1321,31 @@ func (m *DumpRequest) Size() (n int) {
 	return n
 }
 
+func (m *SetStorageBandwidthRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Filter != nil {
+		l = m.Filter.Size()
+		n += 1 + l + sovDebug(uint64(l))
+	}
+	if m.UploadBytesPerSecond != 0 {
+		n += 1 + sovDebug(uint64(m.UploadBytesPerSecond))
+	}
+	if m.DownloadBytesPerSecond != 0 {
+		n += 1 + sovDebug(uint64(m.DownloadBytesPerSecond))
+	}
+	if m.BurstBytes != 0 {
+		n += 1 + sovDebug(uint64(m.BurstBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovDebug(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1831,6 +2032,150 @@ func (m *DumpRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SetStorageBandwidthRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDebug
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetStorageBandwidthRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetStorageBandwidthRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Filter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Filter == nil {
+				m.Filter = &Filter{}
+			}
+			if err := m.Filter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UploadBytesPerSecond", wireType)
+			}
+			m.UploadBytesPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UploadBytesPerSecond |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownloadBytesPerSecond", wireType)
+			}
+			m.DownloadBytesPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DownloadBytesPerSecond |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstBytes", wireType)
+			}
+			m.BurstBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDebug(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipDebug(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/migrations"
+	"github.com/pachyderm/pachyderm/v2/src/server/auth"
 	enterpriseserver "github.com/pachyderm/pachyderm/v2/src/server/enterprise/server"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs/server"
 )
 
 var state_2_1_0 migrations.State = state_2_0_0.
@@ -13,4 +15,19 @@ var state_2_1_0 migrations.State = state_2_0_0.
 	}).
 	Apply("Remove old EnterpriseConfig record from etcd", func(ctx context.Context, env migrations.Env) error {
 		return enterpriseserver.DeleteEnterpriseConfigFromEtcd(ctx, env.EtcdClient)
+	}).
+	Apply("pfs branch reflog v0", func(ctx context.Context, env migrations.Env) error {
+		return pfsserver.SetupPostgresReflogV0(ctx, env.Tx)
+	}).
+	Apply("Add auth S3 credentials table", func(ctx context.Context, env migrations.Env) error {
+		return auth.CreateS3CredentialsTable(ctx, env.Tx)
+	}).
+	Apply("Add pfs materialize queue", func(ctx context.Context, env migrations.Env) error {
+		return pfsserver.SetupPostgresMaterializeQueueV0(ctx, env.Tx)
+	}).
+	Apply("Add pfs multipart uploads", func(ctx context.Context, env migrations.Env) error {
+		return pfsserver.SetupPostgresMultipartUploadsV0(ctx, env.Tx)
+	}).
+	Apply("Add pfs session commits", func(ctx context.Context, env migrations.Env) error {
+		return pfsserver.SetupPostgresSessionCommitsV0(ctx, env.Tx)
 	})
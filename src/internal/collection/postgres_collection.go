@@ -290,14 +290,24 @@ func (c *postgresCollection) list(
 	query := fmt.Sprintf("select key, createdat, updatedat, proto from collections.%s", c.table)
 
 	var args []interface{}
-	if len(withFields) > 0 {
-		fields := []string{}
-		i := 1
-		for k, v := range withFields {
-			fields = append(fields, fmt.Sprintf("%s = $%d", k, i))
-			args = append(args, v)
-			i++
+	fields := []string{}
+	for k, v := range withFields {
+		args = append(args, v)
+		fields = append(fields, fmt.Sprintf("%s = $%d", k, len(args)))
+	}
+	if opts.After != "" {
+		cmp := ">="
+		if opts.Order == SortDescend {
+			cmp = "<="
 		}
+		target, err := targetToSQL(opts.Target)
+		if err != nil {
+			return err
+		}
+		args = append(args, opts.After)
+		fields = append(fields, fmt.Sprintf("%s %s (select %s from collections.%s where key = $%d)", target, cmp, target, c.table, len(args)))
+	}
+	if len(fields) > 0 {
 		query += " where " + strings.Join(fields, " and ")
 	}
 
@@ -308,6 +318,11 @@ func (c *postgresCollection) list(
 			return err
 		} else {
 			query += fmt.Sprintf(" order by %s %s", target, order)
+			if target != "key" {
+				// break ties by key, so that a listing which starts after a
+				// key sees the rows that tie with it in the same order
+				query += fmt.Sprintf(", key %s", order)
+			}
 		}
 	}
 
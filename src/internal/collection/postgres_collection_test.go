@@ -143,6 +143,25 @@ func PostgresCollectionBasicTests(suite *testing.T, newCollection func(context.C
 			checkDefaultCollection(t, defaultRead, RowDiff{})
 		})
 
+		subsuite.Run("After", func(t *testing.T) {
+			t.Parallel()
+			listKeys := func(opts *col.Options) []string {
+				keys := []string{}
+				require.NoError(t, defaultRead.List(&col.TestItem{}, opts, func(key string) error {
+					keys = append(keys, key)
+					return nil
+				}))
+				return keys
+			}
+			keys := listKeys(col.DefaultOptions())
+			require.Equal(t, defaultCollectionSize, len(keys))
+			// The rows were all created in one transaction, so they all tie
+			// with the row to start after, and are listed in the same order.
+			opts := col.DefaultOptions()
+			opts.After = keys[3]
+			require.Equal(t, keys, listKeys(opts))
+		})
+
 		subsuite.Run("InvalidIndex", func(t *testing.T) {
 			t.Parallel()
 			err := defaultWriter(context.Background(), func(rw col.ReadWriteCollection) error {
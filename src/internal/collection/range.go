@@ -34,12 +34,17 @@ type Options struct {
 	Order  SortOrder
 	// Limit is only implemented for postgres collections
 	Limit int
+	// After starts the listing at the sort target value of the row with this
+	// key, so that a paginated listing doesn't rescan its previous pages. The
+	// rows which tie with it are included, so the caller skips up to the key.
+	// It is only implemented for postgres collections.
+	After string
 }
 
 // DefaultOptions are the default sort options when iterating through etcd
 // key/values.
 func DefaultOptions() *Options {
-	return &Options{SortByCreateRevision, SortDescend, 0}
+	return &Options{SortByCreateRevision, SortDescend, 0, ""}
 }
 
 func listFuncs(opts *Options) (func(*mvccpb.KeyValue) etcd.OpOption, func(kv1 *mvccpb.KeyValue, kv2 *mvccpb.KeyValue) int) {
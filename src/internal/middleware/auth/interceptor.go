@@ -42,6 +42,9 @@ var authHandlers = map[string]authHandler{
 	"/auth_v2.API/GetRoleBinding":        authenticated,
 	"/auth_v2.API/ModifyRoleBinding":     authenticated,
 	"/auth_v2.API/RevokeAuthToken":       authenticated,
+	"/auth_v2.API/CreateS3Credentials":   authenticated,
+	"/auth_v2.API/ListS3Credentials":     authenticated,
+	"/auth_v2.API/RevokeS3Credentials":   authenticated,
 	"/auth_v2.API/GetGroups":             authenticated,
 	"/auth_v2.API/GetPermissions":        authenticated,
 	"/auth_v2.API/GetRolesForPermission": authenticated,
@@ -65,9 +68,10 @@ var authHandlers = map[string]authHandler{
 	// Debug API
 	//
 
-	"/debug_v2.Debug/Profile": authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
-	"/debug_v2.Debug/Binary":  authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
-	"/debug_v2.Debug/Dump":    authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
+	"/debug_v2.Debug/Profile":             authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
+	"/debug_v2.Debug/Binary":              authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
+	"/debug_v2.Debug/Dump":                authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_DUMP)),
+	"/debug_v2.Debug/SetStorageBandwidth": authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DEBUG_SET_STORAGE_BANDWIDTH)),
 
 	//
 	// Enterprise API
@@ -121,46 +125,57 @@ var authHandlers = map[string]authHandler{
 	//
 
 	// TODO: Add methods to handle repo permissions
-	"/pfs_v2.API/ActivateAuth":     clusterPermissions(auth.Permission_CLUSTER_AUTH_ACTIVATE),
-	"/pfs_v2.API/CreateRepo":       authDisabledOr(authenticated),
-	"/pfs_v2.API/InspectRepo":      authDisabledOr(authenticated),
-	"/pfs_v2.API/ListRepo":         authDisabledOr(authenticated),
-	"/pfs_v2.API/DeleteRepo":       authDisabledOr(authenticated),
-	"/pfs_v2.API/StartCommit":      authDisabledOr(authenticated),
-	"/pfs_v2.API/FinishCommit":     authDisabledOr(authenticated),
-	"/pfs_v2.API/InspectCommit":    authDisabledOr(authenticated),
-	"/pfs_v2.API/ListCommit":       authDisabledOr(authenticated),
-	"/pfs_v2.API/SubscribeCommit":  authDisabledOr(authenticated),
-	"/pfs_v2.API/ClearCommit":      authDisabledOr(authenticated),
-	"/pfs_v2.API/InspectCommitSet": authDisabledOr(authenticated),
-	"/pfs_v2.API/ListCommitSet":    authDisabledOr(authenticated),
-	"/pfs_v2.API/SquashCommitSet":  authDisabledOr(authenticated),
-	"/pfs_v2.API/DropCommitSet":    authDisabledOr(authenticated),
-	"/pfs_v2.API/CreateBranch":     authDisabledOr(authenticated),
-	"/pfs_v2.API/InspectBranch":    authDisabledOr(authenticated),
-	"/pfs_v2.API/ListBranch":       authDisabledOr(authenticated),
-	"/pfs_v2.API/DeleteBranch":     authDisabledOr(authenticated),
-	"/pfs_v2.API/ModifyFile":       authDisabledOr(authenticated),
-	"/pfs_v2.API/GetFile":          authDisabledOr(authenticated),
+	"/pfs_v2.API/ActivateAuth":      clusterPermissions(auth.Permission_CLUSTER_AUTH_ACTIVATE),
+	"/pfs_v2.API/CreateRepo":        authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectRepo":       authDisabledOr(authenticated),
+	"/pfs_v2.API/ListRepo":          authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteRepo":        authDisabledOr(authenticated),
+	"/pfs_v2.API/StartCommit":       authDisabledOr(authenticated),
+	"/pfs_v2.API/FinishCommit":      authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectCommit":     authDisabledOr(authenticated),
+	"/pfs_v2.API/ListCommit":        authDisabledOr(authenticated),
+	"/pfs_v2.API/SubscribeCommit":   authDisabledOr(authenticated),
+	"/pfs_v2.API/SubscribeFile":     authDisabledOr(authenticated),
+	"/pfs_v2.API/ClearCommit":       authDisabledOr(authenticated),
+	"/pfs_v2.API/CompactCommit":     authDisabledOr(authenticated),
+	"/pfs_v2.API/MaterializeCommit": authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectCommitSet":  authDisabledOr(authenticated),
+	"/pfs_v2.API/ListCommitSet":     authDisabledOr(authenticated),
+	"/pfs_v2.API/SquashCommitSet":   authDisabledOr(authenticated),
+	"/pfs_v2.API/DropCommitSet":     authDisabledOr(authenticated),
+	"/pfs_v2.API/CreateBranch":      authDisabledOr(authenticated),
+	"/pfs_v2.API/InspectBranch":     authDisabledOr(authenticated),
+	"/pfs_v2.API/ListBranch":        authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteBranch":      authDisabledOr(authenticated),
+	"/pfs_v2.API/ListReflog":        authDisabledOr(authenticated),
+	"/pfs_v2.API/ResetBranch":       authDisabledOr(authenticated),
+	"/pfs_v2.API/VerifyCommit":      authDisabledOr(authenticated),
+	"/pfs_v2.API/AttestCommit":      authDisabledOr(authenticated),
+	"/pfs_v2.API/ModifyFile":        authDisabledOr(authenticated),
+	"/pfs_v2.API/GetFile":           authDisabledOr(authenticated),
 	// TODO: GetFileTAR is unauthenticated for performance reasons. Normal authentication
 	// will be applied internally when a commit is used. When a file set id is used, we lean
 	// on the capability based authentication of file sets.
-	"/pfs_v2.API/GetFileTAR":         unauthenticated,
-	"/pfs_v2.API/InspectFile":        authDisabledOr(authenticated),
-	"/pfs_v2.API/ListFile":           authDisabledOr(authenticated),
-	"/pfs_v2.API/WalkFile":           authDisabledOr(authenticated),
-	"/pfs_v2.API/GlobFile":           authDisabledOr(authenticated),
-	"/pfs_v2.API/DiffFile":           authDisabledOr(authenticated),
-	"/pfs_v2.API/DeleteAll":          authDisabledOr(authenticated),
-	"/pfs_v2.API/Fsck":               authDisabledOr(authenticated),
-	"/pfs_v2.API/CreateFileSet":      authDisabledOr(authenticated),
-	"/pfs_v2.API/GetFileSet":         authDisabledOr(authenticated),
-	"/pfs_v2.API/AddFileSet":         authDisabledOr(authenticated),
-	"/pfs_v2.API/RenewFileSet":       authDisabledOr(authenticated),
-	"/pfs_v2.API/ComposeFileSet":     authDisabledOr(authenticated),
-	"/pfs_v2.API/RunLoadTest":        authDisabledOr(authenticated),
-	"/pfs_v2.API/RunLoadTestDefault": authDisabledOr(authenticated),
-	"/pfs_v2.API/CheckStorage":       authDisabledOr(authenticated),
+	"/pfs_v2.API/GetFileTAR":             unauthenticated,
+	"/pfs_v2.API/InspectFile":            authDisabledOr(authenticated),
+	"/pfs_v2.API/ListFile":               authDisabledOr(authenticated),
+	"/pfs_v2.API/WalkFile":               authDisabledOr(authenticated),
+	"/pfs_v2.API/GlobFile":               authDisabledOr(authenticated),
+	"/pfs_v2.API/DiffFile":               authDisabledOr(authenticated),
+	"/pfs_v2.API/DiskUsage":              authDisabledOr(authenticated),
+	"/pfs_v2.API/RepoDiskUsage":          authDisabledOr(authenticated),
+	"/pfs_v2.API/DeleteAll":              authDisabledOr(authenticated),
+	"/pfs_v2.API/CheckDeleteAll":         authDisabledOr(authenticated),
+	"/pfs_v2.API/Fsck":                   authDisabledOr(authenticated),
+	"/pfs_v2.API/CreateFileSet":          authDisabledOr(authenticated),
+	"/pfs_v2.API/GetFileSet":             authDisabledOr(authenticated),
+	"/pfs_v2.API/AddFileSet":             authDisabledOr(authenticated),
+	"/pfs_v2.API/RenewFileSet":           authDisabledOr(authenticated),
+	"/pfs_v2.API/ComposeFileSet":         authDisabledOr(authenticated),
+	"/pfs_v2.API/RunLoadTest":            authDisabledOr(authenticated),
+	"/pfs_v2.API/RunLoadTestDefault":     authDisabledOr(authenticated),
+	"/pfs_v2.API/CheckStorage":           authDisabledOr(authenticated),
+	"/pfs_v2.API/ReclaimOrphanedObjects": authDisabledOr(clusterPermissions(auth.Permission_CLUSTER_DELETE_ALL)),
 
 	//
 	// PPS API
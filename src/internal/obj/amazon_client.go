@@ -160,6 +160,8 @@ func (c *amazonClient) Put(ctx context.Context, name string, r io.Reader) (retEr
 	defer func() { retErr = c.transformError(retErr, name) }()
 	ctx, cf := context.WithCancel(ctx)
 	defer cf()
+	// The uploader sends seekable parts, so the SDK sets Content-MD5 on each
+	// part and S3 rejects parts which were corrupted in transit.
 	_, err := c.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
 		ACL:             aws.String(c.advancedConfig.UploadACL),
 		Body:            r,
@@ -284,6 +286,22 @@ func (c *amazonClient) Exists(ctx context.Context, name string) (bool, error) {
 	return true, nil
 }
 
+func (c *amazonClient) Stat(ctx context.Context, name string) (_ *ObjectInfo, retErr error) {
+	defer func() { retErr = c.transformError(retErr, name) }()
+	out, err := c.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectInfo{
+		SizeBytes: aws.Int64Value(out.ContentLength),
+		ETag:      strings.Trim(aws.StringValue(out.ETag), `"`),
+		Version:   aws.StringValue(out.VersionId),
+	}, nil
+}
+
 func (c *amazonClient) BucketURL() ObjectStoreURL {
 	return ObjectStoreURL{
 		Scheme: "s3",
@@ -438,6 +438,13 @@ func NewClientFromURLAndSecret(url *ObjectStoreURL, reverse ...bool) (c Client,
 	case "local":
 		root := strings.ReplaceAll(url.Bucket, ".", "/")
 		c, err = NewLocalClient("/" + root)
+	case "replicated":
+		var primary, secondary Client
+		primary, secondary, err = ReplicaClientsFromURLAndSecret(url, reverse...)
+		if err != nil {
+			return nil, err
+		}
+		c, err = newReplicatedClientFromURL(url, primary, secondary)
 	}
 	switch {
 	case err != nil:
@@ -460,9 +467,24 @@ type ObjectStoreURL struct {
 }
 
 func (s ObjectStoreURL) String() string {
+	if s.Scheme == "replicated" {
+		return fmt.Sprintf("%s:///%s?%s", s.Scheme, s.Object, s.Bucket)
+	}
 	return fmt.Sprintf("%s://%s/%s", s.Scheme, s.Bucket, s.Object)
 }
 
+// ObjectURL returns the URL of the object with the given name in the same
+// bucket, in a form which can be parsed by ParseURL.
+func (s ObjectStoreURL) ObjectURL(name string) string {
+	s.Object = strings.Trim(name, "/")
+	switch s.Scheme {
+	case "as", "wasb":
+		// In Azure, the first part of the path is the container name.
+		return fmt.Sprintf("%s:///%s/%s", s.Scheme, s.Bucket, s.Object)
+	}
+	return s.String()
+}
+
 // ParseURL parses an URL into ObjectStoreURL.
 func ParseURL(urlStr string) (*ObjectStoreURL, error) {
 	url, err := url.Parse(urlStr)
@@ -498,6 +520,20 @@ func ParseURL(urlStr string) (*ObjectStoreURL, error) {
 			Bucket: url.Host + "/" + parts[0],
 			Object: key,
 		}, nil
+	case "replicated":
+		// The backends of a replicated object store are passed as query
+		// parameters, which we keep (encoded) in place of the bucket.
+		params := url.Query()
+		for _, k := range []string{"primary", "secondary"} {
+			if params.Get(k) == "" {
+				return nil, errors.Errorf("malformed replicated URI, missing %s: %v", k, urlStr)
+			}
+		}
+		return &ObjectStoreURL{
+			Scheme: url.Scheme,
+			Bucket: params.Encode(),
+			Object: strings.Trim(url.Path, "/"),
+		}, nil
 	}
 	return nil, errors.Errorf("unrecognized object store: %s", url.Scheme)
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -58,11 +59,30 @@ func (c *googleClient) Exists(ctx context.Context, name string) (bool, error) {
 	return true, nil
 }
 
+func (c *googleClient) Stat(ctx context.Context, name string) (_ *ObjectInfo, retErr error) {
+	defer func() { retErr = c.transformError(retErr, name) }()
+	attrs, err := c.bucket.Object(name).Attrs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectInfo{
+		SizeBytes: attrs.Size,
+		ETag:      attrs.Etag,
+		Version:   strconv.FormatInt(attrs.Generation, 10),
+	}, nil
+}
+
 func (c *googleClient) Put(ctx context.Context, name string, r io.Reader) (retErr error) {
 	defer func() { retErr = c.transformError(retErr, name) }()
 	ctx, cf := context.WithCancel(ctx)
 	defer cf() // this aborts the write if the writer is not already closed
 	wc := c.bucket.Object(name).NewWriter(ctx)
+	// GCS rejects the upload if the data doesn't match the MD5 sum.
+	sum, err := contentMD5(r)
+	if err != nil {
+		return err
+	}
+	wc.MD5 = sum
 	if _, err := io.Copy(wc, r); err != nil {
 		return err
 	}
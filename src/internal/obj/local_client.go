@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -92,6 +93,20 @@ func (c *fsClient) Exists(ctx context.Context, name string) (bool, error) {
 	return true, nil
 }
 
+func (c *fsClient) Stat(ctx context.Context, name string) (_ *ObjectInfo, retErr error) {
+	defer func() { retErr = c.transformError(retErr, name) }()
+	fi, err := os.Stat(c.finalPathFor(name))
+	if err != nil {
+		return nil, err
+	}
+	// Objects are replaced by renaming, so the modification time changes
+	// whenever the content does.
+	return &ObjectInfo{
+		SizeBytes: fi.Size(),
+		ETag:      fmt.Sprintf("%x-%x", fi.ModTime().UnixNano(), fi.Size()),
+	}, nil
+}
+
 func (c *fsClient) Walk(ctx context.Context, prefix string, cb func(string) error) error {
 	dirEnts, err := os.ReadDir(filepath.Join(c.rootDir, "objects"))
 	if err != nil {
@@ -1,7 +1,10 @@
 package obj
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/require"
 )
@@ -18,3 +21,21 @@ func newTestLocalClient(t testing.TB) Client {
 	require.NoError(t, err)
 	return c
 }
+
+func TestLocalClientStat(t *testing.T) {
+	ctx := context.Background()
+	c := newTestLocalClient(t)
+	require.NoError(t, c.Put(ctx, "test", strings.NewReader("foo")))
+	info, err := Stat(ctx, c, "test")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), info.SizeBytes)
+	require.NotEqual(t, "", info.ETag)
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, c.Put(ctx, "test", strings.NewReader("barbaz")))
+	info2, err := Stat(ctx, c, "test")
+	require.NoError(t, err)
+	require.Equal(t, int64(6), info2.SizeBytes)
+	require.NotEqual(t, info.ETag, info2.ETag)
+	_, err = Stat(ctx, c, "missing")
+	require.YesError(t, err)
+}
@@ -2,9 +2,11 @@ package obj
 
 import (
 	"context"
+	"crypto/md5"
 	"encoding/base64"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/storage"
@@ -112,6 +114,19 @@ func (c *microsoftClient) Exists(ctx context.Context, name string) (bool, error)
 	return exists, nil
 }
 
+// TODO: should respect context
+func (c *microsoftClient) Stat(_ context.Context, name string) (_ *ObjectInfo, retErr error) {
+	defer func() { retErr = c.transformError(retErr, name) }()
+	blob := c.container.GetBlobReference(name)
+	if err := blob.GetProperties(nil); err != nil {
+		return nil, err
+	}
+	return &ObjectInfo{
+		SizeBytes: blob.Properties.ContentLength,
+		ETag:      strings.Trim(blob.Properties.Etag, `"`),
+	}, nil
+}
+
 func (c *microsoftClient) BucketURL() ObjectStoreURL {
 	return ObjectStoreURL{
 		Scheme: "as",
@@ -179,7 +194,10 @@ func (w *microsoftWriter) writeBlock(block []byte) (retErr error) {
 	w.eg.Go(func() error {
 		defer w.limiter.Release()
 		defer bufPool.Put(block[:cap(block)]) //nolint:staticcheck // []byte is sufficiently pointer-like for our purposes
-		if err := w.blob.PutBlock(blockID, block, nil); err != nil {
+		// Have the server verify the block against its Content-MD5.
+		sum := md5.Sum(block)
+		opts := &storage.PutBlockOptions{ContentMD5: base64.StdEncoding.EncodeToString(sum[:])}
+		if err := w.blob.PutBlock(blockID, block, opts); err != nil {
 			w.err = err
 			return err
 		}
@@ -50,6 +50,8 @@ func (c *minioClient) Put(ctx context.Context, name string, r io.Reader) (retErr
 	opts := minio.PutObjectOptions{
 		ContentType: "application/octet-stream",
 		PartSize:    uint64(8 * 1024 * 1024),
+		// Have the server verify each part against its Content-MD5.
+		SendContentMd5: true,
 	}
 	_, err := c.Client.PutObjectWithContext(ctx, c.bucket, name, r, -1, opts)
 	return err
@@ -107,6 +109,18 @@ func (c *minioClient) Exists(ctx context.Context, name string) (bool, error) {
 	return true, nil
 }
 
+func (c *minioClient) Stat(ctx context.Context, name string) (_ *ObjectInfo, retErr error) {
+	defer func() { retErr = c.transformError(retErr, name) }()
+	info, err := c.StatObjectWithContext(ctx, c.bucket, name, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectInfo{
+		SizeBytes: info.Size,
+		ETag:      info.ETag,
+	}, nil
+}
+
 func (c *minioClient) BucketURL() ObjectStoreURL {
 	u := c.Client.EndpointURL()
 	return ObjectStoreURL{
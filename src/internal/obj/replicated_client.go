@@ -0,0 +1,446 @@
+package obj
+
+import (
+	"bytes"
+	"context"
+	"io"
+	neturl "net/url"
+	"sync"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pacherr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	replicatedFailoverMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_object_storage_replicated",
+		Name:      "failovers_total",
+		Help:      "Number of object storage operations that failed on the primary and were retried against the secondary, by operation name",
+	}, []string{"op"})
+	replicatedHedgeMetric = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_object_storage_replicated",
+		Name:      "hedged_gets_total",
+		Help:      "Number of gets for which a hedged request was sent to the secondary",
+	})
+	replicatedHedgeWinsMetric = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_object_storage_replicated",
+		Name:      "hedged_gets_won_total",
+		Help:      "Number of hedged gets that were served by the secondary",
+	})
+	replicatedAsyncMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_object_storage_replicated",
+		Name:      "async_replications_total",
+		Help:      "Number of asynchronous replications to the secondary, by result (success, failure or dropped)",
+	}, []string{"result"})
+)
+
+const (
+	// DefaultReplicationQueueSize is the default number of objects that can
+	// be waiting for asynchronous replication before new ones are dropped.
+	DefaultReplicationQueueSize = 10000
+	// DefaultReplicationWorkers is the default number of goroutines copying
+	// objects to the secondary in asynchronous mode.
+	DefaultReplicationWorkers = 10
+	replicationTimeout        = 5 * time.Minute
+)
+
+var _ Client = &replicatedClient{}
+var _ io.Closer = &replicatedClient{}
+
+// replicatedClient is a Client which writes every object to two backends and
+// reads from the secondary when the primary fails or is slow.
+type replicatedClient struct {
+	primary, secondary Client
+	async              bool
+	hedgeDelay         time.Duration
+	queue              chan string
+	// mu guards closed, so that puts don't send to the queue once it is
+	// closed, and workers is done when the queue has been drained.
+	mu      sync.RWMutex
+	closed  bool
+	workers sync.WaitGroup
+}
+
+// ReplicatedClientOption configures a replicated client.
+type ReplicatedClientOption func(rc *replicatedClient)
+
+// WithAsyncReplication makes puts return as soon as the primary write
+// succeeds, copying the object to the secondary in the background.
+// Objects which fail to replicate (or which are dropped because more than
+// queueSize objects are waiting) are logged and can be fixed with
+// RepairReplicas. The client must be closed to stop the workers once the
+// queued objects have been replicated.
+func WithAsyncReplication(workers, queueSize int) ReplicatedClientOption {
+	return func(rc *replicatedClient) {
+		if workers < 1 {
+			workers = DefaultReplicationWorkers
+		}
+		if queueSize < 1 {
+			queueSize = DefaultReplicationQueueSize
+		}
+		rc.async = true
+		rc.queue = make(chan string, queueSize)
+		rc.workers.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer rc.workers.Done()
+				rc.replicate()
+			}()
+		}
+	}
+}
+
+// WithHedgedGets sends a second get to the secondary when the primary has
+// not finished within delay, and uses whichever finishes first.
+// Hedged gets buffer the object in memory.
+func WithHedgedGets(delay time.Duration) ReplicatedClientOption {
+	return func(rc *replicatedClient) {
+		rc.hedgeDelay = delay
+	}
+}
+
+// NewReplicatedClient constructs a Client which writes to both primary and
+// secondary and reads from primary, failing over to secondary on errors.
+// By default writes to the secondary are synchronous, so a put only
+// succeeds if both backends accepted the object.
+// The client implements io.Closer, which waits for asynchronous replication.
+func NewReplicatedClient(primary, secondary Client, opts ...ReplicatedClientOption) Client {
+	rc := &replicatedClient{
+		primary:   primary,
+		secondary: secondary,
+	}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	return rc
+}
+
+func (rc *replicatedClient) Put(ctx context.Context, name string, r io.Reader) error {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	// Once the client is closed, puts are replicated synchronously.
+	if rc.async && !rc.closed {
+		if err := rc.primary.Put(ctx, name, r); err != nil {
+			return err
+		}
+		select {
+		case rc.queue <- name:
+		default:
+			replicatedAsyncMetric.WithLabelValues("dropped").Inc()
+			log.Warnf("replication queue full, object %q will not be replicated until repaired", name)
+		}
+		return nil
+	}
+	return miscutil.WithPipe(func(w io.Writer) error {
+		return rc.primary.Put(ctx, name, io.TeeReader(r, w))
+	}, func(r io.Reader) error {
+		return errors.Wrapf(rc.secondary.Put(ctx, name, r), "error writing %q to secondary", name)
+	})
+}
+
+// Close stops asynchronous replication, and waits for the workers to
+// replicate the objects which are already queued.
+func (rc *replicatedClient) Close() error {
+	rc.mu.Lock()
+	if !rc.async || rc.closed {
+		rc.mu.Unlock()
+		return nil
+	}
+	rc.closed = true
+	close(rc.queue)
+	rc.mu.Unlock()
+	rc.workers.Wait()
+	return nil
+}
+
+func (rc *replicatedClient) replicate() {
+	for name := range rc.queue {
+		if err := func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
+			defer cancel()
+			return Copy(ctx, rc.primary, rc.secondary, name, name)
+		}(); err != nil {
+			// The object may have been deleted before we got to it.
+			if pacherr.IsNotExist(err) {
+				continue
+			}
+			replicatedAsyncMetric.WithLabelValues("failure").Inc()
+			log.Errorf("could not replicate object %q to secondary: %v", name, err)
+			continue
+		}
+		replicatedAsyncMetric.WithLabelValues("success").Inc()
+	}
+}
+
+func (rc *replicatedClient) Get(ctx context.Context, name string, w io.Writer) error {
+	if rc.hedgeDelay > 0 {
+		return rc.hedgedGet(ctx, name, w)
+	}
+	cw := &countWriter{w: w}
+	err := rc.primary.Get(ctx, name, cw)
+	if err == nil || ctx.Err() != nil {
+		return err
+	}
+	replicatedFailoverMetric.WithLabelValues("get").Inc()
+	log.Warnf("could not get %q from primary, failing over to secondary: %v", name, err)
+	// Skip the bytes the primary already wrote before it failed.
+	if err2 := rc.secondary.Get(ctx, name, &skipWriter{w: w, skip: cw.n}); err2 != nil {
+		return err
+	}
+	return nil
+}
+
+type getResult struct {
+	buf       *bytes.Buffer
+	err       error
+	secondary bool
+}
+
+func (rc *replicatedClient) hedgedGet(ctx context.Context, name string, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan getResult, 2)
+	get := func(c Client, secondary bool) {
+		buf := &bytes.Buffer{}
+		err := c.Get(ctx, name, buf)
+		results <- getResult{buf: buf, err: err, secondary: secondary}
+	}
+	go get(rc.primary, false)
+	timer := time.NewTimer(rc.hedgeDelay)
+	defer timer.Stop()
+	var primaryErr error
+	hedged, pending := false, 1
+	hedge := func() {
+		if !hedged {
+			hedged = true
+			pending++
+			go get(rc.secondary, true)
+		}
+	}
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if !hedged {
+				replicatedHedgeMetric.Inc()
+			}
+			hedge()
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				if res.secondary {
+					replicatedHedgeWinsMetric.Inc()
+				}
+				_, err := io.Copy(w, res.buf)
+				return errors.EnsureStack(err)
+			}
+			if !res.secondary {
+				primaryErr = res.err
+				if ctx.Err() != nil {
+					return primaryErr
+				}
+				if !hedged {
+					replicatedFailoverMetric.WithLabelValues("get").Inc()
+				}
+				hedge()
+			}
+		}
+	}
+	return primaryErr
+}
+
+func (rc *replicatedClient) Delete(ctx context.Context, name string) error {
+	err := rc.primary.Delete(ctx, name)
+	if err2 := rc.secondary.Delete(ctx, name); err2 != nil && !pacherr.IsNotExist(err2) && err == nil {
+		err = errors.Wrapf(err2, "error deleting %q from secondary", name)
+	}
+	return err
+}
+
+func (rc *replicatedClient) Walk(ctx context.Context, prefix string, fn func(name string) error) error {
+	var called bool
+	err := rc.primary.Walk(ctx, prefix, func(name string) error {
+		called = true
+		return fn(name)
+	})
+	// Only fail over if the primary failed before calling fn, otherwise fn
+	// would see the same names twice.
+	if err == nil || called || ctx.Err() != nil {
+		return err
+	}
+	replicatedFailoverMetric.WithLabelValues("walk").Inc()
+	log.Warnf("could not walk %q on primary, failing over to secondary: %v", prefix, err)
+	if err2 := rc.secondary.Walk(ctx, prefix, fn); err2 != nil {
+		return err
+	}
+	return nil
+}
+
+func (rc *replicatedClient) Exists(ctx context.Context, name string) (bool, error) {
+	exists, err := rc.primary.Exists(ctx, name)
+	if err == nil || ctx.Err() != nil {
+		return exists, err
+	}
+	replicatedFailoverMetric.WithLabelValues("exists").Inc()
+	log.Warnf("could not check existence of %q on primary, failing over to secondary: %v", name, err)
+	exists, err2 := rc.secondary.Exists(ctx, name)
+	if err2 != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// Stat only uses the primary, since the attributes of an object differ
+// between the backends.
+func (rc *replicatedClient) Stat(ctx context.Context, name string) (*ObjectInfo, error) {
+	return Stat(ctx, rc.primary, name)
+}
+
+func (rc *replicatedClient) BucketURL() ObjectStoreURL {
+	return rc.primary.BucketURL()
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(data []byte) (int, error) {
+	n, err := cw.w.Write(data)
+	cw.n += int64(n)
+	return n, err
+}
+
+// skipWriter discards the first skip bytes written to it.
+type skipWriter struct {
+	w    io.Writer
+	skip int64
+}
+
+func (sw *skipWriter) Write(data []byte) (int, error) {
+	if sw.skip >= int64(len(data)) {
+		sw.skip -= int64(len(data))
+		return len(data), nil
+	}
+	n, err := sw.w.Write(data[sw.skip:])
+	n += int(sw.skip)
+	sw.skip = 0
+	return n, err
+}
+
+// ReplicaClientsFromURLAndSecret constructs the primary and secondary clients
+// of a replicated object store URL of the form
+//   replicated://?primary=<url>&secondary=<url>[&mode=sync|async][&hedge=<duration>]
+// Both backends read their credentials from the mounted storage secret.
+func ReplicaClientsFromURLAndSecret(url *ObjectStoreURL, reverse ...bool) (primary, secondary Client, retErr error) {
+	if url.Scheme != "replicated" {
+		return nil, nil, errors.Errorf("not a replicated object store: %s", url.Scheme)
+	}
+	params, err := neturl.ParseQuery(url.Bucket)
+	if err != nil {
+		return nil, nil, errors.EnsureStack(err)
+	}
+	newClient := func(k string) (Client, error) {
+		u, err := ParseURL(params.Get(k))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing %s url", k)
+		}
+		if u.Scheme == "replicated" {
+			return nil, errors.Errorf("%s of a replicated object store cannot be replicated", k)
+		}
+		return NewClientFromURLAndSecret(u, reverse...)
+	}
+	if primary, err = newClient("primary"); err != nil {
+		return nil, nil, err
+	}
+	if secondary, err = newClient("secondary"); err != nil {
+		return nil, nil, err
+	}
+	return primary, secondary, nil
+}
+
+func newReplicatedClientFromURL(url *ObjectStoreURL, primary, secondary Client) (Client, error) {
+	params, err := neturl.ParseQuery(url.Bucket)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var opts []ReplicatedClientOption
+	switch mode := params.Get("mode"); mode {
+	case "", "sync":
+	case "async":
+		opts = append(opts, WithAsyncReplication(DefaultReplicationWorkers, DefaultReplicationQueueSize))
+	default:
+		return nil, errors.Errorf("unrecognized replication mode: %s", mode)
+	}
+	if hedge := params.Get("hedge"); hedge != "" {
+		delay, err := time.ParseDuration(hedge)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing hedge delay")
+		}
+		opts = append(opts, WithHedgedGets(delay))
+	}
+	return NewReplicatedClient(primary, secondary, opts...), nil
+}
+
+// RepairStats describes the work done by RepairReplicas.
+type RepairStats struct {
+	// CopiedToPrimary and CopiedToSecondary are the objects which were
+	// missing from the primary and secondary respectively.
+	CopiedToPrimary, CopiedToSecondary []string
+}
+
+// RepairReplicas reconciles the objects under prefix in primary and secondary,
+// copying objects which are missing from one backend from the other.
+// If dryRun is true, the missing objects are reported but not copied.
+func RepairReplicas(ctx context.Context, primary, secondary Client, prefix string, dryRun bool) (*RepairStats, error) {
+	list := func(c Client) (map[string]struct{}, error) {
+		names := make(map[string]struct{})
+		if err := c.Walk(ctx, prefix, func(name string) error {
+			names[name] = struct{}{}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		return names, nil
+	}
+	primaryNames, err := list(primary)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing primary")
+	}
+	secondaryNames, err := list(secondary)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing secondary")
+	}
+	stats := &RepairStats{}
+	for name := range primaryNames {
+		if _, ok := secondaryNames[name]; ok {
+			continue
+		}
+		if !dryRun {
+			if err := Copy(ctx, primary, secondary, name, name); err != nil {
+				return stats, errors.Wrapf(err, "error copying %q to secondary", name)
+			}
+		}
+		stats.CopiedToSecondary = append(stats.CopiedToSecondary, name)
+	}
+	for name := range secondaryNames {
+		if _, ok := primaryNames[name]; ok {
+			continue
+		}
+		if !dryRun {
+			if err := Copy(ctx, secondary, primary, name, name); err != nil {
+				return stats, errors.Wrapf(err, "error copying %q to primary", name)
+			}
+		}
+		stats.CopiedToPrimary = append(stats.CopiedToPrimary, name)
+	}
+	return stats, nil
+}
@@ -0,0 +1,96 @@
+package obj
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestReplicatedClient(t *testing.T) {
+	t.Parallel()
+	TestSuite(t, func(t testing.TB) Client {
+		return NewReplicatedClient(newTestLocalClient(t), newTestLocalClient(t))
+	})
+}
+
+func TestReplicatedClientAsync(t *testing.T) {
+	t.Parallel()
+	TestSuite(t, func(t testing.TB) Client {
+		c := NewReplicatedClient(newTestLocalClient(t), newTestLocalClient(t), WithAsyncReplication(1, 10))
+		t.Cleanup(func() { require.NoError(t, c.(io.Closer).Close()) })
+		return c
+	})
+}
+
+func TestReplicatedClientAsyncClose(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	primary, secondary := newTestLocalClient(t), newTestLocalClient(t)
+	c := NewReplicatedClient(primary, secondary, WithAsyncReplication(1, 10))
+	names := []string{"a", "b", "c"}
+	for _, name := range names {
+		require.NoError(t, c.Put(ctx, name, bytes.NewReader([]byte(name))))
+	}
+	// Closing drains the queue, so every object is on the secondary.
+	require.NoError(t, c.(io.Closer).Close())
+	for _, name := range names {
+		requireExists(t, secondary, name, true)
+	}
+	// Puts after closing are replicated synchronously.
+	require.NoError(t, c.Put(ctx, "d", bytes.NewReader([]byte("d"))))
+	requireExists(t, secondary, "d", true)
+	require.NoError(t, c.(io.Closer).Close())
+}
+
+func TestReplicatedClientHedged(t *testing.T) {
+	t.Parallel()
+	TestSuite(t, func(t testing.TB) Client {
+		return NewReplicatedClient(newTestLocalClient(t), newTestLocalClient(t), WithHedgedGets(time.Millisecond))
+	})
+}
+
+func TestReplicatedClientFailover(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	primary, secondary := newTestLocalClient(t), newTestLocalClient(t)
+	for _, c := range []Client{
+		NewReplicatedClient(primary, secondary),
+		NewReplicatedClient(primary, secondary, WithHedgedGets(time.Hour)),
+	} {
+		data := []byte("foo bar")
+		require.NoError(t, c.Put(ctx, "object", bytes.NewReader(data)))
+		require.NoError(t, primary.Delete(ctx, "object"))
+		buf := &bytes.Buffer{}
+		require.NoError(t, c.Get(ctx, "object", buf))
+		require.Equal(t, data, buf.Bytes())
+		require.NoError(t, c.Delete(ctx, "object"))
+	}
+}
+
+func TestRepairReplicas(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	primary, secondary := newTestLocalClient(t), newTestLocalClient(t)
+	require.NoError(t, primary.Put(ctx, "a", bytes.NewReader([]byte("a"))))
+	require.NoError(t, secondary.Put(ctx, "b", bytes.NewReader([]byte("b"))))
+	require.NoError(t, primary.Put(ctx, "c", bytes.NewReader([]byte("c"))))
+	require.NoError(t, secondary.Put(ctx, "c", bytes.NewReader([]byte("c"))))
+
+	stats, err := RepairReplicas(ctx, primary, secondary, "", true)
+	require.NoError(t, err)
+	require.ElementsEqual(t, []string{"a"}, stats.CopiedToSecondary)
+	require.ElementsEqual(t, []string{"b"}, stats.CopiedToPrimary)
+	requireExists(t, secondary, "a", false)
+
+	_, err = RepairReplicas(ctx, primary, secondary, "", false)
+	require.NoError(t, err)
+	requireExists(t, secondary, "a", true)
+	requireExists(t, primary, "b", true)
+	stats, err = RepairReplicas(ctx, primary, secondary, "", true)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(stats.CopiedToPrimary)+len(stats.CopiedToSecondary))
+}
@@ -0,0 +1,32 @@
+package obj
+
+import (
+	"context"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+// ObjectInfo contains the attributes of an object which identify its content.
+type ObjectInfo struct {
+	SizeBytes int64
+	// ETag changes whenever the content of the object changes.
+	ETag string
+	// Version is set by object stores with versioning enabled.
+	Version string
+}
+
+// Stater is implemented by Clients which can return the attributes of an
+// object without reading it.
+type Stater interface {
+	Stat(ctx context.Context, name string) (*ObjectInfo, error)
+}
+
+// Stat returns the attributes of an object, or an error if the client does
+// not support it.
+func Stat(ctx context.Context, c Client, name string) (*ObjectInfo, error) {
+	s, ok := c.(Stater)
+	if !ok {
+		return nil, errors.Errorf("object store %v does not support stat", c.BucketURL())
+	}
+	return s.Stat(ctx, name)
+}
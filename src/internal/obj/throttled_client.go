@@ -0,0 +1,194 @@
+package obj
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+)
+
+var (
+	throttleLimitMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_object_storage_throttle",
+		Name:      "bytes_per_second",
+		Help:      "The configured object storage bandwidth limit in bytes per second, by direction (upload or download). 0 means unlimited.",
+	}, []string{"direction"})
+	throttleBurstMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_object_storage_throttle",
+		Name:      "burst_bytes",
+		Help:      "The configured object storage bandwidth burst in bytes, by direction (upload or download).",
+	}, []string{"direction"})
+	throttleWaitMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pfs_object_storage_throttle",
+		Name:      "wait_seconds_total",
+		Help:      "Total time spent waiting for object storage bandwidth, by direction (upload or download).",
+	}, []string{"direction"})
+)
+
+const (
+	uploadDirection   = "upload"
+	downloadDirection = "download"
+	// DefaultThrottleBurst is the burst used when a bandwidth limit is set
+	// without a burst.
+	DefaultThrottleBurst = 1024 * 1024
+)
+
+// Throttle limits the upload and download throughput of the clients which
+// share it. Its limits can be changed while it is in use.
+type Throttle struct {
+	mu                 sync.Mutex
+	upload, download   *rate.Limiter
+	uploadBPS, downBPS int64
+	burst              int64
+}
+
+// NewThrottle creates a Throttle with the given limits, see SetLimits.
+func NewThrottle(uploadBytesPerSecond, downloadBytesPerSecond, burstBytes int64) *Throttle {
+	t := &Throttle{
+		upload:   rate.NewLimiter(rate.Inf, 0),
+		download: rate.NewLimiter(rate.Inf, 0),
+	}
+	t.SetLimits(uploadBytesPerSecond, downloadBytesPerSecond, burstBytes)
+	return t
+}
+
+// SetLimits sets the upload and download limits in bytes per second, and the
+// number of bytes which can be transferred in a burst above those limits.
+// A limit < 1 means unlimited, a burst < 1 means DefaultThrottleBurst.
+func (t *Throttle) SetLimits(uploadBytesPerSecond, downloadBytesPerSecond, burstBytes int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if uploadBytesPerSecond < 1 {
+		uploadBytesPerSecond = 0
+	}
+	if downloadBytesPerSecond < 1 {
+		downloadBytesPerSecond = 0
+	}
+	if burstBytes < 1 {
+		burstBytes = DefaultThrottleBurst
+	}
+	t.uploadBPS, t.downBPS, t.burst = uploadBytesPerSecond, downloadBytesPerSecond, burstBytes
+	setLimit(t.upload, uploadDirection, uploadBytesPerSecond, burstBytes)
+	setLimit(t.download, downloadDirection, downloadBytesPerSecond, burstBytes)
+}
+
+func setLimit(l *rate.Limiter, direction string, bytesPerSecond, burstBytes int64) {
+	limit := rate.Inf
+	if bytesPerSecond > 0 {
+		limit = rate.Limit(bytesPerSecond)
+	}
+	if burstBytes > math.MaxInt32 {
+		burstBytes = math.MaxInt32
+	}
+	l.SetLimit(limit)
+	l.SetBurst(int(burstBytes))
+	throttleLimitMetric.WithLabelValues(direction).Set(float64(bytesPerSecond))
+	throttleBurstMetric.WithLabelValues(direction).Set(float64(burstBytes))
+}
+
+// Limits returns the current limits, see SetLimits.
+func (t *Throttle) Limits() (uploadBytesPerSecond, downloadBytesPerSecond, burstBytes int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.uploadBPS, t.downBPS, t.burst
+}
+
+var globalThrottle = NewThrottle(0, 0, 0)
+
+// GlobalThrottle returns the process wide Throttle, which is shared by all
+// of the object storage clients created for chunk storage.
+func GlobalThrottle() *Throttle {
+	return globalThrottle
+}
+
+var _ Client = &throttledClient{}
+
+// throttledClient is a Client which limits the number of bytes per second
+// read from and written to the wrapped client.
+type throttledClient struct {
+	Client
+	throttle *Throttle
+}
+
+// NewThrottledClient constructs a Client which limits the throughput of puts
+// and gets using throttle.
+func NewThrottledClient(client Client, throttle *Throttle) Client {
+	return &throttledClient{
+		Client:   client,
+		throttle: throttle,
+	}
+}
+
+func (tc *throttledClient) Put(ctx context.Context, name string, r io.Reader) error {
+	return tc.Client.Put(ctx, name, &throttledReader{
+		ctx:     ctx,
+		r:       r,
+		limiter: tc.throttle.upload,
+	})
+}
+
+func (tc *throttledClient) Get(ctx context.Context, name string, w io.Writer) error {
+	return tc.Client.Get(ctx, name, &throttledWriter{
+		ctx:     ctx,
+		w:       w,
+		limiter: tc.throttle.download,
+	})
+}
+
+// wait blocks until n bytes can be transferred, in pieces no larger than the
+// limiter's burst.
+func wait(ctx context.Context, limiter *rate.Limiter, direction string, n int) error {
+	if limiter.Limit() == rate.Inf {
+		return nil
+	}
+	start := time.Now()
+	defer func() {
+		throttleWaitMetric.WithLabelValues(direction).Add(time.Since(start).Seconds())
+	}()
+	for n > 0 {
+		m := n
+		if burst := limiter.Burst(); m > burst && burst > 0 {
+			m = burst
+		}
+		if err := limiter.WaitN(ctx, m); err != nil {
+			return err
+		}
+		n -= m
+	}
+	return nil
+}
+
+type throttledReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+func (tr *throttledReader) Read(data []byte) (int, error) {
+	n, err := tr.r.Read(data)
+	if err2 := wait(tr.ctx, tr.limiter, uploadDirection, n); err2 != nil {
+		return n, err2
+	}
+	return n, err
+}
+
+type throttledWriter struct {
+	ctx     context.Context
+	w       io.Writer
+	limiter *rate.Limiter
+}
+
+func (tw *throttledWriter) Write(data []byte) (int, error) {
+	if err := wait(tw.ctx, tw.limiter, downloadDirection, len(data)); err != nil {
+		return 0, err
+	}
+	return tw.w.Write(data)
+}
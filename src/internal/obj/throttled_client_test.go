@@ -0,0 +1,44 @@
+package obj
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+func TestThrottledClient(t *testing.T) {
+	t.Parallel()
+	TestSuite(t, func(t testing.TB) Client {
+		return NewThrottledClient(newTestLocalClient(t), NewThrottle(0, 0, 0))
+	})
+}
+
+func TestThrottledClientLimits(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	throttle := NewThrottle(0, 0, 0)
+	c := NewThrottledClient(newTestLocalClient(t), throttle)
+	data := make([]byte, 100*1024)
+	// The first burst is free, the rest is limited to 100KB/s.
+	throttle.SetLimits(100*1024, 100*1024, 50*1024)
+	start := time.Now()
+	require.NoError(t, c.Put(ctx, "object", bytes.NewReader(data)))
+	require.True(t, time.Since(start) >= 400*time.Millisecond)
+	start = time.Now()
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.Get(ctx, "object", buf))
+	require.True(t, time.Since(start) >= 400*time.Millisecond)
+	require.Equal(t, data, buf.Bytes())
+	up, down, burst := throttle.Limits()
+	require.Equal(t, int64(100*1024), up)
+	require.Equal(t, int64(100*1024), down)
+	require.Equal(t, int64(50*1024), burst)
+	// Removing the limits takes effect immediately.
+	throttle.SetLimits(0, 0, 0)
+	start = time.Now()
+	require.NoError(t, c.Get(ctx, "object", &bytes.Buffer{}))
+	require.True(t, time.Since(start) < 400*time.Millisecond)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/promutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/tracing"
 	"github.com/prometheus/client_golang/prometheus"
@@ -128,3 +129,22 @@ func (o *tracingObjClient) Exists(ctx context.Context, name string) (retVal bool
 	defer tracing.FinishAnySpan(span)
 	return o.Client.Exists(ctx, name)
 }
+
+// Stat implements Stater if the wrapped client does
+func (o *tracingObjClient) Stat(ctx context.Context, name string) (_ *ObjectInfo, retErr error) {
+	objectOperationMetric.WithLabelValues(o.provider, "stat").Inc()
+	span, ctx := tracing.AddSpanToAnyExisting(ctx, "/"+o.provider+"/Stat",
+		"name", name)
+	defer func() {
+		tracing.FinishAnySpan(span, "err", retErr)
+	}()
+	return Stat(ctx, o.Client, name)
+}
+
+// Close implements io.Closer if the wrapped client does
+func (o *tracingObjClient) Close() error {
+	if closer, ok := o.Client.(io.Closer); ok {
+		return errors.EnsureStack(closer.Close())
+	}
+	return nil
+}
@@ -69,6 +69,14 @@ func (uc *uniformClient) Exists(ctx context.Context, p string) (_ bool, retErr e
 	return exists, err
 }
 
+func (uc *uniformClient) Stat(ctx context.Context, name string) (_ *ObjectInfo, retErr error) {
+	defer func() {
+		retErr = errors.EnsureStack(retErr)
+	}()
+	name = strings.Trim(name, "/")
+	return Stat(ctx, uc.c, name)
+}
+
 func (uc *uniformClient) BucketURL() ObjectStoreURL {
 	return uc.c.BucketURL()
 }
@@ -2,8 +2,10 @@ package obj
 
 import (
 	"context"
+	"crypto/md5"
 	"io"
 
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
 )
 
@@ -16,6 +18,28 @@ func Copy(ctx context.Context, src, dst Client, srcPath, dstPath string) (retErr
 	})
 }
 
+// contentMD5 returns the MD5 sum of the remaining data in r if r can be
+// rewound, so that backends which support it can verify uploads end-to-end.
+// It returns nil if r is not seekable.
+func contentMD5(r io.Reader) ([]byte, error) {
+	rs, ok := r.(io.ReadSeeker)
+	if !ok {
+		return nil, nil
+	}
+	start, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	h := md5.New()
+	if _, err := io.Copy(h, rs); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if _, err := rs.Seek(start, io.SeekStart); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return h.Sum(nil), nil
+}
+
 type testURL struct {
 	Client
 }
@@ -0,0 +1,29 @@
+package pfsdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// PutSessionCommit records a commit started by the write session of a
+// gateway, or renews its record. The pfs master finishes the commit if its
+// record isn't renewed or deleted within ttl, so that the commits of the
+// sessions lost to a restart of their gateway are finished.
+func PutSessionCommit(ctx context.Context, db *pachsql.DB, commit *pfs.Commit, ttl time.Duration) error {
+	_, err := db.ExecContext(ctx, `INSERT INTO pfs.session_commits (commit_id, expires_at)
+		VALUES ($1, CURRENT_TIMESTAMP + make_interval(secs => $2))
+		ON CONFLICT (commit_id) DO UPDATE SET expires_at = EXCLUDED.expires_at`,
+		CommitKey(commit), ttl.Seconds())
+	return errors.EnsureStack(err)
+}
+
+// DeleteSessionCommit deletes the record of a commit of a write session, once
+// the session has finished it.
+func DeleteSessionCommit(ctx context.Context, db *pachsql.DB, commit *pfs.Commit) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM pfs.session_commits WHERE commit_id = $1`, CommitKey(commit))
+	return errors.EnsureStack(err)
+}
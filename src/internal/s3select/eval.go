@@ -0,0 +1,818 @@
+package s3select
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Values are nil (for NULL and MISSING), bool, int64, float64 or string.
+// The fields of JSON records can also be objects and arrays, which can only
+// be selected.
+
+// env is what expressions are evaluated against.
+type env struct {
+	rec   record
+	alias string
+	// aggs are the results of the aggregates of a query, once all of its
+	// records have been aggregated
+	aggs []interface{}
+}
+
+type expr interface {
+	eval(e *env) (interface{}, error)
+}
+
+type literal struct {
+	v interface{}
+}
+
+func (l *literal) eval(*env) (interface{}, error) {
+	return l.v, nil
+}
+
+type pathElem struct {
+	name string
+	// quoted names are matched case sensitively
+	quoted bool
+}
+
+func (p pathElem) matches(name string) bool {
+	if p.quoted {
+		return p.name == name
+	}
+	return strings.EqualFold(p.name, name)
+}
+
+// column refers to a field of the record, optionally qualified by the alias
+// of S3Object, e.g. s.name, or to a nested field of a JSON record, e.g.
+// s.address.city.
+type column struct {
+	path []pathElem
+}
+
+func (c *column) eval(e *env) (interface{}, error) {
+	if e.rec == nil {
+		return nil, newError("%s can only be referred to in aggregates", c.path[len(c.path)-1].name)
+	}
+	path := c.path
+	if len(path) > 1 && ((e.alias != "" && path[0].matches(e.alias)) || path[0].matches("S3Object")) {
+		path = path[1:]
+	}
+	return e.rec.get(path), nil
+}
+
+type notExpr struct {
+	x expr
+}
+
+func (n *notExpr) eval(e *env) (interface{}, error) {
+	v, err := n.x.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, newError("NOT requires a boolean, not %v", v)
+	}
+	return !b, nil
+}
+
+type negExpr struct {
+	x expr
+}
+
+func (n *negExpr) eval(e *env) (interface{}, error) {
+	v, err := n.x.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return arithmetic("-", int64(0), v)
+}
+
+type binaryExpr struct {
+	op   string
+	x, y expr
+}
+
+func (b *binaryExpr) eval(e *env) (interface{}, error) {
+	x, err := b.x.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	switch b.op {
+	case "AND", "OR":
+		// Short circuit, following three-valued logic.
+		xb, err := logical(b.op, x)
+		if err != nil {
+			return nil, err
+		}
+		if xb != nil && *xb == (b.op == "OR") {
+			return *xb, nil
+		}
+		y, err := b.y.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		yb, err := logical(b.op, y)
+		if err != nil {
+			return nil, err
+		}
+		if yb != nil && *yb == (b.op == "OR") {
+			return *yb, nil
+		}
+		if xb == nil || yb == nil {
+			return nil, nil
+		}
+		return b.op == "AND", nil
+	}
+	y, err := b.y.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	if x == nil || y == nil {
+		return nil, nil
+	}
+	switch b.op {
+	case "=", "!=", "<", "<=", ">", ">=":
+		c, ok := compare(x, y)
+		if !ok {
+			if b.op == "=" || b.op == "!=" {
+				return b.op == "!=", nil
+			}
+			// Values which can't be ordered, such as a number and an empty
+			// CSV field, compare as unknown.
+			return nil, nil
+		}
+		switch b.op {
+		case "=":
+			return c == 0, nil
+		case "!=":
+			return c != 0, nil
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	case "||":
+		xs, _ := toString(x)
+		ys, _ := toString(y)
+		return xs + ys, nil
+	}
+	return arithmetic(b.op, x, y)
+}
+
+func logical(op string, v interface{}) (*bool, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, newError("%s requires booleans, not %v", op, v)
+	}
+	return &b, nil
+}
+
+type isNullExpr struct {
+	x   expr
+	not bool
+}
+
+func (n *isNullExpr) eval(e *env) (interface{}, error) {
+	v, err := n.x.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	return (v == nil) != n.not, nil
+}
+
+type likeExpr struct {
+	x, pattern expr
+	escape     string
+	not        bool
+	// re is the compiled pattern, if it is a literal
+	re *regexp.Regexp
+}
+
+func (l *likeExpr) eval(e *env) (interface{}, error) {
+	v, err := l.x.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	re := l.re
+	if re == nil {
+		p, err := l.pattern.eval(e)
+		if err != nil || p == nil {
+			return nil, err
+		}
+		pattern, ok := p.(string)
+		if !ok {
+			return nil, newError("LIKE pattern must be a string, not %v", p)
+		}
+		re = likeRegexp(pattern, l.escape)
+	}
+	s, _ := toString(v)
+	return re.MatchString(s) != l.not, nil
+}
+
+type betweenExpr struct {
+	x, lo, hi expr
+	not       bool
+}
+
+func (b *betweenExpr) eval(e *env) (interface{}, error) {
+	ge, err := (&binaryExpr{op: ">=", x: b.x, y: b.lo}).eval(e)
+	if err != nil {
+		return nil, err
+	}
+	le, err := (&binaryExpr{op: "<=", x: b.x, y: b.hi}).eval(e)
+	if err != nil {
+		return nil, err
+	}
+	if ge == nil || le == nil {
+		return nil, nil
+	}
+	return (ge == true && le == true) != b.not, nil
+}
+
+type inExpr struct {
+	x    expr
+	list []expr
+	not  bool
+}
+
+func (in *inExpr) eval(e *env) (interface{}, error) {
+	v, err := in.x.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	for _, x := range in.list {
+		y, err := x.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		if c, ok := compare(v, y); ok && c == 0 {
+			return !in.not, nil
+		}
+	}
+	return in.not, nil
+}
+
+type castExpr struct {
+	x   expr
+	typ string
+}
+
+func (c *castExpr) eval(e *env) (interface{}, error) {
+	v, err := c.x.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	if s, ok := v.(string); ok && strings.TrimSpace(s) == "" && c.typ != "STRING" {
+		// Empty fields, which is how CSV leaves out values, cast to NULL.
+		return nil, nil
+	}
+	switch c.typ {
+	case "INT":
+		switch v := v.(type) {
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case string:
+			s := strings.TrimSpace(v)
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return i, nil
+			}
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return int64(f), nil
+			}
+		default:
+			if n, ok := toNumber(v); ok {
+				if f, ok := n.(float64); ok {
+					return int64(f), nil
+				}
+				return n, nil
+			}
+		}
+	case "FLOAT":
+		if n, ok := toNumber(v); ok {
+			if i, ok := n.(int64); ok {
+				return float64(i), nil
+			}
+			return n, nil
+		}
+	case "STRING":
+		if s, ok := toString(v); ok {
+			return s, nil
+		}
+	case "BOOL":
+		switch v := v.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		case int64:
+			return v != 0, nil
+		case float64:
+			return v != 0, nil
+		}
+	}
+	return nil, newError("cannot cast %v to %s", v, c.typ)
+}
+
+// scalarFuncs are the supported functions, by their number of arguments, -1
+// for any number.
+var scalarFuncs = map[string]int{
+	"LOWER":            1,
+	"UPPER":            1,
+	"TRIM":             1,
+	"CHAR_LENGTH":      1,
+	"CHARACTER_LENGTH": 1,
+	"COALESCE":         -1,
+}
+
+type callExpr struct {
+	name string
+	args []expr
+}
+
+func (c *callExpr) eval(e *env) (interface{}, error) {
+	var args []interface{}
+	for _, x := range c.args {
+		v, err := x.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	if c.name == "COALESCE" {
+		for _, v := range args {
+			if v != nil {
+				return v, nil
+			}
+		}
+		return nil, nil
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+	s, ok := args[0].(string)
+	if !ok {
+		return nil, newError("%s requires a string, not %v", c.name, args[0])
+	}
+	switch c.name {
+	case "LOWER":
+		return strings.ToLower(s), nil
+	case "UPPER":
+		return strings.ToUpper(s), nil
+	case "TRIM":
+		return strings.TrimSpace(s), nil
+	default:
+		return int64(len([]rune(s))), nil
+	}
+}
+
+type aggFunc int
+
+const (
+	aggCount aggFunc = iota
+	aggSum
+	aggAvg
+	aggMin
+	aggMax
+)
+
+var aggregateFuncs = map[string]aggFunc{
+	"COUNT": aggCount,
+	"SUM":   aggSum,
+	"AVG":   aggAvg,
+	"MIN":   aggMin,
+	"MAX":   aggMax,
+}
+
+type aggregate struct {
+	fn aggFunc
+	x  expr
+	// star is set for COUNT(*)
+	star bool
+	// index is the index of the aggregate among the aggregates of its query
+	index int
+}
+
+func (a *aggregate) eval(e *env) (interface{}, error) {
+	if e.aggs == nil {
+		return nil, newError("aggregates can only be selected")
+	}
+	return e.aggs[a.index], nil
+}
+
+// aggState accumulates the values of an aggregate.
+type aggState struct {
+	count int64
+	sum   interface{}
+	value interface{}
+}
+
+func (s *aggState) add(a *aggregate, e *env) error {
+	if a.star {
+		s.count++
+		return nil
+	}
+	v, err := a.x.eval(e)
+	if err != nil || v == nil {
+		return err
+	}
+	if str, ok := v.(string); ok && strings.TrimSpace(str) == "" {
+		// Empty fields, which is how CSV leaves out values, are aggregated
+		// as NULL.
+		return nil
+	}
+	s.count++
+	switch a.fn {
+	case aggSum, aggAvg:
+		n, ok := toNumber(v)
+		if !ok {
+			return newError("cannot aggregate %v, it is not a number", v)
+		}
+		if s.sum == nil {
+			s.sum = n
+			return nil
+		}
+		sum, err := arithmetic("+", s.sum, n)
+		if err != nil {
+			return err
+		}
+		s.sum = sum
+	case aggMin, aggMax:
+		if n, ok := toNumber(v); ok {
+			// Compare numeric strings, as CSV fields are, as numbers.
+			v = n
+		}
+		if s.value == nil {
+			s.value = v
+			return nil
+		}
+		c, ok := compare(v, s.value)
+		if !ok {
+			return newError("cannot compare %v with %v", v, s.value)
+		}
+		if (a.fn == aggMin && c < 0) || (a.fn == aggMax && c > 0) {
+			s.value = v
+		}
+	}
+	return nil
+}
+
+func (s *aggState) result(fn aggFunc) interface{} {
+	switch fn {
+	case aggCount:
+		return s.count
+	case aggSum:
+		return s.sum
+	case aggAvg:
+		if s.count == 0 {
+			return nil
+		}
+		sum, _ := toFloat(s.sum)
+		return sum / float64(s.count)
+	default:
+		return s.value
+	}
+}
+
+// subexprs returns the expressions x is made of.
+func subexprs(x expr) []expr {
+	switch x := x.(type) {
+	case *notExpr:
+		return []expr{x.x}
+	case *negExpr:
+		return []expr{x.x}
+	case *binaryExpr:
+		return []expr{x.x, x.y}
+	case *isNullExpr:
+		return []expr{x.x}
+	case *likeExpr:
+		return []expr{x.x, x.pattern}
+	case *betweenExpr:
+		return []expr{x.x, x.lo, x.hi}
+	case *inExpr:
+		return append([]expr{x.x}, x.list...)
+	case *castExpr:
+		return []expr{x.x}
+	case *callExpr:
+		return x.args
+	case *aggregate:
+		if x.x != nil {
+			return []expr{x.x}
+		}
+	}
+	return nil
+}
+
+func containsAggregate(x expr) bool {
+	if _, ok := x.(*aggregate); ok {
+		return true
+	}
+	for _, y := range subexprs(x) {
+		if containsAggregate(y) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsColumn returns whether x refers to a column outside of an
+// aggregate.
+func containsColumn(x expr) bool {
+	switch x.(type) {
+	case *column:
+		return true
+	case *aggregate:
+		return false
+	}
+	for _, y := range subexprs(x) {
+		if containsColumn(y) {
+			return true
+		}
+	}
+	return false
+}
+
+// toNumber converts a value to an int64 or float64, parsing strings.
+func toNumber(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case int64, float64:
+		return v, true
+	case json.Number:
+		return toNumber(string(v))
+	case string:
+		s := strings.TrimSpace(v)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, true
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	n, ok := toNumber(v)
+	if !ok {
+		return 0, false
+	}
+	if i, ok := n.(int64); ok {
+		return float64(i), true
+	}
+	return n.(float64), true
+}
+
+func toString(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case json.Number:
+		return string(v), true
+	case nil:
+		return "", false
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// compare compares two values, returning whether they're comparable. Numbers
+// are compared with strings holding numbers, such as the fields of CSV
+// records, as numbers.
+func compare(x, y interface{}) (int, bool) {
+	_, xs := x.(string)
+	_, ys := y.(string)
+	if xs && ys {
+		return strings.Compare(x.(string), y.(string)), true
+	}
+	if xb, ok := x.(bool); ok {
+		yb, ok := y.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case xb == yb:
+			return 0, true
+		case yb:
+			return -1, true
+		default:
+			return 1, true
+		}
+	}
+	xn, ok := toNumber(x)
+	if !ok {
+		return 0, false
+	}
+	yn, ok := toNumber(y)
+	if !ok {
+		return 0, false
+	}
+	xi, xInt := xn.(int64)
+	yi, yInt := yn.(int64)
+	if xInt && yInt {
+		switch {
+		case xi < yi:
+			return -1, true
+		case xi > yi:
+			return 1, true
+		}
+		return 0, true
+	}
+	xf, _ := toFloat(xn)
+	yf, _ := toFloat(yn)
+	switch {
+	case xf < yf:
+		return -1, true
+	case xf > yf:
+		return 1, true
+	}
+	return 0, true
+}
+
+func arithmetic(op string, x, y interface{}) (interface{}, error) {
+	xn, ok := toNumber(x)
+	if !ok {
+		return nil, newError("cannot apply %s to %v, it is not a number", op, x)
+	}
+	yn, ok := toNumber(y)
+	if !ok {
+		return nil, newError("cannot apply %s to %v, it is not a number", op, y)
+	}
+	xi, xInt := xn.(int64)
+	yi, yInt := yn.(int64)
+	if xInt && yInt {
+		switch op {
+		case "+":
+			return xi + yi, nil
+		case "-":
+			return xi - yi, nil
+		case "*":
+			return xi * yi, nil
+		case "/", "%":
+			if yi == 0 {
+				return nil, newError("division by zero")
+			}
+			if op == "/" {
+				return xi / yi, nil
+			}
+			return xi % yi, nil
+		}
+	}
+	xf, _ := toFloat(xn)
+	yf, _ := toFloat(yn)
+	switch op {
+	case "+":
+		return xf + yf, nil
+	case "-":
+		return xf - yf, nil
+	case "*":
+		return xf * yf, nil
+	case "/", "%":
+		if yf == 0 {
+			return nil, newError("division by zero")
+		}
+		if op == "/" {
+			return xf / yf, nil
+		}
+		return math.Mod(xf, yf), nil
+	}
+	return nil, newError("unsupported operator %s", op)
+}
+
+// record is a record of the object a query is run over.
+type record interface {
+	// get returns the field at path, or nil if there is none.
+	get(path []pathElem) interface{}
+	// fields returns the names and values of all of the record's fields.
+	fields() ([]string, []interface{})
+}
+
+type csvHeader struct {
+	names []string
+}
+
+func newCSVHeader(names []string) *csvHeader {
+	return &csvHeader{names: names}
+}
+
+func (h *csvHeader) index(p pathElem) (int, bool) {
+	for i, name := range h.names {
+		if name == p.name {
+			return i, true
+		}
+	}
+	if !p.quoted {
+		for i, name := range h.names {
+			if strings.EqualFold(name, p.name) {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+type csvRecord struct {
+	values []string
+	header *csvHeader
+}
+
+func (r *csvRecord) get(path []pathElem) interface{} {
+	if len(path) != 1 {
+		return nil
+	}
+	if r.header != nil {
+		if i, ok := r.header.index(path[0]); ok {
+			if i < len(r.values) {
+				return r.values[i]
+			}
+			return nil
+		}
+	}
+	// Columns can also be referred to by position, as _1, _2, etc.
+	if strings.HasPrefix(path[0].name, "_") {
+		if i, err := strconv.Atoi(path[0].name[1:]); err == nil && i >= 1 && i <= len(r.values) {
+			return r.values[i-1]
+		}
+	}
+	return nil
+}
+
+func (r *csvRecord) fields() ([]string, []interface{}) {
+	names := make([]string, len(r.values))
+	values := make([]interface{}, len(r.values))
+	for i, v := range r.values {
+		if r.header != nil && i < len(r.header.names) {
+			names[i] = r.header.names[i]
+		} else {
+			names[i] = "_" + strconv.Itoa(i+1)
+		}
+		values[i] = v
+	}
+	return names, values
+}
+
+type jsonRecord map[string]interface{}
+
+func (r jsonRecord) get(path []pathElem) interface{} {
+	var v interface{} = map[string]interface{}(r)
+	for _, p := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v, ok = m[p.name]
+		if !ok && !p.quoted {
+			for name, field := range m {
+				if strings.EqualFold(name, p.name) {
+					v, ok = field, true
+					break
+				}
+			}
+		}
+		if !ok {
+			return nil
+		}
+	}
+	if n, ok := v.(json.Number); ok {
+		v, _ = toNumber(string(n))
+	}
+	return v
+}
+
+func (r jsonRecord) fields() ([]string, []interface{}) {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]interface{}, len(names))
+	for i, name := range names {
+		values[i] = r[name]
+	}
+	return names, values
+}
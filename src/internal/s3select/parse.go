@@ -0,0 +1,592 @@
+package s3select
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q at position %d", t.text, t.pos)
+}
+
+// lex splits a query into tokens.
+func lex(sql string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i + 1
+			for j < len(sql) && (sql[j] == '_' || unicode.IsLetter(rune(sql[j])) || unicode.IsDigit(rune(sql[j]))) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: sql[i:j], pos: i})
+			i = j
+		case unicode.IsDigit(rune(c)):
+			j := i + 1
+			for j < len(sql) && (unicode.IsDigit(rune(sql[j])) || sql[j] == '.' || sql[j] == 'e' || sql[j] == 'E' ||
+				((sql[j] == '-' || sql[j] == '+') && (sql[j-1] == 'e' || sql[j-1] == 'E'))) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: sql[i:j], pos: i})
+			i = j
+		case c == '\'' || c == '"':
+			// Quotes are escaped by doubling them.
+			var b strings.Builder
+			j := i + 1
+			for {
+				if j >= len(sql) {
+					return nil, newError("unterminated quote at position %d", i)
+				}
+				if sql[j] == c {
+					if j+1 < len(sql) && sql[j+1] == c {
+						b.WriteByte(c)
+						j += 2
+						continue
+					}
+					break
+				}
+				b.WriteByte(sql[j])
+				j++
+			}
+			kind := tokenString
+			if c == '"' {
+				kind = tokenQuotedIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: b.String(), pos: i})
+			i = j + 1
+		default:
+			symbol := string(c)
+			if i+1 < len(sql) {
+				switch sql[i : i+2] {
+				case "!=", "<>", "<=", ">=", "||":
+					symbol = sql[i : i+2]
+				}
+			}
+			if !strings.Contains("*,().=!<>+-/%|", symbol[:1]) || symbol == "!" || symbol == "|" {
+				return nil, newError("unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: symbol, pos: i})
+			i += len(symbol)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(sql)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	query  *Query
+}
+
+// Parse parses an S3 Select SQL query, which has the form
+//
+//	SELECT <projection> FROM S3Object [[AS] alias] [WHERE <condition>] [LIMIT <n>]
+//
+// The projection is either * or a list of expressions, optionally named with
+// AS. A projection of aggregates (COUNT, SUM, AVG, MIN and MAX) produces a
+// single record.
+func Parse(sql string) (*Query, error) {
+	tokens, err := lex(sql)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, query: &Query{limit: -1}}
+	if err := p.parseQuery(); err != nil {
+		return nil, err
+	}
+	return p.query, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword returns whether t is the (case insensitive) keyword kw.
+func isKeyword(t token, kw string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, kw)
+}
+
+func (p *parser) acceptKeyword(kw string) bool {
+	if isKeyword(p.peek(), kw) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if !p.acceptKeyword(kw) {
+		return newError("expected %s, found %v", kw, p.peek())
+	}
+	return nil
+}
+
+func (p *parser) acceptSymbol(symbol string) bool {
+	if t := p.peek(); t.kind == tokenSymbol && t.text == symbol {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectSymbol(symbol string) error {
+	if !p.acceptSymbol(symbol) {
+		return newError("expected %q, found %v", symbol, p.peek())
+	}
+	return nil
+}
+
+var reservedWords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "LIMIT": true, "AS": true,
+	"AND": true, "OR": true, "NOT": true, "IS": true, "NULL": true, "MISSING": true,
+	"TRUE": true, "FALSE": true, "LIKE": true, "BETWEEN": true, "IN": true,
+	"CAST": true, "ESCAPE": true,
+}
+
+func (p *parser) parseQuery() error {
+	if err := p.expectKeyword("SELECT"); err != nil {
+		return err
+	}
+	if p.acceptSymbol("*") {
+		p.query.star = true
+	} else {
+		for {
+			x, err := p.parseExpr()
+			if err != nil {
+				return err
+			}
+			item := selectItem{expr: x}
+			if p.acceptKeyword("AS") {
+				t := p.next()
+				if t.kind != tokenIdent && t.kind != tokenQuotedIdent {
+					return newError("expected a name, found %v", t)
+				}
+				item.name = t.text
+			} else if t := p.peek(); (t.kind == tokenIdent && !reservedWords[strings.ToUpper(t.text)]) || t.kind == tokenQuotedIdent {
+				item.name = p.next().text
+			}
+			p.query.items = append(p.query.items, item)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+	}
+	if err := p.expectKeyword("FROM"); err != nil {
+		return err
+	}
+	if t := p.next(); !isKeyword(t, "S3Object") {
+		return newError("expected S3Object, found %v", t)
+	}
+	if p.acceptKeyword("AS") {
+		t := p.next()
+		if t.kind != tokenIdent && t.kind != tokenQuotedIdent {
+			return newError("expected an alias, found %v", t)
+		}
+		p.query.alias = t.text
+	} else if t := p.peek(); (t.kind == tokenIdent && !reservedWords[strings.ToUpper(t.text)]) || t.kind == tokenQuotedIdent {
+		p.query.alias = p.next().text
+	}
+	if p.acceptKeyword("WHERE") {
+		x, err := p.parseExpr()
+		if err != nil {
+			return err
+		}
+		if containsAggregate(x) {
+			return newError("aggregates are not allowed in WHERE")
+		}
+		p.query.where = x
+	}
+	if p.acceptKeyword("LIMIT") {
+		t := p.next()
+		limit, err := strconv.Atoi(t.text)
+		if t.kind != tokenNumber || err != nil || limit < 0 {
+			return newError("expected a non-negative integer limit, found %v", t)
+		}
+		p.query.limit = limit
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return newError("unexpected %v", t)
+	}
+	return p.query.check()
+}
+
+func (p *parser) parseExpr() (expr, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (expr, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") {
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryExpr{op: "OR", x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") {
+		y, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryExpr{op: "AND", x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.acceptKeyword("NOT") {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	x, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind == tokenSymbol {
+		switch t.text {
+		case "=", "!=", "<>", "<", "<=", ">", ">=":
+			p.next()
+			y, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			op := t.text
+			if op == "<>" {
+				op = "!="
+			}
+			return &binaryExpr{op: op, x: x, y: y}, nil
+		}
+	}
+	if p.acceptKeyword("IS") {
+		not := p.acceptKeyword("NOT")
+		if !p.acceptKeyword("NULL") && !p.acceptKeyword("MISSING") {
+			return nil, newError("expected NULL or MISSING, found %v", p.peek())
+		}
+		return &isNullExpr{x: x, not: not}, nil
+	}
+	not := p.acceptKeyword("NOT")
+	switch {
+	case p.acceptKeyword("LIKE"):
+		pattern, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		like := &likeExpr{x: x, pattern: pattern, not: not}
+		if p.acceptKeyword("ESCAPE") {
+			t := p.next()
+			if t.kind != tokenString || len(t.text) != 1 {
+				return nil, newError("expected a single character escape, found %v", t)
+			}
+			like.escape = t.text
+		}
+		if lit, ok := pattern.(*literal); ok {
+			s, ok := lit.v.(string)
+			if !ok {
+				return nil, newError("LIKE pattern must be a string")
+			}
+			like.re = likeRegexp(s, like.escape)
+		}
+		return like, nil
+	case p.acceptKeyword("BETWEEN"):
+		lo, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		hi, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &betweenExpr{x: x, lo: lo, hi: hi, not: not}, nil
+	case p.acceptKeyword("IN"):
+		if err := p.expectSymbol("("); err != nil {
+			return nil, err
+		}
+		in := &inExpr{x: x, not: not}
+		for {
+			y, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			in.list = append(in.list, y)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		return in, nil
+	}
+	if not {
+		return nil, newError("expected LIKE, BETWEEN or IN after NOT, found %v", p.peek())
+	}
+	return x, nil
+}
+
+func (p *parser) parseAdditive() (expr, error) {
+	x, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenSymbol || (t.text != "+" && t.text != "-" && t.text != "||") {
+			return x, nil
+		}
+		p.next()
+		y, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryExpr{op: t.text, x: x, y: y}
+	}
+}
+
+func (p *parser) parseMultiplicative() (expr, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenSymbol || (t.text != "*" && t.text != "/" && t.text != "%") {
+			return x, nil
+		}
+		p.next()
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryExpr{op: t.text, x: x, y: y}
+	}
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.acceptSymbol("-") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &negExpr{x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return &literal{v: i}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, newError("malformed number %v", t)
+		}
+		return &literal{v: f}, nil
+	case tokenString:
+		return &literal{v: t.text}, nil
+	case tokenQuotedIdent:
+		return p.parsePath(pathElem{name: t.text, quoted: true})
+	case tokenSymbol:
+		if t.text == "(" {
+			x, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectSymbol(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		}
+	case tokenIdent:
+		switch strings.ToUpper(t.text) {
+		case "NULL", "MISSING":
+			return &literal{}, nil
+		case "TRUE":
+			return &literal{v: true}, nil
+		case "FALSE":
+			return &literal{v: false}, nil
+		case "CAST":
+			return p.parseCast()
+		}
+		if reservedWords[strings.ToUpper(t.text)] {
+			break
+		}
+		if p.peek().kind == tokenSymbol && p.peek().text == "(" {
+			p.next()
+			return p.parseCall(strings.ToUpper(t.text))
+		}
+		return p.parsePath(pathElem{name: t.text})
+	}
+	return nil, newError("unexpected %v", t)
+}
+
+func (p *parser) parsePath(first pathElem) (expr, error) {
+	path := []pathElem{first}
+	for p.acceptSymbol(".") {
+		t := p.next()
+		if t.kind != tokenIdent && t.kind != tokenQuotedIdent {
+			return nil, newError("expected a field name, found %v", t)
+		}
+		path = append(path, pathElem{name: t.text, quoted: t.kind == tokenQuotedIdent})
+	}
+	return &column{path: path}, nil
+}
+
+func (p *parser) parseCast() (expr, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	x, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
+	t := p.next()
+	typ := strings.ToUpper(t.text)
+	switch typ {
+	case "INT", "INTEGER", "BIGINT", "SMALLINT":
+		typ = "INT"
+	case "FLOAT", "DOUBLE", "REAL", "DECIMAL", "NUMERIC":
+		typ = "FLOAT"
+	case "STRING", "VARCHAR", "CHAR", "TEXT":
+		typ = "STRING"
+	case "BOOL", "BOOLEAN":
+		typ = "BOOL"
+	default:
+		return nil, newError("unsupported type %v", t)
+	}
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return &castExpr{x: x, typ: typ}, nil
+}
+
+func (p *parser) parseCall(name string) (expr, error) {
+	if fn, ok := aggregateFuncs[name]; ok {
+		agg := &aggregate{fn: fn, index: len(p.query.aggs)}
+		if fn == aggCount && p.acceptSymbol("*") {
+			agg.star = true
+		} else {
+			x, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if containsAggregate(x) {
+				return nil, newError("aggregates cannot be nested")
+			}
+			agg.x = x
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		p.query.aggs = append(p.query.aggs, agg)
+		return agg, nil
+	}
+	if _, ok := scalarFuncs[name]; !ok {
+		return nil, newError("unsupported function %s", name)
+	}
+	call := &callExpr{name: name}
+	if !p.acceptSymbol(")") {
+		for {
+			x, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, x)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+	}
+	if arity := scalarFuncs[name]; arity >= 0 && len(call.args) != arity {
+		return nil, newError("%s takes %d argument(s), not %d", name, arity, len(call.args))
+	}
+	return call, nil
+}
+
+// likeRegexp translates a LIKE pattern, in which % matches any string and _
+// matches any character, to a regular expression.
+func likeRegexp(pattern, escape string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?s)^")
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+			escaped = false
+		case escape != "" && string(c) == escape:
+			escaped = true
+		case c == '%':
+			b.WriteString(".*")
+		case c == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,450 @@
+// Package s3select implements the SQL dialect of S3 Select, which queries the
+// records of a single CSV or JSON object. It supports projection, filtering,
+// the COUNT, SUM, AVG, MIN and MAX aggregates and LIMIT.
+//
+// See https://docs.aws.amazon.com/AmazonS3/latest/userguide/s3-glacier-select-sql-reference-select.html
+package s3select
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/sdata"
+)
+
+// Error is returned for queries which are malformed, or which can't be
+// evaluated over the records they're run on.
+type Error struct {
+	msg string
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+func newError(format string, args ...interface{}) error {
+	return &Error{msg: fmt.Sprintf(format, args...)}
+}
+
+// Query is a parsed S3 Select query.
+type Query struct {
+	star  bool
+	items []selectItem
+	alias string
+	where expr
+	// limit is the maximum number of rows returned, or -1 for no limit. With
+	// aggregates there is a single row, which is computed from every record.
+	limit int
+	aggs  []*aggregate
+}
+
+type selectItem struct {
+	expr expr
+	name string
+}
+
+// check validates the projection of a query.
+func (q *Query) check() error {
+	if len(q.aggs) == 0 {
+		return nil
+	}
+	if q.star {
+		return newError("cannot select * with aggregates")
+	}
+	for _, item := range q.items {
+		if !containsAggregate(item.expr) && containsColumn(item.expr) {
+			return newError("cannot select columns outside of aggregates along with aggregates")
+		}
+	}
+	return nil
+}
+
+// Input describes the serialization of the object a query is run over.
+type Input struct {
+	// CompressionType is NONE (the default) or GZIP.
+	CompressionType string
+	CSV             *CSVInput
+	JSON            *JSONInput
+}
+
+// CSVInput describes a CSV object.
+type CSVInput struct {
+	// FileHeaderInfo is NONE (the default) if the object has no header, USE
+	// if its first line names its columns or IGNORE if its first line should
+	// be skipped. Columns can always be referred to by position, as _1, _2,
+	// etc.
+	FileHeaderInfo string
+	// FieldDelimiter defaults to ",".
+	FieldDelimiter string
+	// Comments is the character which starts comment lines, if any.
+	Comments string
+	// QuoteCharacter can only be `"`.
+	QuoteCharacter string
+	// RecordDelimiter can only be "\n", which also accepts "\r\n".
+	RecordDelimiter string
+}
+
+// JSONInput describes a JSON object. Whether its Type is DOCUMENT or LINES,
+// it is read as a sequence of JSON objects.
+type JSONInput struct {
+	Type string
+}
+
+// Output describes the serialization of the records a query returns.
+type Output struct {
+	CSV  *CSVOutput
+	JSON *JSONOutput
+}
+
+// CSVOutput describes CSV records, which have no header.
+type CSVOutput struct {
+	// FieldDelimiter can only be ",".
+	FieldDelimiter string
+	// RecordDelimiter can only be "\n".
+	RecordDelimiter string
+}
+
+// JSONOutput describes JSON records, written as one object per line.
+type JSONOutput struct {
+	// RecordDelimiter can only be "\n".
+	RecordDelimiter string
+}
+
+// Stats counts the bytes of the object a query was run over, and of the
+// records it returned.
+type Stats struct {
+	// BytesScanned counts the bytes read from the object.
+	BytesScanned int64
+	// BytesProcessed counts the bytes of the object once decompressed.
+	BytesProcessed int64
+	// BytesReturned counts the bytes of the records written.
+	BytesReturned int64
+}
+
+// Run runs q over the object read from r, and writes the records it selects
+// to w.
+func (q *Query) Run(r io.Reader, in Input, out Output, w io.Writer) (*Stats, error) {
+	stats := &Stats{}
+	var src io.Reader = &countingReader{r: r, n: &stats.BytesScanned}
+	switch strings.ToUpper(in.CompressionType) {
+	case "", "NONE":
+	case "GZIP":
+		gr, err := gzip.NewReader(src)
+		if err != nil {
+			return nil, newError("could not read gzip compressed object: %v", err)
+		}
+		defer gr.Close()
+		src = gr
+	default:
+		return nil, newError("unsupported compression type %q", in.CompressionType)
+	}
+	rr, err := newRecordReader(&countingReader{r: src, n: &stats.BytesProcessed}, in)
+	if err != nil {
+		return nil, err
+	}
+	rw, err := newRecordWriter(&countingWriter{w: w, n: &stats.BytesReturned}, out)
+	if err != nil {
+		return nil, err
+	}
+	states := make([]aggState, len(q.aggs))
+	for returned := 0; q.limit < 0 || returned < q.limit; {
+		rec, err := rr.next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		e := &env{rec: rec, alias: q.alias}
+		if q.where != nil {
+			v, err := q.where.eval(e)
+			if err != nil {
+				return nil, err
+			}
+			if v != true {
+				continue
+			}
+		}
+		if len(q.aggs) > 0 {
+			for i, agg := range q.aggs {
+				if err := states[i].add(agg, e); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		returned++
+		names, values, err := q.project(e)
+		if err != nil {
+			return nil, err
+		}
+		if err := rw.write(names, values); err != nil {
+			return nil, err
+		}
+	}
+	if len(q.aggs) > 0 && q.limit != 0 {
+		e := &env{alias: q.alias}
+		for i, agg := range q.aggs {
+			e.aggs = append(e.aggs, states[i].result(agg.fn))
+		}
+		names, values, err := q.project(e)
+		if err != nil {
+			return nil, err
+		}
+		if err := rw.write(names, values); err != nil {
+			return nil, err
+		}
+	}
+	if err := rw.flush(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// project returns the names and values of the fields q selects from a
+// record.
+func (q *Query) project(e *env) ([]string, []interface{}, error) {
+	if q.star {
+		names, values := e.rec.fields()
+		return names, values, nil
+	}
+	names := make([]string, len(q.items))
+	values := make([]interface{}, len(q.items))
+	for i, item := range q.items {
+		v, err := item.expr.eval(e)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i] = v
+		names[i] = item.name
+		if names[i] == "" {
+			if c, ok := item.expr.(*column); ok {
+				names[i] = c.path[len(c.path)-1].name
+			} else {
+				names[i] = "_" + strconv.Itoa(i+1)
+			}
+		}
+	}
+	return names, values, nil
+}
+
+type recordReader interface {
+	// next returns the next record, or io.EOF once there are none left.
+	next() (record, error)
+}
+
+func newRecordReader(r io.Reader, in Input) (recordReader, error) {
+	switch {
+	case in.CSV != nil && in.JSON == nil:
+		return newCSVReader(r, in.CSV)
+	case in.JSON != nil && in.CSV == nil:
+		switch strings.ToUpper(in.JSON.Type) {
+		case "", "DOCUMENT", "LINES":
+		default:
+			return nil, newError("unsupported JSON type %q", in.JSON.Type)
+		}
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+		return &jsonReader{dec: dec}, nil
+	}
+	return nil, newError("the input must be either CSV or JSON")
+}
+
+type csvReader struct {
+	cr     *csv.Reader
+	header *csvHeader
+}
+
+func newCSVReader(r io.Reader, in *CSVInput) (*csvReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	if in.FieldDelimiter != "" {
+		c, err := singleRune("field delimiter", in.FieldDelimiter)
+		if err != nil {
+			return nil, err
+		}
+		cr.Comma = c
+	}
+	if in.Comments != "" {
+		c, err := singleRune("comment character", in.Comments)
+		if err != nil {
+			return nil, err
+		}
+		cr.Comment = c
+	}
+	if in.QuoteCharacter != "" && in.QuoteCharacter != `"` {
+		return nil, newError("unsupported quote character %q", in.QuoteCharacter)
+	}
+	if in.RecordDelimiter != "" && in.RecordDelimiter != "\n" && in.RecordDelimiter != "\r\n" {
+		return nil, newError("unsupported record delimiter %q", in.RecordDelimiter)
+	}
+	reader := &csvReader{cr: cr}
+	switch strings.ToUpper(in.FileHeaderInfo) {
+	case "", "NONE":
+	case "USE", "IGNORE":
+		names, err := cr.Read()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, newError("could not read CSV header: %v", err)
+		}
+		if strings.EqualFold(in.FileHeaderInfo, "USE") {
+			reader.header = newCSVHeader(names)
+		}
+	default:
+		return nil, newError("unsupported file header info %q", in.FileHeaderInfo)
+	}
+	return reader, nil
+}
+
+func singleRune(what, s string) (rune, error) {
+	c, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || c == utf8.RuneError {
+		return 0, newError("%s must be a single character, not %q", what, s)
+	}
+	return c, nil
+}
+
+func (r *csvReader) next() (record, error) {
+	values, err := r.cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, newError("could not read CSV record: %v", err)
+	}
+	return &csvRecord{values: values, header: r.header}, nil
+}
+
+type jsonReader struct {
+	dec *json.Decoder
+}
+
+func (r *jsonReader) next() (record, error) {
+	var v interface{}
+	if err := r.dec.Decode(&v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, newError("could not read JSON record: %v", err)
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, newError("JSON records must be objects, not %T", v)
+	}
+	return jsonRecord(m), nil
+}
+
+type recordWriter interface {
+	write(names []string, values []interface{}) error
+	flush() error
+}
+
+func newRecordWriter(w io.Writer, out Output) (recordWriter, error) {
+	switch {
+	case out.CSV != nil && out.JSON == nil:
+		if out.CSV.FieldDelimiter != "" && out.CSV.FieldDelimiter != "," {
+			return nil, newError("unsupported output field delimiter %q", out.CSV.FieldDelimiter)
+		}
+		if out.CSV.RecordDelimiter != "" && out.CSV.RecordDelimiter != "\n" {
+			return nil, newError("unsupported output record delimiter %q", out.CSV.RecordDelimiter)
+		}
+		return &tupleWriter{w: w, csv: true}, nil
+	case out.JSON != nil && out.CSV == nil:
+		if out.JSON.RecordDelimiter != "" && out.JSON.RecordDelimiter != "\n" {
+			return nil, newError("unsupported output record delimiter %q", out.JSON.RecordDelimiter)
+		}
+		return &tupleWriter{w: w}, nil
+	}
+	return nil, newError("the output must be either CSV or JSON")
+}
+
+// tupleWriter writes records with an sdata.TupleWriter, which is replaced
+// whenever the fields of the records change, as they can between the records
+// of a JSON object or of a CSV object without a header.
+type tupleWriter struct {
+	w     io.Writer
+	csv   bool
+	tw    sdata.TupleWriter
+	names []string
+}
+
+func (w *tupleWriter) write(names []string, values []interface{}) error {
+	if w.tw == nil || !equalNames(w.names, names) {
+		if err := w.flush(); err != nil {
+			return err
+		}
+		w.names = names
+		if w.csv {
+			w.tw = sdata.NewCSVWriter(w.w, nil)
+		} else {
+			w.tw = sdata.NewJSONWriter(w.w, names)
+		}
+	}
+	row := make(sdata.Tuple, len(values))
+	for i, v := range values {
+		if w.csv {
+			s := formatCSV(v)
+			row[i] = &s
+		} else {
+			row[i] = v
+		}
+	}
+	return errors.EnsureStack(w.tw.WriteTuple(row))
+}
+
+func (w *tupleWriter) flush() error {
+	if w.tw == nil {
+		return nil
+	}
+	return errors.EnsureStack(w.tw.Flush())
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatCSV formats a value as a CSV field, nulls are written as empty
+// fields.
+func formatCSV(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	s, _ := toString(v)
+	return s
+}
+
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	*r.n += int64(n)
+	return n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	*w.n += int64(n)
+	return n, errors.EnsureStack(err)
+}
@@ -0,0 +1,121 @@
+package s3select
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+)
+
+const people = `name,age,city
+alice,34,Boston
+bob,27,Denver
+carol,45,Boston
+dave,,Austin
+`
+
+const peopleJSON = `{"name": "alice", "age": 34, "address": {"city": "Boston"}}
+{"name": "bob", "age": 27, "address": {"city": "Denver"}}
+{"name": "carol", "age": 45, "address": {"city": "Boston"}}
+{"name": "dave", "address": {"city": "Austin"}}
+`
+
+func run(t *testing.T, sql, data string, in Input, out Output) string {
+	q, err := Parse(sql)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	stats, err := q.Run(strings.NewReader(data), in, out, &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), stats.BytesReturned)
+	return buf.String()
+}
+
+func TestCSV(t *testing.T) {
+	in := Input{CSV: &CSVInput{FileHeaderInfo: "USE"}}
+	out := Output{CSV: &CSVOutput{}}
+	for _, test := range []struct {
+		sql, expected string
+	}{
+		{"SELECT * FROM S3Object", "alice,34,Boston\nbob,27,Denver\ncarol,45,Boston\ndave,,Austin\n"},
+		{"SELECT s.name FROM S3Object s WHERE s.city = 'Boston'", "alice\ncarol\n"},
+		{"SELECT name, _2 FROM S3Object WHERE CAST(age AS INT) > 30", "alice,34\ncarol,45\n"},
+		{"SELECT name FROM S3Object WHERE age < 30 OR name LIKE 'd%'", "bob\ndave\n"},
+		{"SELECT name FROM S3Object WHERE age IS NULL OR age = ''", "dave\n"},
+		{"SELECT name FROM S3Object WHERE city IN ('Denver', 'Austin') AND NOT name = 'dave'", "bob\n"},
+		{"SELECT name FROM S3Object WHERE age BETWEEN 30 AND 40", "alice\n"},
+		{"SELECT UPPER(name) || '!' FROM S3Object LIMIT 2", "ALICE!\nBOB!\n"},
+		{"SELECT COUNT(*), SUM(CAST(age AS INT)), MIN(age), MAX(city) FROM S3Object", "4,106,27,Denver\n"},
+		{"SELECT COUNT(*) FROM S3Object s WHERE s.city = 'Boston'", "2\n"},
+		{"SELECT AVG(age) FROM S3Object WHERE age != ''", "35.333333333333336\n"},
+		{"SELECT COUNT(*) FROM S3Object LIMIT 1", "4\n"},
+		{"SELECT COUNT(*) FROM S3Object LIMIT 0", ""},
+		{"SELECT name FROM S3Object WHERE city = 'Boston' LIMIT 1", "alice\n"},
+	} {
+		t.Run(test.sql, func(t *testing.T) {
+			require.Equal(t, test.expected, run(t, test.sql, people, in, out))
+		})
+	}
+}
+
+func TestCSVNoHeader(t *testing.T) {
+	in := Input{CSV: &CSVInput{FieldDelimiter: "|"}}
+	out := Output{JSON: &JSONOutput{}}
+	data := "a|1\nb|2|x\n"
+	require.Equal(t, "{\"_1\":\"a\",\"_2\":\"1\"}\n{\"_1\":\"b\",\"_2\":\"2\",\"_3\":\"x\"}\n", run(t, "SELECT * FROM S3Object", data, in, out))
+	require.Equal(t, "{\"first\":\"b\"}\n", run(t, "SELECT _1 AS first FROM S3Object WHERE _2 > 1", data, in, out))
+}
+
+func TestJSON(t *testing.T) {
+	in := Input{JSON: &JSONInput{Type: "LINES"}}
+	out := Output{JSON: &JSONOutput{}}
+	for _, test := range []struct {
+		sql, expected string
+	}{
+		{"SELECT s.name, s.address.city FROM S3Object s WHERE s.age >= 34", "{\"city\":\"Boston\",\"name\":\"alice\"}\n{\"city\":\"Boston\",\"name\":\"carol\"}\n"},
+		{"SELECT * FROM S3Object WHERE age IS MISSING", "{\"address\":{\"city\":\"Austin\"},\"name\":\"dave\"}\n"},
+		{"SELECT COUNT(age) AS n, SUM(age) / COUNT(age) AS mean FROM S3Object", "{\"mean\":35,\"n\":3}\n"},
+		{"SELECT name FROM S3Object WHERE age * 2 > 60 LIMIT 1", "{\"name\":\"alice\"}\n"},
+	} {
+		t.Run(test.sql, func(t *testing.T) {
+			require.Equal(t, test.expected, run(t, test.sql, peopleJSON, in, out))
+		})
+	}
+}
+
+func TestGzip(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write([]byte(people))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	in := Input{CompressionType: "GZIP", CSV: &CSVInput{FileHeaderInfo: "IGNORE"}}
+	require.Equal(t, "4\n", run(t, "SELECT COUNT(*) FROM S3Object", buf.String(), in, Output{CSV: &CSVOutput{}}))
+}
+
+func TestErrors(t *testing.T) {
+	for _, sql := range []string{
+		"SELECT",
+		"SELECT * FROM table",
+		"SELECT name, COUNT(*) FROM S3Object",
+		"SELECT * FROM S3Object WHERE COUNT(*) > 1",
+		"SELECT * FROM S3Object LIMIT -1",
+		"SELECT SUM(COUNT(*)) FROM S3Object",
+		"SELECT 'unterminated FROM S3Object",
+		"SELECT FOO(name) FROM S3Object",
+	} {
+		t.Run(sql, func(t *testing.T) {
+			_, err := Parse(sql)
+			require.YesError(t, err)
+			var qe *Error
+			require.True(t, errors.As(err, &qe))
+		})
+	}
+	q, err := Parse("SELECT CAST(name AS INT) FROM S3Object")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = q.Run(strings.NewReader(people), Input{CSV: &CSVInput{FileHeaderInfo: "USE"}}, Output{CSV: &CSVOutput{}}, &buf)
+	require.YesError(t, err)
+}
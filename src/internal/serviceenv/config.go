@@ -98,6 +98,26 @@ type PachdSpecificConfiguration struct {
 	// TODO: Merge this with the worker specific pod name (PPS_POD_NAME) into a global configuration pod name.
 	PachdPodName                 string `env:"PACHD_POD_NAME,required"`
 	EnableWorkerSecurityContexts bool   `env:"ENABLE_WORKER_SECURITY_CONTEXTS,default=true"`
+	// S3GatewayWriteSessionIdleSeconds is how long an S3 gateway write
+	// session can be idle before its commits are finished
+	S3GatewayWriteSessionIdleSeconds int `env:"S3GATEWAY_WRITE_SESSION_IDLE_SECONDS,default=30"`
+	// S3GatewayBatchWindowSeconds, if set, batches the S3 gateway writes of
+	// each client into one commit per branch, which is finished once the
+	// client has made no writes for this long
+	S3GatewayBatchWindowSeconds int `env:"S3GATEWAY_BATCH_WINDOW_SECONDS,default=0"`
+	// WebDAVPort, if set, is the port PFS is served over WebDAV on
+	WebDAVPort uint16 `env:"WEBDAV_PORT,default=0"`
+	// WebDAVBatchWindowSeconds is how long the WebDAV writes of each client
+	// to a branch are batched into one commit after the last of them, zero
+	// makes a commit per write
+	WebDAVBatchWindowSeconds int `env:"WEBDAV_BATCH_WINDOW_SECONDS,default=10"`
+	// PFSAttestationSecret is the secret the key which signs commit
+	// attestations is derived from, commits can't be attested without it
+	PFSAttestationSecret string `env:"PFS_ATTESTATION_SECRET,default="`
+	// AuthS3CredentialSecret is the secret the key which encrypts the secret
+	// access keys of S3 credentials is derived from, S3 credentials can't be
+	// created without it
+	AuthS3CredentialSecret string `env:"AUTH_S3_CREDENTIAL_SECRET,default="`
 }
 
 // StorageConfiguration contains the storage configuration.
@@ -113,6 +133,11 @@ type StorageConfiguration struct {
 	StorageFileSetsMaxOpen         int    `env:"STORAGE_FILESETS_MAX_OPEN,default=50"`
 	StorageDiskCacheSize           int    `env:"STORAGE_DISK_CACHE_SIZE,default=100"`
 	StorageMemoryCacheSize         int    `env:"STORAGE_MEMORY_CACHE_SIZE,default=100"`
+	StorageSkipChunkVerification   bool   `env:"STORAGE_SKIP_CHUNK_VERIFICATION,default=false"`
+	StorageUploadBytesPerSecond    int64  `env:"STORAGE_UPLOAD_BYTES_PER_SECOND,default=0"`
+	StorageDownloadBytesPerSecond  int64  `env:"STORAGE_DOWNLOAD_BYTES_PER_SECOND,default=0"`
+	StorageBandwidthBurstBytes     int64  `env:"STORAGE_BANDWIDTH_BURST_BYTES,default=0"`
+	StorageOrphanReclaimAge        string `env:"STORAGE_ORPHAN_RECLAIM_AGE"`
 }
 
 // WorkerFullConfiguration contains the full worker configuration.
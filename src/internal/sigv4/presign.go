@@ -0,0 +1,199 @@
+// Package sigv4 implements presigned URLs for the S3 gateway, which are
+// authenticated with an AWS signature version 4 in the query string.
+//
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
+package sigv4
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+)
+
+const (
+	algorithm     = "AWS4-HMAC-SHA256"
+	timeFormat    = "20060102T150405Z"
+	dateFormat    = "20060102"
+	signedHeaders = "host"
+	// unsignedPayload is signed in place of the hash of the body, which isn't
+	// known when a URL is presigned.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// DefaultRegion is the region presigned URLs are signed for. The S3
+	// gateway accepts any region.
+	DefaultRegion = "us-east-1"
+	// MaxExpiry is the longest time a presigned URL can be valid for.
+	MaxExpiry = 7 * 24 * time.Hour
+)
+
+// IsPresigned returns whether a request is authenticated by a presigned URL.
+func IsPresigned(r *http.Request) bool {
+	return r.URL.Query().Get("X-Amz-Algorithm") != ""
+}
+
+// Presign returns `rawURL` with the query parameters which authenticate a
+// `method` request to it, made within `expiry` of `now`, as the holder of the
+// given credentials.
+func Presign(method, rawURL, accessKeyID, secretAccessKey, region string, now time.Time, expiry time.Duration) (string, error) {
+	if expiry <= 0 || expiry > MaxExpiry {
+		return "", errors.Errorf("expiry must be between 1s and %v", MaxExpiry)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	now = now.UTC()
+	scope := credentialScope(now.Format(dateFormat), region)
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", algorithm)
+	query.Set("X-Amz-Credential", accessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", now.Format(timeFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+	signature := sign(secretAccessKey, now.Format(dateFormat), region,
+		stringToSign(now.Format(timeFormat), scope, canonicalRequest(method, u.Path, query, u.Host)))
+	query.Set("X-Amz-Signature", signature)
+	u.RawQuery = canonicalQuery(query)
+	return u.String(), nil
+}
+
+// Presigned holds the authentication parameters of a presigned request.
+type Presigned struct {
+	AccessKeyID string
+	Region      string
+	Date        time.Time
+	Expires     time.Duration
+
+	date      string
+	signature string
+}
+
+// Parse parses the authentication parameters of a presigned request.
+func Parse(r *http.Request) (*Presigned, error) {
+	query := r.URL.Query()
+	if query.Get("X-Amz-Algorithm") != algorithm {
+		return nil, errors.Errorf("unsupported algorithm %q", query.Get("X-Amz-Algorithm"))
+	}
+	if query.Get("X-Amz-SignedHeaders") != signedHeaders {
+		return nil, errors.Errorf("unsupported signed headers %q", query.Get("X-Amz-SignedHeaders"))
+	}
+	credential := strings.Split(query.Get("X-Amz-Credential"), "/")
+	if len(credential) != 5 || credential[3] != "s3" || credential[4] != "aws4_request" {
+		return nil, errors.Errorf("malformed credential %q", query.Get("X-Amz-Credential"))
+	}
+	date, err := time.Parse(timeFormat, query.Get("X-Amz-Date"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "malformed date %q", query.Get("X-Amz-Date"))
+	}
+	if date.Format(dateFormat) != credential[1] {
+		return nil, errors.Errorf("credential date %q does not match %q", credential[1], query.Get("X-Amz-Date"))
+	}
+	seconds, err := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "malformed expiry %q", query.Get("X-Amz-Expires"))
+	}
+	expires := time.Duration(seconds) * time.Second
+	if expires <= 0 || expires > MaxExpiry {
+		return nil, errors.Errorf("expiry must be between 1s and %v", MaxExpiry)
+	}
+	return &Presigned{
+		AccessKeyID: credential[0],
+		Region:      credential[2],
+		Date:        date,
+		Expires:     expires,
+		date:        credential[1],
+		signature:   query.Get("X-Amz-Signature"),
+	}, nil
+}
+
+// Expired returns whether a presigned request is made after its expiry, or
+// before it was signed, allowing a minute of clock skew.
+func (p *Presigned) Expired(now time.Time) bool {
+	return now.Before(p.Date.Add(-time.Minute)) || now.After(p.Date.Add(p.Expires))
+}
+
+// Verify returns whether a presigned request is signed with
+// `secretAccessKey`.
+func (p *Presigned) Verify(r *http.Request, secretAccessKey string) bool {
+	query := r.URL.Query()
+	query.Del("X-Amz-Signature")
+	expected := sign(secretAccessKey, p.date, p.Region,
+		stringToSign(p.Date.Format(timeFormat), credentialScope(p.date, p.Region),
+			canonicalRequest(r.Method, r.URL.Path, query, r.Host)))
+	return hmac.Equal([]byte(expected), []byte(p.signature))
+}
+
+func credentialScope(date, region string) string {
+	return fmt.Sprintf("%s/%s/s3/aws4_request", date, region)
+}
+
+func canonicalRequest(method, path string, query url.Values, host string) string {
+	return strings.Join([]string{
+		method,
+		canonicalURI(path),
+		canonicalQuery(query),
+		"host:" + host + "\n",
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+}
+
+func stringToSign(timestamp, scope, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	return strings.Join([]string{algorithm, timestamp, scope, hex.EncodeToString(hash[:])}, "\n")
+}
+
+func sign(secretAccessKey, date, region, stringToSign string) string {
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, content string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(content))
+	return mac.Sum(nil)
+}
+
+// canonicalURI encodes each segment of a path, leaving only the characters
+// which are unreserved in URIs unescaped.
+func canonicalURI(path string) string {
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery encodes a query with its keys sorted, using the same
+// escaping as canonicalURI.
+func canonicalQuery(query url.Values) string {
+	return strings.Replace(query.Encode(), "+", "%20", -1)
+}
+
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
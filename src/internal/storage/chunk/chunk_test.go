@@ -16,6 +16,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/randutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/kv"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/track"
 	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
 )
@@ -110,6 +111,35 @@ func TestCheck(t *testing.T) {
 	require.YesError(t, err)
 }
 
+func TestCorruption(t *testing.T) {
+	ctx := context.Background()
+	db := dockertestenv.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	objC, chunks := NewTestStorage(t, db, tr)
+	random := rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+	as := generateAnnotations(random, test{1 * units.KB, 1 * units.MB})
+	writeAnnotations(t, chunks, as, "")
+	// Truncate every object.
+	require.NoError(t, objC.Walk(ctx, "", func(p string) error {
+		buf := &bytes.Buffer{}
+		require.NoError(t, objC.Get(ctx, p, buf))
+		require.NoError(t, objC.Delete(ctx, p))
+		return objC.Put(ctx, p, bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+	}))
+	var dataRefs []*DataRef
+	for _, a := range as {
+		dataRefs = append(dataRefs, a.dataRefs...)
+	}
+	for _, opts := range [][]StorageOption{nil, {WithSkipVerification()}} {
+		// Use a new storage so the reads are not served from the cache.
+		chunks := NewStorage(objC, kv.NewMemCache(10), db, tr, opts...)
+		r := chunks.NewReader(ctx, dataRefs)
+		err := r.Get(&bytes.Buffer{})
+		require.YesError(t, err)
+		require.True(t, IsCorruptChunk(err), "unexpected error: %v", err)
+	}
+}
+
 func deleteOne(t testing.TB, objC obj.Client) {
 	ctx := context.Background()
 	done := false
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	fmt "fmt"
 	"path"
+	"sort"
 	"time"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
@@ -14,6 +15,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/kv"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/track"
+	"github.com/sirupsen/logrus"
 )
 
 // Client mediates access to a content-addressed store
@@ -26,11 +28,12 @@ type Client interface {
 // trackedClient allows manipulation of individual chunks, by maintaining consistency between
 // a tracker and an kv.Store
 type trackedClient struct {
-	store   kv.Store
-	db      *pachsql.DB
-	tracker track.Tracker
-	renewer *Renewer
-	ttl     time.Duration
+	store      kv.Store
+	db         *pachsql.DB
+	tracker    track.Tracker
+	renewer    *Renewer
+	ttl        time.Duration
+	skipVerify bool
 }
 
 // NewClient returns a client which will write to objc, mdstore, and tracker.  Name is used
@@ -133,10 +136,39 @@ func (c *trackedClient) afterUpload(ctx context.Context, chunkID ID, gen uint64)
 	return nil
 }
 
-// Get writes data for a chunk with ID chunkID to w.
+// Get calls cb with the data for a chunk with ID chunkID.
+// The data is verified against chunkID before cb is called, unless
+// verification has been disabled.
 func (c *trackedClient) Get(ctx context.Context, chunkID ID, cb kv.ValueCallback) error {
+	gen, err := c.getGen(ctx, chunkID)
+	if err != nil {
+		return err
+	}
+	key := chunkKey(chunkID, gen)
+	err = c.store.Get(ctx, key, func(data []byte) error {
+		if !c.skipVerify {
+			if err := verifyData(chunkID, data); err != nil {
+				return err
+			}
+		}
+		return cb(data)
+	})
+	corruptErr := &CorruptChunkError{}
+	if errors.As(err, &corruptErr) {
+		corruptErr.Object = string(key)
+		referrers, err := c.referrers(ctx, chunkID)
+		if err != nil {
+			logrus.Warnf("could not determine referrers of corrupt chunk %v: %v", chunkID.HexString(), err)
+		}
+		corruptErr.Referrers = referrers
+	}
+	return err
+}
+
+// getGen returns the generation of the uploaded object for a chunk.
+func (c *trackedClient) getGen(ctx context.Context, chunkID ID) (uint64, error) {
 	var gen uint64
-	err := c.db.Get(&gen, `
+	err := c.db.GetContext(ctx, &gen, `
 	SELECT gen
 	FROM storage.chunk_objects
 	WHERE uploaded = TRUE AND tombstone = FALSE AND chunk_id = $1
@@ -146,10 +178,39 @@ func (c *trackedClient) Get(ctx context.Context, chunkID ID, cb kv.ValueCallback
 		if err == sql.ErrNoRows {
 			err = errors.Errorf("no objects for chunk %v", chunkID)
 		}
-		return err
+		return 0, err
 	}
-	key := chunkKey(chunkID, gen)
-	return c.store.Get(ctx, key, cb)
+	return gen, nil
+}
+
+// maxReferrerLookups bounds the number of tracker objects visited when
+// looking up the referrers of a chunk.
+const maxReferrerLookups = 1000
+
+// referrers returns the top level tracker objects (the ones nothing else
+// points to) which transitively point to the chunk.
+func (c *trackedClient) referrers(ctx context.Context, chunkID ID) ([]string, error) {
+	var roots []string
+	visited := make(map[string]struct{})
+	queue := []string{chunkID.TrackerID()}
+	for len(queue) > 0 && len(visited) < maxReferrerLookups {
+		id := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[id]; ok {
+			continue
+		}
+		visited[id] = struct{}{}
+		ups, err := c.tracker.GetUpstream(ctx, id)
+		if err != nil {
+			return roots, err
+		}
+		if len(ups) == 0 && id != chunkID.TrackerID() {
+			roots = append(roots, id)
+		}
+		queue = append(queue, ups...)
+	}
+	sort.Strings(roots)
+	return roots, nil
 }
 
 // Close closes the client, stopping the background renewal of created objects
@@ -160,14 +221,25 @@ func (c *trackedClient) Close() error {
 	return nil
 }
 
-// Check checks that there are entries for the chunk and they have successfully uploaded objects.
+// Check checks that there is an uploaded object for the chunk, and if
+// readChunk is true, that the object's data matches the chunk's hash.
 func (c *trackedClient) Check(ctx context.Context, id ID, readChunk bool) error {
-	_, last, err := c.CheckEntries(ctx, id, 1, readChunk)
+	gen, err := c.getGen(ctx, id)
+	if err != nil {
+		return err
+	}
+	key := chunkKey(id, gen)
+	if readChunk {
+		return c.store.Get(ctx, key, func(data []byte) error {
+			return verifyData(id, data)
+		})
+	}
+	exists, err := c.store.Exists(ctx, key)
 	if err != nil {
 		return err
 	}
-	if last == nil {
-		return errors.Errorf("no entries for chunk %v", id)
+	if !exists {
+		return errors.Errorf("missing object %s for chunk %v", key, id)
 	}
 	return nil
 }
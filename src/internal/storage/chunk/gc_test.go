@@ -1,6 +1,7 @@
 package chunk
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"math/rand"
@@ -53,6 +54,68 @@ func TestGC(t *testing.T) {
 	require.Equal(t, 0, count)
 }
 
+func TestOrphanDetector(t *testing.T) {
+	ctx := context.Background()
+	db := dockertestenv.NewTestDB(t)
+	tracker := track.NewTestTracker(t, db)
+	oc, s := NewTestStorage(t, db, tracker)
+
+	writeRandom(t, s)
+	count, err := countObjects(ctx, oc)
+	require.NoError(t, err)
+	// simulate a crash between uploading an object and creating its entry
+	data := []byte("orphaned chunk")
+	orphanKey := chunkPath(Hash(data), 1<<40)
+	require.NoError(t, oc.Put(ctx, orphanKey, bytes.NewReader(data)))
+
+	runOnce := func(minAge time.Duration, dryRun bool) []*Orphan {
+		var orphans []*Orphan
+		require.NoError(t, NewOrphanDetector(s, minAge, dryRun).RunOnce(ctx, func(o *Orphan) error {
+			orphans = append(orphans, o)
+			return nil
+		}))
+		return orphans
+	}
+	// the orphan is too young to be reclaimed
+	orphans := runOnce(time.Hour, false)
+	require.Equal(t, 1, len(orphans))
+	require.Equal(t, orphanKey, orphans[0].Key)
+	require.Equal(t, OrphanUntracked, orphans[0].Reason)
+	require.Equal(t, int64(len(data)), orphans[0].SizeBytes)
+	require.False(t, orphans[0].Reclaimable)
+	// a dry run reports it as reclaimable without reclaiming it
+	orphans = runOnce(0, true)
+	require.Equal(t, 1, len(orphans))
+	require.True(t, orphans[0].Reclaimable)
+	require.False(t, orphans[0].Reclaimed)
+	exists, err := oc.Exists(ctx, orphanKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+	// a real run deletes it, and leaves the tracked objects alone
+	orphans = runOnce(0, false)
+	require.Equal(t, 1, len(orphans))
+	require.True(t, orphans[0].Reclaimed)
+	exists, err = oc.Exists(ctx, orphanKey)
+	require.NoError(t, err)
+	require.False(t, exists)
+	newCount, err := countObjects(ctx, oc)
+	require.NoError(t, err)
+	require.Equal(t, count, newCount)
+	require.Equal(t, 0, len(runOnce(0, false)))
+}
+
+func TestParseChunkKey(t *testing.T) {
+	id := Hash([]byte("test"))
+	chunkID, gen, err := parseChunkKey(chunkPath(id, 42))
+	require.NoError(t, err)
+	require.Equal(t, id, chunkID)
+	require.Equal(t, uint64(42), gen)
+	for _, key := range []string{"chunk/abc", "other/" + id.HexString() + ".0", "chunk/zz.0", "chunk/" + id.HexString() + ".zz"} {
+		_, _, err := parseChunkKey(key)
+		require.YesError(t, err)
+	}
+}
+
 func countObjects(ctx context.Context, client obj.Client) (int, error) {
 	var count int
 	if err := client.Walk(ctx, "", func(string) error {
@@ -30,6 +30,23 @@ func WithObjectCache(fastLayer obj.Client, size int) StorageOption {
 	}
 }
 
+// WithSkipVerification disables verifying chunks against their content hash
+// when they are read from object storage.
+// This is faster, but corrupt chunks will only be detected if they fail
+// to decrypt or decompress.
+func WithSkipVerification() StorageOption {
+	return func(s *Storage) {
+		s.skipVerify = true
+	}
+}
+
+// WithThrottle limits the object storage bandwidth used by the storage.
+func WithThrottle(throttle *obj.Throttle) StorageOption {
+	return func(s *Storage) {
+		s.objClient = obj.NewThrottledClient(s.objClient, throttle)
+	}
+}
+
 // WithSecret sets the secret used to generate chunk encryption keys
 func WithSecret(secret []byte) StorageOption {
 	return func(s *Storage) {
@@ -73,10 +90,17 @@ func WithNoUpload() WriterOption {
 
 // StorageOptions returns the chunk storage options for the config.
 func StorageOptions(conf *serviceenv.StorageConfiguration) ([]StorageOption, error) {
-	var opts []StorageOption
+	// The global throttle is always used so that bandwidth limits can be
+	// set at runtime.
+	throttle := obj.GlobalThrottle()
+	throttle.SetLimits(conf.StorageUploadBytesPerSecond, conf.StorageDownloadBytesPerSecond, conf.StorageBandwidthBurstBytes)
+	opts := []StorageOption{WithThrottle(throttle)}
 	if conf.StorageUploadConcurrencyLimit > 0 {
 		opts = append(opts, WithMaxConcurrentObjects(0, conf.StorageUploadConcurrencyLimit))
 	}
+	if conf.StorageSkipChunkVerification {
+		opts = append(opts, WithSkipVerification())
+	}
 	if conf.StorageDiskCacheSize > 0 {
 		diskCache, err := obj.NewLocalClient(filepath.Join(os.TempDir(), "pfs-cache", uuid.NewWithoutDashes()))
 		if err != nil {
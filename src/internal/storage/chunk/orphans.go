@@ -0,0 +1,286 @@
+package chunk
+
+import (
+	"context"
+	"database/sql"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pacherr"
+	"github.com/sirupsen/logrus"
+)
+
+// The reasons an object is considered orphaned.
+const (
+	// OrphanUntracked is an object with no chunk entry in postgres.
+	OrphanUntracked = "no chunk entry"
+	// OrphanNoTracker is an object whose chunk entry is not tombstoned, but
+	// whose chunk has no tracker object.
+	OrphanNoTracker = "no tracker object"
+	// OrphanSuperseded is an object whose upload never completed, and which
+	// was replaced by a later upload of the same chunk.
+	OrphanSuperseded = "superseded upload"
+	// OrphanUnrecognized is an object under the chunk prefix whose name is
+	// not a chunk object name. It is reported, but never reclaimed.
+	OrphanUnrecognized = "unrecognized object"
+)
+
+// Orphan is an object in object storage which is not referenced by the
+// chunk entries and tracker in postgres.
+type Orphan struct {
+	Key       string
+	ChunkID   ID
+	Gen       uint64
+	SizeBytes int64
+	// Age is a lower bound on the age of the object.
+	Age         time.Duration
+	Reason      string
+	Reclaimable bool
+	Reclaimed   bool
+}
+
+// OrphanDetector finds and reclaims orphaned chunk objects, which can be
+// left behind by crashes between uploading an object and tracking it.
+// Reclaiming an object either tombstones its chunk entry, so that the chunk
+// GC deletes it, or deletes the object directly when it has no entry.
+type OrphanDetector struct {
+	s      *Storage
+	minAge time.Duration
+	dryRun bool
+	log    *logrus.Logger
+}
+
+// NewOrphanDetector creates an OrphanDetector. Only orphans which are at
+// least minAge old are reclaimed, and nothing is reclaimed if dryRun is true.
+func NewOrphanDetector(s *Storage, minAge time.Duration, dryRun bool) *OrphanDetector {
+	return &OrphanDetector{
+		s:      s,
+		minAge: minAge,
+		dryRun: dryRun,
+		log:    logrus.StandardLogger(),
+	}
+}
+
+// RunForever calls RunOnce periodically until the context is cancelled,
+// logging the orphans that are found.
+func (od *OrphanDetector) RunForever(ctx context.Context, period time.Duration) error {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		if err := od.RunOnce(ctx, func(o *Orphan) error {
+			od.log.WithFields(logrus.Fields{
+				"key":       o.Key,
+				"size":      o.SizeBytes,
+				"age":       o.Age,
+				"reason":    o.Reason,
+				"reclaimed": o.Reclaimed,
+			}).Infof("found orphaned chunk object")
+			return nil
+		}); err != nil {
+			select {
+			case <-ctx.Done():
+				return err
+			default:
+			}
+			od.log.Errorf("during orphaned chunk object detection: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce walks all of the chunk objects, calling cb with each orphan that
+// is found, after reclaiming it if it is reclaimable.
+func (od *OrphanDetector) RunOnce(ctx context.Context, cb func(*Orphan) error) error {
+	return od.s.store.Walk(ctx, []byte(prefix+"/"), func(key []byte) error {
+		o, err := od.check(ctx, string(key))
+		if err != nil {
+			return err
+		}
+		if o == nil {
+			return nil
+		}
+		o.Reclaimable = o.Reason != OrphanUnrecognized && o.Age >= od.minAge
+		if o.Reclaimable && !od.dryRun {
+			if err := od.reclaim(ctx, o); err != nil {
+				return err
+			}
+		}
+		return cb(o)
+	})
+}
+
+type orphanEntry struct {
+	Entry
+	Size      int64     `db:"size"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// check returns the orphan for the object, or nil if it isn't orphaned.
+func (od *OrphanDetector) check(ctx context.Context, key string) (*Orphan, error) {
+	chunkID, gen, err := parseChunkKey(key)
+	if err != nil {
+		return &Orphan{Key: key, Reason: OrphanUnrecognized, SizeBytes: od.objectSize(ctx, key)}, nil
+	}
+	o := &Orphan{Key: key, ChunkID: chunkID, Gen: gen}
+	ent := &orphanEntry{}
+	if err := od.s.db.GetContext(ctx, ent, `
+	SELECT chunk_id, gen, uploaded, tombstone, size, created_at FROM storage.chunk_objects
+	WHERE chunk_id = $1 AND gen = $2
+	`, chunkID, gen); err != nil {
+		if err != sql.ErrNoRows {
+			return nil, errors.EnsureStack(err)
+		}
+		// The gens are allocated in order, so the object is at least as old as
+		// the first entry with a later gen.
+		var createdAt time.Time
+		if err := od.s.db.GetContext(ctx, &createdAt, `
+		SELECT created_at FROM storage.chunk_objects
+		WHERE gen > $1 ORDER BY gen LIMIT 1
+		`, gen); err != nil && err != sql.ErrNoRows {
+			return nil, errors.EnsureStack(err)
+		}
+		if !createdAt.IsZero() {
+			o.Age = od.age(ctx, createdAt)
+		}
+		o.Reason = OrphanUntracked
+		o.SizeBytes = od.objectSize(ctx, key)
+		return o, nil
+	}
+	// Tombstoned entries are handled by the chunk GC.
+	if ent.Tombstone {
+		return nil, nil
+	}
+	o.Age = od.age(ctx, ent.CreatedAt)
+	o.SizeBytes = ent.Size
+	if _, err := od.s.tracker.GetExpiresAt(ctx, chunkID.TrackerID()); err != nil {
+		if !pacherr.IsNotExist(err) {
+			return nil, err
+		}
+		o.Reason = OrphanNoTracker
+		return o, nil
+	}
+	if !ent.Uploaded {
+		var superseded bool
+		if err := od.s.db.GetContext(ctx, &superseded, `
+		SELECT EXISTS (
+			SELECT 1 FROM storage.chunk_objects
+			WHERE chunk_id = $1 AND gen > $2 AND uploaded = TRUE
+		)`, chunkID, gen); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if superseded {
+			o.Reason = OrphanSuperseded
+			return o, nil
+		}
+	}
+	return nil, nil
+}
+
+// age returns how long ago t was, according to the database's clock, since
+// the timestamps in the database are written with it.
+func (od *OrphanDetector) age(ctx context.Context, t time.Time) time.Duration {
+	var now time.Time
+	if err := od.s.db.GetContext(ctx, &now, `SELECT CURRENT_TIMESTAMP::TIMESTAMP`); err != nil {
+		return 0
+	}
+	if now.Before(t) {
+		return 0
+	}
+	return now.Sub(t)
+}
+
+// objectSize reads the object to determine its size, returning -1 if the
+// object can't be read.
+func (od *OrphanDetector) objectSize(ctx context.Context, key string) int64 {
+	size := int64(-1)
+	if err := od.s.store.Get(ctx, []byte(key), func(data []byte) error {
+		size = int64(len(data))
+		return nil
+	}); err != nil {
+		od.log.Warnf("could not read orphaned chunk object %v: %v", key, err)
+	}
+	return size
+}
+
+func (od *OrphanDetector) reclaim(ctx context.Context, o *Orphan) error {
+	switch o.Reason {
+	case OrphanUntracked:
+		// Make sure the entry wasn't created since the object was checked.
+		var exists bool
+		if err := od.s.db.GetContext(ctx, &exists, `
+		SELECT EXISTS (SELECT 1 FROM storage.chunk_objects WHERE chunk_id = $1 AND gen = $2)
+		`, o.ChunkID, o.Gen); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if exists {
+			return nil
+		}
+		if err := od.s.store.Delete(ctx, []byte(o.Key)); err != nil {
+			return err
+		}
+	case OrphanNoTracker:
+		// Don't reclaim the object if its chunk was tracked again since it
+		// was checked.
+		reclaimed, err := od.tombstone(ctx, `
+		UPDATE storage.chunk_objects
+		SET tombstone = TRUE
+		WHERE chunk_id = $1 AND gen = $2
+		AND NOT EXISTS (SELECT 1 FROM storage.tracker_objects WHERE str_id = $3)
+		`, o.ChunkID, o.Gen, o.ChunkID.TrackerID())
+		if err != nil || !reclaimed {
+			return err
+		}
+	case OrphanSuperseded:
+		reclaimed, err := od.tombstone(ctx, `
+		UPDATE storage.chunk_objects
+		SET tombstone = TRUE
+		WHERE chunk_id = $1 AND gen = $2 AND uploaded = FALSE
+		`, o.ChunkID, o.Gen)
+		if err != nil || !reclaimed {
+			return err
+		}
+	default:
+		return nil
+	}
+	o.Reclaimed = true
+	return nil
+}
+
+// tombstone runs an update which tombstones a chunk entry, and returns
+// whether an entry was updated.
+func (od *OrphanDetector) tombstone(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	res, err := od.s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	return affected > 0, nil
+}
+
+// parseChunkKey is the inverse of chunkKey.
+func parseChunkKey(key string) (ID, uint64, error) {
+	dir, name := path.Split(key)
+	parts := strings.Split(name, ".")
+	if strings.TrimSuffix(dir, "/") != prefix || len(parts) != 2 {
+		return nil, 0, errors.Errorf("invalid chunk object key %q", key)
+	}
+	chunkID, err := IDFromHex(parts[0])
+	if err != nil {
+		return nil, 0, errors.EnsureStack(err)
+	}
+	gen, err := strconv.ParseUint(parts[1], 16, 64)
+	if err != nil {
+		return nil, 0, errors.EnsureStack(err)
+	}
+	return chunkID, gen, nil
+}
@@ -2,6 +2,7 @@ package chunk
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"time"
 
@@ -32,6 +33,15 @@ func WithOffsetBytes(offsetBytes int64) ReaderOption {
 	}
 }
 
+// OffsetBytes returns the offset set by the reader options.
+func OffsetBytes(opts ...ReaderOption) int64 {
+	r := &Reader{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r.offsetBytes
+}
+
 func newReader(ctx context.Context, client Client, memCache kv.GetPut, deduper *miscutil.WorkDeduper, prefetchLimit int, dataRefs []*DataRef, opts ...ReaderOption) *Reader {
 	r := &Reader{
 		ctx:           ctx,
@@ -137,6 +147,9 @@ func (dr *DataReader) Get(w io.Writer) error {
 	b.InitialInterval = 1 * time.Millisecond
 	return backoff.RetryUntilCancel(dr.ctx, func() error {
 		return getFromCache(dr.ctx, dr.memCache, ref, func(chunk []byte) error {
+			if int64(len(chunk)) < dr.dataRef.OffsetBytes+dr.dataRef.SizeBytes {
+				return newCorruptChunkError(ref.Id, fmt.Sprintf("chunk is %d bytes, but data reference ends at %d", len(chunk), dr.dataRef.OffsetBytes+dr.dataRef.SizeBytes))
+			}
 			data := chunk[dr.dataRef.OffsetBytes+dr.offset : dr.dataRef.OffsetBytes+dr.dataRef.SizeBytes]
 			_, err := w.Write(data)
 			return err
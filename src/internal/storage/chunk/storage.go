@@ -29,6 +29,7 @@ type Storage struct {
 	memCache      kv.GetPut
 	deduper       *miscutil.WorkDeduper
 	prefetchLimit int
+	skipVerify    bool
 
 	createOpts CreateOptions
 }
@@ -56,7 +57,7 @@ func NewStorage(objC obj.Client, memCache kv.GetPut, db *pachsql.DB, tracker tra
 
 // NewReader creates a new Reader.
 func (s *Storage) NewReader(ctx context.Context, dataRefs []*DataRef, opts ...ReaderOption) *Reader {
-	client := NewClient(s.store, s.db, s.tracker, nil)
+	client := s.newClient(nil)
 	return newReader(ctx, client, s.memCache, s.deduper, s.prefetchLimit, dataRefs, opts...)
 }
 
@@ -67,10 +68,16 @@ func (s *Storage) NewWriter(ctx context.Context, name string, cb WriterCallback,
 	if name == "" {
 		panic("name must not be empty")
 	}
-	client := NewClient(s.store, s.db, s.tracker, NewRenewer(ctx, s.tracker, name, defaultChunkTTL))
+	client := s.newClient(NewRenewer(ctx, s.tracker, name, defaultChunkTTL))
 	return newWriter(ctx, client, s.memCache, s.deduper, s.createOpts, cb, opts...)
 }
 
+func (s *Storage) newClient(renewer *Renewer) Client {
+	c := NewClient(s.store, s.db, s.tracker, renewer).(*trackedClient)
+	c.skipVerify = s.skipVerify
+	return c
+}
+
 // List lists all of the chunks in object storage.
 func (s *Storage) List(ctx context.Context, cb func(id ID) error) error {
 	return s.store.Walk(ctx, nil, func(key []byte) error {
@@ -107,6 +114,13 @@ func (s *Storage) Check(ctx context.Context, begin, end []byte, readChunks bool)
 	return count, nil
 }
 
+// CheckChunk checks that the object for a chunk exists, and if readChunk is
+// true, that its data matches the chunk's hash.
+func (s *Storage) CheckChunk(ctx context.Context, id ID, readChunk bool) error {
+	c := NewClient(s.store, s.db, s.tracker, nil).(*trackedClient)
+	return c.Check(ctx, id, readChunk)
+}
+
 // keyAfter returns a byte slice ordered immediately after x lexicographically
 // the motivating use case is iteration.
 func keyAfter(x []byte) []byte {
@@ -5,6 +5,7 @@ import (
 	"compress/gzip"
 	"context"
 	"crypto/cipher"
+	"fmt"
 	io "io"
 	"io/ioutil"
 
@@ -44,27 +45,25 @@ func Create(ctx context.Context, opts CreateOptions, ptext []byte, createFunc fu
 	}, nil
 }
 
-// Get calls client.Get to retrieve a chunk, then verifies, decrypts, and decompresses the data.
+// Get calls client.Get to retrieve a chunk, then decrypts, and decompresses the data.
+// The client is responsible for verifying the data against the chunk ID.
 // cb is called with the uncompressed plaintext
 func Get(ctx context.Context, client Client, ref *Ref, cb kv.ValueCallback) error {
 	if ref.EncryptionAlgo != EncryptionAlgo_CHACHA20 {
 		return errors.Errorf("unknown encryption algorithm %d", ref.EncryptionAlgo)
 	}
 	return client.Get(ctx, ref.Id, func(ctext []byte) error {
-		if err := verifyData(ref.Id, ctext); err != nil {
-			return err
-		}
 		var r io.Reader = bytes.NewReader(ctext)
 		var err error
 		if r, err = decrypt(ref.Dek, r); err != nil {
 			return err
 		}
 		if r, err = decompress(ref.CompressionAlgo, r); err != nil {
-			return err
+			return newCorruptChunkError(ref.Id, errors.Wrap(err, "decompress").Error())
 		}
 		rawData, err := ioutil.ReadAll(r)
 		if err != nil {
-			return err
+			return newCorruptChunkError(ref.Id, errors.Wrap(err, "decompress").Error())
 		}
 		return cb(rawData)
 	})
@@ -183,11 +182,43 @@ func cryptoXOR(key, dst, src []byte) {
 func verifyData(id ID, x []byte) error {
 	actualHash := Hash(x)
 	if !bytes.Equal(actualHash[:], id) {
-		return errors.Errorf("bad chunk. HAVE: %v WANT: %v", actualHash, id)
+		return newCorruptChunkError(id, fmt.Sprintf("hash mismatch, have: %v (%d bytes)", actualHash.HexString(), len(x)))
 	}
 	return nil
 }
 
+// CorruptChunkError is returned when the data read for a chunk does not match
+// its content hash, or cannot be decoded.
+type CorruptChunkError struct {
+	ID     ID
+	Reason string
+	// Object is the object storage key the data was read from.
+	Object string
+	// Referrers are the top level tracker objects (such as commits) which
+	// reference the chunk, if they could be determined.
+	Referrers []string
+}
+
+func newCorruptChunkError(id ID, reason string) error {
+	return errors.EnsureStack(&CorruptChunkError{ID: id, Reason: reason})
+}
+
+func (e *CorruptChunkError) Error() string {
+	msg := fmt.Sprintf("chunk %v is corrupt: %s", e.ID.HexString(), e.Reason)
+	if e.Object != "" {
+		msg += fmt.Sprintf(" (object %s)", e.Object)
+	}
+	if len(e.Referrers) > 0 {
+		msg += fmt.Sprintf(", referenced by %v", e.Referrers)
+	}
+	return msg
+}
+
+// IsCorruptChunk returns true if err is, or wraps, a CorruptChunkError.
+func IsCorruptChunk(err error) bool {
+	return errors.As(err, new(*CorruptChunkError))
+}
+
 // Key returns a unique key for the Ref suitable for use in hash tables
 func (r *Ref) Key() pachhash.Output {
 	data, err := r.Marshal()
@@ -5,6 +5,8 @@ import (
 	"io"
 	"sort"
 	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset/index"
 )
 
 type Buffer struct {
@@ -13,9 +15,11 @@ type Buffer struct {
 }
 
 type file struct {
-	path  string
-	datum string
-	buf   *bytes.Buffer
+	path        string
+	datum       string
+	externalRef *index.ExternalRef
+	metadata    *index.Metadata
+	buf         *bytes.Buffer
 }
 
 func NewBuffer() *Buffer {
@@ -26,6 +30,21 @@ func NewBuffer() *Buffer {
 }
 
 func (b *Buffer) Add(path, datum string) io.Writer {
+	return b.add(path, datum).buf
+}
+
+// AddExternal adds a file which references an external object. Data added
+// to the file afterwards is appended after the external object.
+func (b *Buffer) AddExternal(path, datum string, ref *index.ExternalRef) {
+	b.add(path, datum).externalRef = ref
+}
+
+// SetMetadata sets the metadata of a file, keeping the data added to it.
+func (b *Buffer) SetMetadata(path, datum string, md *index.Metadata) {
+	b.add(path, datum).metadata = md
+}
+
+func (b *Buffer) add(path, datum string) *file {
 	path = Clean(path, false)
 	if _, ok := b.additive[path]; !ok {
 		b.additive[path] = make(map[string]*file)
@@ -38,8 +57,7 @@ func (b *Buffer) Add(path, datum string) io.Writer {
 			buf:   &bytes.Buffer{},
 		}
 	}
-	f := datumFiles[datum]
-	return f.buf
+	return datumFiles[datum]
 }
 
 func (b *Buffer) Delete(path, datum string) {
@@ -68,8 +86,14 @@ func (b *Buffer) Delete(path, datum string) {
 }
 
 func (b *Buffer) WalkAdditive(cb func(path, datum string, r io.Reader) error) error {
+	return b.walkAdditive(func(path, datum string, _ *index.ExternalRef, _ *index.Metadata, r io.Reader) error {
+		return cb(path, datum, r)
+	})
+}
+
+func (b *Buffer) walkAdditive(cb func(path, datum string, ref *index.ExternalRef, md *index.Metadata, r io.Reader) error) error {
 	for _, file := range sortFiles(b.additive) {
-		if err := cb(file.path, file.datum, bytes.NewReader(file.buf.Bytes())); err != nil {
+		if err := cb(file.path, file.datum, file.externalRef, file.metadata, bytes.NewReader(file.buf.Bytes())); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,86 @@
+package fileset
+
+import (
+	"context"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset/index"
+)
+
+// CheckCallback is called by Check for each path with a chunk that failed the
+// check. When an index chunk fails the check, the paths it covers can't be
+// read, so the callback is called with the first path of the range instead.
+type CheckCallback = func(path string, err error) error
+
+// Check checks that all of the index and data chunks referenced by a file set
+// exist in object storage. If readChunks is true, the chunks are also read and
+// verified against their hashes. It returns the number of distinct chunks
+// checked.
+func (s *Storage) Check(ctx context.Context, id ID, readChunks bool, cb CheckCallback) (int, error) {
+	prims, err := s.flattenPrimitives(ctx, []ID{id})
+	if err != nil {
+		return 0, err
+	}
+	checked := make(map[string]error)
+	checkChunk := func(id chunk.ID) error {
+		key := string(id)
+		if err, ok := checked[key]; ok {
+			return err
+		}
+		err := s.chunks.CheckChunk(ctx, id, readChunks)
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		checked[key] = err
+		return err
+	}
+	for _, prim := range prims {
+		for _, topIdx := range []*index.Index{prim.Deletive, prim.Additive} {
+			if err := s.checkIndex(ctx, topIdx, checkChunk, cb); err != nil {
+				return len(checked), err
+			}
+		}
+	}
+	return len(checked), nil
+}
+
+type errBadRange struct {
+	path, lastPath string
+	err            error
+}
+
+func (e *errBadRange) Error() string {
+	return e.err.Error()
+}
+
+func (s *Storage) checkIndex(ctx context.Context, topIdx *index.Index, checkChunk func(chunk.ID) error, cb CheckCallback) error {
+	if topIdx == nil {
+		return nil
+	}
+	var badRange *errBadRange
+	ir := index.NewReader(s.chunks, topIdx, index.WithRangeCallback(func(idx *index.Index) error {
+		if err := checkChunk(chunk.ID(idx.Range.ChunkRef.Ref.Id)); err != nil {
+			badRange = &errBadRange{path: idx.Path, lastPath: idx.Range.LastPath, err: err}
+			return badRange
+		}
+		return nil
+	}))
+	err := ir.Iterate(ctx, func(idx *index.Index) error {
+		for _, dataRef := range idx.File.DataRefs {
+			if err := checkChunk(chunk.ID(dataRef.Ref.Id)); err != nil {
+				if ctx.Err() != nil {
+					return err
+				}
+				if err := cb(idx.Path, err); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil && badRange != nil && ctx.Err() == nil {
+		return cb(badRange.path, errors.Wrapf(badRange.err, "index chunk for paths %q to %q", badRange.path, badRange.lastPath))
+	}
+	return err
+}
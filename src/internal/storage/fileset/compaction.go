@@ -3,6 +3,7 @@ package fileset
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
@@ -69,6 +70,37 @@ func (s *Storage) Compact(ctx context.Context, ids []ID, ttl time.Duration, opts
 	return w.Close()
 }
 
+// Materialize is like Compact, but it also copies the content of files which
+// reference external objects into the resulting file set, so that it no longer
+// depends on the external objects.
+func (s *Storage) Materialize(ctx context.Context, ids []ID, ttl time.Duration, opts ...index.Option) (*ID, error) {
+	w := s.newWriter(ctx, WithTTL(ttl))
+	fs, err := s.Open(ctx, ids, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := fs.Iterate(ctx, func(f File) error {
+		idx := f.Index()
+		return w.Delete(idx.Path, idx.File.Datum)
+	}, true); err != nil {
+		return nil, err
+	}
+	if err := fs.Iterate(ctx, func(f File) error {
+		idx := f.Index()
+		if idx.File.ExternalRef == nil {
+			return w.Copy(f, idx.File.Datum)
+		}
+		return miscutil.WithPipe(func(pw io.Writer) error {
+			return f.Content(ctx, pw)
+		}, func(r io.Reader) error {
+			return w.add(idx.Path, &index.File{Datum: idx.File.Datum, Metadata: idx.File.Metadata}, r)
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return w.Close()
+}
+
 // CompactCallback is the standard callback signature for a compaction operation.
 type CompactCallback func(context.Context, []ID, time.Duration) (*ID, error)
 
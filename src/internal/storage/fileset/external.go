@@ -0,0 +1,150 @@
+package fileset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset/index"
+)
+
+var (
+	// ErrExternalObjectChanged is returned when reading a file which was added
+	// by reference to an external object that has changed since it was added.
+	ErrExternalObjectChanged = errors.Errorf("external object changed")
+	// ErrAppendExternal is returned when appending a file which was added by
+	// reference to another file.
+	ErrAppendExternal = errors.Errorf("cannot append a file which references an external object")
+)
+
+// NewExternalRef creates a reference to an object in an external object
+// store, recording the attributes which identify its current content.
+func NewExternalRef(ctx context.Context, client obj.Client, name string) (*index.ExternalRef, error) {
+	info, err := obj.Stat(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	return &index.ExternalRef{
+		URL:       client.BucketURL().ObjectURL(name),
+		SizeBytes: info.SizeBytes,
+		Etag:      info.ETag,
+		Version:   info.Version,
+	}, nil
+}
+
+// externalRefKey identifies the content of an external object.
+func externalRefKey(ref *index.ExternalRef) string {
+	return fmt.Sprintf("%s\x00%d\x00%s\x00%s", ref.URL, ref.SizeBytes, ref.Etag, ref.Version)
+}
+
+// externalClients caches the clients for external object stores by bucket.
+var externalClients sync.Map
+
+func externalClient(urlStr string) (obj.Client, string, error) {
+	url, err := obj.ParseURL(urlStr)
+	if err != nil {
+		return nil, "", err
+	}
+	key := url.Scheme + "://" + url.Bucket
+	if c, ok := externalClients.Load(key); ok {
+		return c.(obj.Client), url.Object, nil
+	}
+	c, err := obj.NewClientFromURLAndSecret(url, false)
+	if err != nil {
+		return nil, "", err
+	}
+	actual, _ := externalClients.LoadOrStore(key, c)
+	return actual.(obj.Client), url.Object, nil
+}
+
+// checkExternal returns an error if an external object has changed since it
+// was referenced.
+func checkExternal(ctx context.Context, client obj.Client, name string, ref *index.ExternalRef) error {
+	info, err := obj.Stat(ctx, client, name)
+	if err != nil {
+		return err
+	}
+	changed := info.SizeBytes != ref.SizeBytes
+	if ref.Version != "" {
+		changed = changed || info.Version != ref.Version
+	} else {
+		changed = changed || info.ETag != ref.Etag
+	}
+	if changed {
+		return errors.Wrapf(ErrExternalObjectChanged, "%v (etag %q, version %q, size %d was referenced, found etag %q, version %q, size %d)",
+			ref.URL, ref.Etag, ref.Version, ref.SizeBytes, info.ETag, info.Version, info.SizeBytes)
+	}
+	return nil
+}
+
+// readExternal writes the content of an external object, starting at offset,
+// to w.
+func readExternal(ctx context.Context, ref *index.ExternalRef, w io.Writer, offset int64) error {
+	client, name, err := externalClient(ref.URL)
+	if err != nil {
+		return err
+	}
+	if err := checkExternal(ctx, client, name, ref); err != nil {
+		return err
+	}
+	ew := &externalWriter{w: w, skip: offset}
+	if err := client.Get(ctx, name, ew); err != nil {
+		return err
+	}
+	// The object may have been replaced after it was checked.
+	if ew.n != ref.SizeBytes {
+		return errors.Wrapf(ErrExternalObjectChanged, "%v (read %d bytes, expected %d)", ref.URL, ew.n, ref.SizeBytes)
+	}
+	return nil
+}
+
+// externalWriter counts the bytes written to it, and skips the first skip
+// bytes.
+type externalWriter struct {
+	w    io.Writer
+	skip int64
+	n    int64
+}
+
+func (ew *externalWriter) Write(data []byte) (int, error) {
+	size := len(data)
+	ew.n += int64(size)
+	if ew.skip >= int64(size) {
+		ew.skip -= int64(size)
+		return size, nil
+	}
+	data = data[ew.skip:]
+	ew.skip = 0
+	if _, err := ew.w.Write(data); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// fileContent writes the content of the file described by idx to w. The
+// content of an external object comes before the content of the data refs.
+func fileContent(ctx context.Context, chunks *chunk.Storage, idx *index.Index, w io.Writer, opts ...chunk.ReaderOption) error {
+	ref := idx.File.ExternalRef
+	if ref == nil {
+		r := chunks.NewReader(ctx, idx.File.DataRefs, opts...)
+		return r.Get(w)
+	}
+	offset := chunk.OffsetBytes(opts...)
+	if offset < ref.SizeBytes {
+		if err := readExternal(ctx, ref, w, offset); err != nil {
+			return err
+		}
+		offset = 0
+	} else {
+		offset -= ref.SizeBytes
+	}
+	if len(idx.File.DataRefs) == 0 {
+		return nil
+	}
+	r := chunks.NewReader(ctx, idx.File.DataRefs, append(opts, chunk.WithOffsetBytes(offset))...)
+	return r.Get(w)
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"math/rand"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/dockertestenv"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/randutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/require"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
@@ -259,3 +261,150 @@ func TestStableHash(t *testing.T) {
 	}
 	require.True(t, bytes.Equal(stableHash, getHash()), msg)
 }
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	db := dockertestenv.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	objC, chunks := chunk.NewTestStorage(t, db, tr)
+	storage := NewStorage(NewTestStore(t, db), tr, chunks)
+	random := rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+	var files []*testFile
+	for _, fileName := range index.Generate("abc") {
+		files = append(files, &testFile{
+			path:  "/" + fileName,
+			datum: DefaultFileDatum,
+			data:  randutil.Bytes(random, units.KB),
+		})
+	}
+	id := writeFileSet(t, storage, files)
+	check := func(readChunks bool) (paths []string) {
+		n, err := storage.Check(ctx, id, readChunks, func(path string, err error) error {
+			paths = append(paths, path)
+			return nil
+		})
+		require.NoError(t, err)
+		require.True(t, n > 0)
+		return paths
+	}
+	require.Equal(t, 0, len(check(false)))
+	require.Equal(t, 0, len(check(true)))
+	// Delete the chunk objects out from under the file set.
+	var names []string
+	require.NoError(t, objC.Walk(ctx, "", func(name string) error {
+		names = append(names, name)
+		return nil
+	}))
+	for _, name := range names {
+		require.NoError(t, objC.Delete(ctx, name))
+	}
+	require.True(t, len(check(false)) > 0)
+	require.True(t, len(check(true)) > 0)
+}
+
+func TestExternal(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t)
+	objC, err := obj.NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, objC.Put(ctx, "external", strings.NewReader("external data")))
+	ref, err := NewExternalRef(ctx, objC, "external")
+	require.NoError(t, err)
+	require.Equal(t, int64(len("external data")), ref.SizeBytes)
+	w := storage.NewWriter(ctx)
+	require.NoError(t, w.AddExternal("/a", DefaultFileDatum, ref, strings.NewReader(" and more")))
+	require.NoError(t, w.Add("/b", DefaultFileDatum, strings.NewReader("internal data")))
+	id, err := w.Close()
+	require.NoError(t, err)
+	getContent := func(id ID, p string, opts ...chunk.ReaderOption) (string, error) {
+		fs, err := storage.Open(ctx, []ID{id})
+		if err != nil {
+			return "", err
+		}
+		buf := &bytes.Buffer{}
+		if err := fs.Iterate(ctx, func(f File) error {
+			if f.Index().Path != p {
+				return nil
+			}
+			return f.Content(ctx, buf, opts...)
+		}); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	content, err := getContent(*id, "/a")
+	require.NoError(t, err)
+	require.Equal(t, "external data and more", content)
+	content, err = getContent(*id, "/a", chunk.WithOffsetBytes(9))
+	require.NoError(t, err)
+	require.Equal(t, "data and more", content)
+	content, err = getContent(*id, "/a", chunk.WithOffsetBytes(17))
+	require.NoError(t, err)
+	require.Equal(t, "d more", content)
+	// Materialize the file set, then change the external object.
+	materializedId, err := storage.Materialize(ctx, []ID{*id}, time.Hour)
+	require.NoError(t, err)
+	fs, err := storage.Open(ctx, []ID{*materializedId})
+	require.NoError(t, err)
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		require.True(t, f.Index().File.ExternalRef == nil)
+		return nil
+	}))
+	require.NoError(t, objC.Put(ctx, "external", strings.NewReader("changed")))
+	_, err = getContent(*id, "/a")
+	require.YesError(t, err)
+	require.True(t, errors.Is(err, ErrExternalObjectChanged))
+	content, err = getContent(*materializedId, "/a")
+	require.NoError(t, err)
+	require.Equal(t, "external data and more", content)
+	content, err = getContent(*materializedId, "/b")
+	require.NoError(t, err)
+	require.Equal(t, "internal data", content)
+}
+
+func TestMetadata(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t)
+	var ids []ID
+	write := func(cb func(uw *UnorderedWriter)) {
+		uw, err := storage.NewUnorderedWriter(ctx)
+		require.NoError(t, err)
+		cb(uw)
+		id, err := uw.Close()
+		require.NoError(t, err)
+		ids = append(ids, *id)
+	}
+	check := func(ids []ID, expectedContent string, expected map[string]string) {
+		fs, err := storage.Open(ctx, ids)
+		require.NoError(t, err)
+		require.NoError(t, fs.Iterate(ctx, func(f File) error {
+			buf := &bytes.Buffer{}
+			require.NoError(t, f.Content(ctx, buf))
+			require.Equal(t, expectedContent, buf.String())
+			require.Equal(t, expected, f.Index().File.Metadata.GetValues())
+			return nil
+		}))
+	}
+	write(func(uw *UnorderedWriter) {
+		require.NoError(t, uw.Put("/a", DefaultFileDatum, false, strings.NewReader("foo")))
+		require.NoError(t, uw.SetMetadata("/a", DefaultFileDatum, map[string]string{"k": "v1"}))
+	})
+	check(ids, "foo", map[string]string{"k": "v1"})
+	// Appending keeps the metadata, and setting it replaces it.
+	write(func(uw *UnorderedWriter) {
+		require.NoError(t, uw.Put("/a", DefaultFileDatum, true, strings.NewReader("bar")))
+	})
+	check(ids, "foobar", map[string]string{"k": "v1"})
+	write(func(uw *UnorderedWriter) {
+		require.NoError(t, uw.SetMetadata("/a", DefaultFileDatum, map[string]string{"k": "v2"}))
+	})
+	check(ids, "foobar", map[string]string{"k": "v2"})
+	compacted, err := storage.Compact(ctx, ids[1:], time.Hour)
+	require.NoError(t, err)
+	check([]ID{ids[0], *compacted}, "foobar", map[string]string{"k": "v2"})
+	// Overwriting drops the metadata.
+	write(func(uw *UnorderedWriter) {
+		require.NoError(t, uw.Put("/a", DefaultFileDatum, false, strings.NewReader("baz")))
+	})
+	check(ids, "baz", nil)
+}
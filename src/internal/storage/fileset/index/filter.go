@@ -0,0 +1,101 @@
+package index
+
+import (
+	"github.com/cespare/xxhash/v2"
+)
+
+const (
+	filterBitsPerKey = 10
+	filterNumHashes  = 7
+	// maxFilterKeys is the maximum number of keys in a range filter.
+	// Ranges which cover more keys than this do not have a filter.
+	maxFilterKeys = 1 << 17
+)
+
+// filterKeys is the set of hashed keys covered by a range.
+// A nil filterKeys means that the range covers too many keys for a filter.
+type filterKeys map[uint64]struct{}
+
+func newFilterKeys() filterKeys {
+	return make(filterKeys)
+}
+
+// addPath adds a path and its parent directories to the keys.
+func (fk filterKeys) addPath(p string) filterKeys {
+	if fk == nil {
+		return nil
+	}
+	fk[xxhash.Sum64String(p)] = struct{}{}
+	for i := 0; i < len(p)-1; i++ {
+		if p[i] == '/' {
+			fk[xxhash.Sum64String(p[:i+1])] = struct{}{}
+		}
+	}
+	return fk.checkSize()
+}
+
+// merge adds the keys from another range to the keys.
+func (fk filterKeys) merge(other filterKeys) filterKeys {
+	if fk == nil || other == nil {
+		return nil
+	}
+	for h := range other {
+		fk[h] = struct{}{}
+	}
+	return fk.checkSize()
+}
+
+func (fk filterKeys) checkSize() filterKeys {
+	if len(fk) > maxFilterKeys {
+		return nil
+	}
+	return fk
+}
+
+// filter creates a Bloom filter for the keys, or returns nil if there are
+// too many keys.
+func (fk filterKeys) filter() *BloomFilter {
+	if fk == nil {
+		return nil
+	}
+	numBits := len(fk) * filterBitsPerKey
+	if numBits < 64 {
+		numBits = 64
+	}
+	f := &BloomFilter{
+		NumHashes: filterNumHashes,
+		Bits:      make([]byte, (numBits+7)/8),
+	}
+	for h := range fk {
+		f.add(h)
+	}
+	return f
+}
+
+func (f *BloomFilter) add(h uint64) {
+	m := uint64(len(f.Bits)) * 8
+	h1, h2 := h&0xffffffff, h>>32
+	for i := uint64(0); i < uint64(f.NumHashes); i++ {
+		bit := (h1 + i*h2) % m
+		f.Bits[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// MayContain returns false if the range which the filter belongs to
+// definitely does not contain the path or directory.
+// Directories must have a trailing slash.
+func (f *BloomFilter) MayContain(p string) bool {
+	if f == nil || len(f.Bits) == 0 {
+		return true
+	}
+	m := uint64(len(f.Bits)) * 8
+	h := xxhash.Sum64String(p)
+	h1, h2 := h&0xffffffff, h>>32
+	for i := uint64(0); i < uint64(f.NumHashes); i++ {
+		bit := (h1 + i*h2) % m
+		if f.Bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
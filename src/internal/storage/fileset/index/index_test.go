@@ -2,6 +2,7 @@ package index
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -112,6 +113,13 @@ func Check(t *testing.T, permString string) {
 		actual = actualFiles(t, topIdx, chunks, WithRange(pathRange(expected)))
 		require.Equal(t, expected, actual)
 	})
+	t.Run("PrefixLowerBound", func(t *testing.T) {
+		prefixFiles := expectedFiles(fileNames, string(fileNames[len(fileNames)/2][0]))
+		lower := prefixFiles[len(prefixFiles)/2]
+		expected := prefixFiles[len(prefixFiles)/2:]
+		actual := actualFiles(t, topIdx, chunks, WithPrefix(string(lower[0])), WithLowerBound(lower))
+		require.Equal(t, expected, actual)
+	})
 }
 
 func TestSingleLevel(t *testing.T) {
@@ -121,3 +129,61 @@ func TestSingleLevel(t *testing.T) {
 func TestMultiLevel(t *testing.T) {
 	Check(t, "abcdefg")
 }
+
+func TestBloomFilter(t *testing.T) {
+	keys := newFilterKeys()
+	for i := 0; i < 1000; i++ {
+		keys = keys.addPath(fmt.Sprintf("/dir-%d/file-%d", i%10, i))
+	}
+	f := keys.filter()
+	for i := 0; i < 1000; i++ {
+		require.True(t, f.MayContain(fmt.Sprintf("/dir-%d/file-%d", i%10, i)))
+	}
+	require.True(t, f.MayContain("/"))
+	require.True(t, f.MayContain("/dir-0/"))
+	var falsePositives int
+	for i := 1000; i < 2000; i++ {
+		if f.MayContain(fmt.Sprintf("/dir-%d/file-%d", i%10, i)) {
+			falsePositives++
+		}
+	}
+	require.True(t, falsePositives < 50, "too many false positives: %d", falsePositives)
+	// Too many keys for a filter.
+	keys = newFilterKeys()
+	for i := 0; i <= maxFilterKeys; i++ {
+		keys = keys.addPath(fmt.Sprintf("/%d", i))
+	}
+	require.Nil(t, keys.filter())
+	require.True(t, keys.filter().MayContain("/missing"))
+}
+
+func TestFilter(t *testing.T) {
+	db := dockertestenv.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	_, chunks := chunk.NewTestStorage(t, db, tr)
+	averageBits = 12
+	var fileNames []string
+	for _, name := range Generate("abcdef") {
+		fileNames = append(fileNames, "/"+name[:2]+"/"+name[2:])
+	}
+	topIdx := write(t, chunks, fileNames)
+	require.NotNil(t, topIdx.Range.Filter)
+	// Count the index levels read for each lookup.
+	var rangesRead int
+	countRanges := WithRangeCallback(func(*Index) error {
+		rangesRead++
+		return nil
+	})
+	for _, fileName := range []string{fileNames[0], fileNames[len(fileNames)/2], fileNames[len(fileNames)-1]} {
+		rangesRead = 0
+		require.Equal(t, []string{fileName}, actualFiles(t, topIdx, chunks, WithExact(fileName), countRanges))
+		require.True(t, rangesRead > 0)
+	}
+	// Missing paths and directories are found without reading the index levels.
+	for _, opt := range []Option{WithExact("/ab/missing"), WithExact("/zz/cdef"), WithPrefix("/ab/missing/"), WithPrefix("/zz/")} {
+		rangesRead = 0
+		require.Equal(t, []string{}, actualFiles(t, topIdx, chunks, opt, countRanges))
+		require.Equal(t, 0, rangesRead)
+	}
+	require.Equal(t, expectedFiles(fileNames, "/ab/"), actualFiles(t, topIdx, chunks, WithPrefix("/ab/")))
+}
@@ -23,6 +23,22 @@ func WithPrefix(prefix string) Option {
 	}
 }
 
+// WithLowerBound restricts a read to the paths which are greater than or equal
+// to lower, in addition to any prefix or range filter set before it.
+func WithLowerBound(lower string) Option {
+	return func(r *Reader) {
+		if r.filter == nil {
+			r.filter = &pathFilter{}
+		}
+		if r.filter.pathRange == nil {
+			r.filter.pathRange = &PathRange{}
+		}
+		if lower > r.filter.pathRange.Lower {
+			r.filter.pathRange = &PathRange{Lower: lower, Upper: r.filter.pathRange.Upper}
+		}
+	}
+}
+
 // WithExact adds a path filter that matches a single path
 func WithExact(key string) Option {
 	return WithRange(&PathRange{Upper: key, Lower: key})
@@ -34,3 +50,12 @@ func WithDatum(datum string) Option {
 		r.datum = datum
 	}
 }
+
+// WithRangeCallback sets a callback which is called with each range index
+// before the index level that it points to is read.
+// The index must not be retained after the callback returns.
+func WithRangeCallback(cb func(*Index) error) Option {
+	return func(r *Reader) {
+		r.rangeCb = cb
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"strings"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
@@ -14,10 +15,11 @@ import (
 
 // Reader is used for reading a multilevel index.
 type Reader struct {
-	chunks *chunk.Storage
-	filter *pathFilter
-	topIdx *Index
-	datum  string
+	chunks  *chunk.Storage
+	filter  *pathFilter
+	topIdx  *Index
+	datum   string
+	rangeCb func(*Index) error
 }
 
 type pathFilter struct {
@@ -78,7 +80,13 @@ func (r *Reader) Iterate(ctx context.Context, cb func(*Index) error) error {
 		if !r.atStart(idx.Range.LastPath) {
 			continue
 		}
-		levels = append(levels, pbutil.NewReader(newLevelReader(ctx, pbr, r.chunks, idx)))
+		// The first range at each level that is not skipped is the only range
+		// that can contain the first path matching an exact or directory filter,
+		// so nothing matches if its filter does not contain the path.
+		if !r.mayContain(idx.Range.Filter) {
+			return nil
+		}
+		levels = append(levels, pbutil.NewReader(newLevelReader(ctx, pbr, r.chunks, idx, r.rangeCb)))
 	}
 }
 
@@ -92,12 +100,13 @@ func (r *Reader) topLevel() pbutil.Reader {
 // atStart returns true when the name is in the valid range for a filter (always true if no filter is set).
 // For a range filter, this means the name is >= to the lower bound.
 // For a prefix filter, this means the name is >= to the prefix.
+// Both must hold when a prefix filter is combined with a lower bound.
 func (r *Reader) atStart(name string) bool {
 	if r.filter == nil {
 		return true
 	}
-	if r.filter.pathRange != nil && r.filter.pathRange.Lower != "" {
-		return name >= r.filter.pathRange.Lower
+	if r.filter.pathRange != nil && r.filter.pathRange.Lower != "" && name < r.filter.pathRange.Lower {
+		return false
 	}
 	return name >= r.filter.prefix
 }
@@ -121,20 +130,41 @@ func (r *Reader) atEnd(name string) bool {
 	return name[:cmpSize] > r.filter.prefix[:cmpSize]
 }
 
+// mayContain returns false when a range filter shows that the range cannot
+// contain the first path matching the reader's filter.
+// Only exact path filters and prefix filters for directories can be checked.
+func (r *Reader) mayContain(f *BloomFilter) bool {
+	if r.filter == nil || f == nil {
+		return true
+	}
+	if pr := r.filter.pathRange; pr != nil {
+		if pr.Lower != "" && pr.Lower == pr.Upper {
+			return f.MayContain(pr.Lower)
+		}
+		return true
+	}
+	if strings.HasSuffix(r.filter.prefix, "/") {
+		return f.MayContain(r.filter.prefix)
+	}
+	return true
+}
+
 type levelReader struct {
-	ctx    context.Context
-	parent pbutil.Reader
-	chunks *chunk.Storage
-	idx    *Index
-	buf    *bytes.Buffer
+	ctx     context.Context
+	parent  pbutil.Reader
+	chunks  *chunk.Storage
+	idx     *Index
+	buf     *bytes.Buffer
+	rangeCb func(*Index) error
 }
 
-func newLevelReader(ctx context.Context, parent pbutil.Reader, chunks *chunk.Storage, idx *Index) *levelReader {
+func newLevelReader(ctx context.Context, parent pbutil.Reader, chunks *chunk.Storage, idx *Index, rangeCb func(*Index) error) *levelReader {
 	return &levelReader{
-		ctx:    ctx,
-		parent: parent,
-		chunks: chunks,
-		idx:    idx,
+		ctx:     ctx,
+		parent:  parent,
+		chunks:  chunks,
+		idx:     idx,
+		rangeCb: rangeCb,
 	}
 }
 
@@ -159,6 +189,11 @@ func (lr *levelReader) Read(data []byte) (int, error) {
 
 func (lr *levelReader) setup() error {
 	if lr.buf == nil {
+		if lr.rangeCb != nil {
+			if err := lr.rangeCb(lr.idx); err != nil {
+				return err
+			}
+		}
 		r := lr.chunks.NewReader(lr.ctx, []*chunk.DataRef{lr.idx.Range.ChunkRef})
 		lr.buf = &bytes.Buffer{}
 		if err := r.Get(lr.buf); err != nil {
@@ -175,6 +210,11 @@ func (lr *levelReader) next() error {
 	if err := lr.parent.Read(lr.idx); err != nil {
 		return err
 	}
+	if lr.rangeCb != nil {
+		if err := lr.rangeCb(lr.idx); err != nil {
+			return err
+		}
+	}
 	r := lr.chunks.NewReader(lr.ctx, []*chunk.DataRef{lr.idx.Range.ChunkRef})
 	lr.buf.Reset()
 	return r.Get(lr.buf)
@@ -59,6 +59,9 @@ func SizeBytes(idx *Index) int64 {
 		return size
 	}
 	if idx.File != nil {
+		if idx.File.ExternalRef != nil {
+			size += idx.File.ExternalRef.SizeBytes
+		}
 		for _, dataRef := range idx.File.DataRefs {
 			size += dataRef.SizeBytes
 		}
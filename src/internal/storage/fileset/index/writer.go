@@ -22,6 +22,8 @@ type levelWriter struct {
 type data struct {
 	idx   *Index
 	level int
+	// keys are the filter keys of the range for range indexes.
+	keys filterKeys
 }
 
 // Writer is used for creating a multilevel index into a serialized file set.
@@ -51,7 +53,7 @@ func (w *Writer) WriteIndex(idx *Index) error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	w.setupLevels()
-	return w.writeIndex(idx, 0)
+	return w.writeIndex(idx, 0, nil)
 }
 
 func (w *Writer) setupLevels() {
@@ -65,7 +67,7 @@ func (w *Writer) setupLevels() {
 	}
 }
 
-func (w *Writer) writeIndex(idx *Index, level int) error {
+func (w *Writer) writeIndex(idx *Index, level int, keys filterKeys) error {
 	l := w.levels[level]
 	var refDataRefs []*chunk.DataRef
 	if idx.Range != nil {
@@ -82,6 +84,7 @@ func (w *Writer) writeIndex(idx *Index, level int) error {
 		Data: &data{
 			idx:   idx,
 			level: level,
+			keys:  keys,
 		},
 	}); err != nil {
 		return err
@@ -115,10 +118,23 @@ func (w *Writer) callback(level int) chunk.WriterCallback {
 		if lw.lastIdx.Range != nil {
 			lastPath = lw.lastIdx.Range.LastPath
 		}
+		// Collect the filter keys from all of the indexes in the chunk, including
+		// the first index when it started in the previous chunk, so the filter
+		// is a superset of the paths that can be reached through the range.
+		keys := newFilterKeys()
+		for _, a := range annotations {
+			d := a.Data.(*data)
+			if level == 0 {
+				keys = keys.addPath(d.idx.Path)
+			} else {
+				keys = keys.merge(d.keys)
+			}
+		}
 		idx.Range = &Range{
 			Offset:   dataRef.OffsetBytes,
 			LastPath: lastPath,
 			ChunkRef: chunk.Reference(dataRef),
+			Filter:   keys.filter(),
 		}
 		// Set the root index when the writer is closed and we are at the top index level.
 		if w.closed {
@@ -133,7 +149,7 @@ func (w *Writer) callback(level int) chunk.WriterCallback {
 			})
 		}
 		// Write index entry in next index level.
-		return w.writeIndex(idx, level+1)
+		return w.writeIndex(idx, level+1, keys)
 	}
 }
 
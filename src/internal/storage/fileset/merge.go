@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset/index"
 	"github.com/pachyderm/pachyderm/v2/src/internal/stream"
@@ -60,12 +61,22 @@ func (mr *MergeReader) iterate(ctx context.Context, cb func(File) error) error {
 			return cb(newFileReader(mr.chunks, fss[0].file.Index()))
 		}
 		var dataRefs []*chunk.DataRef
-		for _, fs := range fss {
+		var md *index.Metadata
+		for i, fs := range fss {
 			idx := fs.file.Index()
+			// External objects can only be at the start of a file.
+			if i > 0 && idx.File.ExternalRef != nil {
+				return errors.Wrapf(ErrAppendExternal, "path %v", idx.Path)
+			}
 			dataRefs = append(dataRefs, idx.File.DataRefs...)
+			// The metadata set last replaces the metadata set before it.
+			if idx.File.Metadata != nil {
+				md = idx.File.Metadata
+			}
 		}
 		mergeIdx := fss[0].file.Index()
 		mergeIdx.File.DataRefs = dataRefs
+		mergeIdx.File.Metadata = md
 		return cb(newMergeFileReader(mr.chunks, mergeIdx))
 
 	})
@@ -107,8 +118,7 @@ func (mfr *MergeFileReader) Index() *index.Index {
 
 // Content returns the content of the merged file.
 func (mfr *MergeFileReader) Content(ctx context.Context, w io.Writer, opts ...chunk.ReaderOption) error {
-	r := mfr.chunks.NewReader(ctx, mfr.idx.File.DataRefs, opts...)
-	return r.Get(w)
+	return fileContent(ctx, mfr.chunks, mfr.idx, w, opts...)
 }
 
 // Hash returns the hash of the file.
@@ -129,7 +139,7 @@ func (mfr *MergeFileReader) Hash(ctx context.Context) ([]byte, error) {
 	if err := cw.Close(); err != nil {
 		return nil, err
 	}
-	return hashDataRefs(resolvedDataRefs)
+	return hashDataRefs(mfr.idx.File.ExternalRef, resolvedDataRefs)
 }
 
 type fileStream struct {
@@ -72,11 +72,10 @@ func (fr *FileReader) Index() *index.Index {
 
 // Content writes the content of the file.
 func (fr *FileReader) Content(ctx context.Context, w io.Writer, opts ...chunk.ReaderOption) error {
-	r := fr.chunks.NewReader(ctx, fr.idx.File.DataRefs, opts...)
-	return r.Get(w)
+	return fileContent(ctx, fr.chunks, fr.idx, w, opts...)
 }
 
 // Hash returns the hash of the file.
 func (fr *FileReader) Hash(_ context.Context) ([]byte, error) {
-	return hashDataRefs(fr.idx.File.DataRefs)
+	return hashDataRefs(fr.idx.File.ExternalRef, fr.idx.File.DataRefs)
 }
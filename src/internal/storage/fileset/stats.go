@@ -0,0 +1,47 @@
+package fileset
+
+import (
+	"context"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset/index"
+)
+
+// Stats are statistics about the layers of a file set.
+type Stats struct {
+	// Layers is the number of primitive file sets in the file set.
+	Layers int64
+	// IndexSizeBytes is the total size of the multilevel indexes of the layers.
+	IndexSizeBytes int64
+	// ReadAmplification is the number of indexes which are merged to read a
+	// file, which is an estimate of how much slower reads are than they would
+	// be for a fully compacted file set.
+	ReadAmplification int64
+}
+
+// Stats computes statistics about the layers of a file set.
+// The indexes of all of the layers are read to compute the index size.
+func (s *Storage) Stats(ctx context.Context, id ID) (*Stats, error) {
+	prims, err := s.flattenPrimitives(ctx, []ID{id})
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{Layers: int64(len(prims))}
+	for _, prim := range prims {
+		for _, topIdx := range []*index.Index{prim.Deletive, prim.Additive} {
+			if topIdx == nil {
+				continue
+			}
+			stats.ReadAmplification++
+			ir := index.NewReader(s.chunks, topIdx, index.WithRangeCallback(func(idx *index.Index) error {
+				stats.IndexSizeBytes += idx.Range.ChunkRef.SizeBytes
+				return nil
+			}))
+			if err := ir.Iterate(ctx, func(_ *index.Index) error {
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stats, nil
+}
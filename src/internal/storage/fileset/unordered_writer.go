@@ -76,6 +76,33 @@ func (uw *UnorderedWriter) Put(p, datum string, appendFile bool, r io.Reader) (r
 	}
 }
 
+// PutExternal adds a file which references an external object, replacing
+// any existing file at the path.
+func (uw *UnorderedWriter) PutExternal(p, datum string, ref *index.ExternalRef) error {
+	if err := uw.validate(p); err != nil {
+		return err
+	}
+	if datum == "" {
+		datum = DefaultFileDatum
+	}
+	uw.buffer.Delete(p, datum)
+	uw.buffer.AddExternal(p, datum, ref)
+	return nil
+}
+
+// SetMetadata sets the metadata of a file, which replaces its existing
+// metadata. The file is created if it doesn't exist.
+func (uw *UnorderedWriter) SetMetadata(p, datum string, md map[string]string) error {
+	if err := uw.validate(p); err != nil {
+		return err
+	}
+	if datum == "" {
+		datum = DefaultFileDatum
+	}
+	uw.buffer.SetMetadata(p, datum, &index.Metadata{Values: md})
+	return nil
+}
+
 func (uw *UnorderedWriter) validate(p string) error {
 	if uw.validator != nil {
 		return uw.validator(p)
@@ -90,8 +117,8 @@ func (uw *UnorderedWriter) serialize() error {
 		return nil
 	}
 	return uw.withWriter(func(w *Writer) error {
-		if err := uw.buffer.WalkAdditive(func(path, datum string, r io.Reader) error {
-			return w.Add(path, datum, r)
+		if err := uw.buffer.walkAdditive(func(path, datum string, ref *index.ExternalRef, md *index.Metadata, r io.Reader) error {
+			return w.add(path, &index.File{Datum: datum, ExternalRef: ref, Metadata: md}, r)
 		}); err != nil {
 			return err
 		}
@@ -168,6 +195,9 @@ func (uw *UnorderedWriter) Copy(ctx context.Context, fs FileSet, datum string, a
 	}
 	return uw.withWriter(func(w *Writer) error {
 		return fs.Iterate(ctx, func(f File) error {
+			if appendFile && f.Index().File.ExternalRef != nil {
+				return errors.Wrapf(ErrAppendExternal, "path %v", f.Index().Path)
+			}
 			if !appendFile {
 				if err := w.Delete(f.Index().Path, datum); err != nil {
 					return err
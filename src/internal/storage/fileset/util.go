@@ -146,8 +146,13 @@ func (i *Iterator) Next() (File, error) {
 	}
 }
 
-func hashDataRefs(dataRefs []*chunk.DataRef) ([]byte, error) {
+func hashDataRefs(ref *index.ExternalRef, dataRefs []*chunk.DataRef) ([]byte, error) {
 	h := pachhash.New()
+	if ref != nil {
+		if _, err := h.Write([]byte(externalRefKey(ref))); err != nil {
+			return nil, err
+		}
+	}
 	for _, dataRef := range dataRefs {
 		_, err := h.Write(dataRef.Hash)
 		if err != nil {
@@ -158,6 +163,9 @@ func hashDataRefs(dataRefs []*chunk.DataRef) ([]byte, error) {
 }
 
 func SizeFromIndex(idx *index.Index) (size int64) {
+	if idx.File.ExternalRef != nil {
+		size += idx.File.ExternalRef.SizeBytes
+	}
 	for _, dr := range idx.File.DataRefs {
 		size += dr.SizeBytes
 	}
@@ -50,15 +50,29 @@ func newWriter(ctx context.Context, storage *Storage, tracker track.Tracker, chu
 }
 
 func (w *Writer) Add(path, datum string, r io.Reader) error {
+	return w.AddExternal(path, datum, nil, r)
+}
+
+// AddExternal adds a file which references an external object, followed by
+// the content from r, which may be empty.
+func (w *Writer) AddExternal(path, datum string, ref *index.ExternalRef, r io.Reader) error {
+	return w.add(path, &index.File{
+		Datum:       datum,
+		ExternalRef: ref,
+	}, r)
+}
+
+func (w *Writer) add(path string, file *index.File, r io.Reader) error {
 	idx := &index.Index{
 		Path: path,
-		File: &index.File{
-			Datum: datum,
-		},
+		File: file,
 	}
 	if err := w.nextIdx(idx); err != nil {
 		return err
 	}
+	if file.ExternalRef != nil {
+		w.sizeBytes += file.ExternalRef.SizeBytes
+	}
 	n, err := io.Copy(w.cw, r)
 	w.sizeBytes += n
 	return err
@@ -108,12 +122,17 @@ func (w *Writer) Copy(file File, datum string) error {
 	copyIdx := &index.Index{
 		Path: idx.Path,
 		File: &index.File{
-			Datum: datum,
+			Datum:       datum,
+			ExternalRef: idx.File.ExternalRef,
+			Metadata:    idx.File.Metadata,
 		},
 	}
 	if err := w.nextIdx(copyIdx); err != nil {
 		return err
 	}
+	if idx.File.ExternalRef != nil {
+		w.sizeBytes += idx.File.ExternalRef.SizeBytes
+	}
 	// Copy the file data refs.
 	for _, dataRef := range idx.File.DataRefs {
 		w.sizeBytes += dataRef.SizeBytes
@@ -96,6 +96,9 @@ type extractAuthTokensFunc func(context.Context, *auth.ExtractAuthTokensRequest)
 type restoreAuthTokenFunc func(context.Context, *auth.RestoreAuthTokenRequest) (*auth.RestoreAuthTokenResponse, error)
 type deleteExpiredAuthTokensFunc func(context.Context, *auth.DeleteExpiredAuthTokensRequest) (*auth.DeleteExpiredAuthTokensResponse, error)
 type RotateRootTokenFunc func(context.Context, *auth.RotateRootTokenRequest) (*auth.RotateRootTokenResponse, error)
+type createS3CredentialsFunc func(context.Context, *auth.CreateS3CredentialsRequest) (*auth.CreateS3CredentialsResponse, error)
+type listS3CredentialsFunc func(context.Context, *auth.ListS3CredentialsRequest) (*auth.ListS3CredentialsResponse, error)
+type revokeS3CredentialsFunc func(context.Context, *auth.RevokeS3CredentialsRequest) (*auth.RevokeS3CredentialsResponse, error)
 
 type mockActivateAuth struct{ handler activateAuthFunc }
 type mockDeactivateAuth struct{ handler deactivateAuthFunc }
@@ -125,6 +128,9 @@ type mockExtractAuthTokens struct{ handler extractAuthTokensFunc }
 type mockRestoreAuthToken struct{ handler restoreAuthTokenFunc }
 type mockDeleteExpiredAuthTokens struct{ handler deleteExpiredAuthTokensFunc }
 type mockRotateRootToken struct{ handler RotateRootTokenFunc }
+type mockCreateS3Credentials struct{ handler createS3CredentialsFunc }
+type mockListS3Credentials struct{ handler listS3CredentialsFunc }
+type mockRevokeS3Credentials struct{ handler revokeS3CredentialsFunc }
 
 func (mock *mockActivateAuth) Use(cb activateAuthFunc)                             { mock.handler = cb }
 func (mock *mockDeactivateAuth) Use(cb deactivateAuthFunc)                         { mock.handler = cb }
@@ -151,6 +157,9 @@ func (mock *mockExtractAuthTokens) Use(cb extractAuthTokensFunc)
 func (mock *mockRestoreAuthToken) Use(cb restoreAuthTokenFunc)                     { mock.handler = cb }
 func (mock *mockDeleteExpiredAuthTokens) Use(cb deleteExpiredAuthTokensFunc)       { mock.handler = cb }
 func (mock *mockRotateRootToken) Use(cb RotateRootTokenFunc)                       { mock.handler = cb }
+func (mock *mockCreateS3Credentials) Use(cb createS3CredentialsFunc)               { mock.handler = cb }
+func (mock *mockListS3Credentials) Use(cb listS3CredentialsFunc)                   { mock.handler = cb }
+func (mock *mockRevokeS3Credentials) Use(cb revokeS3CredentialsFunc)               { mock.handler = cb }
 
 type authServerAPI struct {
 	mock *mockAuthServer
@@ -183,6 +192,9 @@ type mockAuthServer struct {
 	RestoreAuthToken           mockRestoreAuthToken
 	DeleteExpiredAuthTokens    mockDeleteExpiredAuthTokens
 	RotateRootToken            mockRotateRootToken
+	CreateS3Credentials        mockCreateS3Credentials
+	ListS3Credentials          mockListS3Credentials
+	RevokeS3Credentials        mockRevokeS3Credentials
 }
 
 func (api *authServerAPI) Activate(ctx context.Context, req *auth.ActivateRequest) (*auth.ActivateResponse, error) {
@@ -339,6 +351,24 @@ func (api *authServerAPI) RotateRootToken(ctx context.Context, req *auth.RotateR
 	}
 	return nil, errors.Errorf("unhandled pachd mock auth.RotateRootToken")
 }
+func (api *authServerAPI) CreateS3Credentials(ctx context.Context, req *auth.CreateS3CredentialsRequest) (*auth.CreateS3CredentialsResponse, error) {
+	if api.mock.CreateS3Credentials.handler != nil {
+		return api.mock.CreateS3Credentials.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock auth.CreateS3Credentials")
+}
+func (api *authServerAPI) ListS3Credentials(ctx context.Context, req *auth.ListS3CredentialsRequest) (*auth.ListS3CredentialsResponse, error) {
+	if api.mock.ListS3Credentials.handler != nil {
+		return api.mock.ListS3Credentials.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock auth.ListS3Credentials")
+}
+func (api *authServerAPI) RevokeS3Credentials(ctx context.Context, req *auth.RevokeS3CredentialsRequest) (*auth.RevokeS3CredentialsResponse, error) {
+	if api.mock.RevokeS3Credentials.handler != nil {
+		return api.mock.RevokeS3Credentials.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock auth.RevokeS3Credentials")
+}
 
 /* Enterprise Server Mocks */
 
@@ -443,6 +473,17 @@ type composeFileSetFunc func(context.Context, *pfs.ComposeFileSetRequest) (*pfs.
 type runLoadTestFunc func(context.Context, *pfs.RunLoadTestRequest) (*pfs.RunLoadTestResponse, error)
 type runLoadTestDefaultFunc func(context.Context, *types.Empty) (*pfs.RunLoadTestResponse, error)
 type checkStorageFunc func(context.Context, *pfs.CheckStorageRequest) (*pfs.CheckStorageResponse, error)
+type reclaimOrphanedObjectsFunc func(*pfs.ReclaimOrphanedObjectsRequest, pfs.API_ReclaimOrphanedObjectsServer) error
+type materializeCommitFunc func(context.Context, *pfs.MaterializeCommitRequest) (*types.Empty, error)
+type compactCommitFunc func(context.Context, *pfs.CompactCommitRequest) (*types.Empty, error)
+type diskUsageFunc func(*pfs.DiskUsageRequest, pfs.API_DiskUsageServer) error
+type repoDiskUsageFunc func(context.Context, *pfs.RepoDiskUsageRequest) (*pfs.RepoDiskUsageResponse, error)
+type subscribeFileFunc func(*pfs.SubscribeFileRequest, pfs.API_SubscribeFileServer) error
+type listReflogFunc func(*pfs.ListReflogRequest, pfs.API_ListReflogServer) error
+type resetBranchFunc func(context.Context, *pfs.ResetBranchRequest) (*types.Empty, error)
+type verifyCommitFunc func(*pfs.VerifyCommitRequest, pfs.API_VerifyCommitServer) error
+type attestCommitFunc func(context.Context, *pfs.AttestCommitRequest) (*pfs.CommitAttestation, error)
+type checkDeleteAllFunc func(context.Context, *types.Empty) (*types.Empty, error)
 
 type mockActivateAuthPFS struct{ handler activateAuthPFSFunc }
 type mockCreateRepo struct{ handler createRepoFunc }
@@ -481,88 +522,121 @@ type mockComposeFileSet struct{ handler composeFileSetFunc }
 type mockRunLoadTest struct{ handler runLoadTestFunc }
 type mockRunLoadTestDefault struct{ handler runLoadTestDefaultFunc }
 type mockCheckStorage struct{ handler checkStorageFunc }
-
-func (mock *mockActivateAuthPFS) Use(cb activateAuthPFSFunc)       { mock.handler = cb }
-func (mock *mockCreateRepo) Use(cb createRepoFunc)                 { mock.handler = cb }
-func (mock *mockInspectRepo) Use(cb inspectRepoFunc)               { mock.handler = cb }
-func (mock *mockListRepo) Use(cb listRepoFunc)                     { mock.handler = cb }
-func (mock *mockDeleteRepo) Use(cb deleteRepoFunc)                 { mock.handler = cb }
-func (mock *mockStartCommit) Use(cb startCommitFunc)               { mock.handler = cb }
-func (mock *mockFinishCommit) Use(cb finishCommitFunc)             { mock.handler = cb }
-func (mock *mockInspectCommit) Use(cb inspectCommitFunc)           { mock.handler = cb }
-func (mock *mockListCommit) Use(cb listCommitFunc)                 { mock.handler = cb }
-func (mock *mockSubscribeCommit) Use(cb subscribeCommitFunc)       { mock.handler = cb }
-func (mock *mockClearCommit) Use(cb clearCommitFunc)               { mock.handler = cb }
-func (mock *mockSquashCommitSet) Use(cb squashCommitSetFunc)       { mock.handler = cb }
-func (mock *mockDropCommitSet) Use(cb dropCommitSetFunc)           { mock.handler = cb }
-func (mock *mockInspectCommitSet) Use(cb inspectCommitSetFunc)     { mock.handler = cb }
-func (mock *mockListCommitSet) Use(cb listCommitSetFunc)           { mock.handler = cb }
-func (mock *mockCreateBranch) Use(cb createBranchFunc)             { mock.handler = cb }
-func (mock *mockInspectBranch) Use(cb inspectBranchFunc)           { mock.handler = cb }
-func (mock *mockListBranch) Use(cb listBranchFunc)                 { mock.handler = cb }
-func (mock *mockDeleteBranch) Use(cb deleteBranchFunc)             { mock.handler = cb }
-func (mock *mockModifyFile) Use(cb modifyFileFunc)                 { mock.handler = cb }
-func (mock *mockGetFile) Use(cb getFileFunc)                       { mock.handler = cb }
-func (mock *mockGetFileTAR) Use(cb getFileTARFunc)                 { mock.handler = cb }
-func (mock *mockInspectFile) Use(cb inspectFileFunc)               { mock.handler = cb }
-func (mock *mockListFile) Use(cb listFileFunc)                     { mock.handler = cb }
-func (mock *mockWalkFile) Use(cb walkFileFunc)                     { mock.handler = cb }
-func (mock *mockGlobFile) Use(cb globFileFunc)                     { mock.handler = cb }
-func (mock *mockDiffFile) Use(cb diffFileFunc)                     { mock.handler = cb }
-func (mock *mockDeleteAllPFS) Use(cb deleteAllPFSFunc)             { mock.handler = cb }
-func (mock *mockFsck) Use(cb fsckFunc)                             { mock.handler = cb }
-func (mock *mockCreateFileSet) Use(cb createFileSetFunc)           { mock.handler = cb }
-func (mock *mockAddFileSet) Use(cb addFileSetFunc)                 { mock.handler = cb }
-func (mock *mockGetFileSet) Use(cb getFileSetFunc)                 { mock.handler = cb }
-func (mock *mockRenewFileSet) Use(cb renewFileSetFunc)             { mock.handler = cb }
-func (mock *mockComposeFileSet) Use(cb composeFileSetFunc)         { mock.handler = cb }
-func (mock *mockRunLoadTest) Use(cb runLoadTestFunc)               { mock.handler = cb }
-func (mock *mockRunLoadTestDefault) Use(cb runLoadTestDefaultFunc) { mock.handler = cb }
-func (mock *mockCheckStorage) Use(cb checkStorageFunc)             { mock.handler = cb }
+type mockReclaimOrphanedObjects struct{ handler reclaimOrphanedObjectsFunc }
+type mockMaterializeCommit struct{ handler materializeCommitFunc }
+type mockCompactCommit struct{ handler compactCommitFunc }
+type mockDiskUsage struct{ handler diskUsageFunc }
+type mockRepoDiskUsage struct{ handler repoDiskUsageFunc }
+type mockSubscribeFile struct{ handler subscribeFileFunc }
+type mockListReflog struct{ handler listReflogFunc }
+type mockResetBranch struct{ handler resetBranchFunc }
+type mockVerifyCommit struct{ handler verifyCommitFunc }
+type mockAttestCommit struct{ handler attestCommitFunc }
+type mockCheckDeleteAll struct{ handler checkDeleteAllFunc }
+
+func (mock *mockActivateAuthPFS) Use(cb activateAuthPFSFunc)               { mock.handler = cb }
+func (mock *mockCreateRepo) Use(cb createRepoFunc)                         { mock.handler = cb }
+func (mock *mockInspectRepo) Use(cb inspectRepoFunc)                       { mock.handler = cb }
+func (mock *mockListRepo) Use(cb listRepoFunc)                             { mock.handler = cb }
+func (mock *mockDeleteRepo) Use(cb deleteRepoFunc)                         { mock.handler = cb }
+func (mock *mockStartCommit) Use(cb startCommitFunc)                       { mock.handler = cb }
+func (mock *mockFinishCommit) Use(cb finishCommitFunc)                     { mock.handler = cb }
+func (mock *mockInspectCommit) Use(cb inspectCommitFunc)                   { mock.handler = cb }
+func (mock *mockListCommit) Use(cb listCommitFunc)                         { mock.handler = cb }
+func (mock *mockSubscribeCommit) Use(cb subscribeCommitFunc)               { mock.handler = cb }
+func (mock *mockClearCommit) Use(cb clearCommitFunc)                       { mock.handler = cb }
+func (mock *mockSquashCommitSet) Use(cb squashCommitSetFunc)               { mock.handler = cb }
+func (mock *mockDropCommitSet) Use(cb dropCommitSetFunc)                   { mock.handler = cb }
+func (mock *mockInspectCommitSet) Use(cb inspectCommitSetFunc)             { mock.handler = cb }
+func (mock *mockListCommitSet) Use(cb listCommitSetFunc)                   { mock.handler = cb }
+func (mock *mockCreateBranch) Use(cb createBranchFunc)                     { mock.handler = cb }
+func (mock *mockInspectBranch) Use(cb inspectBranchFunc)                   { mock.handler = cb }
+func (mock *mockListBranch) Use(cb listBranchFunc)                         { mock.handler = cb }
+func (mock *mockDeleteBranch) Use(cb deleteBranchFunc)                     { mock.handler = cb }
+func (mock *mockModifyFile) Use(cb modifyFileFunc)                         { mock.handler = cb }
+func (mock *mockGetFile) Use(cb getFileFunc)                               { mock.handler = cb }
+func (mock *mockGetFileTAR) Use(cb getFileTARFunc)                         { mock.handler = cb }
+func (mock *mockInspectFile) Use(cb inspectFileFunc)                       { mock.handler = cb }
+func (mock *mockListFile) Use(cb listFileFunc)                             { mock.handler = cb }
+func (mock *mockWalkFile) Use(cb walkFileFunc)                             { mock.handler = cb }
+func (mock *mockGlobFile) Use(cb globFileFunc)                             { mock.handler = cb }
+func (mock *mockDiffFile) Use(cb diffFileFunc)                             { mock.handler = cb }
+func (mock *mockDeleteAllPFS) Use(cb deleteAllPFSFunc)                     { mock.handler = cb }
+func (mock *mockFsck) Use(cb fsckFunc)                                     { mock.handler = cb }
+func (mock *mockCreateFileSet) Use(cb createFileSetFunc)                   { mock.handler = cb }
+func (mock *mockAddFileSet) Use(cb addFileSetFunc)                         { mock.handler = cb }
+func (mock *mockGetFileSet) Use(cb getFileSetFunc)                         { mock.handler = cb }
+func (mock *mockRenewFileSet) Use(cb renewFileSetFunc)                     { mock.handler = cb }
+func (mock *mockComposeFileSet) Use(cb composeFileSetFunc)                 { mock.handler = cb }
+func (mock *mockRunLoadTest) Use(cb runLoadTestFunc)                       { mock.handler = cb }
+func (mock *mockRunLoadTestDefault) Use(cb runLoadTestDefaultFunc)         { mock.handler = cb }
+func (mock *mockCheckStorage) Use(cb checkStorageFunc)                     { mock.handler = cb }
+func (mock *mockReclaimOrphanedObjects) Use(cb reclaimOrphanedObjectsFunc) { mock.handler = cb }
+func (mock *mockMaterializeCommit) Use(cb materializeCommitFunc)           { mock.handler = cb }
+func (mock *mockCompactCommit) Use(cb compactCommitFunc)                   { mock.handler = cb }
+func (mock *mockDiskUsage) Use(cb diskUsageFunc)                           { mock.handler = cb }
+func (mock *mockRepoDiskUsage) Use(cb repoDiskUsageFunc)                   { mock.handler = cb }
+func (mock *mockSubscribeFile) Use(cb subscribeFileFunc)                   { mock.handler = cb }
+func (mock *mockListReflog) Use(cb listReflogFunc)                         { mock.handler = cb }
+func (mock *mockResetBranch) Use(cb resetBranchFunc)                       { mock.handler = cb }
+func (mock *mockVerifyCommit) Use(cb verifyCommitFunc)                     { mock.handler = cb }
+func (mock *mockAttestCommit) Use(cb attestCommitFunc)                     { mock.handler = cb }
+func (mock *mockCheckDeleteAll) Use(cb checkDeleteAllFunc) { mock.handler = cb }
 
 type pfsServerAPI struct {
 	mock *mockPFSServer
 }
 
 type mockPFSServer struct {
-	api                pfsServerAPI
-	ActivateAuth       mockActivateAuthPFS
-	CreateRepo         mockCreateRepo
-	InspectRepo        mockInspectRepo
-	ListRepo           mockListRepo
-	DeleteRepo         mockDeleteRepo
-	StartCommit        mockStartCommit
-	FinishCommit       mockFinishCommit
-	InspectCommit      mockInspectCommit
-	ListCommit         mockListCommit
-	SubscribeCommit    mockSubscribeCommit
-	ClearCommit        mockClearCommit
-	SquashCommitSet    mockSquashCommitSet
-	DropCommitSet      mockDropCommitSet
-	InspectCommitSet   mockInspectCommitSet
-	ListCommitSet      mockListCommitSet
-	CreateBranch       mockCreateBranch
-	InspectBranch      mockInspectBranch
-	ListBranch         mockListBranch
-	DeleteBranch       mockDeleteBranch
-	ModifyFile         mockModifyFile
-	GetFile            mockGetFile
-	GetFileTAR         mockGetFileTAR
-	InspectFile        mockInspectFile
-	ListFile           mockListFile
-	WalkFile           mockWalkFile
-	GlobFile           mockGlobFile
-	DiffFile           mockDiffFile
-	DeleteAll          mockDeleteAllPFS
-	Fsck               mockFsck
-	CreateFileSet      mockCreateFileSet
-	AddFileSet         mockAddFileSet
-	GetFileSet         mockGetFileSet
-	RenewFileSet       mockRenewFileSet
-	ComposeFileSet     mockComposeFileSet
-	RunLoadTest        mockRunLoadTest
-	RunLoadTestDefault mockRunLoadTestDefault
-	CheckStorage       mockCheckStorage
+	api                    pfsServerAPI
+	ActivateAuth           mockActivateAuthPFS
+	CreateRepo             mockCreateRepo
+	InspectRepo            mockInspectRepo
+	ListRepo               mockListRepo
+	DeleteRepo             mockDeleteRepo
+	StartCommit            mockStartCommit
+	FinishCommit           mockFinishCommit
+	InspectCommit          mockInspectCommit
+	ListCommit             mockListCommit
+	SubscribeCommit        mockSubscribeCommit
+	ClearCommit            mockClearCommit
+	SquashCommitSet        mockSquashCommitSet
+	DropCommitSet          mockDropCommitSet
+	InspectCommitSet       mockInspectCommitSet
+	ListCommitSet          mockListCommitSet
+	CreateBranch           mockCreateBranch
+	InspectBranch          mockInspectBranch
+	ListBranch             mockListBranch
+	DeleteBranch           mockDeleteBranch
+	ModifyFile             mockModifyFile
+	GetFile                mockGetFile
+	GetFileTAR             mockGetFileTAR
+	InspectFile            mockInspectFile
+	ListFile               mockListFile
+	WalkFile               mockWalkFile
+	GlobFile               mockGlobFile
+	DiffFile               mockDiffFile
+	DeleteAll              mockDeleteAllPFS
+	Fsck                   mockFsck
+	CreateFileSet          mockCreateFileSet
+	AddFileSet             mockAddFileSet
+	GetFileSet             mockGetFileSet
+	RenewFileSet           mockRenewFileSet
+	ComposeFileSet         mockComposeFileSet
+	RunLoadTest            mockRunLoadTest
+	RunLoadTestDefault     mockRunLoadTestDefault
+	CheckStorage           mockCheckStorage
+	ReclaimOrphanedObjects mockReclaimOrphanedObjects
+	MaterializeCommit      mockMaterializeCommit
+	CompactCommit          mockCompactCommit
+	DiskUsage              mockDiskUsage
+	RepoDiskUsage          mockRepoDiskUsage
+	SubscribeFile          mockSubscribeFile
+	ListReflog             mockListReflog
+	ResetBranch            mockResetBranch
+	VerifyCommit           mockVerifyCommit
+	AttestCommit           mockAttestCommit
+	CheckDeleteAll mockCheckDeleteAll
 }
 
 func (api *pfsServerAPI) ActivateAuth(ctx context.Context, req *pfs.ActivateAuthRequest) (*pfs.ActivateAuthResponse, error) {
@@ -787,6 +861,72 @@ func (api *pfsServerAPI) CheckStorage(ctx context.Context, req *pfs.CheckStorage
 	}
 	return nil, errors.Errorf("unhandled pachd mock CheckStorage")
 }
+func (api *pfsServerAPI) ReclaimOrphanedObjects(req *pfs.ReclaimOrphanedObjectsRequest, serv pfs.API_ReclaimOrphanedObjectsServer) error {
+	if api.mock.ReclaimOrphanedObjects.handler != nil {
+		return api.mock.ReclaimOrphanedObjects.handler(req, serv)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.ReclaimOrphanedObjects")
+}
+func (api *pfsServerAPI) MaterializeCommit(ctx context.Context, req *pfs.MaterializeCommitRequest) (*types.Empty, error) {
+	if api.mock.MaterializeCommit.handler != nil {
+		return api.mock.MaterializeCommit.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.MaterializeCommit")
+}
+func (api *pfsServerAPI) CompactCommit(ctx context.Context, req *pfs.CompactCommitRequest) (*types.Empty, error) {
+	if api.mock.CompactCommit.handler != nil {
+		return api.mock.CompactCommit.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.CompactCommit")
+}
+func (api *pfsServerAPI) DiskUsage(req *pfs.DiskUsageRequest, serv pfs.API_DiskUsageServer) error {
+	if api.mock.DiskUsage.handler != nil {
+		return api.mock.DiskUsage.handler(req, serv)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.DiskUsage")
+}
+func (api *pfsServerAPI) RepoDiskUsage(ctx context.Context, req *pfs.RepoDiskUsageRequest) (*pfs.RepoDiskUsageResponse, error) {
+	if api.mock.RepoDiskUsage.handler != nil {
+		return api.mock.RepoDiskUsage.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.RepoDiskUsage")
+}
+func (api *pfsServerAPI) SubscribeFile(req *pfs.SubscribeFileRequest, serv pfs.API_SubscribeFileServer) error {
+	if api.mock.SubscribeFile.handler != nil {
+		return api.mock.SubscribeFile.handler(req, serv)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.SubscribeFile")
+}
+func (api *pfsServerAPI) ListReflog(req *pfs.ListReflogRequest, serv pfs.API_ListReflogServer) error {
+	if api.mock.ListReflog.handler != nil {
+		return api.mock.ListReflog.handler(req, serv)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.ListReflog")
+}
+func (api *pfsServerAPI) ResetBranch(ctx context.Context, req *pfs.ResetBranchRequest) (*types.Empty, error) {
+	if api.mock.ResetBranch.handler != nil {
+		return api.mock.ResetBranch.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.ResetBranch")
+}
+func (api *pfsServerAPI) VerifyCommit(req *pfs.VerifyCommitRequest, serv pfs.API_VerifyCommitServer) error {
+	if api.mock.VerifyCommit.handler != nil {
+		return api.mock.VerifyCommit.handler(req, serv)
+	}
+	return errors.Errorf("unhandled pachd mock pfs.VerifyCommit")
+}
+func (api *pfsServerAPI) AttestCommit(ctx context.Context, req *pfs.AttestCommitRequest) (*pfs.CommitAttestation, error) {
+	if api.mock.AttestCommit.handler != nil {
+		return api.mock.AttestCommit.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.AttestCommit")
+}
+func (api *pfsServerAPI) CheckDeleteAll(ctx context.Context, req *types.Empty) (*types.Empty, error) {
+	if api.mock.CheckDeleteAll.handler != nil {
+		return api.mock.CheckDeleteAll.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pfs.CheckDeleteAll")
+}
 
 /* PPS Server Mocks */
 
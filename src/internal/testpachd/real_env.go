@@ -134,4 +134,6 @@ func DefaultConfigOptions(config *serviceenv.Configuration) {
 	config.StorageGCPolling = "30s"
 	config.StorageCompactionMaxFanIn = 10
 	config.StorageMemoryCacheSize = 20
+	config.PFSAttestationSecret = "test"
+	config.AuthS3CredentialSecret = "test"
 }
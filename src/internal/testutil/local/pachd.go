@@ -381,7 +381,7 @@ func RunLocal() (retErr error) {
 	go waitForError("S3 Server", errChan, requireNoncriticalServers, func() error {
 		router := s3.Router(s3.NewMasterDriver(), func() (*client.APIClient, error) {
 			return client.NewFromURI(fmt.Sprintf("localhost:%d", env.Config().PeerPort))
-		})
+		}, env.GetDBClient(), s3.WithCredentialLookup(env.AuthServer()))
 		server := s3.Server(env.Config().S3GatewayPort, router)
 
 		if err != nil {
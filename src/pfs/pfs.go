@@ -1,11 +1,14 @@
 package pfs
 
 import (
+	"bytes"
+	"crypto/ed25519"
 	"encoding/hex"
 	"hash"
 
 	"github.com/gogo/protobuf/proto"
 
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachhash"
 )
 
@@ -34,6 +37,48 @@ func DecodeHash(hash string) ([]byte, error) {
 	return hex.DecodeString(hash)
 }
 
+// ChainHash returns the root hash of a commit from the root hash of its
+// parent, which is empty for a commit without a parent, and the hash of its
+// content.
+func ChainHash(parent, content []byte) []byte {
+	h := NewHash()
+	h.Write(parent)
+	h.Write(content)
+	return h.Sum(nil)
+}
+
+// SignedBytes returns the bytes of the attestation which are signed, the
+// attestation serialized with an empty signature.
+func (a *CommitAttestation) SignedBytes() ([]byte, error) {
+	unsigned := proto.Clone(a).(*CommitAttestation)
+	unsigned.Signature = nil
+	data, err := proto.Marshal(unsigned)
+	return data, errors.EnsureStack(err)
+}
+
+// Verify checks that the attestation is signed by its public key and that
+// its root hash is consistent with its parent and content hashes. Callers
+// should also check that the public key is the one of the expected cluster.
+func (a *CommitAttestation) Verify() error {
+	if a.Hash == nil {
+		return errors.Errorf("attestation has no commit hash")
+	}
+	if !bytes.Equal(a.Hash.Root, ChainHash(a.Hash.Parent, a.Hash.Content)) {
+		return errors.Errorf("attestation root hash does not match its parent and content hashes")
+	}
+	if len(a.PublicKey) != ed25519.PublicKeySize {
+		return errors.Errorf("attestation public key has invalid size %d", len(a.PublicKey))
+	}
+	data, err := a.SignedBytes()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(a.PublicKey), data, a.Signature) {
+		return errors.Errorf("attestation signature is invalid")
+	}
+	return nil
+}
+
 func (r *Repo) String() string {
 	if r.Type == UserRepoType {
 		return r.Name
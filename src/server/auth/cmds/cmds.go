@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/v2/src/auth"
 	"github.com/pachyderm/pachyderm/v2/src/client"
 	"github.com/pachyderm/pachyderm/v2/src/identity"
@@ -459,6 +460,124 @@ func GetRobotTokenCmd() *cobra.Command {
 	return cmdutil.CreateAlias(getAuthToken, "auth get-robot-token")
 }
 
+// CreateS3CredentialsCmd returns a cobra command that creates an access key
+// and secret key pair for the S3 gateway on behalf of a user
+func CreateS3CredentialsCmd() *cobra.Command {
+	var principal string
+	var readOnly bool
+	var buckets []string
+	var ttl string
+	createS3Credentials := &cobra.Command{
+		Use:   "{{alias}}",
+		Short: "Create S3 credentials for the S3 gateway.",
+		Long: "Create an access key ID and secret access key for the S3 gateway. " +
+			"The credentials act as the current user, or as --principal, and can be " +
+			"limited to reads and to a set of buckets.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := newClient(false)
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+
+			req := &auth.CreateS3CredentialsRequest{
+				Principal: principal,
+				ReadOnly:  readOnly,
+				Buckets:   buckets,
+			}
+			if ttl != "" {
+				d, err := time.ParseDuration(ttl)
+				if err != nil {
+					return errors.Wrapf(err, "could not parse duration %q", ttl)
+				}
+				req.TTL = int64(d.Seconds())
+			}
+			resp, err := c.CreateS3Credentials(c.Ctx(), req)
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			fmt.Printf("Access key ID: %s\n", resp.Credential.AccessKeyID)
+			fmt.Printf("Secret access key: %s\n", resp.SecretAccessKey)
+			return nil
+		}),
+	}
+	createS3Credentials.PersistentFlags().StringVar(&principal, "principal", "", "The principal "+
+		"the credentials act as. If not set, the credentials act as the current user.")
+	createS3Credentials.PersistentFlags().BoolVar(&readOnly, "read-only", false, "Only allow reads with the credentials.")
+	createS3Credentials.PersistentFlags().StringSliceVar(&buckets, "bucket", nil, "Only allow access "+
+		"to the given bucket with the credentials, can be repeated.")
+	createS3Credentials.PersistentFlags().StringVar(&ttl, "ttl", "", "if set, the "+
+		"credentials will have the given lifetime. If not set, the credentials expire along with the current session."+
+		" This flag should be a golang duration (e.g. \"30s\" or \"1h2m3s\").")
+	return cmdutil.CreateAlias(createS3Credentials, "auth create-s3-credentials")
+}
+
+// ListS3CredentialsCmd returns a cobra command that lists the S3 credentials
+// of a user
+func ListS3CredentialsCmd() *cobra.Command {
+	var principal string
+	listS3Credentials := &cobra.Command{
+		Use:   "{{alias}}",
+		Short: "List the S3 credentials of a user.",
+		Long:  "List the S3 credentials of the current user, or of --principal.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := newClient(false)
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+
+			resp, err := c.ListS3Credentials(c.Ctx(), &auth.ListS3CredentialsRequest{Principal: principal})
+			if err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			for _, cred := range resp.Credentials {
+				scope := "read-write"
+				if cred.ReadOnly {
+					scope = "read-only"
+				}
+				buckets := "*"
+				if len(cred.Buckets) > 0 {
+					buckets = strings.Join(cred.Buckets, ",")
+				}
+				expiration := "never"
+				if cred.Expiration != nil {
+					t, err := types.TimestampFromProto(cred.Expiration)
+					if err != nil {
+						return errors.EnsureStack(err)
+					}
+					expiration = t.Format(time.RFC822)
+				}
+				fmt.Printf("%s\t%s\t%s\texpires: %s\n", cred.AccessKeyID, scope, buckets, expiration)
+			}
+			return nil
+		}),
+	}
+	listS3Credentials.PersistentFlags().StringVar(&principal, "principal", "", "The principal "+
+		"to list the credentials of. If not set, the credentials of the current user are listed.")
+	return cmdutil.CreateAlias(listS3Credentials, "auth list-s3-credentials")
+}
+
+// RevokeS3CredentialsCmd returns a cobra command that revokes S3 credentials
+func RevokeS3CredentialsCmd() *cobra.Command {
+	revokeS3Credentials := &cobra.Command{
+		Use:   "{{alias}} <access-key-id>",
+		Short: "Revoke S3 credentials.",
+		Long:  "Revoke the S3 credentials with the given access key ID.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := newClient(false)
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+
+			_, err = c.RevokeS3Credentials(c.Ctx(), &auth.RevokeS3CredentialsRequest{AccessKeyID: args[0]})
+			return grpcutil.ScrubGRPC(err)
+		}),
+	}
+	return cmdutil.CreateAlias(revokeS3Credentials, "auth revoke-s3-credentials")
+}
+
 func GetGroupsCmd() *cobra.Command {
 	var enterprise bool
 	getGroups := &cobra.Command{
@@ -806,6 +925,9 @@ func Cmds() []*cobra.Command {
 	commands = append(commands, LogoutCmd())
 	commands = append(commands, WhoamiCmd())
 	commands = append(commands, GetRobotTokenCmd())
+	commands = append(commands, CreateS3CredentialsCmd())
+	commands = append(commands, ListS3CredentialsCmd())
+	commands = append(commands, RevokeS3CredentialsCmd())
 	commands = append(commands, UseAuthTokenCmd())
 	commands = append(commands, GetConfigCmd())
 	commands = append(commands, SetConfigCmd())
@@ -20,3 +20,25 @@ ON auth.auth_tokens (subject);
 `)
 	return err
 }
+
+// CreateS3CredentialsTable sets up the postgres table which stores the S3
+// credentials issued for the S3 gateway. Secret access keys are stored
+// encrypted, and session tokens hashed, as in auth_tokens.
+func CreateS3CredentialsTable(ctx context.Context, tx *pachsql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS auth.s3_credentials (
+	access_key_id VARCHAR(64) PRIMARY KEY,
+	encrypted_secret_access_key BYTEA NOT NULL,
+	session_token_hash VARCHAR(4096) NOT NULL,
+	principal VARCHAR(64) NOT NULL,
+	read_only BOOLEAN NOT NULL,
+	buckets TEXT NOT NULL,
+	expiration TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX s3_credentials_principal_index
+ON auth.s3_credentials (principal);
+`)
+	return err
+}
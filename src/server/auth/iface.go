@@ -37,5 +37,9 @@ type APIServer interface {
 	GetPipelineAuthTokenInTransaction(*txncontext.TransactionContext, string) (string, error)
 	RevokeAuthTokenInTransaction(*txncontext.TransactionContext, *auth_client.RevokeAuthTokenRequest) (*auth_client.RevokeAuthTokenResponse, error)
 
+	// LookupS3Credential is an internal API used by the S3 gateway to resolve
+	// an access key ID to its credential and secrets
+	LookupS3Credential(context.Context, string) (*auth_client.S3Credential, *auth_client.S3CredentialSecrets, error)
+
 	GetPermissionsInTransaction(*txncontext.TransactionContext, *auth_client.GetPermissionsRequest) (*auth_client.GetPermissionsResponse, error)
 }
@@ -82,6 +82,10 @@ type apiServer struct {
 	// direct access to a repo anyways, so the cluster role bindings don't affect their access,
 	// and the OIDC server doesn't run in the sidecar so the config doesn't matter.
 	watchesEnabled bool
+
+	// s3CredentialKey encrypts the secret access keys of S3 credentials, it
+	// is nil if the cluster has no S3 credential secret.
+	s3CredentialKey []byte
 }
 
 // LogReq is like log.Logger.Log(), but it assumes that it's being called from
@@ -118,15 +122,16 @@ func NewAuthServer(env Env, public, requireNoncriticalServers, watchesEnabled bo
 		nil,
 	)
 	s := &apiServer{
-		env:            env,
-		log:            log.NewLogger("auth.API", env.Logger), // TODO this should be configured in the env
-		authConfig:     authConfigCollection(env.DB, env.Listener),
-		roleBindings:   roleBindingsCollection(env.DB, env.Listener),
-		members:        membersCollection(env.DB, env.Listener),
-		groups:         groupsCollection(env.DB, env.Listener),
-		oidcStates:     oidcStates,
-		public:         public,
-		watchesEnabled: watchesEnabled,
+		env:             env,
+		log:             log.NewLogger("auth.API", env.Logger), // TODO this should be configured in the env
+		authConfig:      authConfigCollection(env.DB, env.Listener),
+		roleBindings:    roleBindingsCollection(env.DB, env.Listener),
+		members:         membersCollection(env.DB, env.Listener),
+		groups:          groupsCollection(env.DB, env.Listener),
+		oidcStates:      oidcStates,
+		public:          public,
+		watchesEnabled:  watchesEnabled,
+		s3CredentialKey: s3CredentialKey(env.Config.AuthS3CredentialSecret),
 	}
 
 	if public {
@@ -378,6 +383,7 @@ func (a *apiServer) Deactivate(ctx context.Context, req *auth.DeactivateRequest)
 	if err := dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
 		a.roleBindings.ReadWrite(sqlTx).DeleteAll()
 		a.deleteAllAuthTokens(ctx, sqlTx)
+		a.deleteAllS3Credentials(sqlTx)
 		a.members.ReadWrite(sqlTx).DeleteAll()
 		a.groups.ReadWrite(sqlTx).DeleteAll()
 		a.authConfig.ReadWrite(sqlTx).DeleteAll()
@@ -1473,6 +1479,9 @@ func (a *apiServer) DeleteExpiredAuthTokens(ctx context.Context, req *auth.Delet
 	if _, err := a.env.DB.Exec(`DELETE FROM auth.auth_tokens WHERE NOW() > expiration`); err != nil {
 		return nil, errors.Wrapf(err, "error deleting expired tokens")
 	}
+	if err := a.deleteExpiredS3Credentials(ctx); err != nil {
+		return nil, err
+	}
 	return &auth.DeleteExpiredAuthTokensResponse{}, nil
 }
 
@@ -0,0 +1,346 @@
+package server
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+)
+
+// s3AccessKeyPrefix starts the access key IDs issued by pachyderm, so they can
+// be told apart from auth tokens used as access keys.
+const s3AccessKeyPrefix = "PACH"
+
+type s3CredentialRow struct {
+	AccessKeyID              string     `db:"access_key_id"`
+	EncryptedSecretAccessKey []byte     `db:"encrypted_secret_access_key"`
+	SessionTokenHash         string     `db:"session_token_hash"`
+	Principal                string     `db:"principal"`
+	ReadOnly                 bool       `db:"read_only"`
+	Buckets                  string     `db:"buckets"`
+	Expiration               *time.Time `db:"expiration"`
+	CreatedAt                time.Time  `db:"created_at"`
+}
+
+func (row *s3CredentialRow) credential() (*auth.S3Credential, error) {
+	cred := &auth.S3Credential{
+		AccessKeyID: row.AccessKeyID,
+		Principal:   row.Principal,
+		ReadOnly:    row.ReadOnly,
+	}
+	if row.Buckets != "" {
+		cred.Buckets = strings.Split(row.Buckets, ",")
+	}
+	var err error
+	if cred.Created, err = types.TimestampProto(row.CreatedAt); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if row.Expiration != nil {
+		if cred.Expiration, err = types.TimestampProto(*row.Expiration); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	return cred, nil
+}
+
+// s3CredentialKey derives the key which encrypts the secret access keys of S3
+// credentials from the cluster's S3 credential secret, so that the key is
+// never stored. It returns nil if there is no secret.
+func s3CredentialKey(secret string) []byte {
+	if secret == "" {
+		return nil
+	}
+	key := sha256.Sum256([]byte("pachyderm s3 credentials\x00" + secret))
+	return key[:]
+}
+
+func (a *apiServer) s3CredentialCipher() (cipher.AEAD, error) {
+	if a.s3CredentialKey == nil {
+		return nil, errors.Errorf("the cluster has no S3 credential secret (AUTH_S3_CREDENTIAL_SECRET)")
+	}
+	block, err := aes.NewCipher(a.s3CredentialKey)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	return aead, errors.EnsureStack(err)
+}
+
+// encryptS3Secret encrypts the secret access key of a credential, bound to
+// its access key ID so that it can't be moved to another credential.
+func (a *apiServer) encryptS3Secret(accessKeyID, secretAccessKey string) ([]byte, error) {
+	aead, err := a.s3CredentialCipher()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return aead.Seal(nonce, nonce, []byte(secretAccessKey), []byte(accessKeyID)), nil
+}
+
+func (a *apiServer) decryptS3Secret(accessKeyID string, encrypted []byte) (string, error) {
+	aead, err := a.s3CredentialCipher()
+	if err != nil {
+		return "", err
+	}
+	if len(encrypted) < aead.NonceSize() {
+		return "", errors.Errorf("the secret of S3 credentials %q is malformed", accessKeyID)
+	}
+	nonce, ciphertext := encrypted[:aead.NonceSize()], encrypted[aead.NonceSize():]
+	secretAccessKey, err := aead.Open(nil, nonce, ciphertext, []byte(accessKeyID))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not decrypt the secret of S3 credentials %q", accessKeyID)
+	}
+	return string(secretAccessKey), nil
+}
+
+// s3SessionToken derives the session token of a credential from its secret
+// access key, keyed by the cluster's S3 credential key, so that only its hash
+// is stored and holders of the secret access key can't derive it.
+func (a *apiServer) s3SessionToken(accessKeyID, secretAccessKey string) string {
+	mac := hmac.New(sha256.New, a.s3CredentialKey)
+	mac.Write([]byte(accessKeyID + "\x00" + secretAccessKey))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// randomString returns a string with n random bytes in the given encoding.
+func randomString(n int, encode func([]byte) string) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	return encode(buf), nil
+}
+
+// CreateS3Credentials implements the protobuf auth.CreateS3Credentials RPC
+func (a *apiServer) CreateS3Credentials(ctx context.Context, req *auth.CreateS3CredentialsRequest) (resp *auth.CreateS3CredentialsResponse, retErr error) {
+	a.LogReq(req)
+	// Don't log response to avoid logging the secret access key
+	defer func(start time.Time) { a.LogResp(req, nil, retErr, time.Since(start)) }(time.Now())
+
+	callerInfo, err := a.getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	principal := req.Principal
+	if principal == "" {
+		principal = callerInfo.Subject
+	}
+	if err := a.checkCanonicalSubject(principal); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(principal, auth.PachPrefix) {
+		return nil, errors.New("cannot create S3 credentials for pach: users")
+	}
+	var expiration *time.Time
+	if req.TTL > 0 {
+		t := time.Now().Add(time.Duration(req.TTL) * time.Second)
+		expiration = &t
+	}
+	if principal == callerInfo.Subject {
+		// Credentials must not outlive the session which created them.
+		if callerInfo.Expiration != nil && (expiration == nil || expiration.After(*callerInfo.Expiration)) {
+			expiration = callerInfo.Expiration
+		}
+	} else if err := a.CheckClusterIsAuthorized(ctx, auth.Permission_CLUSTER_AUTH_GET_ROBOT_TOKEN); err != nil {
+		return nil, err
+	}
+	for _, bucket := range req.Buckets {
+		if bucket == "" || strings.Contains(bucket, ",") {
+			return nil, errors.Errorf("invalid bucket name %q", bucket)
+		}
+	}
+
+	accessKeyID, err := randomString(10, base32.StdEncoding.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	secretAccessKey, err := randomString(30, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	row := &s3CredentialRow{
+		AccessKeyID: s3AccessKeyPrefix + accessKeyID,
+		Principal:   principal,
+		ReadOnly:    req.ReadOnly,
+		Buckets:     strings.Join(req.Buckets, ","),
+		Expiration:  expiration,
+		CreatedAt:   time.Now(),
+	}
+	if row.EncryptedSecretAccessKey, err = a.encryptS3Secret(row.AccessKeyID, secretAccessKey); err != nil {
+		return nil, err
+	}
+	// The session token is the auth token the S3 gateway acts as the
+	// principal with, so revoking the principal's tokens revokes the
+	// credentials as well.
+	row.SessionTokenHash = auth.HashToken(a.s3SessionToken(row.AccessKeyID, secretAccessKey))
+	if err := dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
+		if _, err := sqlTx.Exec(`INSERT INTO auth.auth_tokens (token_hash, subject, expiration) VALUES ($1, $2, $3)`,
+			row.SessionTokenHash, row.Principal, row.Expiration); err != nil {
+			return errors.Wrapf(err, "error storing token")
+		}
+		_, err := sqlTx.NamedExec(`INSERT INTO auth.s3_credentials
+			(access_key_id, encrypted_secret_access_key, session_token_hash, principal, read_only, buckets, expiration, created_at)
+			VALUES (:access_key_id, :encrypted_secret_access_key, :session_token_hash, :principal, :read_only, :buckets, :expiration, :created_at)`, row)
+		return errors.Wrapf(err, "error storing S3 credentials")
+	}); err != nil {
+		return nil, err
+	}
+	cred, err := row.credential()
+	if err != nil {
+		return nil, err
+	}
+	return &auth.CreateS3CredentialsResponse{
+		Credential:      cred,
+		SecretAccessKey: secretAccessKey,
+	}, nil
+}
+
+// ListS3Credentials implements the protobuf auth.ListS3Credentials RPC
+func (a *apiServer) ListS3Credentials(ctx context.Context, req *auth.ListS3CredentialsRequest) (resp *auth.ListS3CredentialsResponse, retErr error) {
+	a.LogReq(req)
+	defer func(start time.Time) { a.LogResp(req, resp, retErr, time.Since(start)) }(time.Now())
+
+	callerInfo, err := a.getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	principal := req.Principal
+	if principal == "" {
+		principal = callerInfo.Subject
+	}
+	if principal != callerInfo.Subject {
+		if err := a.CheckClusterIsAuthorized(ctx, auth.Permission_CLUSTER_AUTH_GET_ROBOT_TOKEN); err != nil {
+			return nil, err
+		}
+	}
+	var rows []*s3CredentialRow
+	if err := a.env.DB.SelectContext(ctx, &rows, `SELECT access_key_id, principal, read_only, buckets, expiration, created_at
+		FROM auth.s3_credentials WHERE principal = $1 ORDER BY created_at`, principal); err != nil {
+		return nil, errors.Wrapf(err, "error querying S3 credentials")
+	}
+	resp = &auth.ListS3CredentialsResponse{}
+	for _, row := range rows {
+		cred, err := row.credential()
+		if err != nil {
+			return nil, err
+		}
+		resp.Credentials = append(resp.Credentials, cred)
+	}
+	return resp, nil
+}
+
+// RevokeS3Credentials implements the protobuf auth.RevokeS3Credentials RPC
+func (a *apiServer) RevokeS3Credentials(ctx context.Context, req *auth.RevokeS3CredentialsRequest) (resp *auth.RevokeS3CredentialsResponse, retErr error) {
+	a.LogReq(req)
+	defer func(start time.Time) { a.LogResp(req, resp, retErr, time.Since(start)) }(time.Now())
+
+	callerInfo, err := a.getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row, err := a.getS3Credential(ctx, req.AccessKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if row.Principal != callerInfo.Subject {
+		if err := a.CheckClusterIsAuthorized(ctx, auth.Permission_CLUSTER_AUTH_GET_ROBOT_TOKEN); err != nil {
+			return nil, err
+		}
+	}
+	if err := dbutil.WithTx(ctx, a.env.DB, func(sqlTx *pachsql.Tx) error {
+		if _, err := sqlTx.Exec(`DELETE FROM auth.s3_credentials WHERE access_key_id = $1`, row.AccessKeyID); err != nil {
+			return errors.Wrapf(err, "error deleting S3 credentials")
+		}
+		return a.deleteAuthToken(sqlTx, row.SessionTokenHash)
+	}); err != nil {
+		return nil, err
+	}
+	return &auth.RevokeS3CredentialsResponse{}, nil
+}
+
+// LookupS3Credential is an internal API used by the S3 gateway to resolve an
+// access key ID to its credential and secrets. Not an RPC.
+func (a *apiServer) LookupS3Credential(ctx context.Context, accessKeyID string) (*auth.S3Credential, *auth.S3CredentialSecrets, error) {
+	if err := a.isActive(ctx); err != nil {
+		return nil, nil, err
+	}
+	row, err := a.getS3Credential(ctx, accessKeyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if row.Expiration != nil && time.Now().After(*row.Expiration) {
+		return nil, nil, auth.ErrExpiredToken
+	}
+	// The credential is revoked along with its session token.
+	if _, err := a.lookupAuthTokenInfo(ctx, row.SessionTokenHash); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, nil, auth.ErrBadToken
+		}
+		return nil, nil, err
+	}
+	secretAccessKey, err := a.decryptS3Secret(row.AccessKeyID, row.EncryptedSecretAccessKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	sessionToken := a.s3SessionToken(row.AccessKeyID, secretAccessKey)
+	if auth.HashToken(sessionToken) != row.SessionTokenHash {
+		return nil, nil, errors.Errorf("the session token of S3 credentials %q doesn't match, the S3 credential secret may have changed", row.AccessKeyID)
+	}
+	cred, err := row.credential()
+	if err != nil {
+		return nil, nil, err
+	}
+	return cred, &auth.S3CredentialSecrets{
+		SecretAccessKey: secretAccessKey,
+		SessionToken:    sessionToken,
+	}, nil
+}
+
+func (a *apiServer) getS3Credential(ctx context.Context, accessKeyID string) (*s3CredentialRow, error) {
+	row := &s3CredentialRow{}
+	if err := a.env.DB.GetContext(ctx, row, `SELECT access_key_id, encrypted_secret_access_key, session_token_hash, principal, read_only, buckets, expiration, created_at
+		FROM auth.s3_credentials WHERE access_key_id = $1`, accessKeyID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Errorf("S3 credentials %q not found", accessKeyID)
+		}
+		return nil, errors.Wrapf(err, "error querying S3 credentials")
+	}
+	return row, nil
+}
+
+// deleteExpiredS3Credentials deletes the S3 credentials which have expired or
+// whose session token has been revoked, such as the short-lived credentials
+// created for presigned URLs.
+func (a *apiServer) deleteExpiredS3Credentials(ctx context.Context) error {
+	if _, err := a.env.DB.ExecContext(ctx, `DELETE FROM auth.s3_credentials c WHERE NOW() > c.expiration
+		OR NOT EXISTS (SELECT 1 FROM auth.auth_tokens t WHERE t.token_hash = c.session_token_hash)`); err != nil {
+		return errors.Wrapf(err, "error deleting expired S3 credentials")
+	}
+	return nil
+}
+
+func (a *apiServer) deleteAllS3Credentials(sqlTx *pachsql.Tx) error {
+	if _, err := sqlTx.Exec(`DELETE FROM auth.s3_credentials`); err != nil {
+		return errors.Wrapf(err, "error deleting all S3 credentials")
+	}
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/license"
 	"github.com/pachyderm/pachyderm/v2/src/pfs"
 	"github.com/pachyderm/pachyderm/v2/src/pps"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
 
 	minio "github.com/minio/minio-go/v6"
 )
@@ -1543,6 +1544,51 @@ func TestDeleteAllRepos(t *testing.T) {
 	require.Equal(t, adminRepo, listResp[0].Repo.Name)
 }
 
+// TestDeleteAllRetentionLocked tests that DeleteAll fails before deleting
+// anything when a repo is under a retention lock, so that auth and pipelines
+// survive it
+func TestDeleteAllRetentionLocked(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	tu.DeleteAll(t)
+	defer tu.DeleteAll(t)
+	adminClient := tu.GetAuthenticatedPachClient(t, auth.RootUser)
+
+	// admin creates a locked repo and a pipeline reading from it
+	repo := tu.UniqueString(t.Name())
+	require.NoError(t, adminClient.CreateRepoWithRetention(repo, 30*time.Second))
+	commit, err := adminClient.StartCommit(repo, "master")
+	require.NoError(t, err)
+	require.NoError(t, adminClient.PutFile(commit, "/file", strings.NewReader("content")))
+	require.NoError(t, adminClient.FinishCommit(repo, "master", commit.ID))
+	pipeline := tu.UniqueString("pipeline")
+	require.NoError(t, adminClient.CreatePipeline(
+		pipeline,
+		"", // default image: DefaultUserImage
+		[]string{"bash"},
+		[]string{"cp /pfs/*/* /pfs/out/"},
+		&pps.ParallelismSpec{Constant: 1},
+		client.NewPFSInput(repo, "/*"),
+		"", // default output branch: master
+		false,
+	))
+
+	// DeleteAll fails, and auth, the pipeline, and the repo are left intact
+	err = adminClient.DeleteAll()
+	require.YesError(t, err)
+	require.True(t, pfsserver.IsRetentionLockedErr(err), err)
+	_, err = adminClient.WhoAmI(adminClient.Ctx(), &auth.WhoAmIRequest{})
+	require.NoError(t, err)
+	_, err = adminClient.InspectPipeline(pipeline, false)
+	require.NoError(t, err)
+	_, err = adminClient.InspectRepo(repo)
+	require.NoError(t, err)
+
+	// DeleteAll succeeds once the retention period has expired
+	require.NoErrorWithinTRetry(t, 2*time.Minute, adminClient.DeleteAll)
+}
+
 // TestListDatum tests that you must have READER access to all of job's
 // input repos to call ListDatum on that job
 func TestListDatum(t *testing.T) {
@@ -2346,6 +2392,95 @@ func TestS3GatewayAuthRequests(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestS3GatewayCredentials tests that S3 credentials issued by the auth
+// service can be used with the S3 gateway, within their scope, until they are
+// revoked
+func TestS3GatewayCredentials(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	tu.DeleteAll(t)
+	defer tu.DeleteAll(t)
+
+	alice := robot(tu.UniqueString("alice"))
+	aliceClient := tu.GetAuthenticatedPachClient(t, alice)
+	repo, other := tu.UniqueString("TestS3GatewayCredentials"), tu.UniqueString("other")
+	require.NoError(t, aliceClient.CreateRepo(repo))
+	require.NoError(t, aliceClient.CreateRepo(other))
+	require.NoError(t, aliceClient.PutFile(client.NewCommit(repo, "master", ""), "file", strings.NewReader("foo")))
+
+	resp, err := aliceClient.CreateS3Credentials(aliceClient.Ctx(), &auth.CreateS3CredentialsRequest{
+		ReadOnly: true,
+		Buckets:  []string{"master." + repo},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(resp.Credential.AccessKeyID, "PACH"))
+	require.Equal(t, alice, resp.Credential.Principal)
+
+	ip := os.Getenv("VM_IP")
+	if ip == "" {
+		ip = "127.0.0.1"
+	}
+	address := net.JoinHostPort(ip, "30600")
+	minioClient, err := minio.NewV4(address, resp.Credential.AccessKeyID, resp.SecretAccessKey, false)
+	require.NoError(t, err)
+
+	// reads of the bucket in scope succeed
+	buckets, err := minioClient.ListBuckets()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(buckets))
+	require.Equal(t, "master."+repo, buckets[0].Name)
+	obj, err := minioClient.GetObject("master."+repo, "file", minio.GetObjectOptions{})
+	require.NoError(t, err)
+	data, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(data))
+
+	// writes and other buckets are rejected
+	_, err = minioClient.PutObject("master."+repo, "file2", strings.NewReader("bar"), 3, minio.PutObjectOptions{})
+	require.YesError(t, err)
+	_, err = minioClient.GetObject("master."+other, "file", minio.GetObjectOptions{})
+	require.NoError(t, err)
+	_, err = minioClient.StatObject("master."+other, "file", minio.StatObjectOptions{})
+	require.YesError(t, err)
+
+	// objects can't be copied into the bucket in scope from other buckets
+	require.NoError(t, aliceClient.PutFile(client.NewCommit(other, "master", ""), "secret", strings.NewReader("baz")))
+	writeResp, err := aliceClient.CreateS3Credentials(aliceClient.Ctx(), &auth.CreateS3CredentialsRequest{
+		Buckets: []string{"master." + repo},
+	})
+	require.NoError(t, err)
+	writeClient, err := minio.NewV4(address, writeResp.Credential.AccessKeyID, writeResp.SecretAccessKey, false)
+	require.NoError(t, err)
+	dst, err := minio.NewDestinationInfo("master."+repo, "copy", nil, nil)
+	require.NoError(t, err)
+	require.YesError(t, writeClient.CopyObject(dst, minio.NewSourceInfo("master."+other, "secret", nil)))
+	require.NoError(t, writeClient.CopyObject(dst, minio.NewSourceInfo("master."+repo, "file", nil)))
+
+	// the credentials can't be created on behalf of others without permission
+	_, err = aliceClient.CreateS3Credentials(aliceClient.Ctx(), &auth.CreateS3CredentialsRequest{
+		Principal: robot(tu.UniqueString("bob")),
+	})
+	require.YesError(t, err)
+	require.Matches(t, "needs permissions \\[CLUSTER_AUTH_GET_ROBOT_TOKEN\\] on CLUSTER", err.Error())
+
+	listResp, err := aliceClient.ListS3Credentials(aliceClient.Ctx(), &auth.ListS3CredentialsRequest{})
+	require.NoError(t, err)
+	var accessKeyIDs []string
+	for _, credential := range listResp.Credentials {
+		accessKeyIDs = append(accessKeyIDs, credential.AccessKeyID)
+	}
+	require.ElementsEqual(t, []string{resp.Credential.AccessKeyID, writeResp.Credential.AccessKeyID}, accessKeyIDs)
+
+	// revoked credentials are rejected
+	_, err = aliceClient.RevokeS3Credentials(aliceClient.Ctx(), &auth.RevokeS3CredentialsRequest{
+		AccessKeyID: resp.Credential.AccessKeyID,
+	})
+	require.NoError(t, err)
+	_, err = minioClient.ListBuckets()
+	require.YesError(t, err)
+}
+
 // TestDeleteFailedPipeline creates a pipeline with an invalid image and then
 // tries to delete it (which shouldn't be blocked by the auth system)
 func TestDeleteFailedPipeline(t *testing.T) {
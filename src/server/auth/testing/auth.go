@@ -140,6 +140,26 @@ func (a *InactiveAPIServer) RevokeAuthTokensForUser(context.Context, *auth.Revok
 	return nil, auth.ErrNotActivated
 }
 
+// CreateS3Credentials implements the CreateS3Credentials RPC, but just returns NotActivatedError
+func (a *InactiveAPIServer) CreateS3Credentials(context.Context, *auth.CreateS3CredentialsRequest) (*auth.CreateS3CredentialsResponse, error) {
+	return nil, auth.ErrNotActivated
+}
+
+// ListS3Credentials implements the ListS3Credentials RPC, but just returns NotActivatedError
+func (a *InactiveAPIServer) ListS3Credentials(context.Context, *auth.ListS3CredentialsRequest) (*auth.ListS3CredentialsResponse, error) {
+	return nil, auth.ErrNotActivated
+}
+
+// RevokeS3Credentials implements the RevokeS3Credentials RPC, but just returns NotActivatedError
+func (a *InactiveAPIServer) RevokeS3Credentials(context.Context, *auth.RevokeS3CredentialsRequest) (*auth.RevokeS3CredentialsResponse, error) {
+	return nil, auth.ErrNotActivated
+}
+
+// LookupS3Credential returns NotActivatedError
+func (a *InactiveAPIServer) LookupS3Credential(context.Context, string) (*auth.S3Credential, *auth.S3CredentialSecrets, error) {
+	return nil, nil, auth.ErrNotActivated
+}
+
 // SetGroupsForUser implements the SetGroupsForUser RPC, but just returns NotActivatedError
 func (a *InactiveAPIServer) SetGroupsForUser(context.Context, *auth.SetGroupsForUserRequest) (*auth.SetGroupsForUserResponse, error) {
 	return nil, auth.ErrNotActivated
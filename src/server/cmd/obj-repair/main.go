@@ -0,0 +1,54 @@
+// obj-repair reconciles the two backends of a replicated object store,
+// copying objects which are missing from one backend from the other.
+// It reads backend credentials from the mounted storage secret, so it is
+// meant to be run inside a pachd pod:
+//   obj-repair [-dry-run] [-prefix <prefix>] 'replicated://?primary=<url>&secondary=<url>'
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	dryRun bool
+	prefix string
+)
+
+func init() {
+	flag.BoolVar(&dryRun, "dry-run", false, "only report the objects which are missing, don't copy them")
+	flag.StringVar(&prefix, "prefix", "", "only reconcile objects under this prefix")
+}
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+	log := logrus.StandardLogger()
+	if flag.NArg() != 1 {
+		log.Fatal("exactly 1 argument (the replicated object store url) required")
+	}
+	url, err := obj.ParseURL(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	primary, secondary, err := obj.ReplicaClientsFromURLAndSecret(url)
+	if err != nil {
+		log.Fatal(err)
+	}
+	stats, err := obj.RepairReplicas(ctx, primary, secondary, prefix, dryRun)
+	if stats != nil {
+		for _, name := range stats.CopiedToSecondary {
+			log.Infof("missing from secondary: %s", name)
+		}
+		for _, name := range stats.CopiedToPrimary {
+			log.Infof("missing from primary: %s", name)
+		}
+		log.Infof("%d objects missing from secondary, %d objects missing from primary (dry run: %v)", len(stats.CopiedToSecondary), len(stats.CopiedToPrimary), dryRun)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -716,6 +716,36 @@ This resets the cluster to its initial state.`,
 	}
 	subcommands = append(subcommands, cmdutil.CreateAlias(squashDocs, "squash"))
 
+	logDocs := &cobra.Command{
+		Short: "Print the history of an existing Pachyderm resource.",
+		Long:  "Print the history of an existing Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(logDocs, "log"))
+
+	resetDocs := &cobra.Command{
+		Short: "Reset an existing Pachyderm resource to an earlier state.",
+		Long:  "Reset an existing Pachyderm resource to an earlier state.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(resetDocs, "reset"))
+
+	verifyDocs := &cobra.Command{
+		Short: "Verify the integrity of an existing Pachyderm resource.",
+		Long:  "Verify the integrity of an existing Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(verifyDocs, "verify"))
+
+	attestDocs := &cobra.Command{
+		Short: "Produce a signed attestation of an existing Pachyderm resource.",
+		Long:  "Produce a signed attestation of an existing Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(attestDocs, "attest"))
+
+	presignDocs := &cobra.Command{
+		Short: "Produce a presigned URL for an existing Pachyderm resource.",
+		Long:  "Produce a presigned URL for an existing Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(presignDocs, "presign"))
+
 	createDocs := &cobra.Command{
 		Short: "Create a new instance of a Pachyderm resource.",
 		Long:  "Create a new instance of a Pachyderm resource.",
@@ -870,6 +900,7 @@ func applyRootUsageFunc(rootCmd *cobra.Command) {
 			"tag":
 			// These are ignored - they will show up in the help topics section
 		case
+			"attest",
 			"copy",
 			"create",
 			"delete",
@@ -881,13 +912,17 @@ func applyRootUsageFunc(rootCmd *cobra.Command) {
 			"glob",
 			"inspect",
 			"list",
+			"log",
+			"presign",
 			"put",
+			"reset",
 			"restart",
 			"squash",
 			"start",
 			"stop",
 			"subscribe",
-			"update":
+			"update",
+			"verify":
 			actions = append(actions, subcmd)
 		case
 			"extract",
@@ -8,6 +8,7 @@ import (
 	"path"
 	"runtime/debug"
 	"runtime/pprof"
+	"time"
 
 	adminclient "github.com/pachyderm/pachyderm/v2/src/admin"
 	authclient "github.com/pachyderm/pachyderm/v2/src/auth"
@@ -46,6 +47,7 @@ import (
 	licenseserver "github.com/pachyderm/pachyderm/v2/src/server/license/server"
 	"github.com/pachyderm/pachyderm/v2/src/server/pfs/s3"
 	pfs_server "github.com/pachyderm/pachyderm/v2/src/server/pfs/server"
+	"github.com/pachyderm/pachyderm/v2/src/server/pfs/webdav"
 	pps_server "github.com/pachyderm/pachyderm/v2/src/server/pps/server"
 	txnserver "github.com/pachyderm/pachyderm/v2/src/server/transaction/server"
 	transactionclient "github.com/pachyderm/pachyderm/v2/src/transaction"
@@ -880,7 +882,11 @@ func doFullMode(config interface{}) (retErr error) {
 	go waitForError("S3 Server", errChan, requireNoncriticalServers, func() error {
 		router := s3.Router(s3.NewMasterDriver(), func() (*client.APIClient, error) {
 			return env.GetPachClient(context.Background()), nil
-		})
+		}, env.GetDBClient(), s3.WithCredentialLookup(env.AuthServer()),
+			s3.WithWriteSessions(
+				time.Duration(env.Config().S3GatewayWriteSessionIdleSeconds)*time.Second,
+				time.Duration(env.Config().S3GatewayBatchWindowSeconds)*time.Second,
+			))
 		server := s3.Server(env.Config().S3GatewayPort, router)
 		certPath, keyPath, err := tls.GetCertPaths()
 		if err != nil {
@@ -896,6 +902,27 @@ func doFullMode(config interface{}) (retErr error) {
 		server.TLSConfig = &gotls.Config{GetCertificate: cLoader.GetCertificate}
 		return server.ListenAndServeTLS(certPath, keyPath)
 	})
+	if env.Config().WebDAVPort != 0 {
+		go waitForError("WebDAV Server", errChan, requireNoncriticalServers, func() error {
+			router := webdav.Router(func() (*client.APIClient, error) {
+				return env.GetPachClient(context.Background()), nil
+			}, env.GetDBClient(), webdav.WithBatchWindow(time.Duration(env.Config().WebDAVBatchWindowSeconds)*time.Second))
+			server := webdav.Server(env.Config().WebDAVPort, router)
+			certPath, keyPath, err := tls.GetCertPaths()
+			if err != nil {
+				log.Warnf("WebDAV TLS disabled: %v", err)
+				return server.ListenAndServe()
+			}
+			cLoader := tls.NewCertLoader(certPath, keyPath, tls.CertCheckFrequency)
+			// Read TLS cert and key
+			err = cLoader.LoadAndStart()
+			if err != nil {
+				return errors.Wrapf(err, "couldn't load TLS cert for WebDAV server: %v", err)
+			}
+			server.TLSConfig = &gotls.Config{GetCertificate: cLoader.GetCertificate}
+			return server.ListenAndServeTLS(certPath, keyPath)
+		})
+	}
 	go waitForError("Prometheus Server", errChan, requireNoncriticalServers, func() error {
 		http.Handle("/metrics", promhttp.Handler())
 		return http.ListenAndServe(fmt.Sprintf(":%v", env.Config().PrometheusPort), nil)
@@ -104,6 +104,32 @@ func Cmds() []*cobra.Command {
 	dump.Flags().Int64VarP(&limit, "limit", "l", 0, "Limit sets the limit for the number of commits / jobs that are returned for each repo / pipeline in the dump.")
 	commands = append(commands, cmdutil.CreateAlias(dump, "debug dump"))
 
+	var upload, download, burst int64
+	setBandwidth := &cobra.Command{
+		Use:   "{{alias}}",
+		Short: "Set the object storage bandwidth limits.",
+		Long:  "Set the object storage bandwidth limits of pachd and the workers, in bytes per second. A limit of 0 means unlimited. Only the pachd replica which serves the command is limited, not every pachd replica. The limits reset to the configured values when the pods restart.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			client, err := client.NewOnUserMachine("debug-set-bandwidth")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			filter, err := createFilter(pachd, pipeline, worker)
+			if err != nil {
+				return err
+			}
+			return client.SetStorageBandwidth(filter, upload, download, burst)
+		}),
+	}
+	setBandwidth.Flags().Int64Var(&upload, "upload", 0, "The upload limit in bytes per second, 0 means unlimited.")
+	setBandwidth.Flags().Int64Var(&download, "download", 0, "The download limit in bytes per second, 0 means unlimited.")
+	setBandwidth.Flags().Int64Var(&burst, "burst", 0, "The number of bytes which can be transferred in a burst above the limits, 0 means the default.")
+	setBandwidth.Flags().BoolVar(&pachd, "pachd", false, "Only set the limits for pachd.")
+	setBandwidth.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Only set the limits for the worker pods of the given pipeline.")
+	setBandwidth.Flags().StringVarP(&worker, "worker", "w", "", "Only set the limits for the given worker pod.")
+	commands = append(commands, cmdutil.CreateAlias(setBandwidth, "debug set-bandwidth"))
+
 	debug := &cobra.Command{
 		Short: "Debug commands for analyzing a running cluster.",
 		Long:  "Debug commands for analyzing a running cluster.",
@@ -18,6 +18,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/ppsutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/serviceenv"
 	"github.com/pachyderm/pachyderm/v2/src/pfs"
@@ -329,6 +330,100 @@ func redirectProfileFunc(ctx context.Context, profile *debug.Profile) redirectFu
 	}
 }
 
+// SetStorageBandwidth sets the object storage bandwidth limits of pachd and
+// the workers selected by the filter. The limits of pachd are only set on the
+// replica which serves the request, the other replicas keep their limits.
+func (s *debugServer) SetStorageBandwidth(ctx context.Context, request *debug.SetStorageBandwidthRequest) (*types.Empty, error) {
+	setLocal := func() {
+		obj.GlobalThrottle().SetLimits(request.UploadBytesPerSecond, request.DownloadBytesPerSecond, request.BurstBytes)
+		log.Infof("storage bandwidth limits set to upload=%d download=%d burst=%d bytes per second",
+			request.UploadBytesPerSecond, request.DownloadBytesPerSecond, request.BurstBytes)
+	}
+	redirect := func(c debug.DebugClient, filter *debug.Filter) error {
+		req := *request
+		req.Filter = filter
+		_, err := c.SetStorageBandwidth(ctx, &req)
+		return err
+	}
+	pachClient := s.env.GetPachClient(ctx)
+	setPipeline := func(pipelineInfo *pps.PipelineInfo) error {
+		pods, err := s.getWorkerPods(pipelineInfo)
+		if err != nil {
+			return err
+		}
+		for _, pod := range pods {
+			if err := s.setWorkerStorageBandwidth(&pod, redirect); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if request.Filter != nil {
+		switch f := request.Filter.Filter.(type) {
+		case *debug.Filter_Pachd:
+			setLocal()
+			return &types.Empty{}, nil
+		case *debug.Filter_Pipeline:
+			pipelineInfo, err := pachClient.InspectPipeline(f.Pipeline.Name, true)
+			if err != nil {
+				return nil, err
+			}
+			return &types.Empty{}, setPipeline(pipelineInfo)
+		case *debug.Filter_Worker:
+			if f.Worker.Redirected {
+				// Storage is handled by the storage container, so the
+				// user container forwards the request to it.
+				if s.sidecarClient == nil {
+					setLocal()
+					return &types.Empty{}, nil
+				}
+				return &types.Empty{}, redirect(s.sidecarClient.DebugClient, request.Filter)
+			}
+			pod, err := s.env.GetKubeClient().CoreV1().Pods(s.env.Config().Namespace).Get(ctx, f.Worker.Pod, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &types.Empty{}, s.setWorkerStorageBandwidth(pod, redirect)
+		}
+	}
+	// No filter, set everywhere.
+	setLocal()
+	pipelineInfos, err := pachClient.ListPipeline(true)
+	if err != nil {
+		return nil, err
+	}
+	for _, pipelineInfo := range pipelineInfos {
+		if err := setPipeline(pipelineInfo); err != nil {
+			return nil, err
+		}
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *debugServer) setWorkerStorageBandwidth(pod *v1.Pod, redirect func(debug.DebugClient, *debug.Filter) error) (retErr error) {
+	if pod.Status.Phase != v1.PodRunning {
+		// The worker will pick up the configured limits when it starts.
+		return nil
+	}
+	c, err := workerserver.NewClient(pod.Status.PodIP)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			log.Errorf("errored closing worker client: %v", err)
+		}
+	}()
+	return errors.Wrapf(redirect(c.DebugClient, &debug.Filter{
+		Filter: &debug.Filter_Worker{
+			Worker: &debug.Worker{
+				Pod:        pod.Name,
+				Redirected: true,
+			},
+		},
+	}), "error setting storage bandwidth for worker %v", pod.Name)
+}
+
 func (s *debugServer) Binary(request *debug.BinaryRequest, server debug.Debug_BinaryServer) error {
 	pachClient := s.env.GetPachClient(server.Context())
 	return s.handleRedirect(
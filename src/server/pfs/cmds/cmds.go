@@ -13,8 +13,10 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	prompt "github.com/c-bata/go-prompt"
+	units "github.com/docker/go-units"
 	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	"github.com/mattn/go-isatty"
@@ -67,6 +69,7 @@ or type (e.g. csv, binary, images, etc).`,
 	commands = append(commands, cmdutil.CreateDocsAlias(repoDocs, "repo", " repo$"))
 
 	var description string
+	var retention time.Duration
 	createRepo := &cobra.Command{
 		Use:   "{{alias}} <repo>",
 		Short: "Create a new repo.",
@@ -78,20 +81,22 @@ or type (e.g. csv, binary, images, etc).`,
 			}
 			defer c.Close()
 
+			request := &pfs.CreateRepoRequest{
+				Repo:        client.NewRepo(args[0]),
+				Description: description,
+			}
+			if retention != 0 {
+				request.Retention = types.DurationProto(retention)
+			}
 			err = txncmds.WithActiveTransaction(c, func(c *client.APIClient) error {
-				_, err = c.PfsAPIClient.CreateRepo(
-					c.Ctx(),
-					&pfs.CreateRepoRequest{
-						Repo:        client.NewRepo(args[0]),
-						Description: description,
-					},
-				)
+				_, err = c.PfsAPIClient.CreateRepo(c.Ctx(), request)
 				return err
 			})
 			return grpcutil.ScrubGRPC(err)
 		}),
 	}
 	createRepo.Flags().StringVarP(&description, "description", "d", "", "A description of the repo.")
+	createRepo.Flags().DurationVar(&retention, "retention", 0, "Lock the finished commits of the repo for this long, during which they cannot be squashed or dropped and the repo and its branches cannot be deleted, even by cluster admins.")
 	commands = append(commands, cmdutil.CreateAlias(createRepo, "create repo"))
 
 	updateRepo := &cobra.Command{
@@ -105,21 +110,31 @@ or type (e.g. csv, binary, images, etc).`,
 			}
 			defer c.Close()
 
+			request := &pfs.CreateRepoRequest{
+				Repo:        cmdutil.ParseRepo(args[0]),
+				Description: description,
+				Update:      true,
+			}
+			if retention != 0 {
+				request.Retention = types.DurationProto(retention)
+			}
 			err = txncmds.WithActiveTransaction(c, func(c *client.APIClient) error {
-				_, err = c.PfsAPIClient.CreateRepo(
-					c.Ctx(),
-					&pfs.CreateRepoRequest{
-						Repo:        cmdutil.ParseRepo(args[0]),
-						Description: description,
-						Update:      true,
-					},
-				)
+				if request.Retention != nil && request.Description == "" {
+					// Only the retention is being updated, keep the description.
+					repoInfo, err := c.PfsAPIClient.InspectRepo(c.Ctx(), &pfs.InspectRepoRequest{Repo: request.Repo})
+					if err != nil {
+						return err
+					}
+					request.Description = repoInfo.Description
+				}
+				_, err = c.PfsAPIClient.CreateRepo(c.Ctx(), request)
 				return err
 			})
 			return grpcutil.ScrubGRPC(err)
 		}),
 	}
 	updateRepo.Flags().StringVarP(&description, "description", "d", "", "A description of the repo.")
+	updateRepo.Flags().DurationVar(&retention, "retention", 0, "Extend the retention period of the repo. The retention period cannot be shortened.")
 	shell.RegisterCompletionFunc(updateRepo, shell.RepoCompletion)
 	commands = append(commands, cmdutil.CreateAlias(updateRepo, "update repo"))
 
@@ -360,6 +375,7 @@ $ {{alias}} test@fork -p XXX`,
 	shell.RegisterCompletionFunc(finishCommit, shell.BranchCompletion)
 	commands = append(commands, cmdutil.CreateAlias(finishCommit, "finish commit"))
 
+	var details bool
 	inspectCommit := &cobra.Command{
 		Use:   "{{alias}} <repo>@<branch-or-commit>",
 		Short: "Return info about a commit.",
@@ -380,8 +396,9 @@ $ {{alias}} test@fork -p XXX`,
 			commitInfo, err := c.PfsAPIClient.InspectCommit(
 				c.Ctx(),
 				&pfs.InspectCommitRequest{
-					Commit: commit,
-					Wait:   pfs.CommitState_STARTED,
+					Commit:  commit,
+					Wait:    pfs.CommitState_STARTED,
+					Details: details,
 				})
 			if err != nil {
 				return grpcutil.ScrubGRPC(err)
@@ -401,11 +418,100 @@ $ {{alias}} test@fork -p XXX`,
 			return pretty.PrintDetailedCommitInfo(os.Stdout, ci)
 		}),
 	}
+	inspectCommit.Flags().BoolVar(&details, "details", false, "Report the number of file set layers in the commit, the total size of their indexes and the estimated read amplification.")
 	inspectCommit.Flags().AddFlagSet(outputFlags)
 	inspectCommit.Flags().AddFlagSet(timestampFlags)
 	shell.RegisterCompletionFunc(inspectCommit, shell.BranchCompletion)
 	commands = append(commands, cmdutil.CreateAlias(inspectCommit, "inspect commit"))
 
+	var ancestors bool
+	verifyCommit := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>",
+		Short: "Verify the hash of a commit.",
+		Long:  "Verify the hash of a commit. The hash of the content of the commit is recomputed and checked against the hash computed when the commit was finished, and against the root hash of its parent.",
+		Example: `
+# verify the head of the master branch of repo foo
+$ {{alias}} foo@master
+
+# verify the head of the master branch of repo foo and all of its ancestors
+$ {{alias}} foo@master --ancestors`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			commit, err := cmdutil.ParseCommit(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			resps, err := c.VerifyCommit(commit.Branch.Repo.Name, commit.Branch.Name, commit.ID, ancestors)
+			if err != nil {
+				return err
+			}
+			var failed int
+			if raw {
+				e := cmdutil.Encoder(output, os.Stdout)
+				for _, resp := range resps {
+					if err := e.EncodeProto(resp); err != nil {
+						return err
+					}
+				}
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			} else {
+				writer := tabwriter.NewWriter(os.Stdout, pretty.VerifyCommitHeader)
+				for _, resp := range resps {
+					pretty.PrintVerifyCommitResponse(writer, resp)
+				}
+				if err := writer.Flush(); err != nil {
+					return err
+				}
+			}
+			for _, resp := range resps {
+				if resp.Error != "" {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return errors.Errorf("%d commit(s) failed verification", failed)
+			}
+			return nil
+		}),
+	}
+	verifyCommit.Flags().BoolVar(&ancestors, "ancestors", false, "Verify the ancestors of the commit as well.")
+	verifyCommit.Flags().AddFlagSet(outputFlags)
+	shell.RegisterCompletionFunc(verifyCommit, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(verifyCommit, "verify commit"))
+
+	attestCommit := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>",
+		Short: "Produce a signed attestation of the hash of a commit.",
+		Long:  "Produce a signed attestation of the hash of a commit. The commit is verified first, and the attestation is signed with the ed25519 key of the cluster, whose public key is included in the attestation.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			commit, err := cmdutil.ParseCommit(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			attestation, err := c.AttestCommit(commit.Branch.Repo.Name, commit.Branch.Name, commit.ID)
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				output = "json"
+			}
+			return cmdutil.Encoder(output, os.Stdout).EncodeProto(attestation)
+		}),
+	}
+	attestCommit.Flags().StringVarP(&output, "output", "o", "", "Output format: \"json\" or \"yaml\"")
+	shell.RegisterCompletionFunc(attestCommit, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(attestCommit, "attest commit"))
+
 	var from string
 	var number int64
 	var originStr string
@@ -750,6 +856,67 @@ $ {{alias}} test@master --new`,
 	shell.RegisterCompletionFunc(subscribeCommit, shell.BranchCompletion)
 	commands = append(commands, cmdutil.CreateAlias(subscribeCommit, "subscribe commit"))
 
+	subscribeFile := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch>:<pattern>",
+		Short: "Print the changes to files which match a glob pattern as commits are finished.",
+		Long:  "Print the changes to the files which match a glob pattern as commits are finished on the specified branch. By default, the changes in all existing commits on the branch are returned first. Commits which do not change matching files are skipped.",
+		Example: `
+# subscribe to changes to csv files under "data" in repo "test" on branch "master"
+$ {{alias}} "test@master:data/*.csv"
+
+# subscribe to changes to files in repo "test" on branch "master", but only since commit XXX.
+$ {{alias}} "test@master:**" --from XXX
+
+# subscribe to changes to files in repo "test" on branch "master", but only for new commits created from now on.
+$ {{alias}} "test@master:**" --new`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) (retErr error) {
+			file, err := cmdutil.ParseFile(args[0])
+			if err != nil {
+				return err
+			}
+			branch := file.Commit.Branch
+			var fromCommit *pfs.Commit
+			if newCommits && from != "" {
+				return errors.Errorf("--new and --from cannot be used together")
+			} else if newCommits || from != "" {
+				fromCommit = branch.NewCommit(from)
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				return c.SubscribeFile(branch, file.Path, fromCommit, func(event *pfs.SubscribeFileEvent) error {
+					return encoder.EncodeProto(event)
+				})
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			w := tabwriter.NewWriter(os.Stdout, pretty.FileChangeHeader)
+			defer func() {
+				if err := w.Flush(); retErr == nil {
+					retErr = err
+				}
+			}()
+			return c.SubscribeFile(branch, file.Path, fromCommit, func(event *pfs.SubscribeFileEvent) error {
+				for _, change := range event.Changes {
+					pretty.PrintFileChange(w, event.CommitInfo, change)
+				}
+				// Flush after each commit so that the changes are printed as
+				// they happen.
+				return w.Flush()
+			})
+		}),
+	}
+	subscribeFile.Flags().StringVar(&from, "from", "", "subscribe to the changes in all commits since this commit")
+	subscribeFile.MarkFlagCustom("from", "__pachctl_get_commit $(__parse_repo ${nouns[0]})")
+	subscribeFile.Flags().BoolVar(&newCommits, "new", false, "subscribe to only the changes in new commits created from now on")
+	subscribeFile.Flags().AddFlagSet(outputFlags)
+	shell.RegisterCompletionFunc(subscribeFile, shell.FileCompletion)
+	commands = append(commands, cmdutil.CreateAlias(subscribeFile, "subscribe file"))
+
 	squashCommit := &cobra.Command{
 		Use:   "{{alias}} <commit-id>",
 		Short: "Squash the sub-commits of a commit.",
@@ -771,6 +938,65 @@ The squash will fail if it includes a commit with no children`,
 	shell.RegisterCompletionFunc(squashCommit, shell.BranchCompletion)
 	commands = append(commands, cmdutil.CreateAlias(squashCommit, "squash commit"))
 
+	compactCommit := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>",
+		Short: "Fully compact the file set layers of a commit.",
+		Long: `Fully compact the file set layers of a commit.
+Reads from a commit merge all of its file set layers, so commits which accumulate many layers are slow to read. The layers of an open commit are compacted into a single layer, and a finished commit is compacted after it is finished. Use 'inspect commit --details' to see the layers of a commit.`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			commit, err := cmdutil.ParseCommit(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			_, err = c.PfsAPIClient.CompactCommit(
+				c.Ctx(),
+				&pfs.CompactCommitRequest{
+					Commit: commit,
+				},
+			)
+			return grpcutil.ScrubGRPC(err)
+		}),
+	}
+	shell.RegisterCompletionFunc(compactCommit, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(compactCommit, "compact commit"))
+
+	var background bool
+	materializeCommit := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>",
+		Short: "Copy the content of externally referenced objects into a commit.",
+		Long: `Copy the content of externally referenced objects into a commit.
+Files added with 'put file --by-reference' are read from their source objects on demand. Materializing a finished commit copies their content into pachyderm, after which the commit no longer depends on the source objects.
+The hashes of the materialized files change, since they are computed from the content stored in pachyderm instead of the reference, and so do the hashes of the commit and its descendants.
+With --background, the commit is materialized by pachd once it is finished, and the command returns without waiting for it.`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			commit, err := cmdutil.ParseCommit(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			_, err = c.PfsAPIClient.MaterializeCommit(
+				c.Ctx(),
+				&pfs.MaterializeCommitRequest{
+					Commit:     commit,
+					Background: background,
+				},
+			)
+			return grpcutil.ScrubGRPC(err)
+		}),
+	}
+	materializeCommit.Flags().BoolVar(&background, "background", false, "Materialize the commit in the background once it is finished, rather than waiting for it.")
+	shell.RegisterCompletionFunc(materializeCommit, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(materializeCommit, "materialize commit"))
+
 	deleteCommit := &cobra.Command{
 		Use:   "{{alias}} <commit-id>",
 		Short: "Delete the sub-commits of a commit.",
@@ -972,6 +1198,81 @@ Any pachctl command that can take a commit, can take a branch name instead.`,
 	shell.RegisterCompletionFunc(deleteBranch, shell.BranchCompletion)
 	commands = append(commands, cmdutil.CreateAlias(deleteBranch, "delete branch"))
 
+	var reflogNumber int64
+	logBranch := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch>",
+		Short: "Print the moves of a branch head.",
+		Long:  "Print the moves of a branch head from newest to oldest, with when, by whom and why the head moved.",
+		Example: `
+# print the head moves of branch "master" in repo "foo"
+$ {{alias}} foo@master
+
+# print the last 5 head moves of branch "master" in repo "foo"
+$ {{alias}} foo@master -n 5`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			branch, err := cmdutil.ParseBranch(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			entries, err := c.ListReflog(branch.Repo.Name, branch.Name, reflogNumber)
+			if err != nil {
+				return err
+			}
+			if raw {
+				encoder := cmdutil.Encoder(output, os.Stdout)
+				for _, entry := range entries {
+					if err := encoder.EncodeProto(entry); err != nil {
+						return err
+					}
+				}
+				return nil
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.ReflogHeader)
+			for _, entry := range entries {
+				pretty.PrintReflogEntry(writer, entry, fullTimestamps)
+			}
+			return writer.Flush()
+		}),
+	}
+	logBranch.Flags().Int64VarP(&reflogNumber, "number", "n", 0, "print at most n head moves")
+	logBranch.Flags().AddFlagSet(outputFlags)
+	logBranch.Flags().AddFlagSet(timestampFlags)
+	shell.RegisterCompletionFunc(logBranch, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(logBranch, "log branch"))
+
+	var reflogIndex int64
+	resetBranch := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch> --to-reflog <n>",
+		Short: "Move a branch head back to a head in its reflog.",
+		Long:  "Move a branch head back to the head it had after the reflog entry n, as printed by 'log branch'. Entry 0 is the current head, so --to-reflog 1 undoes the last head move. The reset is recorded in the reflog too.",
+		Example: `
+# undo the last head move of branch "master" in repo "foo"
+$ {{alias}} foo@master --to-reflog 1`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			branch, err := cmdutil.ParseBranch(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			return c.ResetBranch(branch.Repo.Name, branch.Name, reflogIndex)
+		}),
+	}
+	resetBranch.Flags().Int64Var(&reflogIndex, "to-reflog", 0, "the index of the reflog entry to reset the branch to")
+	resetBranch.MarkFlagRequired("to-reflog")
+	shell.RegisterCompletionFunc(resetBranch, shell.BranchCompletion)
+	commands = append(commands, cmdutil.CreateAlias(resetBranch, "reset branch"))
+
 	fileDocs := &cobra.Command{
 		Short: "Docs for files.",
 		Long: `Files are the lowest level data objects in Pachyderm.
@@ -987,6 +1288,7 @@ from commits with 'get file'.`,
 	var recursive bool
 	var parallelism int
 	var appendFile bool
+	var byReference bool
 	var compress bool
 	var enableProgress bool
 	var fullPath bool
@@ -1019,6 +1321,10 @@ $ {{alias}} repo@branch:/dir -f http://host/example.png
 # Put the data from an S3 bucket at repo@branch:/s3_object
 $ {{alias}} repo@branch -r -f s3://my_bucket
 
+# Reference the objects in an S3 bucket at repo@branch:/s3_object without
+# copying them
+$ {{alias}} repo@branch -r --by-reference -f s3://my_bucket
+
 # Put several files or URLs that are listed in file.
 # Files and URLs should be newline delimited.
 $ {{alias}} repo@branch -i file
@@ -1102,13 +1408,13 @@ $ {{alias}} repo@branch -i http://host/path`,
 						if !fullPath {
 							target = filepath.Base(source)
 						}
-						if err := putFileHelper(mf, joinPaths("", target), source, recursive, appendFile); err != nil {
+						if err := putFileHelper(mf, joinPaths("", target), source, recursive, appendFile, byReference); err != nil {
 							return err
 						}
 					} else if len(sources) == 1 {
 						// We have a single source and the user has specified a path,
 						// we use the path and ignore source (in terms of naming the file).
-						if err := putFileHelper(mf, file.Path, source, recursive, appendFile); err != nil {
+						if err := putFileHelper(mf, file.Path, source, recursive, appendFile, byReference); err != nil {
 							return err
 						}
 					} else {
@@ -1118,7 +1424,7 @@ $ {{alias}} repo@branch -i http://host/path`,
 						if !fullPath {
 							target = filepath.Base(source)
 						}
-						if err := putFileHelper(mf, joinPaths(file.Path, target), source, recursive, appendFile); err != nil {
+						if err := putFileHelper(mf, joinPaths(file.Path, target), source, recursive, appendFile, byReference); err != nil {
 							return err
 						}
 					}
@@ -1133,6 +1439,7 @@ $ {{alias}} repo@branch -i http://host/path`,
 	putFile.Flags().BoolVarP(&compress, "compress", "", false, "Compress data during upload. This parameter might help you upload your uncompressed data, such as CSV files, to Pachyderm faster. Use 'compress' with caution, because if your data is already compressed, this parameter might slow down the upload speed instead of increasing.")
 	putFile.Flags().IntVarP(&parallelism, "parallelism", "p", DefaultParallelism, "The maximum number of files that can be uploaded in parallel.")
 	putFile.Flags().BoolVarP(&appendFile, "append", "a", false, "Append to the existing content of the file, either from previous commits or previous calls to 'put file' within this commit.")
+	putFile.Flags().BoolVar(&byReference, "by-reference", false, "Reference the objects at an object storage URL rather than copying their content. The objects are read on demand, and reads fail if the objects change.")
 	putFile.Flags().BoolVar(&enableProgress, "progress", isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd()), "Print progress bars.")
 	putFile.Flags().BoolVar(&fullPath, "full-path", false, "If true, use the entire path provided to -f as the target filename in PFS. By default only the base of the path is used.")
 	shell.RegisterCompletionFunc(putFile,
@@ -1282,6 +1589,45 @@ $ {{alias}} 'foo@master:/test\[\].txt'`,
 	shell.RegisterCompletionFunc(getFile, shell.FileCompletion)
 	commands = append(commands, cmdutil.CreateAlias(getFile, "get file"))
 
+	var method string
+	var expiry time.Duration
+	var endpoint string
+	presignFile := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>:<path/in/pfs>",
+		Short: "Return a presigned URL for a file.",
+		Long: "Return a presigned URL through which the file can be downloaded (GET) or uploaded (PUT) " +
+			"via the S3 gateway, without any other credentials, until the URL expires. " +
+			"Files can only be uploaded to the head of a branch.",
+		Example: `
+# get a URL to download file "XXX" on branch "master" in repo "foo" for a day
+$ {{alias}} foo@master:XXX --expiry 24h
+
+# get a URL to upload file "XXX" to branch "master" in repo "foo"
+$ {{alias}} foo@master:XXX --method PUT`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			file, err := cmdutil.ParseFile(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := newClient("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			presignedURL, err := c.PresignFileURL(endpoint, file, strings.ToUpper(method), expiry)
+			if err != nil {
+				return err
+			}
+			fmt.Println(presignedURL)
+			return nil
+		}),
+	}
+	presignFile.Flags().StringVar(&method, "method", "GET", "The HTTP method the URL can be used with, GET or PUT.")
+	presignFile.Flags().DurationVar(&expiry, "expiry", time.Hour, "How long the URL is valid for, at most 168h.")
+	presignFile.Flags().StringVar(&endpoint, "endpoint", "http://localhost:30600", "The address of the S3 gateway the URL points to.")
+	shell.RegisterCompletionFunc(presignFile, shell.FileCompletion)
+	commands = append(commands, cmdutil.CreateAlias(presignFile, "presign file"))
+
 	inspectFile := &cobra.Command{
 		Use:   "{{alias}} <repo>@<branch-or-commit>:<path/in/pfs>",
 		Short: "Return info about a file.",
@@ -1315,6 +1661,9 @@ $ {{alias}} 'foo@master:/test\[\].txt'`,
 	shell.RegisterCompletionFunc(inspectFile, shell.FileCompletion)
 	commands = append(commands, cmdutil.CreateAlias(inspectFile, "inspect file"))
 
+	var fileSort string
+	var reverseFiles bool
+	var startAfter string
 	listFile := &cobra.Command{
 		Use:   "{{alias}} <repo>@<branch-or-commit>[:<path/in/pfs>]",
 		Short: "Return the files in a directory.",
@@ -1336,12 +1685,25 @@ $ {{alias}} foo@master^2
 
 # list file under directory "dir[1]" on branch "master" in repo "foo"
 # the path is interpreted as a glob pattern: quote and protect regex characters
-$ {{alias}} 'foo@master:dir\[1\]'`,
+$ {{alias}} 'foo@master:dir\[1\]'
+
+# list top-level files on branch "master" in repo "foo" from largest to smallest
+$ {{alias}} foo@master --sort size --reverse`,
 		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
 			file, err := cmdutil.ParseFile(args[0])
 			if err != nil {
 				return err
 			}
+			sortValue, ok := pfs.FileSort_value["FILE_SORT_"+strings.ToUpper(fileSort)]
+			if !ok {
+				return errors.Errorf("invalid sort %q, must be one of path, size or committed", fileSort)
+			}
+			req := &pfs.ListFileRequest{
+				File:       file,
+				StartAfter: startAfter,
+				Sort:       pfs.FileSort(sortValue),
+				Reverse:    reverseFiles,
+			}
 			c, err := client.NewOnUserMachine("user")
 			if err != nil {
 				return err
@@ -1349,15 +1711,16 @@ $ {{alias}} 'foo@master:dir\[1\]'`,
 			defer c.Close()
 			if raw {
 				encoder := cmdutil.Encoder(output, os.Stdout)
-				return c.ListFile(file.Commit, file.Path, func(fi *pfs.FileInfo) error {
+				_, err := c.ListFilePage(req, func(fi *pfs.FileInfo) error {
 					return encoder.EncodeProto(fi)
 				})
+				return err
 			} else if output != "" {
 				return errors.New("cannot set --output (-o) without --raw")
 			}
 			header := pretty.FileHeader
 			writer := tabwriter.NewWriter(os.Stdout, header)
-			if err := c.ListFile(file.Commit, file.Path, func(fi *pfs.FileInfo) error {
+			if _, err := c.ListFilePage(req, func(fi *pfs.FileInfo) error {
 				pretty.PrintFileInfo(writer, fi, fullTimestamps, false)
 				return nil
 			}); err != nil {
@@ -1366,6 +1729,9 @@ $ {{alias}} 'foo@master:dir\[1\]'`,
 			return writer.Flush()
 		}),
 	}
+	listFile.Flags().StringVar(&fileSort, "sort", "path", "Sort the files by path, size or committed.")
+	listFile.Flags().BoolVar(&reverseFiles, "reverse", false, "Reverse the sort order.")
+	listFile.Flags().StringVar(&startAfter, "start-after", "", "List only the files with paths after this path.")
 	listFile.Flags().AddFlagSet(outputFlags)
 	listFile.Flags().AddFlagSet(timestampFlags)
 	shell.RegisterCompletionFunc(listFile, shell.FileCompletion)
@@ -1558,11 +1924,11 @@ Objects are a low-level resource and should not be accessed directly by most use
 	}
 	commands = append(commands, cmdutil.CreateDocsAlias(objectDocs, "object", " object$"))
 
-	var fix bool
+	var fix, deep, readChunkData, resume bool
 	fsck := &cobra.Command{
 		Use:   "{{alias}}",
 		Short: "Run a file system consistency check on pfs.",
-		Long:  "Run a file system consistency check on the pachyderm file system, ensuring the correct provenance relationships are satisfied.",
+		Long:  "Run a file system consistency check on the pachyderm file system, ensuring the correct provenance relationships are satisfied. With --deep, also check that the data referenced by every commit exists in object storage, reporting the commits and paths that are affected.",
 		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
 			c, err := client.NewOnUserMachine("user")
 			if err != nil {
@@ -1570,7 +1936,7 @@ Objects are a low-level resource and should not be accessed directly by most use
 			}
 			defer c.Close()
 			errors := false
-			if err = c.Fsck(fix, func(resp *pfs.FsckResponse) error {
+			cb := func(resp *pfs.FsckResponse) error {
 				if resp.Error != "" {
 					errors = true
 					fmt.Printf("Error: %s\n", resp.Error)
@@ -1578,7 +1944,13 @@ Objects are a low-level resource and should not be accessed directly by most use
 					fmt.Printf("Fix applied: %v", resp.Fix)
 				}
 				return nil
-			}); err != nil {
+			}
+			if deep {
+				err = c.FsckDeep(fix, readChunkData, resume, cb)
+			} else {
+				err = c.Fsck(fix, cb)
+			}
+			if err != nil {
 				return err
 			}
 			if !errors {
@@ -1588,8 +1960,121 @@ Objects are a low-level resource and should not be accessed directly by most use
 		}),
 	}
 	fsck.Flags().BoolVarP(&fix, "fix", "f", false, "Attempt to fix as many issues as possible.")
+	fsck.Flags().BoolVar(&deep, "deep", false, "Also check that the data referenced by every commit exists in object storage.")
+	fsck.Flags().BoolVar(&readChunkData, "read-chunk-data", false, "Read and verify the data during a deep check, rather than only checking that it exists.")
+	fsck.Flags().BoolVar(&resume, "resume", false, "Resume an interrupted deep check from its last checkpoint.")
 	commands = append(commands, cmdutil.CreateAlias(fsck, "fsck"))
 
+	var minAge time.Duration
+	var dryRun bool
+	reclaimOrphans := &cobra.Command{
+		Use:   "{{alias}}",
+		Short: "Find and reclaim orphaned objects in object storage.",
+		Long:  "Find the chunk objects in object storage which are not referenced by the chunk metadata, such as objects left behind by a crash between uploading and tracking them. Orphans which are at least --min-age old are reclaimed, either by deleting them directly or by marking them for garbage collection.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			var count, reclaimable int
+			var size, reclaimableSize int64
+			writer := tabwriter.NewWriter(os.Stdout, pretty.OrphanedObjectHeader)
+			if err := c.ReclaimOrphanedObjects(minAge, dryRun, func(o *pfs.OrphanedObject) error {
+				count++
+				if o.SizeBytes > 0 {
+					size += o.SizeBytes
+				}
+				if o.Reclaimed || o.Reclaimable {
+					reclaimable++
+					if o.SizeBytes > 0 {
+						reclaimableSize += o.SizeBytes
+					}
+				}
+				pretty.PrintOrphanedObject(writer, o)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			verb := "reclaimed"
+			if dryRun {
+				verb = "reclaimable"
+			}
+			fmt.Printf("%d orphaned objects (%s), %d %s (%s)\n", count, units.BytesSize(float64(size)), reclaimable, verb, units.BytesSize(float64(reclaimableSize)))
+			return nil
+		}),
+	}
+	reclaimOrphans.Flags().DurationVar(&minAge, "min-age", 24*time.Hour, "Only reclaim orphaned objects which are at least this old.")
+	reclaimOrphans.Flags().BoolVar(&dryRun, "dry-run", false, "Only report the orphaned objects, don't reclaim them.")
+	commands = append(commands, cmdutil.CreateAlias(reclaimOrphans, "reclaim-orphans"))
+
+	var depth int64
+	var history bool
+	du := &cobra.Command{
+		Use:   "{{alias}} <repo>@<branch-or-commit>[:<path/to/dir>]",
+		Short: "Report the disk usage of the directories in a commit.",
+		Long: `Report the aggregated sizes and file counts of the directories under a path in a commit, down to --depth directory levels below the path. The sizes are computed from the file set index, without reading any data.
+With --history, report the logical size of the finished commits in a repo and the size of the distinct chunks they reference, which is what the history of the repo costs in object storage.`,
+		Example: `
+# Report the disk usage of the top level directories on master
+$ {{alias}} foo@master
+
+# Report the disk usage of the directories under /dir, two levels deep
+$ {{alias}} foo@master:/dir --depth 2
+
+# Compare the logical size of the history of repo foo with its unique size
+$ {{alias}} foo --history`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			if history {
+				repo := cmdutil.ParseRepo(args[0])
+				resp, err := c.RepoDiskUsage(repo.Name)
+				if err != nil {
+					return err
+				}
+				if raw {
+					return cmdutil.Encoder(output, os.Stdout).EncodeProto(resp)
+				} else if output != "" {
+					return errors.New("cannot set --output (-o) without --raw")
+				}
+				pretty.PrintRepoDiskUsage(os.Stdout, repo.Name, resp)
+				return nil
+			}
+			file, err := cmdutil.ParseFile(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				e := cmdutil.Encoder(output, os.Stdout)
+				return c.DiskUsage(file.Commit, file.Path, depth, func(info *pfs.DiskUsageInfo) error {
+					return e.EncodeProto(info)
+				})
+			} else if output != "" {
+				return errors.New("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.DiskUsageHeader)
+			if err := c.DiskUsage(file.Commit, file.Path, depth, func(info *pfs.DiskUsageInfo) error {
+				pretty.PrintDiskUsageInfo(writer, info)
+				return nil
+			}); err != nil {
+				return err
+			}
+			return writer.Flush()
+		}),
+	}
+	du.Flags().Int64VarP(&depth, "depth", "d", 1, "The number of directory levels below the path to report.")
+	du.Flags().BoolVar(&history, "history", false, "Report the disk usage of the history of a repo, rather than of the directories in a commit.")
+	du.Flags().AddFlagSet(outputFlags)
+	shell.RegisterCompletionFunc(du, shell.FileCompletion)
+	commands = append(commands, cmdutil.CreateAlias(du, "du"))
+
 	var branchStr string
 	var seed int64
 	runLoadTest := &cobra.Command{
@@ -1667,7 +2152,7 @@ Objects are a low-level resource and should not be accessed directly by most use
 	return commands
 }
 
-func putFileHelper(mf client.ModifyFile, path, source string, recursive, appendFile bool) (retErr error) {
+func putFileHelper(mf client.ModifyFile, path, source string, recursive, appendFile, byReference bool) (retErr error) {
 	// Resolve the path and convert to unix path in case we're on windows.
 	path = filepath.ToSlash(filepath.Clean(path))
 	var opts []client.PutFileOption
@@ -1676,8 +2161,14 @@ func putFileHelper(mf client.ModifyFile, path, source string, recursive, appendF
 	}
 	// try parsing the filename as a url, if it is one do a PutFileURL
 	if url, err := url.Parse(source); err == nil && url.Scheme != "" {
+		if byReference {
+			opts = append(opts, client.WithByReferencePutFile())
+		}
 		return mf.PutFileURL(path, url.String(), recursive, opts...)
 	}
+	if byReference {
+		return errors.Errorf("cannot put %v by reference, only object storage URLs can be put by reference", source)
+	}
 	if source == "-" {
 		if recursive {
 			return errors.New("cannot set -r and read from stdin (must also set -f or -i)")
@@ -1701,7 +2192,7 @@ func putFileHelper(mf client.ModifyFile, path, source string, recursive, appendF
 			// don't do a second recursive 'put file', just put the one file at
 			// filePath into childDest, and then this walk loop will go on to the
 			// next one
-			return putFileHelper(mf, childDest, filePath, false, appendFile)
+			return putFileHelper(mf, childDest, filePath, false, appendFile, false)
 		})
 	}
 	f, err := progress.Open(source)
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/server/pfs/fuse"
 
+	units "github.com/docker/go-units"
 	"github.com/hanwen/go-fuse/v2/fs"
 	gofuse "github.com/hanwen/go-fuse/v2/fuse"
 	"github.com/spf13/cobra"
@@ -69,7 +71,12 @@ func mountCmds() []*cobra.Command {
 	var commands []*cobra.Command
 
 	var write bool
+	var history bool
 	var debug bool
+	var cacheDir string
+	var cacheSize string
+	var readAhead int
+	var prefetch bool
 	var repoOpts cmdutil.RepeatedStringArg
 	mount := &cobra.Command{
 		Use:   "{{alias}} <path/to/mount/point>",
@@ -86,8 +93,30 @@ func mountCmds() []*cobra.Command {
 			if err != nil {
 				return err
 			}
+			var cacheOpts *fuse.CacheOptions
+			if cacheSize != "" {
+				size, err := units.FromHumanSize(cacheSize)
+				if err != nil {
+					return errors.Wrapf(err, "invalid cache size %q", cacheSize)
+				}
+				if cacheDir == "" {
+					userCacheDir, err := os.UserCacheDir()
+					if err != nil {
+						return errors.EnsureStack(err)
+					}
+					cacheDir = filepath.Join(userCacheDir, "pachyderm", "fuse")
+				}
+				cacheOpts = &fuse.CacheOptions{
+					Dir:       cacheDir,
+					Size:      size,
+					ReadAhead: readAhead,
+					Prefetch:  prefetch,
+				}
+			}
 			opts := &fuse.Options{
-				Write: write,
+				Write:   write,
+				History: history,
+				Cache:   cacheOpts,
 				Fuse: &fs.Options{
 					MountOptions: gofuse.MountOptions{
 						Debug:  debug,
@@ -103,6 +132,11 @@ func mountCmds() []*cobra.Command {
 		}),
 	}
 	mount.Flags().BoolVarP(&write, "write", "w", false, "Allow writing to pfs through the mount.")
+	mount.Flags().BoolVar(&history, "history", false, "Mount all the branches and commits of each repo, under <repo>/branches/<branch> and <repo>/commits/<commit>.")
+	mount.Flags().StringVar(&cacheSize, "cache-size", "", "Cache the files read through the mount on local disk, up to this size (e.g. 10GB). The cache is disabled if this isn't set.")
+	mount.Flags().StringVar(&cacheDir, "cache-dir", "", "The directory the cache is kept in, which persists across mounts. Defaults to a pachyderm/fuse directory in the user's cache directory.")
+	mount.Flags().IntVar(&readAhead, "read-ahead", 4, "The number of blocks to fetch ahead of sequential reads, when the cache is enabled.")
+	mount.Flags().BoolVar(&prefetch, "prefetch", false, "Fetch the files of a directory into the cache in the background when it is listed.")
 	mount.Flags().BoolVarP(&debug, "debug", "d", false, "Turn on debug messages.")
 	mount.Flags().VarP(&repoOpts, "repos", "r", "Repos and branches / commits to mount, arguments should be of the form \"repo@branch+w\", where the trailing flag \"+w\" indicates write.")
 	mount.MarkFlagCustom("repos", "__pachctl_get_repo_branch")
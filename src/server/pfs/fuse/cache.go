@@ -0,0 +1,479 @@
+package fuse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hashicorp/golang-lru/simplelru"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+const (
+	// cacheBlockSize is the size of the blocks files are cached in. It is
+	// fixed so that the blocks cached by one mount are reused by the next.
+	cacheBlockSize = 8 * 1024 * 1024
+
+	prefetchWorkers   = 4
+	prefetchQueueSize = 1024
+
+	tmpBlockPrefix = ".tmp"
+	// staleTmpBlockAge is the age past which the partial downloads left in
+	// the cache are removed, younger ones may be downloads in progress by a
+	// concurrent mount of the cache.
+	staleTmpBlockAge = time.Hour
+)
+
+// blockCache is a persistent, size-bounded cache of the content of the files
+// read through a mount, in blocks keyed by the hash of each file, so that a
+// file which hasn't changed is downloaded once across mounts and commits.
+// The least recently used blocks are evicted once the cache is full, and the
+// use of a block is recorded in its modification time so that the order of
+// eviction outlives the mount.
+type blockCache struct {
+	c         *client.APIClient
+	dir       string
+	maxSize   int64
+	readAhead int
+	prefetch  bool
+
+	mu     sync.Mutex
+	blocks *simplelru.LRU
+	size   int64
+	// fetches are the blocks being downloaded, so that concurrent reads of
+	// a block download it once
+	fetches map[string]*blockFetch
+
+	prefetches chan *blockRef
+	done       chan struct{}
+	wg         sync.WaitGroup
+}
+
+type blockFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// blockRef is a block of a file, to be prefetched.
+type blockRef struct {
+	fi    *pfs.FileInfo
+	index int64
+}
+
+func newBlockCache(c *client.APIClient, opts *CacheOptions) (*blockCache, error) {
+	if err := os.MkdirAll(opts.Dir, 0777); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	bc := &blockCache{
+		c:          c,
+		dir:        opts.Dir,
+		maxSize:    opts.Size,
+		readAhead:  opts.ReadAhead,
+		prefetch:   opts.Prefetch,
+		fetches:    make(map[string]*blockFetch),
+		prefetches: make(chan *blockRef, prefetchQueueSize),
+		done:       make(chan struct{}),
+	}
+	// The size of the cache is bounded in bytes rather than in blocks.
+	blocks, err := simplelru.NewLRU(math.MaxInt32, bc.onEvicted)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	bc.blocks = blocks
+	if err := bc.populate(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < prefetchWorkers; i++ {
+		bc.wg.Add(1)
+		go bc.prefetchWorker()
+	}
+	return bc, nil
+}
+
+// populate adds the blocks cached by previous mounts to the cache, least
+// recently used first.
+func (bc *blockCache) populate() error {
+	type cachedBlock struct {
+		key     string
+		size    int64
+		modTime time.Time
+	}
+	var cached []cachedBlock
+	if err := filepath.Walk(bc.dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if strings.HasPrefix(info.Name(), tmpBlockPrefix) {
+			// Left behind by a mount which stopped while downloading.
+			if time.Since(info.ModTime()) > staleTmpBlockAge {
+				if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+					return errors.EnsureStack(err)
+				}
+			}
+			return nil
+		}
+		key, err := filepath.Rel(bc.dir, p)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		cached = append(cached, cachedBlock{key: key, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	sort.Slice(cached, func(i, j int) bool {
+		return cached[i].modTime.Before(cached[j].modTime)
+	})
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	for _, block := range cached {
+		bc.add(block.key, block.size)
+	}
+	return nil
+}
+
+func (bc *blockCache) close() {
+	close(bc.done)
+	bc.wg.Wait()
+}
+
+// cacheable returns whether a file can be read through the cache, which
+// requires its hash.
+func cacheable(fi *pfs.FileInfo) bool {
+	return len(fi.Hash) > 0
+}
+
+func blockKey(fi *pfs.FileInfo, index int64) string {
+	hash := fmt.Sprintf("%x", fi.Hash)
+	return filepath.Join(hash[:2], hash, strconv.FormatInt(index, 10))
+}
+
+func numBlocks(fi *pfs.FileInfo) int64 {
+	return (fi.SizeBytes + cacheBlockSize - 1) / cacheBlockSize
+}
+
+// get returns the path of a block of a file in the cache, downloading it if
+// it isn't cached.
+func (bc *blockCache) get(fi *pfs.FileInfo, index int64) (string, error) {
+	key := blockKey(fi, index)
+	for {
+		bc.mu.Lock()
+		if _, ok := bc.blocks.Get(key); ok {
+			bc.mu.Unlock()
+			return filepath.Join(bc.dir, key), nil
+		}
+		if fetch, ok := bc.fetches[key]; ok {
+			bc.mu.Unlock()
+			<-fetch.done
+			if fetch.err != nil {
+				return "", fetch.err
+			}
+			// The block may have been evicted since it was downloaded.
+			continue
+		}
+		fetch := &blockFetch{done: make(chan struct{})}
+		bc.fetches[key] = fetch
+		bc.mu.Unlock()
+
+		size, err := bc.download(fi, index, key)
+		bc.mu.Lock()
+		delete(bc.fetches, key)
+		if err == nil {
+			bc.add(key, size)
+		}
+		bc.mu.Unlock()
+		fetch.err = err
+		close(fetch.done)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(bc.dir, key), nil
+	}
+}
+
+// open opens a block of a file in the cache, downloading it if it isn't
+// cached.
+func (bc *blockCache) open(fi *pfs.FileInfo, index int64) (*os.File, error) {
+	for {
+		p, err := bc.get(fi, index)
+		if err != nil {
+			return nil, err
+		}
+		block, err := os.Open(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The block was either evicted between getting and opening
+				// it, or removed from the cache's directory, in which case
+				// it is dropped so that it is downloaded again.
+				bc.drop(blockKey(fi, index))
+				continue
+			}
+			return nil, errors.WithStack(err)
+		}
+		bc.touch(p)
+		return block, nil
+	}
+}
+
+// drop removes a block from the cache if its file is missing.
+func (bc *blockCache) drop(key string) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if !bc.blocks.Contains(key) {
+		return
+	}
+	if _, err := os.Stat(filepath.Join(bc.dir, key)); os.IsNotExist(err) {
+		bc.blocks.Remove(key)
+	}
+}
+
+// download downloads a block of a file into the cache, and returns its size.
+func (bc *blockCache) download(fi *pfs.FileInfo, index int64, key string) (_ int64, retErr error) {
+	p := filepath.Join(bc.dir, key)
+	if err := os.MkdirAll(filepath.Dir(p), 0777); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(p), tmpBlockPrefix)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer func() {
+		if retErr != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+	offset := index * cacheBlockSize
+	size := fi.SizeBytes - offset
+	if size > cacheBlockSize {
+		size = cacheBlockSize
+	}
+	if err := func() (retErr error) {
+		defer func() {
+			if err := tmp.Close(); err != nil && retErr == nil {
+				retErr = errors.WithStack(err)
+			}
+		}()
+		ctx, cancel := context.WithCancel(bc.c.Ctx())
+		defer cancel()
+		getFileClient, err := bc.c.PfsAPIClient.GetFile(ctx, &pfs.GetFileRequest{
+			File:   fi.File,
+			Offset: offset,
+		})
+		if err != nil {
+			return grpcutil.ScrubGRPC(err)
+		}
+		r := grpcutil.NewStreamingBytesReader(getFileClient, cancel)
+		defer r.Close()
+		_, err = io.CopyN(tmp, r, size)
+		return errors.EnsureStack(err)
+	}(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return size, nil
+}
+
+// add adds a block to the cache, and evicts the least recently used blocks
+// until the cache fits in its size. bc.mu must be held.
+func (bc *blockCache) add(key string, size int64) {
+	bc.blocks.Add(key, size)
+	bc.size += size
+	for bc.size > bc.maxSize && bc.blocks.Len() > 1 {
+		bc.blocks.RemoveOldest()
+	}
+}
+
+// onEvicted is called by the LRU with bc.mu held.
+func (bc *blockCache) onEvicted(key, value interface{}) {
+	bc.size -= value.(int64)
+	// Readers which have the block open keep reading it.
+	if err := os.Remove(filepath.Join(bc.dir, key.(string))); err != nil && !os.IsNotExist(err) {
+		log.Errorf("could not evict block from the fuse cache: %v", err)
+	}
+}
+
+// touch records the use of a block, which orders its eviction in later
+// mounts.
+func (bc *blockCache) touch(p string) {
+	now := time.Now()
+	if err := os.Chtimes(p, now, now); err != nil && !os.IsNotExist(err) {
+		log.Errorf("could not touch block in the fuse cache: %v", err)
+	}
+}
+
+// prefetchBlocks queues blocks of a file to be downloaded in the background.
+// Blocks are dropped if the queue is full.
+func (bc *blockCache) prefetchBlocks(fi *pfs.FileInfo, from, to int64) {
+	if n := numBlocks(fi); to > n {
+		to = n
+	}
+	for index := from; index < to; index++ {
+		select {
+		case bc.prefetches <- &blockRef{fi: fi, index: index}:
+		default:
+			return
+		}
+	}
+}
+
+// prefetchFiles queues the files of a directory to be downloaded in the
+// background, up to a quarter of the size of the cache so that prefetching
+// doesn't evict the rest of the cache.
+func (bc *blockCache) prefetchFiles(fis []*pfs.FileInfo) {
+	budget := bc.maxSize / 4
+	for _, fi := range fis {
+		if fi.SizeBytes > budget {
+			return
+		}
+		budget -= fi.SizeBytes
+		bc.prefetchBlocks(fi, 0, numBlocks(fi))
+	}
+}
+
+func (bc *blockCache) prefetchWorker() {
+	defer bc.wg.Done()
+	for {
+		select {
+		case ref := <-bc.prefetches:
+			if _, err := bc.get(ref.fi, ref.index); err != nil {
+				log.Debugf("could not prefetch block %d of %s: %v", ref.index, ref.fi.File.Path, err)
+			}
+		case <-bc.done:
+			return
+		}
+	}
+}
+
+// cachedFile is a handle of a file opened for reading, which is read through
+// the block cache. Sequential reads are read ahead of.
+type cachedFile struct {
+	cache *blockCache
+	fi    *pfs.FileInfo
+	// path is the path of the file in the loopback filesystem, which holds
+	// its attributes
+	path string
+
+	mu sync.Mutex
+	// f is the open block of the file, the index of which is index
+	f     *os.File
+	index int64
+	// next is the offset following the last read
+	next int64
+}
+
+var _ = (fs.FileHandle)((*cachedFile)(nil))
+var _ = (fs.FileReleaser)((*cachedFile)(nil))
+var _ = (fs.FileGetattrer)((*cachedFile)(nil))
+var _ = (fs.FileReader)((*cachedFile)(nil))
+
+func newCachedFile(cache *blockCache, fi *pfs.FileInfo, path string) *cachedFile {
+	return &cachedFile{cache: cache, fi: fi, path: path, index: -1}
+}
+
+func (f *cachedFile) Read(ctx context.Context, dest []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var n int
+	for n < len(dest) && off+int64(n) < f.fi.SizeBytes {
+		pos := off + int64(n)
+		block, err := f.block(pos / cacheBlockSize)
+		if err != nil {
+			return nil, fs.ToErrno(err)
+		}
+		m, err := block.ReadAt(dest[n:], pos%cacheBlockSize)
+		n += m
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fs.ToErrno(err)
+		}
+		if m == 0 {
+			break
+		}
+	}
+	if off == f.next && n > 0 && f.cache.readAhead > 0 {
+		next := (off+int64(n)-1)/cacheBlockSize + 1
+		f.cache.prefetchBlocks(f.fi, next, next+int64(f.cache.readAhead))
+	}
+	f.next = off + int64(n)
+	return fuse.ReadResultData(dest[:n]), fs.OK
+}
+
+// block returns the open block of the file with index, f.mu must be held.
+func (f *cachedFile) block(index int64) (*os.File, error) {
+	if f.f != nil && f.index == index {
+		return f.f, nil
+	}
+	if f.f != nil {
+		f.f.Close()
+		f.f = nil
+	}
+	block, err := f.cache.open(f.fi, index)
+	if err != nil {
+		return nil, err
+	}
+	f.f, f.index = block, index
+	return block, nil
+}
+
+func (f *cachedFile) Getattr(ctx context.Context, out *fuse.AttrOut) syscall.Errno {
+	st := syscall.Stat_t{}
+	if err := syscall.Lstat(f.path, &st); err != nil {
+		return fs.ToErrno(err)
+	}
+	out.FromStat(&st)
+	return fs.OK
+}
+
+func (f *cachedFile) Release(ctx context.Context) syscall.Errno {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.f != nil {
+		err := f.f.Close()
+		f.f = nil
+		return fs.ToErrno(err)
+	}
+	return fs.OK
+}
+
+// copyFile copies a file into w through the cache.
+func (bc *blockCache) copyFile(fi *pfs.FileInfo, w io.Writer) error {
+	for index := int64(0); index < numBlocks(fi); index++ {
+		if err := func() (retErr error) {
+			block, err := bc.open(fi, index)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := block.Close(); err != nil && retErr == nil {
+					retErr = errors.WithStack(err)
+				}
+			}()
+			_, err = io.Copy(w, block)
+			return errors.EnsureStack(err)
+		}(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,7 +7,6 @@ import (
 	"os/signal"
 	pathpkg "path"
 	"path/filepath"
-	"strings"
 
 	"github.com/hanwen/go-fuse/v2/fs"
 
@@ -41,6 +40,14 @@ func Mount(c *client.APIClient, target string, opts *Options) (retErr error) {
 	if err != nil {
 		return err
 	}
+	if cacheOpts := opts.getCache(); cacheOpts != nil {
+		cache, err := newBlockCache(c, cacheOpts)
+		if err != nil {
+			return err
+		}
+		defer cache.close()
+		root.cache = cache
+	}
 	server, err := fs.Mount(target, root, opts.getFuse())
 	if err != nil {
 		return errors.WithStack(err)
@@ -56,15 +63,16 @@ func Mount(c *client.APIClient, target string, opts *Options) (retErr error) {
 	}()
 	server.Wait()
 	mfcs := make(map[string]*client.ModifyFileClient)
-	mfc := func(repo string) (*client.ModifyFileClient, error) {
-		if mfc, ok := mfcs[repo]; ok {
+	mfc := func(repo, branch string) (*client.ModifyFileClient, error) {
+		key := pathpkg.Join(repo, branch)
+		if mfc, ok := mfcs[key]; ok {
 			return mfc, nil
 		}
-		mfc, err := c.NewModifyFileClient(client.NewCommit(repo, root.branch(repo), ""))
+		mfc, err := c.NewModifyFileClient(client.NewCommit(repo, branch, ""))
 		if err != nil {
 			return nil, err
 		}
-		mfcs[repo] = mfc
+		mfcs[key] = mfc
 		return mfc, nil
 	}
 	defer func() {
@@ -83,8 +91,8 @@ func Mount(c *client.APIClient, target string, opts *Options) (retErr error) {
 		if state != dirty {
 			continue
 		}
-		parts := strings.Split(path, "/")
-		mfc, err := mfc(parts[0])
+		repo, branch, file := root.branchFile(path)
+		mfc, err := mfc(repo, branch)
 		if err != nil {
 			return err
 		}
@@ -92,7 +100,7 @@ func Mount(c *client.APIClient, target string, opts *Options) (retErr error) {
 			f, err := progress.Open(filepath.Join(root.rootPath, path))
 			if err != nil {
 				if errors.Is(err, os.ErrNotExist) {
-					return mfc.DeleteFile(pathpkg.Join(parts[1:]...))
+					return mfc.DeleteFile(file)
 				}
 				return errors.WithStack(err)
 			}
@@ -101,7 +109,7 @@ func Mount(c *client.APIClient, target string, opts *Options) (retErr error) {
 					retErr = errors.WithStack(err)
 				}
 			}()
-			return mfc.PutFile(pathpkg.Join(parts[1:]...), f)
+			return mfc.PutFile(file, f)
 		}(); err != nil {
 			return err
 		}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/pachyderm/pachyderm/v2/src/client"
 	"github.com/pachyderm/pachyderm/v2/src/internal/dockertestenv"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/require"
 	"github.com/pachyderm/pachyderm/v2/src/internal/testpachd"
 	"github.com/pachyderm/pachyderm/v2/src/internal/testutil/random"
@@ -129,6 +130,96 @@ func TestSeek(t *testing.T) {
 	})
 }
 
+func TestCache(t *testing.T) {
+	env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+	require.NoError(t, env.PachClient.CreateRepo("repo"))
+	random.SeedRand(123)
+	src := random.String(20 * MB)
+	require.NoError(t, env.PachClient.PutFile(client.NewCommit("repo", "master", ""), "dir/file", strings.NewReader(src)))
+	require.NoError(t, env.PachClient.PutFile(client.NewCommit("repo", "master", ""), "dir/file2", strings.NewReader("foo")))
+	cacheDir := t.TempDir()
+	cacheFiles := func() []string {
+		var files []string
+		require.NoError(t, filepath.Walk(cacheDir, func(p string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				files = append(files, p)
+			}
+			return err
+		}))
+		return files
+	}
+	cacheOpts := &CacheOptions{
+		Dir:       cacheDir,
+		Size:      GB,
+		ReadAhead: 2,
+	}
+	withMount(t, env.PachClient, &Options{Cache: cacheOpts}, func(mountPoint string) {
+		data, err := ioutil.ReadFile(filepath.Join(mountPoint, "repo", "dir", "file"))
+		require.NoError(t, err)
+		require.Equal(t, sha256.Sum256([]byte(src)), sha256.Sum256(data))
+
+		f, err := os.Open(filepath.Join(mountPoint, "repo", "dir", "file"))
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, f.Close())
+		}()
+		_, err = f.Seek(17*MB, 0)
+		require.NoError(t, err)
+		d, err := ioutil.ReadAll(f)
+		require.NoError(t, err)
+		require.Equal(t, src[17*MB:], string(d))
+	})
+	// The file is cached in 3 blocks, which later mounts reuse.
+	require.Equal(t, 3, len(cacheFiles()))
+
+	// Listing a directory prefetches its files.
+	cacheOpts.Prefetch = true
+	withMount(t, env.PachClient, &Options{Cache: cacheOpts}, func(mountPoint string) {
+		_, err := ioutil.ReadDir(filepath.Join(mountPoint, "repo", "dir"))
+		require.NoError(t, err)
+		require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+			if n := len(cacheFiles()); n != 4 {
+				return errors.Errorf("expected 4 cached blocks, got %d", n)
+			}
+			return nil
+		})
+	})
+
+	// The cache is bounded to its size.
+	cacheOpts.Size = 8*MB + 1
+	cacheOpts.Prefetch = false
+	withMount(t, env.PachClient, &Options{Cache: cacheOpts}, func(mountPoint string) {
+		data, err := ioutil.ReadFile(filepath.Join(mountPoint, "repo", "dir", "file"))
+		require.NoError(t, err)
+		require.Equal(t, sha256.Sum256([]byte(src)), sha256.Sum256(data))
+	})
+	require.Equal(t, 1, len(cacheFiles()))
+
+	// Blocks removed from the cache's directory are downloaded again, and
+	// the partial downloads of concurrent mounts are kept.
+	tmp, err := ioutil.TempFile(cacheDir, tmpBlockPrefix)
+	require.NoError(t, err)
+	require.NoError(t, tmp.Close())
+	cacheOpts.Size = GB
+	bc, err := newBlockCache(env.PachClient, cacheOpts)
+	require.NoError(t, err)
+	defer bc.close()
+	_, err = os.Stat(tmp.Name())
+	require.NoError(t, err)
+	fi, err := env.PachClient.InspectFile(client.NewCommit("repo", "master", ""), "dir/file")
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		buf := &bytes.Buffer{}
+		require.NoError(t, bc.copyFile(fi, buf))
+		require.Equal(t, sha256.Sum256([]byte(src)), sha256.Sum256(buf.Bytes()))
+		for _, p := range cacheFiles() {
+			if p != tmp.Name() {
+				require.NoError(t, os.Remove(p))
+			}
+		}
+	}
+}
+
 func TestHeadlessBranch(t *testing.T) {
 	env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
 	require.NoError(t, env.PachClient.CreateRepo("repo"))
@@ -336,6 +427,65 @@ func TestRepoOpts(t *testing.T) {
 	require.Equal(t, "fizz\n", b.String())
 }
 
+func TestHistory(t *testing.T) {
+	env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+	require.NoError(t, env.PachClient.CreateRepo("repo"))
+	require.NoError(t, env.PachClient.PutFile(client.NewCommit("repo", "master", ""), "file", strings.NewReader("foo\n")))
+	commitInfo, err := env.PachClient.InspectCommit("repo", "master", "")
+	require.NoError(t, err)
+	oldCommit := commitInfo.Commit.ID
+	require.NoError(t, env.PachClient.CreateBranch("repo", "old", "master", oldCommit, nil))
+	require.NoError(t, env.PachClient.PutFile(client.NewCommit("repo", "master", ""), "file", strings.NewReader("bar\n")))
+
+	withMount(t, env.PachClient, &Options{
+		Fuse: &fs.Options{
+			MountOptions: fuse.MountOptions{
+				Debug: true,
+			},
+		},
+		Write:   true,
+		History: true,
+	}, func(mountPoint string) {
+		dirs, err := ioutil.ReadDir(filepath.Join(mountPoint, "repo"))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(dirs))
+		require.Equal(t, "branches", dirs[0].Name())
+		require.Equal(t, "commits", dirs[1].Name())
+
+		branches, err := ioutil.ReadDir(filepath.Join(mountPoint, "repo", "branches"))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(branches))
+		require.Equal(t, "master", branches[0].Name())
+		require.Equal(t, "old", branches[1].Name())
+
+		data, err := ioutil.ReadFile(filepath.Join(mountPoint, "repo", "branches", "master", "file"))
+		require.NoError(t, err)
+		require.Equal(t, "bar\n", string(data))
+		data, err = ioutil.ReadFile(filepath.Join(mountPoint, "repo", "branches", "old", "file"))
+		require.NoError(t, err)
+		require.Equal(t, "foo\n", string(data))
+
+		commits, err := ioutil.ReadDir(filepath.Join(mountPoint, "repo", "commits"))
+		require.NoError(t, err)
+		var found bool
+		for _, commit := range commits {
+			found = found || commit.Name() == oldCommit
+		}
+		require.True(t, found)
+		data, err = ioutil.ReadFile(filepath.Join(mountPoint, "repo", "commits", oldCommit, "file"))
+		require.NoError(t, err)
+		require.Equal(t, "foo\n", string(data))
+
+		// Commits are read-only, branches are written to when unmounting.
+		require.YesError(t, ioutil.WriteFile(filepath.Join(mountPoint, "repo", "commits", oldCommit, "file2"), []byte("foo\n"), 0644))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(mountPoint, "repo", "branches", "old", "file2"), []byte("buzz\n"), 0644))
+	})
+	var b bytes.Buffer
+	require.NoError(t, env.PachClient.GetFile(client.NewCommit("repo", "old", ""), "file2", &b))
+	require.Equal(t, "buzz\n", b.String())
+	require.YesError(t, env.PachClient.GetFile(client.NewCommit("repo", "master", ""), "file2", &b))
+}
+
 func TestOpenCommit(t *testing.T) {
 	env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
 	require.NoError(t, env.PachClient.CreateRepo("in"))
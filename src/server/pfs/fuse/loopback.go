@@ -6,6 +6,7 @@ package fuse
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
 	pathpkg "path"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/hanwen/go-fuse/v2/fs"
 	"github.com/hanwen/go-fuse/v2/fuse"
 
@@ -32,6 +34,13 @@ const (
 	dirty                  // we have full content for this file and the user has written to it
 )
 
+const (
+	// branchesDir and commitsDir are the directories of each repo which
+	// hold its branches and commits in the history layout.
+	branchesDir = "branches"
+	commitsDir  = "commits"
+)
+
 type loopbackRoot struct {
 	loopbackNode
 
@@ -40,15 +49,24 @@ type loopbackRoot struct {
 
 	targetPath string
 
-	write bool
+	write   bool
+	history bool
 
 	c *client.APIClient
 
 	repoOpts map[string]*RepoOptions
 	branches map[string]string
-	commits  map[string]string
-	files    map[string]fileState
-	mu       sync.Mutex
+	// commits are the commits mounted at each commit root path, which is
+	// the path of a repo, or of a branch or commit in the history layout
+	commits map[string]*pfs.Commit
+	files   map[string]fileState
+	// infos are the infos of the files which have been listed, by path
+	infos map[string]*pfs.FileInfo
+	mu    sync.Mutex
+
+	// cache is the disk cache files are read through, it is nil if the
+	// cache is disabled
+	cache *blockCache
 }
 
 type loopbackNode struct {
@@ -359,6 +377,16 @@ func (n *loopbackNode) Readlink(ctx context.Context) ([]byte, syscall.Errno) {
 
 func (n *loopbackNode) Open(ctx context.Context, flags uint32) (fh fs.FileHandle, fuseFlags uint32, errno syscall.Errno) {
 	p := n.path()
+	// Files which are only read are read through the cache, rather than
+	// downloaded into the loopback filesystem.
+	if cache := n.root().cache; cache != nil && !isWrite(flags) && !isCreate(flags) {
+		if err := n.download(p, meta); err != nil {
+			return nil, 0, fs.ToErrno(err)
+		}
+		if fi := n.getFileInfo(p); fi != nil && cacheable(fi) && n.getFileState(p) < full {
+			return newCachedFile(cache, fi, p), 0, 0
+		}
+	}
 	state := full
 	if isWrite(flags) {
 		if errno := n.checkWrite(p); errno != 0 {
@@ -400,9 +428,29 @@ func (n *loopbackNode) Readdir(ctx context.Context) (fs.DirStream, syscall.Errno
 	if err := n.download(n.path(), meta); err != nil {
 		return nil, fs.ToErrno(err)
 	}
+	if cache := n.root().cache; cache != nil && cache.prefetch {
+		n.prefetchDir(cache)
+	}
 	return fs.NewLoopbackDirStream(n.path())
 }
 
+// prefetchDir prefetches the files of the directory into the cache, which
+// haven't been downloaded into the loopback filesystem.
+func (n *loopbackNode) prefetchDir(cache *blockCache) {
+	entries, err := ioutil.ReadDir(n.path())
+	if err != nil {
+		return
+	}
+	var fis []*pfs.FileInfo
+	for _, entry := range entries {
+		p := filepath.Join(n.path(), entry.Name())
+		if fi := n.getFileInfo(p); fi != nil && cacheable(fi) && n.getFileState(p) < full {
+			fis = append(fis, fi)
+		}
+	}
+	cache.prefetchFiles(fis)
+}
+
 func (n *loopbackNode) Getattr(ctx context.Context, f fs.FileHandle, out *fuse.AttrOut) syscall.Errno {
 	if f != nil {
 		return f.(fs.FileGetattrer).Getattr(ctx, out)
@@ -510,11 +558,13 @@ func newLoopbackRoot(root, target string, c *client.APIClient, opts *Options) (*
 		rootDev:    uint64(st.Dev),
 		targetPath: target,
 		write:      opts.getWrite(),
+		history:    opts.getHistory(),
 		c:          c,
 		repoOpts:   opts.getRepoOpts(),
 		branches:   opts.getBranches(),
-		commits:    make(map[string]string),
+		commits:    make(map[string]*pfs.Commit),
 		files:      make(map[string]fileState),
+		infos:      make(map[string]*pfs.FileInfo),
 	}
 	return n, nil
 }
@@ -568,19 +618,40 @@ func (n *loopbackNode) download(path string, state fileState) (retErr error) {
 	if len(parts) < 1 || parts[0] == "" {
 		return nil //already downloaded in downloadRepos
 	}
-	branch := n.root().branch(parts[0])
-	commit, err := n.commit(parts[0])
+	var commit *pfs.Commit
+	var commitRoot string
+	var err error
+	if n.root().history {
+		commit, commitRoot, err = n.downloadHistory(parts)
+		if len(parts) > 3 {
+			parts = parts[3:]
+		} else {
+			parts = nil
+		}
+	} else {
+		repo, branch := parts[0], n.root().branch(parts[0])
+		commitRoot = repo
+		commit, err = n.commit(commitRoot, func() (*pfs.Commit, error) {
+			bi, err := n.c().InspectBranch(repo, branch)
+			if err != nil {
+				return nil, err
+			}
+			return bi.Head, nil
+		})
+		parts = parts[1:]
+	}
 	if err != nil {
 		return err
 	}
-	if commit == "" {
+	if commit == nil {
 		return nil
 	}
-	if err := n.c().ListFile(client.NewCommit(parts[0], branch, commit), pathpkg.Join(parts[1:]...), func(fi *pfs.FileInfo) (retErr error) {
+	if err := n.c().ListFile(commit, pathpkg.Join(parts...), func(fi *pfs.FileInfo) (retErr error) {
 		if fi.FileType == pfs.FileType_DIR {
-			return os.MkdirAll(n.filePath(fi), 0777)
+			return os.MkdirAll(n.filePath(commitRoot, fi), 0777)
 		}
-		p := n.filePath(fi)
+		p := n.filePath(commitRoot, fi)
+		n.setFileInfo(p, fi)
 		// Make sure the directory exists
 		// I think this may be unnecessary based on the constraints the
 		// OS imposes, but don't want to rely on that, especially
@@ -600,6 +671,9 @@ func (n *loopbackNode) download(path string, state fileState) (retErr error) {
 		if state < full {
 			return f.Truncate(int64(fi.SizeBytes))
 		}
+		if cache := n.root().cache; cache != nil && cacheable(fi) {
+			return cache.copyFile(fi, f)
+		}
 		if err := n.c().GetFile(fi.File.Commit, fi.File.Path, f); err != nil {
 			return err
 		}
@@ -629,19 +703,21 @@ func (n *loopbackNode) branch(repo string) string {
 	return "master"
 }
 
-func (n *loopbackNode) commit(repo string) (string, error) {
-	if commit, ok := func() (string, bool) {
+// commit returns the commit mounted at commitRoot, which is resolved once per
+// mount so that the files under it come from one commit. It returns nil if
+// resolve doesn't find the commit.
+func (n *loopbackNode) commit(commitRoot string, resolve func() (*pfs.Commit, error)) (*pfs.Commit, error) {
+	if commit, ok := func() (*pfs.Commit, bool) {
 		n.root().mu.Lock()
 		defer n.root().mu.Unlock()
-		commit, ok := n.root().commits[repo]
+		commit, ok := n.root().commits[commitRoot]
 		return commit, ok
 	}(); ok {
 		return commit, nil
 	}
-	branch := n.root().branch(repo)
-	bi, err := n.root().c.InspectBranch(repo, branch)
+	commit, err := resolve()
 	if err != nil && !errutil.IsNotFoundError(err) {
-		return "", err
+		return nil, err
 	}
 	// Lock mu to assign commits
 	n.root().mu.Lock()
@@ -649,19 +725,124 @@ func (n *loopbackNode) commit(repo string) (string, error) {
 	// You can access branches that don't exist, which allows you to create
 	// branches through the fuse mount.
 	if errutil.IsNotFoundError(err) {
-		n.root().commits[repo] = ""
-		return "", nil
+		n.root().commits[commitRoot] = nil
+		return nil, nil
+	}
+	n.root().commits[commitRoot] = commit
+	return commit, nil
+}
+
+// downloadHistory creates the directories of a path of the history layout
+// which are above the files of a commit, the branches and commits of a repo
+// being listed when their directory is first downloaded. It returns the
+// commit the path is in and the path of the commit's root directory, or a nil
+// commit if the path isn't in one.
+func (n *loopbackNode) downloadHistory(parts []string) (*pfs.Commit, string, error) {
+	repo := parts[0]
+	if len(parts) == 1 {
+		for _, dir := range []string{branchesDir, commitsDir} {
+			if err := os.MkdirAll(filepath.Join(n.root().rootPath, repo, dir), 0777); err != nil {
+				return nil, "", errors.WithStack(err)
+			}
+		}
+		return nil, "", nil
+	}
+	var commit *pfs.Commit
+	var err error
+	commitRoot := pathpkg.Join(parts...)
+	if len(parts) > 3 {
+		commitRoot = pathpkg.Join(parts[:3]...)
+	}
+	switch parts[1] {
+	case branchesDir:
+		if len(parts) == 2 {
+			bis, err := n.c().ListBranch(repo)
+			if err != nil {
+				return nil, "", err
+			}
+			for _, bi := range bis {
+				if err := os.MkdirAll(filepath.Join(n.root().rootPath, repo, branchesDir, bi.Branch.Name), 0777); err != nil {
+					return nil, "", errors.WithStack(err)
+				}
+			}
+			return nil, "", nil
+		}
+		commit, err = n.commit(commitRoot, func() (*pfs.Commit, error) {
+			bi, err := n.c().InspectBranch(repo, parts[2])
+			if err != nil {
+				return nil, err
+			}
+			return bi.Head, nil
+		})
+	case commitsDir:
+		if len(parts) == 2 {
+			cis, err := n.c().ListCommitByRepo(client.NewRepo(repo))
+			if err != nil {
+				return nil, "", err
+			}
+			for _, ci := range cis {
+				if err := n.mkdirCommit(filepath.Join(n.root().rootPath, repo, commitsDir, ci.Commit.ID), ci); err != nil {
+					return nil, "", err
+				}
+			}
+			return nil, "", nil
+		}
+		commit, err = n.commit(commitRoot, func() (*pfs.Commit, error) {
+			ci, err := n.c().InspectCommit(repo, "", parts[2])
+			if err != nil {
+				return nil, err
+			}
+			if err := n.mkdirCommit(filepath.Join(n.root().rootPath, commitRoot), ci); err != nil {
+				return nil, err
+			}
+			return ci.Commit, nil
+		})
+	default:
+		return nil, "", nil
+	}
+	if err != nil || commit == nil {
+		return nil, "", err
+	}
+	// The commit may be empty, in which case listing it doesn't create its
+	// directory.
+	if err := os.MkdirAll(filepath.Join(n.root().rootPath, commitRoot), 0777); err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+	return commit, commitRoot, nil
+}
+
+// mkdirCommit creates the directory of a commit in the history layout, dated
+// with the time the commit was started.
+func (n *loopbackNode) mkdirCommit(p string, ci *pfs.CommitInfo) error {
+	if err := os.MkdirAll(p, 0777); err != nil {
+		return errors.WithStack(err)
+	}
+	if ci.Started == nil {
+		return nil
+	}
+	started, err := types.TimestampFromProto(ci.Started)
+	if err != nil {
+		return errors.EnsureStack(err)
 	}
-	n.root().commits[repo] = bi.Head.ID
-	return bi.Head.ID, nil
+	return errors.EnsureStack(os.Chtimes(p, started, started))
 }
 
 func (n *loopbackNode) repoPath(ri *pfs.RepoInfo) string {
 	return filepath.Join(n.root().rootPath, ri.Repo.Name)
 }
 
-func (n *loopbackNode) filePath(fi *pfs.FileInfo) string {
-	return filepath.Join(n.root().rootPath, fi.File.Commit.Branch.Repo.Name, fi.File.Path)
+func (n *loopbackNode) filePath(commitRoot string, fi *pfs.FileInfo) string {
+	return filepath.Join(n.root().rootPath, commitRoot, fi.File.Path)
+}
+
+// branchFile returns the branch a written path of the mount is uploaded to,
+// and the path of the file in the branch.
+func (r *loopbackRoot) branchFile(path string) (repo, branch, file string) {
+	parts := strings.Split(path, "/")
+	if r.history {
+		return parts[0], parts[2], pathpkg.Join(parts[3:]...)
+	}
+	return parts[0], r.branch(parts[0]), pathpkg.Join(parts[1:]...)
 }
 
 func (n *loopbackNode) getFileState(path string) fileState {
@@ -676,8 +857,25 @@ func (n *loopbackNode) setFileState(path string, state fileState) {
 	n.root().files[n.trimPath(path)] = state
 }
 
+func (n *loopbackNode) getFileInfo(path string) *pfs.FileInfo {
+	n.root().mu.Lock()
+	defer n.root().mu.Unlock()
+	return n.root().infos[n.trimPath(path)]
+}
+
+func (n *loopbackNode) setFileInfo(path string, fi *pfs.FileInfo) {
+	n.root().mu.Lock()
+	defer n.root().mu.Unlock()
+	n.root().infos[n.trimPath(path)] = fi
+}
+
 func (n *loopbackNode) checkWrite(path string) syscall.Errno {
-	repo := strings.Split(n.trimPath(path), "/")[0]
+	parts := strings.Split(n.trimPath(path), "/")
+	repo := parts[0]
+	// Only the files of branches are writable in the history layout.
+	if n.root().history && (len(parts) < 4 || parts[1] != branchesDir) {
+		return syscall.EROFS
+	}
 	ros := n.root().repoOpts
 	if len(ros) > 0 {
 		ro, ok := ros[repo]
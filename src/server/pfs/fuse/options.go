@@ -22,6 +22,17 @@ type Options struct {
 	// RepoOptions is a map from repo names to options associated with them.
 	RepoOptions map[string]*RepoOptions
 
+	// History mounts all the branches and commits of each repo, as
+	// <repo>/branches/<branch> and <repo>/commits/<commit>, rather than one
+	// branch per repo. Only the branches are writable, and the Branch of
+	// the RepoOptions is ignored. There is no <repo>/tags directory,
+	// because PFS has no tags; a commit is named by its ID or by a branch.
+	History bool
+
+	// Cache configures the disk cache of the files read through the mount,
+	// it is disabled if nil.
+	Cache *CacheOptions
+
 	// Unmount is a channel that will be closed when the filesystem has been
 	// unmounted. It can be nil in which case it's ignored.
 	Unmount chan struct{}
@@ -35,6 +46,23 @@ type RepoOptions struct {
 	Write bool
 }
 
+// CacheOptions are the options of the disk cache of a mount, which keeps the
+// files read through the mount in blocks keyed by the hash of each file, so
+// that the files which haven't changed are reused across mounts and commits.
+type CacheOptions struct {
+	// Dir is the directory the cache is kept in, it can be shared by
+	// consecutive mounts.
+	Dir string
+	// Size is the size the cache is bounded to, in bytes.
+	Size int64
+	// ReadAhead is the number of blocks which are prefetched ahead of
+	// sequential reads of a file.
+	ReadAhead int
+	// Prefetch prefetches the files of a directory in the background when
+	// the directory is listed.
+	Prefetch bool
+}
+
 func (o *Options) getFuse() *fs.Options {
 	if o == nil || o.Fuse == nil {
 		// We always return a struct here because otherwise the defaults that
@@ -71,6 +99,20 @@ func (o *Options) getWrite() bool {
 	return o.Write
 }
 
+func (o *Options) getHistory() bool {
+	if o == nil {
+		return false
+	}
+	return o.History
+}
+
+func (o *Options) getCache() *CacheOptions {
+	if o == nil {
+		return nil
+	}
+	return o.Cache
+}
+
 func (o *Options) getUnmount() chan struct{} {
 	if o == nil {
 		return nil
@@ -82,8 +124,13 @@ func (o *Options) validate(c *client.APIClient) error {
 	if o == nil {
 		return nil
 	}
+	if o.Cache != nil && (o.Cache.Dir == "" || o.Cache.Size <= 0) {
+		return errors.Errorf("the fuse cache needs a directory and a positive size")
+	}
 	for repo, opts := range o.RepoOptions {
-		if opts.Write {
+		// Branches are mounted for writing as they are browsed in the history
+		// layout, the upload to an output branch fails when unmounting.
+		if opts.Write && !o.History {
 			if uuid.IsUUIDWithoutDashes(opts.Branch) {
 				return errors.Errorf("can't mount commit %s@%s in Write mode (mount a branch instead)", repo, opts.Branch)
 			}
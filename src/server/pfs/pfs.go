@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
 	"github.com/pachyderm/pachyderm/v2/src/pfs"
@@ -111,6 +112,13 @@ type ErrCommitOnOutputBranch struct {
 	Branch *pfs.Branch
 }
 
+// ErrParentCommitNotFinished represents an error where an attempt was made to
+// start a commit on a commit which has not been finished, such as the open
+// head of a branch.
+type ErrParentCommitNotFinished struct {
+	Commit *pfs.Commit
+}
+
 // ErrSquashWithoutChildren represents an error when attempting to squash a
 // commit that has no children.  Since squash works by removing a commit and
 // leaving its data in any child commits, a squash would result in data loss in
@@ -129,6 +137,14 @@ type ErrDropWithChildren struct {
 	Commit *pfs.Commit
 }
 
+// ErrRetentionLocked represents an error when attempting to squash, drop or
+// delete a finished commit, or its branch or repo, before the retention
+// period of its repo has expired.
+type ErrRetentionLocked struct {
+	Commit *pfs.Commit
+	Until  time.Time
+}
+
 func (e ErrFileNotFound) Error() string {
 	return fmt.Sprintf("file %v not found in repo %v at commit %v", e.File.Path, e.File.Commit.Branch.Repo, e.File.Commit.ID)
 }
@@ -197,6 +213,10 @@ func (e ErrCommitOnOutputBranch) Error() string {
 	return fmt.Sprintf("cannot start a commit on an output branch: %s", e.Branch)
 }
 
+func (e ErrParentCommitNotFinished) Error() string {
+	return fmt.Sprintf("parent commit %s has not been finished", e.Commit)
+}
+
 func (e ErrSquashWithoutChildren) Error() string {
 	return fmt.Sprintf("cannot squash a commit that has no children as that would cause data loss, use the drop operation instead: %s", e.Commit)
 }
@@ -205,6 +225,10 @@ func (e ErrDropWithChildren) Error() string {
 	return fmt.Sprintf("cannot drop a commit that has children: %s", e.Commit)
 }
 
+func (e ErrRetentionLocked) Error() string {
+	return fmt.Sprintf("commit %s is under a retention lock until %s", e.Commit, e.Until.Format(time.RFC3339))
+}
+
 var (
 	commitNotFoundRe          = regexp.MustCompile("commit [^ ]+ not found")
 	commitsetNotFoundRe       = regexp.MustCompile("no commits found for commitset")
@@ -220,8 +244,10 @@ var (
 	ambiguousCommitRe         = regexp.MustCompile("commit .+ is ambiguous")
 	inconsistentCommitRe      = regexp.MustCompile("branch already has a commit in this transaction")
 	commitOnOutputBranchRe    = regexp.MustCompile("cannot start a commit on an output branch")
+	parentCommitNotFinishedRe = regexp.MustCompile("parent commit .+ has not been finished")
 	squashWithoutChildrenRe   = regexp.MustCompile("cannot squash a commit that has no children")
 	dropWithChildrenRe        = regexp.MustCompile("cannot drop a commit that has children")
+	retentionLockedRe         = regexp.MustCompile("commit [^ ]+ is under a retention lock")
 )
 
 // IsCommitNotFoundErr returns true if 'err' has an error message that matches
@@ -357,6 +383,15 @@ func IsCommitOnOutputBranchErr(err error) bool {
 	return commitOnOutputBranchRe.MatchString(err.Error())
 }
 
+// IsParentCommitNotFinishedErr returns true if the err is due to an attempt to
+// start a commit on a commit which has not been finished.
+func IsParentCommitNotFinishedErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	return parentCommitNotFinishedRe.MatchString(err.Error())
+}
+
 func IsSquashWithoutChildrenErr(err error) bool {
 	if err == nil {
 		return false
@@ -370,3 +405,12 @@ func IsDropWithChildrenErr(err error) bool {
 	}
 	return dropWithChildrenRe.MatchString(err.Error())
 }
+
+// IsRetentionLockedErr returns true if 'err' has an error message that matches
+// ErrRetentionLocked
+func IsRetentionLockedErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	return retentionLockedRe.MatchString(err.Error())
+}
@@ -20,17 +20,27 @@ const (
 	// RepoAuthHeader is the header for repos with auth information attached.
 	RepoAuthHeader = "NAME\tCREATED\tSIZE (MASTER)\tACCESS LEVEL\t\n"
 	// CommitHeader is the header for commits.
-	CommitHeader = "REPO\tBRANCH\tCOMMIT\tFINISHED\tSIZE\tORIGIN\tDESCRIPTION\n"
+	CommitHeader = "REPO\tBRANCH\tCOMMIT\tFINISHED\tSIZE\tCHANGES\tORIGIN\tDESCRIPTION\n"
+	// FileChangeHeader is the header for changes to files.
+	FileChangeHeader = "COMMIT\tCHANGE\tPATH\tSIZE\t\n"
 	// CommitSetHeader is the header for commitsets.
 	CommitSetHeader = "ID\tSUBCOMMITS\tPROGRESS\tCREATED\tMODIFIED\n"
 	// BranchHeader is the header for branches.
 	BranchHeader = "BRANCH\tHEAD\tTRIGGER\t\n"
+	// ReflogHeader is the header for reflog entries.
+	ReflogHeader = "INDEX\tTIME\tPRINCIPAL\tREASON\tOLD HEAD\tNEW HEAD\t\n"
+	// VerifyCommitHeader is the header for commit verifications.
+	VerifyCommitHeader = "COMMIT\tROOT HASH\tSTATUS\t\n"
 	// FileHeader is the header for files.
 	FileHeader = "NAME\tTYPE\tSIZE\t\n"
 	// FileHeaderWithCommit is the header for files that includes a commit field.
 	FileHeaderWithCommit = "COMMIT\tNAME\tTYPE\tCOMMITTED\tSIZE\t\n"
 	// DiffFileHeader is the header for files produced by diff file.
 	DiffFileHeader = "OP\t" + FileHeader
+	// OrphanedObjectHeader is the header for orphaned objects.
+	OrphanedObjectHeader = "KEY\tSIZE\tAGE\tREASON\tSTATUS\t\n"
+	// DiskUsageHeader is the header for disk usage.
+	DiskUsageHeader = "PATH\tSIZE\tFILES\t\n"
 )
 
 // PrintRepoInfo pretty-prints repo info.
@@ -73,7 +83,8 @@ func PrintDetailedRepoInfo(repoInfo *PrintableRepoInfo) error {
 		`Name: {{.Repo.Name}}{{if .Description}}
 Description: {{.Description}}{{end}}{{if .FullTimestamps}}
 Created: {{.Created}}{{else}}
-Created: {{prettyAgo .Created}}{{end}}{{if .Details}}
+Created: {{prettyAgo .Created}}{{end}}{{if .Retention}}
+Retention: {{prettyDuration .Retention}}{{end}}{{if .Details}}
 Size of HEAD on master: {{prettySize .Details.SizeBytes}}{{end}}{{if .AuthInfo}}
 Access level: {{ .AuthInfo.AccessLevel.String }}{{end}}
 `)
@@ -160,11 +171,75 @@ func PrintCommitInfo(w io.Writer, commitInfo *pfs.CommitInfo, fullTimestamps boo
 	} else {
 		fmt.Fprintf(w, "%s\t", units.BytesSize(float64(commitInfo.Details.SizeBytes)))
 	}
+	if commitInfo.Details == nil || commitInfo.Details.ChangeSummary == nil {
+		fmt.Fprintf(w, "-\t")
+	} else {
+		fmt.Fprintf(w, "%s\t", compactChangeSummary(commitInfo.Details.ChangeSummary))
+	}
 	fmt.Fprintf(w, "%v\t", commitInfo.Origin.Kind)
 	fmt.Fprintf(w, "%s\t", commitInfo.Description)
 	fmt.Fprintln(w)
 }
 
+// compactChangeSummary renders the counts of added, modified and deleted files
+// in a change summary, e.g. "+3 ~1 -2".
+func compactChangeSummary(summary *pfs.ChangeSummary) string {
+	return fmt.Sprintf("+%d ~%d -%d", summary.FilesAdded, summary.FilesModified, summary.FilesDeleted)
+}
+
+// PrintFileChange pretty-prints a change to a file in a commit.
+func PrintFileChange(w io.Writer, commitInfo *pfs.CommitInfo, change *pfs.DiffFileResponse) {
+	fmt.Fprintf(w, "%s\t", commitInfo.Commit.ID)
+	switch {
+	case change.OldFile == nil:
+		fmt.Fprintf(w, "added\t%s\t%s\t", change.NewFile.File.Path, units.BytesSize(float64(change.NewFile.SizeBytes)))
+	case change.NewFile == nil:
+		fmt.Fprintf(w, "deleted\t%s\t%s\t", change.OldFile.File.Path, units.BytesSize(float64(change.OldFile.SizeBytes)))
+	default:
+		fmt.Fprintf(w, "modified\t%s\t%s\t", change.NewFile.File.Path, units.BytesSize(float64(change.NewFile.SizeBytes)))
+	}
+	fmt.Fprintln(w)
+}
+
+// PrintVerifyCommitResponse pretty-prints the verification of a commit.
+func PrintVerifyCommitResponse(w io.Writer, resp *pfs.VerifyCommitResponse) {
+	fmt.Fprintf(w, "%s\t", resp.Commit)
+	if resp.Hash == nil {
+		fmt.Fprintf(w, "-\t")
+	} else {
+		fmt.Fprintf(w, "%s\t", pfs.EncodeHash(resp.Hash.Root))
+	}
+	if resp.Error == "" {
+		fmt.Fprintf(w, "verified\t")
+	} else {
+		fmt.Fprintf(w, "failed: %s\t", resp.Error)
+	}
+	fmt.Fprintln(w)
+}
+
+// PrintReflogEntry pretty-prints a reflog entry.
+func PrintReflogEntry(w io.Writer, entry *pfs.ReflogEntry, fullTimestamps bool) {
+	fmt.Fprintf(w, "%d\t", entry.Index)
+	if fullTimestamps {
+		fmt.Fprintf(w, "%s\t", entry.Timestamp.String())
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(entry.Timestamp))
+	}
+	if entry.Principal == "" {
+		fmt.Fprintf(w, "-\t")
+	} else {
+		fmt.Fprintf(w, "%s\t", entry.Principal)
+	}
+	fmt.Fprintf(w, "%s\t", strings.ToLower(strings.TrimPrefix(entry.Reason.String(), "REFLOG_")))
+	if entry.OldHead == nil {
+		fmt.Fprintf(w, "-\t")
+	} else {
+		fmt.Fprintf(w, "%s\t", entry.OldHead.ID)
+	}
+	fmt.Fprintf(w, "%s\t", entry.NewHead.ID)
+	fmt.Fprintln(w)
+}
+
 // PrintCommitSetInfo pretty-prints jobset info.
 func PrintCommitSetInfo(w io.Writer, commitSetInfo *pfs.CommitSetInfo, fullTimestamps bool) {
 	// Aggregate some data to print from the jobs in the jobset
@@ -243,7 +318,12 @@ Started: {{.Started}}{{else}}
 Started: {{prettyAgo .Started}}{{end}}{{if .Finished}}{{if .FullTimestamps}}
 Finished: {{.Finished}}{{else}}
 Finished: {{prettyAgo .Finished}}{{end}}{{end}}{{if .Details}}
-Size: {{prettySize .Details.SizeBytes}}{{end}}
+Size: {{prettySize .Details.SizeBytes}}{{with .Details.ChangeSummary}}
+Changes: {{.FilesAdded}} added, {{.FilesModified}} modified, {{.FilesDeleted}} deleted (+{{prettySize .BytesAdded}}, -{{prettySize .BytesRemoved}}){{end}}{{if .Details.NumLayers}}
+Layers: {{.Details.NumLayers}}
+Index Size: {{prettySize .Details.IndexSizeBytes}}
+Read Amplification: {{.Details.ReadAmplification}}{{end}}{{end}}{{if .Hash}}
+Root Hash: {{encodeHash .Hash.Root}}{{end}}
 `)
 	if err != nil {
 		return err
@@ -277,6 +357,50 @@ func PrintFileInfo(w io.Writer, fileInfo *pfs.FileInfo, fullTimestamps, withComm
 	fmt.Fprintln(w)
 }
 
+// PrintDiskUsageInfo pretty-prints the disk usage of a path.
+func PrintDiskUsageInfo(w io.Writer, info *pfs.DiskUsageInfo) {
+	fmt.Fprintf(w, "%s\t", info.Path)
+	fmt.Fprintf(w, "%s\t", units.BytesSize(float64(info.SizeBytes)))
+	fmt.Fprintf(w, "%d\t\n", info.FileCount)
+}
+
+// PrintRepoDiskUsage pretty-prints the disk usage of the history of a repo.
+func PrintRepoDiskUsage(w io.Writer, repo string, resp *pfs.RepoDiskUsageResponse) {
+	fmt.Fprintf(w, "Repo: %s\n", repo)
+	fmt.Fprintf(w, "Commits: %d\n", resp.CommitCount)
+	fmt.Fprintf(w, "Logical Size: %s\n", units.BytesSize(float64(resp.LogicalSizeBytes)))
+	fmt.Fprintf(w, "Unique Size: %s (%d chunks)\n", units.BytesSize(float64(resp.UniqueSizeBytes)), resp.ChunkCount)
+	if resp.UniqueSizeBytes > 0 {
+		fmt.Fprintf(w, "Deduplication Ratio: %.2f\n", float64(resp.LogicalSizeBytes)/float64(resp.UniqueSizeBytes))
+	}
+}
+
+// PrintOrphanedObject pretty-prints an orphaned object.
+func PrintOrphanedObject(w io.Writer, o *pfs.OrphanedObject) {
+	fmt.Fprintf(w, "%s\t", o.Key)
+	if o.SizeBytes < 0 {
+		fmt.Fprint(w, "-\t")
+	} else {
+		fmt.Fprintf(w, "%s\t", units.BytesSize(float64(o.SizeBytes)))
+	}
+	age, err := types.DurationFromProto(o.Age)
+	if err != nil || age <= 0 {
+		fmt.Fprint(w, "-\t")
+	} else {
+		fmt.Fprintf(w, "%s\t", units.HumanDuration(age))
+	}
+	fmt.Fprintf(w, "%s\t", o.Reason)
+	switch {
+	case o.Reclaimed:
+		fmt.Fprint(w, "reclaimed\t")
+	case o.Reclaimable:
+		fmt.Fprint(w, "reclaimable\t")
+	default:
+		fmt.Fprint(w, "retained\t")
+	}
+	fmt.Fprintln(w)
+}
+
 // PrintDiffFileInfo pretty-prints a file info from diff file.
 func PrintDiffFileInfo(w io.Writer, added bool, fileInfo *pfs.FileInfo, fullTimestamps bool) {
 	if added {
@@ -309,10 +433,20 @@ func fileType(fileType pfs.FileType) string {
 }
 
 var funcMap = template.FuncMap{
-	"prettyAgo":    pretty.Ago,
-	"prettySize":   pretty.Size,
-	"fileType":     fileType,
-	"printTrigger": printTrigger,
+	"prettyAgo":      pretty.Ago,
+	"prettySize":     pretty.Size,
+	"fileType":       fileType,
+	"printTrigger":   printTrigger,
+	"encodeHash":     pfs.EncodeHash,
+	"prettyDuration": prettyDuration,
+}
+
+func prettyDuration(d *types.Duration) string {
+	duration, err := types.DurationFromProto(d)
+	if err != nil {
+		return d.String()
+	}
+	return duration.String()
 }
 
 // CompactPrintCommit renders 'c' as a compact string, e.g.
@@ -1,16 +1,43 @@
 package s3
 
 import (
+	"context"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pachyderm/pachyderm/v2/src/auth"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/sigv4"
+	"github.com/pachyderm/s2"
 )
 
+// CredentialLookup resolves the access key IDs of S3 credentials issued by
+// the auth service.
+type CredentialLookup interface {
+	LookupS3Credential(ctx context.Context, accessKeyID string) (*auth.S3Credential, *auth.S3CredentialSecrets, error)
+}
+
 func (c *controller) SecretKey(r *http.Request, accessKey string, region *string) (*string, error) {
 	c.logger.Debugf("SecretKey: %+v", region)
 
+	if c.credentials != nil {
+		// Fall back to treating the access key as an auth token if it isn't
+		// the ID of valid S3 credentials.
+		cred, secrets, err := c.credentials.LookupS3Credential(r.Context(), accessKey)
+		if err == nil {
+			vars := mux.Vars(r)
+			vars["s3gToken"] = secrets.SessionToken
+			if cred.ReadOnly {
+				vars["s3gReadOnly"] = "true"
+			}
+			vars["s3gBuckets"] = strings.Join(cred.Buckets, ",")
+			return &secrets.SecretAccessKey, nil
+		}
+	}
+
 	pc, err := c.clientFactory()
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not create a pach client for auth")
@@ -41,6 +68,12 @@ func (c *controller) SecretKey(r *http.Request, accessKey string, region *string
 func (c *controller) CustomAuth(r *http.Request) (bool, error) {
 	c.logger.Debug("CustomAuth")
 
+	// Presigned URLs carry their signature in the query string rather than
+	// in the authorization header, so they reach custom auth.
+	if sigv4.IsPresigned(r) {
+		return c.presignedAuth(r)
+	}
+
 	pc, err := c.clientFactory()
 	if err != nil {
 		return false, errors.Wrapf(err, "could not create a pach client for auth")
@@ -55,3 +88,81 @@ func (c *controller) CustomAuth(r *http.Request) (bool, error) {
 	// pachyderm auth is disabled
 	return !active, nil
 }
+
+// presignedAuth verifies the signature of a request made with a presigned
+// URL, and authenticates the request as the signer.
+func (c *controller) presignedAuth(r *http.Request) (bool, error) {
+	presigned, err := sigv4.Parse(r)
+	if err != nil {
+		return false, authorizationQueryParametersError(r, err)
+	}
+	if presigned.Expired(time.Now()) {
+		return false, presignedURLExpiredError(r)
+	}
+	secretKey, err := c.SecretKey(r, presigned.AccessKeyID, &presigned.Region)
+	if err != nil {
+		return false, err
+	}
+	if secretKey == nil {
+		return false, s2.InvalidAccessKeyIDError(r)
+	}
+	if !presigned.Verify(r, *secretKey) {
+		return false, s2.SignatureDoesNotMatchError(r)
+	}
+	vars := mux.Vars(r)
+	vars["authAccessKey"] = presigned.AccessKeyID
+	return true, nil
+}
+
+// scopeMiddleware rejects the requests which are outside the scope of the S3
+// credentials they were signed with.
+func (c *controller) scopeMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		if vars["s3gReadOnly"] == "true" && r.Method != http.MethodGet && r.Method != http.MethodHead && !isSelectRequest(r) {
+			s2.WriteError(c.logger, w, r, s2.AccessDeniedError(r))
+			return
+		}
+		if bucket := vars["bucket"]; bucket != "" && !inBucketScope(vars, bucket) {
+			s2.WriteError(c.logger, w, r, s2.AccessDeniedError(r))
+			return
+		}
+		// CopyObject reads from the bucket of its copy source, which isn't a
+		// path variable.
+		if bucket := copySourceBucket(r); bucket != "" && !inBucketScope(vars, bucket) {
+			s2.WriteError(c.logger, w, r, s2.AccessDeniedError(r))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// copySourceBucket returns the bucket a CopyObject request copies from, or an
+// empty string for other requests. The x-amz-copy-source header is parsed as
+// s2 parses it, as either "bucket/key" or "/bucket/key".
+func copySourceBucket(r *http.Request) string {
+	source := r.Header.Get("x-amz-copy-source")
+	if r.Method != http.MethodPut || source == "" {
+		return ""
+	}
+	sourceURL, err := url.Parse(source)
+	if err != nil {
+		// s2 rejects the request
+		return ""
+	}
+	return strings.SplitN(strings.TrimPrefix(sourceURL.Path, "/"), "/", 2)[0]
+}
+
+// inBucketScope returns whether the S3 credentials of a request give access
+// to a bucket.
+func inBucketScope(vars map[string]string, bucket string) bool {
+	if vars["s3gBuckets"] == "" {
+		return true
+	}
+	for _, b := range strings.Split(vars["s3gBuckets"], ",") {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
@@ -96,7 +96,16 @@ func (c *controller) ListObjects(r *http.Request, bucketName, prefix, marker, de
 		pattern = fmt.Sprintf("%s*", glob.QuoteMeta(prefix))
 	}
 
-	err = pc.GlobFile(bucket.Commit, pattern, func(fileInfo *pfsClient.FileInfo) error {
+	// Start the listing at the marker rather than skipping everything before
+	// it on this side of the stream.
+	req := &pfsClient.GlobFileRequest{
+		Commit:  bucket.Commit,
+		Pattern: pattern,
+	}
+	if marker != "" {
+		req.StartAfter = "/" + marker
+	}
+	_, err = pc.GlobFilePage(req, func(fileInfo *pfsClient.FileInfo) error {
 		if fileInfo.FileType == pfsClient.FileType_DIR {
 			if fileInfo.File.Path == "/" {
 				// skip the root directory
@@ -238,6 +247,9 @@ func (c *controller) DeleteBucket(r *http.Request, bucketName string) error {
 
 	_, err = pc.PfsAPIClient.DeleteBranch(pc.Ctx(), &pfs.DeleteBranchRequest{Branch: bucket.Commit.Branch})
 	if err != nil {
+		if pfsServer.IsRetentionLockedErr(err) {
+			return retentionLockedError(r)
+		}
 		return s2.InternalError(r, grpcutil.ScrubGRPC(err))
 	}
 
@@ -250,6 +262,9 @@ func (c *controller) DeleteBucket(r *http.Request, bucketName string) error {
 	if len(repoInfo.Branches) == 0 {
 		_, err = pc.PfsAPIClient.DeleteRepo(pc.Ctx(), &pfs.DeleteRepoRequest{Repo: bucket.Commit.Branch.Repo})
 		if err != nil {
+			if pfsServer.IsRetentionLockedErr(err) {
+				return retentionLockedError(r)
+			}
 			return s2.InternalError(r, grpcutil.ScrubGRPC(err))
 		}
 	}
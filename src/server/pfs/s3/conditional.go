@@ -0,0 +1,166 @@
+package s3
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsServer "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+	"github.com/pachyderm/s2"
+)
+
+// objectLocks serializes the writes made through the gateway to each object,
+// so that the preconditions of a conditional write are checked against the
+// object it replaces. Writers going through other gateways, or writing to
+// PFS directly, aren't serialized with it.
+type objectLocks struct {
+	mu    sync.Mutex
+	locks map[string]*objectLock
+}
+
+type objectLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func newObjectLocks() *objectLocks {
+	return &objectLocks{locks: make(map[string]*objectLock)}
+}
+
+// lock locks an object of a bucket, and returns the function which unlocks
+// it.
+func (l *objectLocks) lock(bucket *Bucket, key string) func() {
+	name := path.Join(bucketKey(bucket), key)
+	l.mu.Lock()
+	lock, ok := l.locks[name]
+	if !ok {
+		lock = &objectLock{}
+		l.locks[name] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(l.locks, name)
+		}
+	}
+}
+
+// checkPreconditions checks the conditional headers of a write to a file
+// against the file in the commit it is written to.
+func (c *controller) checkPreconditions(pc *client.APIClient, r *http.Request, commit *pfs.Commit, file string) error {
+	if !hasPreconditions(r) {
+		return nil
+	}
+	fileInfo, err := pc.InspectFile(commit, file)
+	if err != nil {
+		if !pfsServer.IsFileNotFoundErr(err) && !pfsServer.IsCommitNotFoundErr(err) && !pfsServer.IsBranchNotFoundErr(err) {
+			return maybeNotFoundError(r, err)
+		}
+		fileInfo = nil
+	}
+	return checkWritePreconditions(r, fileInfo)
+}
+
+// hasPreconditions returns whether a request is conditional on the state of
+// the object it targets.
+func hasPreconditions(r *http.Request) bool {
+	for _, h := range []string{"If-Match", "If-None-Match", "If-Modified-Since", "If-Unmodified-Since"} {
+		if r.Header.Get(h) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkReadPreconditions checks the If-Match and If-Unmodified-Since headers
+// of a read of an object, which fail it with a PreconditionFailed error. The
+// If-None-Match and If-Modified-Since headers, which turn the response into a
+// 304, are handled when the object is served.
+func checkReadPreconditions(r *http.Request, fileInfo *pfs.FileInfo) error {
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		if !etagMatches(ifMatch, fileETag(fileInfo)) {
+			return s2.PreconditionFailedError(r)
+		}
+		return nil
+	}
+	if modified, ok := modifiedSince(r.Header.Get("If-Unmodified-Since"), fileInfo); ok && modified {
+		return s2.PreconditionFailedError(r)
+	}
+	return nil
+}
+
+// checkWritePreconditions checks the conditional headers of a write of an
+// object against the object it replaces, which is nil if there is none. An
+// If-None-Match header of * makes the write create-only.
+func checkWritePreconditions(r *http.Request, fileInfo *pfs.FileInfo) error {
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		if fileInfo == nil {
+			return s2.NoSuchKeyError(r)
+		}
+		if !etagMatches(ifMatch, fileETag(fileInfo)) {
+			return s2.PreconditionFailedError(r)
+		}
+	} else if modified, ok := modifiedSince(r.Header.Get("If-Unmodified-Since"), fileInfo); ok && modified {
+		return s2.PreconditionFailedError(r)
+	}
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if fileInfo != nil && etagMatches(ifNoneMatch, fileETag(fileInfo)) {
+			return s2.PreconditionFailedError(r)
+		}
+	} else if modified, ok := modifiedSince(r.Header.Get("If-Modified-Since"), fileInfo); ok && !modified {
+		return s2.PreconditionFailedError(r)
+	}
+	return nil
+}
+
+func fileETag(fileInfo *pfs.FileInfo) string {
+	return fmt.Sprintf("%x", fileInfo.Hash)
+}
+
+// etagMatches returns whether an ETag is in the list of ETags of a
+// conditional header, or the list is *.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		candidate = strings.Trim(strings.TrimPrefix(candidate, "W/"), `"`)
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// modifiedSince returns whether an object was modified after the date of a
+// conditional header. It returns false for ok if the header is unset or
+// malformed, or the object has no modification time, in which case the
+// header is ignored.
+func modifiedSince(header string, fileInfo *pfs.FileInfo) (modified bool, ok bool) {
+	if header == "" || fileInfo == nil || fileInfo.Committed == nil {
+		return false, false
+	}
+	since, err := http.ParseTime(header)
+	if err != nil {
+		return false, false
+	}
+	modTime, err := types.TimestampFromProto(fileInfo.Committed)
+	if err != nil {
+		return false, false
+	}
+	// HTTP dates have a precision of a second.
+	return modTime.Truncate(time.Second).After(since), true
+}
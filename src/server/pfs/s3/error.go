@@ -23,6 +23,26 @@ func writeToOutputBranchError(r *http.Request) *s2.Error {
 	return s2.NewError(r, http.StatusBadRequest, "WriteToOutputBranch", "You cannot write to an output branch")
 }
 
+func retentionLockedError(r *http.Request) *s2.Error {
+	return s2.NewError(r, http.StatusForbidden, "AccessDenied", "The bucket is under a retention lock and cannot be deleted until its retention period expires")
+}
+
+func presignedURLExpiredError(r *http.Request) *s2.Error {
+	return s2.NewError(r, http.StatusForbidden, "AccessDenied", "Request has expired")
+}
+
+func authorizationQueryParametersError(r *http.Request, err error) *s2.Error {
+	return s2.NewError(r, http.StatusBadRequest, "AuthorizationQueryParametersError", err.Error())
+}
+
+func invalidExpressionTypeError(r *http.Request) *s2.Error {
+	return s2.NewError(r, http.StatusBadRequest, "InvalidExpressionType", "The ExpressionType is invalid. Only SQL expressions are supported.")
+}
+
+func invalidTagError(r *http.Request, message string) *s2.Error {
+	return s2.NewError(r, http.StatusBadRequest, "InvalidTag", message)
+}
+
 func maybeNotFoundError(r *http.Request, err error) *s2.Error {
 	if pfs.IsRepoNotFoundErr(err) || pfs.IsBranchNotFoundErr(err) {
 		return s2.NoSuchBucketError(r)
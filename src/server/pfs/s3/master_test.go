@@ -1,9 +1,12 @@
 package s3
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
@@ -516,6 +519,334 @@ func masterResolveSystemRepoBucket(t *testing.T, pachClient *client.APIClient, m
 	require.Equal(t, "spec", fetchedContent)
 }
 
+func masterPresignedURLs(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testpresignedurls")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	endpoint := minioClient.EndpointURL().String()
+	file := client.NewFile(repo, "master", "", "dir/file")
+
+	putURL, err := pachClient.PresignFileURL(endpoint, file, http.MethodPut, time.Minute)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPut, putURL, strings.NewReader("content"))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	getURL, err := pachClient.PresignFileURL(endpoint, file, http.MethodGet, time.Minute)
+	require.NoError(t, err)
+	resp, err = http.Get(getURL)
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "content", string(content))
+
+	// the signature doesn't cover other files or methods
+	resp, err = http.Get(strings.Replace(getURL, "dir/file", "dir/other", 1))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	req, err = http.NewRequest(http.MethodPut, getURL, strings.NewReader("other"))
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	_, err = pachClient.PresignFileURL(endpoint, file, http.MethodDelete, time.Minute)
+	require.YesError(t, err)
+}
+
+func masterWriteSession(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testwritesession")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", "", nil))
+	endpoint := minioClient.EndpointURL().String()
+	commitInfos, err := pachClient.ListCommit(client.NewRepo(repo), nil, nil, 0)
+	require.NoError(t, err)
+	initialCommits := len(commitInfos)
+
+	put := func(session, file, content string, finish bool) {
+		req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/master.%s/%s", endpoint, repo, file), strings.NewReader(content))
+		require.NoError(t, err)
+		req.Header.Set(writeSessionHeader, session)
+		if finish {
+			req.Header.Set(writeSessionFinishHeader, "true")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	for i := 0; i < 5; i++ {
+		put("sync", fmt.Sprintf("file%d", i), "content", false)
+	}
+	put("sync", "last", "content", true)
+
+	// all the writes of the session are in one finished commit
+	commitInfos, err = pachClient.ListCommit(client.NewRepo(repo), nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, initialCommits+1, len(commitInfos))
+	require.NotNil(t, commitInfos[0].Finishing)
+	fileInfos, err := pachClient.ListFileAll(client.NewCommit(repo, "master", ""), "/")
+	require.NoError(t, err)
+	require.Equal(t, 6, len(fileInfos))
+
+	// multi-object deletes are made in a single commit
+	objectsCh := make(chan string, 3)
+	for i := 0; i < 3; i++ {
+		objectsCh <- fmt.Sprintf("file%d", i)
+	}
+	close(objectsCh)
+	for err := range minioClient.RemoveObjects(fmt.Sprintf("master.%s", repo), objectsCh) {
+		require.NoError(t, err.Err)
+	}
+	commitInfos, err = pachClient.ListCommit(client.NewRepo(repo), nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, initialCommits+2, len(commitInfos))
+	fileInfos, err = pachClient.ListFileAll(client.NewCommit(repo, "master", ""), "/")
+	require.NoError(t, err)
+	require.Equal(t, 3, len(fileInfos))
+
+	// the writes of a session to a branch whose head is open, here the
+	// commit of another session, land in the open head
+	put("sync", "file0", "content", false)
+	put("other", "file1", "content", false)
+	objectsCh = make(chan string, 1)
+	objectsCh <- "file0"
+	close(objectsCh)
+	for err := range minioClient.RemoveObjects(fmt.Sprintf("master.%s", repo), objectsCh) {
+		require.NoError(t, err.Err)
+	}
+	put("sync", "file2", "content", true)
+	commitInfos, err = pachClient.ListCommit(client.NewRepo(repo), nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, initialCommits+3, len(commitInfos))
+	require.NotNil(t, commitInfos[0].Finishing)
+	fileInfos, err = pachClient.ListFileAll(client.NewCommit(repo, "master", ""), "/")
+	require.NoError(t, err)
+	require.Equal(t, 5, len(fileInfos))
+}
+
+func masterMultipartUpload(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testmultipartupload")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+	core := minio.Core{Client: minioClient}
+	commitInfos, err := pachClient.ListCommit(client.NewRepo(repo), nil, nil, 0)
+	require.NoError(t, err)
+	initialCommits := len(commitInfos)
+
+	// parts are listed as they're uploaded, and the completed upload is
+	// their concatenation, written in one commit
+	uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	contents := []string{strings.Repeat("a", minPartSize), "b"}
+	var parts []minio.CompletePart
+	for i, content := range contents {
+		part, err := core.PutObjectPart(bucket, "file", uploadID, i+1, strings.NewReader(content), int64(len(content)), "", "", nil)
+		require.NoError(t, err)
+		parts = append(parts, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	listed, err := core.ListObjectParts(bucket, "file", uploadID, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(listed.ObjectParts))
+	_, err = core.CompleteMultipartUpload(bucket, "file", uploadID, parts)
+	require.NoError(t, err)
+	fetched, err := getObject(t, minioClient, bucket, "file")
+	require.NoError(t, err)
+	require.Equal(t, strings.Join(contents, ""), fetched)
+	commitInfos, err = pachClient.ListCommit(client.NewRepo(repo), nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, initialCommits+1, len(commitInfos))
+
+	// the multipart upload isn't staged in a repo
+	repoInfos, err := pachClient.ListRepo()
+	require.NoError(t, err)
+	for _, repoInfo := range repoInfos {
+		require.False(t, strings.HasPrefix(repoInfo.Repo.Name, "_s3gateway"))
+	}
+
+	// aborted uploads can't be completed
+	uploadID, err = core.NewMultipartUpload(bucket, "aborted", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	part, err := core.PutObjectPart(bucket, "aborted", uploadID, 1, strings.NewReader("content"), 7, "", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, core.AbortMultipartUpload(bucket, "aborted", uploadID))
+	_, err = core.CompleteMultipartUpload(bucket, "aborted", uploadID, []minio.CompletePart{{PartNumber: 1, ETag: part.ETag}})
+	require.YesError(t, err)
+}
+
+func masterSelectObjectContent(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testselectobjectcontent")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	commit := client.NewCommit(repo, "master", "")
+	require.NoError(t, pachClient.PutFile(commit, "people.csv", strings.NewReader("name,age\nalice,34\nbob,27\ncarol,45\n")))
+	require.NoError(t, pachClient.PutFile(commit, "people.jsonl", strings.NewReader(`{"name":"alice","age":34}`+"\n"+`{"name":"bob","age":27}`+"\n")))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	selectObject := func(file, expression string, input minio.SelectObjectInputSerialization) (string, error) {
+		results, err := minioClient.SelectObjectContent(context.Background(), bucket, file, minio.SelectObjectOptions{
+			Expression:          expression,
+			ExpressionType:      minio.QueryExpressionTypeSQL,
+			InputSerialization:  input,
+			OutputSerialization: minio.SelectObjectOutputSerialization{CSV: &minio.CSVOutputOptions{}},
+		})
+		if err != nil {
+			return "", err
+		}
+		defer results.Close()
+		out, err := ioutil.ReadAll(results)
+		return string(out), err
+	}
+	csvInput := minio.SelectObjectInputSerialization{CSV: &minio.CSVInputOptions{FileHeaderInfo: minio.CSVFileHeaderInfoUse}}
+	out, err := selectObject("people.csv", "SELECT s.name FROM S3Object s WHERE CAST(s.age AS INT) > 30", csvInput)
+	require.NoError(t, err)
+	require.Equal(t, "alice\ncarol\n", out)
+	out, err = selectObject("people.csv", "SELECT COUNT(*), MAX(age) FROM S3Object", csvInput)
+	require.NoError(t, err)
+	require.Equal(t, "3,45\n", out)
+	out, err = selectObject("people.jsonl", "SELECT name FROM S3Object LIMIT 1", minio.SelectObjectInputSerialization{JSON: &minio.JSONInputOptions{Type: minio.JSONLinesType}})
+	require.NoError(t, err)
+	require.Equal(t, "alice\n", out)
+
+	// malformed queries and missing objects are rejected
+	_, err = selectObject("people.csv", "SELECT FROM S3Object", csvInput)
+	require.YesError(t, err)
+	_, err = selectObject("missing.csv", "SELECT * FROM S3Object", csvInput)
+	keyNotFoundError(t, err)
+}
+
+func masterConditionalRequests(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testconditionalrequests")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	endpoint := minioClient.EndpointURL().String()
+
+	do := func(method, path string, headers map[string]string, body string) (*http.Response, string) {
+		u, err := pachClient.PresignFileURL(endpoint, client.NewFile(repo, "master", "", path), method, time.Minute)
+		require.NoError(t, err)
+		req, err := http.NewRequest(method, u, strings.NewReader(body))
+		require.NoError(t, err)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		content, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp, string(content)
+	}
+
+	// create-only puts only succeed while the object doesn't exist
+	resp, _ := do(http.MethodPut, "file", map[string]string{"If-None-Match": "*"}, "v1")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	require.NotEqual(t, "", etag)
+	resp, _ = do(http.MethodPut, "file", map[string]string{"If-None-Match": "*"}, "v2")
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	// puts conditional on the ETag swap the object only if it is unchanged
+	resp, _ = do(http.MethodPut, "file", map[string]string{"If-Match": `"wrong"`}, "v2")
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	resp, _ = do(http.MethodPut, "file", map[string]string{"If-Match": etag}, "v2")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	newETag := resp.Header.Get("ETag")
+	require.NotEqual(t, etag, newETag)
+	resp, _ = do(http.MethodPut, "file", map[string]string{"If-Match": etag}, "v3")
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	resp, _ = do(http.MethodPut, "missing", map[string]string{"If-Match": "*"}, "v1")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, content := do(http.MethodGet, "file", map[string]string{"If-Match": newETag}, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "v2", content)
+	resp, _ = do(http.MethodGet, "file", map[string]string{"If-Match": etag}, "")
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	resp, _ = do(http.MethodGet, "file", map[string]string{"If-None-Match": newETag}, "")
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+	resp, _ = do(http.MethodGet, "file", map[string]string{"If-None-Match": etag}, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	resp, _ = do(http.MethodGet, "file", map[string]string{"If-Modified-Since": future}, "")
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+	resp, _ = do(http.MethodGet, "file", map[string]string{"If-Modified-Since": past}, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, _ = do(http.MethodGet, "file", map[string]string{"If-Unmodified-Since": past}, "")
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	resp, _ = do(http.MethodPut, "file", map[string]string{"If-Unmodified-Since": past}, "v3")
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	resp, content = do(http.MethodGet, "file", nil, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "v2", content)
+
+	// copies check the conditional headers against their destination
+	src, err := pachClient.PresignFileURL(endpoint, client.NewFile(repo, "master", "", "file"), http.MethodGet, time.Minute)
+	require.NoError(t, err)
+	srcURL, err := url.Parse(src)
+	require.NoError(t, err)
+	resp, _ = do(http.MethodPut, "copy", map[string]string{"x-amz-copy-source": srcURL.Path, "If-None-Match": "*"}, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, _ = do(http.MethodPut, "copy", map[string]string{"x-amz-copy-source": srcURL.Path, "If-None-Match": "*"}, "")
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	resp, _ = do(http.MethodPut, "copy", map[string]string{"x-amz-copy-source": srcURL.Path, "If-Match": "*"}, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, _ = do(http.MethodPut, "missing", map[string]string{"x-amz-copy-source": srcURL.Path, "If-Match": "*"}, "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func masterObjectTagging(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testobjecttagging")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	bucket := fmt.Sprintf("master.%s", repo)
+	commit := client.NewCommit(repo, "master", "")
+
+	_, err := minioClient.PutObject(bucket, "file", strings.NewReader("content"), int64(len("content")), minio.PutObjectOptions{
+		UserTags: map[string]string{"a": "1"},
+	})
+	require.NoError(t, err)
+	tags, err := minioClient.GetObjectTagging(bucket, "file")
+	require.NoError(t, err)
+	require.True(t, strings.Contains(tags, "<Tag><Key>a</Key><Value>1</Value></Tag>"))
+
+	require.NoError(t, minioClient.PutObjectTagging(bucket, "file", map[string]string{"b": "2", "c": "3"}))
+	tags, err = minioClient.GetObjectTagging(bucket, "file")
+	require.NoError(t, err)
+	require.True(t, strings.Contains(tags, "<Tag><Key>b</Key><Value>2</Value></Tag><Tag><Key>c</Key><Value>3</Value></Tag>"))
+	require.False(t, strings.Contains(tags, "<Key>a</Key>"))
+	// tags are stored in the file's metadata, and don't change its content
+	fileInfo, err := pachClient.InspectFile(commit, "file")
+	require.NoError(t, err)
+	require.Equal(t, "2", fileInfo.Metadata["s3/tag/b"])
+	fetchedContent, err := getObject(t, minioClient, bucket, "file")
+	require.NoError(t, err)
+	require.Equal(t, "content", fetchedContent)
+
+	require.NoError(t, minioClient.RemoveObjectTagging(bucket, "file"))
+	tags, err = minioClient.GetObjectTagging(bucket, "file")
+	require.NoError(t, err)
+	require.False(t, strings.Contains(tags, "<Tag>"))
+
+	// overwriting an object drops its tags
+	require.NoError(t, minioClient.PutObjectTagging(bucket, "file", map[string]string{"b": "2"}))
+	_, err = minioClient.PutObject(bucket, "file", strings.NewReader("new"), int64(len("new")), minio.PutObjectOptions{})
+	require.NoError(t, err)
+	tags, err = minioClient.GetObjectTagging(bucket, "file")
+	require.NoError(t, err)
+	require.False(t, strings.Contains(tags, "<Tag>"))
+
+	err = minioClient.PutObjectTagging(bucket, "missing", map[string]string{"b": "2"})
+	keyNotFoundError(t, err)
+	_, err = minioClient.GetObjectTagging(bucket, "missing")
+	keyNotFoundError(t, err)
+}
+
 // TODO: This should be readded as an integration test (probably in src/server/pachyderm_test.go).
 // Commenting out for now to enable the other tests to run against mock pachd.
 //func masterAuthV2(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
@@ -532,7 +863,7 @@ func TestMasterDriver(t *testing.T) {
 	}
 	t.Parallel()
 	env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
-	testRunner(t, env.PachClient, "master", NewMasterDriver(), func(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	testRunner(t, env.PachClient, env.ServiceEnv.GetDBClient(), "master", NewMasterDriver(), func(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
 		t.Run("ListBuckets", func(t *testing.T) {
 			masterListBuckets(t, pachClient, minioClient)
 		})
@@ -605,6 +936,24 @@ func TestMasterDriver(t *testing.T) {
 		t.Run("ResolveSystemRepoBucket", func(t *testing.T) {
 			masterResolveSystemRepoBucket(t, pachClient, minioClient)
 		})
+		t.Run("PresignedURLs", func(t *testing.T) {
+			masterPresignedURLs(t, pachClient, minioClient)
+		})
+		t.Run("WriteSession", func(t *testing.T) {
+			masterWriteSession(t, pachClient, minioClient)
+		})
+		t.Run("MultipartUpload", func(t *testing.T) {
+			masterMultipartUpload(t, pachClient, minioClient)
+		})
+		t.Run("SelectObjectContent", func(t *testing.T) {
+			masterSelectObjectContent(t, pachClient, minioClient)
+		})
+		t.Run("ConditionalRequests", func(t *testing.T) {
+			masterConditionalRequests(t, pachClient, minioClient)
+		})
+		t.Run("ObjectTagging", func(t *testing.T) {
+			masterObjectTagging(t, pachClient, minioClient)
+		})
 		// TODO: Refer to masterAuthV2 function definition.
 		//t.Run("AuthV2", func(t *testing.T) {
 		//	masterAuthV2(t, pachClient, minioClient)
@@ -1,19 +1,17 @@
 package s3
 
 import (
-	"fmt"
+	"context"
+	"database/sql"
 	"io"
 	"net/http"
 	"path"
-	"regexp"
-	"strconv"
-	"strings"
 	"time"
 
-	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/v2/src/client"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
 	pfsClient "github.com/pachyderm/pachyderm/v2/src/pfs"
 	pfsServer "github.com/pachyderm/pachyderm/v2/src/server/pfs"
@@ -21,75 +19,159 @@ import (
 	"github.com/pachyderm/s2"
 )
 
-var multipartChunkPathMatcher = regexp.MustCompile(`([^/]+)/([^/]+)/(.+)/([^/]+)/(\d+)`)
-var multipartKeepPathMatcher = regexp.MustCompile(`([^/]+)/([^/]+)/(.+)/([^/]+)/\.keep`)
+const (
+	// defaultMultipartUploadIdleTimeout is how long a multipart upload can go
+	// without any of its parts being uploaded before it is abandoned.
+	defaultMultipartUploadIdleTimeout = time.Hour
 
-func multipartChunkArgs(path string) (repo string, branch string, key string, uploadID string, partNumber int, err error) {
-	match := multipartChunkPathMatcher.FindStringSubmatch(path)
+	// minPartSize is the smallest size of the parts of a multipart upload,
+	// other than the last one.
+	minPartSize = 5 * 1024 * 1024
+)
 
-	if len(match) == 0 {
-		err = errors.New("invalid file path found in multipath bucket")
-		return
-	}
+// multipartUploads holds the multipart uploads in progress. Each part of an
+// upload is stored as a temporary fileset, holding the part's content at the
+// upload's key. Completing the upload composes the parts' filesets, which
+// concatenates the parts without copying them, and adds the result to the
+// bucket's commit.
+//
+// Uploads and the filesets of their parts are recorded in postgres, so that
+// they survive a restart of pachd and can be continued through any of its
+// replicas. The pfs master renews the parts' filesets for as long as their
+// upload is recorded, and drops the uploads which have been idle for the
+// idle timeout, after which their filesets expire.
+type multipartUploads struct {
+	db          *pachsql.DB
+	idleTimeout time.Duration
+}
 
-	repo = match[1]
-	branch = match[2]
-	key = match[3]
-	uploadID = match[4]
-	partNumber, err = strconv.Atoi(match[5])
-	if err != nil {
-		err = errors.Wrapf(err, "invalid file path found in multipath bucket")
-		return
-	}
-	return
+type multipartUpload struct {
+	ID        string    `db:"upload_id"`
+	Bucket    string    `db:"bucket"`
+	Key       string    `db:"key"`
+	Initiated time.Time `db:"initiated"`
 }
 
-func multipartKeepArgs(path string) (repo string, branch string, key string, uploadID string, err error) {
-	match := multipartKeepPathMatcher.FindStringSubmatch(path)
+type multipartPart struct {
+	PartNumber int    `db:"part_number"`
+	FileSetID  string `db:"fileset_id"`
+	ETag       string `db:"etag"`
+	Size       int64  `db:"size_bytes"`
+}
 
-	if len(match) == 0 {
-		err = errors.New("invalid file path found in multipath bucket")
-		return
+func newMultipartUploads(db *pachsql.DB) *multipartUploads {
+	return &multipartUploads{
+		db:          db,
+		idleTimeout: defaultMultipartUploadIdleTimeout,
 	}
-
-	repo = match[1]
-	branch = match[2]
-	key = match[3]
-	uploadID = match[4]
-	return
 }
 
-func parentDirPath(bucket *Bucket, key string, uploadID string) string {
+// bucketKey identifies the commit a bucket serves, which uploads are
+// matched against regardless of how the bucket is named.
+func bucketKey(bucket *Bucket) string {
 	commitID := bucket.Commit.ID
 	if commitID == "" {
 		commitID = "latest"
 	}
-	return path.Join(bucket.Commit.Branch.Repo.Name, bucket.Commit.Branch.Repo.Type, bucket.Commit.Branch.Name, commitID, key, uploadID)
+	return path.Join(bucket.Commit.Branch.Repo.Name, bucket.Commit.Branch.Repo.Type, bucket.Commit.Branch.Name, commitID)
 }
 
-func chunkPath(bucket *Bucket, key string, uploadID string, partNumber int) string {
-	return path.Join(parentDirPath(bucket, key, uploadID), strconv.Itoa(partNumber))
+func (u *multipartUploads) init(ctx context.Context, bucket *Bucket, key string) (string, error) {
+	id := uuid.NewWithoutDashes()
+	if _, err := u.db.ExecContext(ctx, `INSERT INTO pfs.multipart_uploads (upload_id, bucket, key, expires_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP + make_interval(secs => $4))`,
+		id, bucketKey(bucket), key, u.idleTimeout.Seconds()); err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	return id, nil
 }
 
-func keepPath(bucket *Bucket, key string, uploadID string) string {
-	return path.Join(parentDirPath(bucket, key, uploadID), ".keep")
+// get returns the upload with the given ID, or nil if there is no such
+// upload to key in bucket.
+func (u *multipartUploads) get(ctx context.Context, bucket *Bucket, key, uploadID string) (*multipartUpload, error) {
+	upload := &multipartUpload{}
+	if err := u.db.GetContext(ctx, upload, `SELECT upload_id, bucket, key, initiated FROM pfs.multipart_uploads
+		WHERE upload_id = $1 AND bucket = $2 AND key = $3 AND expires_at > CURRENT_TIMESTAMP`,
+		uploadID, bucketKey(bucket), key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	return upload, nil
 }
 
-func (c *controller) ensureRepo(pc *client.APIClient) error {
-	_, err := pc.InspectBranch(c.repo, "master")
+// touch resets the idle timeout of the upload with the given ID, and returns
+// whether there is such an upload to key in bucket.
+func (u *multipartUploads) touch(ctx context.Context, bucket *Bucket, key, uploadID string) (bool, error) {
+	res, err := u.db.ExecContext(ctx, `UPDATE pfs.multipart_uploads SET expires_at = CURRENT_TIMESTAMP + make_interval(secs => $4)
+		WHERE upload_id = $1 AND bucket = $2 AND key = $3 AND expires_at > CURRENT_TIMESTAMP`,
+		uploadID, bucketKey(bucket), key, u.idleTimeout.Seconds())
 	if err != nil {
-		err = pc.UpdateRepo(c.repo)
-		if err != nil {
-			return err
-		}
+		return false, errors.EnsureStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	return n > 0, nil
+}
 
-		err = pc.CreateBranch(c.repo, "master", "", "", nil)
-		if err != nil {
-			return err
-		}
+// setPart records a part of an upload, replacing the part with the same
+// number, and returns whether the upload is still in progress.
+func (u *multipartUploads) setPart(ctx context.Context, uploadID string, part *multipartPart) (bool, error) {
+	res, err := u.db.ExecContext(ctx, `INSERT INTO pfs.multipart_upload_parts (upload_id, part_number, fileset_id, etag, size_bytes)
+		SELECT upload_id, $2, $3, $4, $5 FROM pfs.multipart_uploads WHERE upload_id = $1
+		ON CONFLICT (upload_id, part_number) DO UPDATE
+		SET fileset_id = EXCLUDED.fileset_id, etag = EXCLUDED.etag, size_bytes = EXCLUDED.size_bytes`,
+		uploadID, part.PartNumber, part.FileSetID, part.ETag, part.Size)
+	if err != nil {
+		return false, errors.EnsureStack(err)
 	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	return n > 0, nil
+}
 
-	return nil
+// parts returns the parts of an upload numbered after partNumberMarker, in
+// order. A negative limit returns all of them.
+func (u *multipartUploads) parts(ctx context.Context, uploadID string, partNumberMarker, limit int) ([]*multipartPart, error) {
+	var parts []*multipartPart
+	if err := u.db.SelectContext(ctx, &parts, `SELECT part_number, fileset_id, etag, size_bytes FROM pfs.multipart_upload_parts
+		WHERE upload_id = $1 AND part_number > $2 ORDER BY part_number LIMIT NULLIF($3, -1)`,
+		uploadID, partNumberMarker, limit); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return parts, nil
+}
+
+// list returns up to limit of the uploads to bucket after the given key and
+// upload ID, ordered by key and then upload ID.
+func (u *multipartUploads) list(ctx context.Context, bucket *Bucket, keyMarker, uploadIDMarker string, limit int) ([]*multipartUpload, error) {
+	var uploads []*multipartUpload
+	if err := u.db.SelectContext(ctx, &uploads, `SELECT upload_id, bucket, key, initiated FROM pfs.multipart_uploads
+		WHERE bucket = $1 AND (key, upload_id) > ($2, $3) AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY key, upload_id LIMIT $4`,
+		bucketKey(bucket), keyMarker, uploadIDMarker, limit); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return uploads, nil
+}
+
+// remove drops an upload, and returns whether there was such an upload. Its
+// parts' filesets expire once they're no longer renewed.
+func (u *multipartUploads) remove(ctx context.Context, uploadID string) (bool, error) {
+	res, err := u.db.ExecContext(ctx, `DELETE FROM pfs.multipart_uploads WHERE upload_id = $1`, uploadID)
+	if err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	return n > 0, nil
 }
 
 func (c *controller) ListMultipart(r *http.Request, bucketName, keyMarker, uploadIDMarker string, maxUploads int) (*s2.ListMultipartResult, error) {
@@ -100,10 +182,6 @@ func (c *controller) ListMultipart(r *http.Request, bucketName, keyMarker, uploa
 		return nil, err
 	}
 
-	if err = c.ensureRepo(pc); err != nil {
-		return nil, err
-	}
-
 	bucket, err := c.driver.bucket(pc, r, bucketName)
 	if err != nil {
 		return nil, err
@@ -113,41 +191,30 @@ func (c *controller) ListMultipart(r *http.Request, bucketName, keyMarker, uploa
 		Uploads: []*s2.Upload{},
 	}
 
-	globPattern := keepPath(bucket, "*", "*")
-	err = pc.GlobFile(client.NewCommit(c.repo, "master", ""), globPattern, func(fileInfo *pfsClient.FileInfo) error {
-		_, _, key, uploadID, err := multipartKeepArgs(fileInfo.File.Path)
-		if err != nil {
-			return nil
-		}
-
-		if key <= keyMarker || uploadID <= uploadIDMarker {
-			return nil
-		}
-
+	// list one more upload than asked for, to tell whether the result is
+	// truncated
+	uploads, err := c.uploads.list(r.Context(), bucket, keyMarker, uploadIDMarker, maxUploads+1)
+	if err != nil {
+		return nil, err
+	}
+	for _, upload := range uploads {
 		if len(result.Uploads) >= maxUploads {
 			if maxUploads > 0 {
 				result.IsTruncated = true
 			}
-			return errutil.ErrBreak
-		}
-
-		timestamp, err := types.TimestampFromProto(fileInfo.Committed)
-		if err != nil {
-			return err
+			break
 		}
 
 		result.Uploads = append(result.Uploads, &s2.Upload{
-			Key:          key,
-			UploadID:     uploadID,
+			Key:          upload.Key,
+			UploadID:     upload.ID,
 			Initiator:    defaultUser,
 			StorageClass: globalStorageClass,
-			Initiated:    timestamp,
+			Initiated:    upload.Initiated,
 		})
+	}
 
-		return nil
-	})
-
-	return &result, err
+	return &result, nil
 }
 
 func (c *controller) InitMultipart(r *http.Request, bucketName, key string) (string, error) {
@@ -158,10 +225,6 @@ func (c *controller) InitMultipart(r *http.Request, bucketName, key string) (str
 		return "", err
 	}
 
-	if err = c.ensureRepo(pc); err != nil {
-		return "", err
-	}
-
 	bucket, err := c.driver.bucket(pc, r, bucketName)
 	if err != nil {
 		return "", err
@@ -174,13 +237,7 @@ func (c *controller) InitMultipart(r *http.Request, bucketName, key string) (str
 		return "", s2.NotImplementedError(r)
 	}
 
-	uploadID := uuid.NewWithoutDashes()
-
-	if err := pc.PutFile(client.NewCommit(c.repo, "master", ""), keepPath(bucket, key, uploadID), strings.NewReader("")); err != nil {
-		return "", err
-	}
-
-	return uploadID, nil
+	return c.uploads.init(r.Context(), bucket, key)
 }
 
 func (c *controller) AbortMultipart(r *http.Request, bucketName, key, uploadID string) (retErr error) {
@@ -193,23 +250,22 @@ func (c *controller) AbortMultipart(r *http.Request, bucketName, key, uploadID s
 		return err
 	}
 
-	if err = c.ensureRepo(pc); err != nil {
-		return err
-	}
-
 	bucket, err := c.driver.bucket(pc, r, bucketName)
 	if err != nil {
 		return err
 	}
 
-	_, err = pc.InspectFile(client.NewCommit(c.repo, "master", ""), keepPath(bucket, key, uploadID))
+	upload, err := c.uploads.get(r.Context(), bucket, key, uploadID)
 	if err != nil {
+		return err
+	}
+	if upload == nil {
 		return s2.NoSuchUploadError(r)
 	}
-
-	err = pc.DeleteFile(client.NewCommit(c.repo, "master", ""), parentDirPath(bucket, key, uploadID))
-	if err != nil {
-		return s2.InternalError(r, err)
+	if ok, err := c.uploads.remove(r.Context(), uploadID); err != nil {
+		return err
+	} else if !ok {
+		return s2.NoSuchUploadError(r)
 	}
 
 	return nil
@@ -225,10 +281,6 @@ func (c *controller) CompleteMultipart(r *http.Request, bucketName, key, uploadI
 		return nil, err
 	}
 
-	if err = c.ensureRepo(pc); err != nil {
-		return nil, err
-	}
-
 	bucket, err := c.driver.bucket(pc, r, bucketName)
 	if err != nil {
 		return nil, err
@@ -241,72 +293,87 @@ func (c *controller) CompleteMultipart(r *http.Request, bucketName, key, uploadI
 		return nil, s2.NotImplementedError(r)
 	}
 
-	_, err = pc.InspectFile(client.NewCommit(c.repo, "master", ""), keepPath(bucket, key, uploadID))
+	if ok, err := c.uploads.touch(r.Context(), bucket, key, uploadID); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, s2.NoSuchUploadError(r)
+	}
+	allParts, err := c.uploads.parts(r.Context(), uploadID, 0, -1)
 	if err != nil {
-		if pfsServer.IsFileNotFoundErr(err) {
-			return nil, s2.NoSuchUploadError(r)
-		}
 		return nil, err
 	}
+	uploadedParts := make(map[int]*multipartPart)
+	for _, part := range allParts {
+		uploadedParts[part.PartNumber] = part
+	}
 
-	// S3 "supports" concurrent complete calls on the same upload ID.
-	// Write to a random file ID in our directory to avoid conflict
-	tmpPath := uuid.NewWithoutDashes()
-
+	var fileSetIDs []string
 	for i, part := range parts {
-		srcPath := chunkPath(bucket, key, uploadID, part.PartNumber)
-
-		fileInfo, err := pc.InspectFile(client.NewCommit(c.repo, "master", ""), srcPath)
-		if err != nil {
-			if pfsServer.IsFileNotFoundErr(err) {
-				return nil, s2.InvalidPartError(r)
-			}
-			return nil, err
+		uploaded, ok := uploadedParts[part.PartNumber]
+		if !ok {
+			return nil, s2.InvalidPartError(r)
 		}
 
 		// Only verify the ETag when it's of the same length as PFS file
 		// hashes. This is because s3 clients will generally use md5 for
 		// ETags, and would otherwise fail.
-		expectedETag := fmt.Sprintf("%x", fileInfo.Hash)
-		if len(part.ETag) == len(expectedETag) && part.ETag != expectedETag {
+		if len(part.ETag) == len(uploaded.ETag) && part.ETag != uploaded.ETag {
 			return nil, s2.InvalidPartError(r)
 		}
 
-		if i < len(parts)-1 && fileInfo.SizeBytes < 5*1024*1024 {
+		if i < len(parts)-1 && uploaded.Size < minPartSize {
 			// each part, except for the last, is expected to be at least 5mb
 			// in s3
 			return nil, s2.EntityTooSmallError(r)
 		}
 
-		if err := pc.CopyFile(
-			client.NewCommit(c.repo, "master", ""), tmpPath,
-			client.NewCommit(c.repo, "master", ""), srcPath,
-			client.WithAppendCopyFile()); err != nil {
-			return nil, err
-		}
+		fileSetIDs = append(fileSetIDs, uploaded.FileSetID)
+	}
+
+	// The parts' filesets each hold their part at the key, so composing them
+	// in order concatenates the parts.
+	fileSetID, err := pc.ComposeFileSet(fileSetIDs, client.DefaultTTL)
+	if err != nil {
+		return nil, err
 	}
 
-	// overwrite file, for "last write wins" behavior
-	if err := pc.CopyFile(bucket.Commit, key, client.NewCommit(c.repo, "master", ""), tmpPath); err != nil {
+	// overwrite file, for "last write wins" behavior, unless the request is
+	// conditional
+	unlock := c.locks.lock(bucket, key)
+	defer unlock()
+	bucketCommit, done, err := c.writeCommit(pc, r, bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	if err := c.checkPreconditions(pc, r, bucketCommit, key); err != nil {
+		return nil, err
+	}
+	if err := pc.DeleteFile(bucketCommit, key); err != nil {
 		if errutil.IsWriteToOutputBranchError(err) {
 			return nil, writeToOutputBranchError(r)
 		}
 		return nil, err
 	}
+	if _, err := pc.PfsAPIClient.AddFileSet(pc.Ctx(), &pfsClient.AddFileSetRequest{
+		Commit:    bucketCommit,
+		FileSetId: fileSetID,
+	}); err != nil {
+		return nil, err
+	}
 
-	err = pc.DeleteFile(client.NewCommit(c.repo, "master", ""), parentDirPath(bucket, key, uploadID))
-	if err != nil {
+	if _, err := c.uploads.remove(r.Context(), uploadID); err != nil {
 		return nil, err
 	}
 
-	fileInfo, err := pc.InspectFile(bucket.Commit, key)
+	fileInfo, err := pc.InspectFile(bucketCommit, key)
 	if err != nil && !pfsServer.IsOutputCommitNotFinishedErr(err) {
 		return nil, err
 	}
 
 	result := s2.CompleteMultipartResult{Location: globalLocation}
 	if fileInfo != nil {
-		result.ETag = fmt.Sprintf("%x", fileInfo.Hash)
+		result.ETag = fileETag(fileInfo)
 		result.Version = fileInfo.File.Commit.ID
 	}
 
@@ -321,14 +388,18 @@ func (c *controller) ListMultipartChunks(r *http.Request, bucketName, key, uploa
 		return nil, err
 	}
 
-	if err = c.ensureRepo(pc); err != nil {
+	bucket, err := c.driver.bucket(pc, r, bucketName)
+	if err != nil {
 		return nil, err
 	}
 
-	bucket, err := c.driver.bucket(pc, r, bucketName)
+	upload, err := c.uploads.get(r.Context(), bucket, key, uploadID)
 	if err != nil {
 		return nil, err
 	}
+	if upload == nil {
+		return nil, s2.NoSuchUploadError(r)
+	}
 
 	result := s2.ListMultipartChunksResult{
 		Initiator:    &defaultUser,
@@ -337,33 +408,27 @@ func (c *controller) ListMultipartChunks(r *http.Request, bucketName, key, uploa
 		Parts:        []*s2.Part{},
 	}
 
-	globPattern := path.Join(parentDirPath(bucket, key, uploadID), "*")
-	err = pc.GlobFile(client.NewCommit(c.repo, "master", ""), globPattern, func(fileInfo *pfsClient.FileInfo) error {
-		_, _, _, _, partNumber, err := multipartChunkArgs(fileInfo.File.Path)
-		if err != nil {
-			return nil
-		}
-
-		if partNumber <= partNumberMarker {
-			return nil
-		}
-
+	// list one more part than asked for, to tell whether the result is
+	// truncated
+	parts, err := c.uploads.parts(r.Context(), upload.ID, partNumberMarker, maxParts+1)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
 		if len(result.Parts) >= maxParts {
 			if maxParts > 0 {
 				result.IsTruncated = true
 			}
-			return errutil.ErrBreak
+			break
 		}
 
 		result.Parts = append(result.Parts, &s2.Part{
-			PartNumber: partNumber,
-			ETag:       fmt.Sprintf("%x", fileInfo.Hash),
+			PartNumber: part.PartNumber,
+			ETag:       part.ETag,
 		})
+	}
 
-		return nil
-	})
-
-	return &result, err
+	return &result, nil
 }
 
 func (c *controller) UploadMultipartChunk(r *http.Request, bucketName, key, uploadID string, partNumber int, reader io.Reader) (string, error) {
@@ -374,32 +439,47 @@ func (c *controller) UploadMultipartChunk(r *http.Request, bucketName, key, uplo
 		return "", err
 	}
 
-	if err = c.ensureRepo(pc); err != nil {
+	bucket, err := c.driver.bucket(pc, r, bucketName)
+	if err != nil {
 		return "", err
 	}
 
-	bucket, err := c.driver.bucket(pc, r, bucketName)
-	if err != nil {
+	if ok, err := c.uploads.touch(r.Context(), bucket, key, uploadID); err != nil {
 		return "", err
+	} else if !ok {
+		return "", s2.NoSuchUploadError(r)
 	}
 
-	_, err = pc.InspectFile(client.NewCommit(c.repo, "master", ""), keepPath(bucket, key, uploadID))
+	// Parts are appended, since an overwriting put deletes the key before its
+	// data, which would delete the earlier parts when they are composed.
+	resp, err := pc.WithCreateFileSetClient(func(mf client.ModifyFile) error {
+		return mf.PutFile(key, reader, client.WithAppendPutFile())
+	})
 	if err != nil {
-		if pfsServer.IsFileNotFoundErr(err) {
-			return "", s2.NoSuchUploadError(r)
-		}
 		return "", err
 	}
-
-	path := chunkPath(bucket, key, uploadID, partNumber)
-	if err := pc.PutFile(client.NewCommit(c.repo, "master", ""), path, reader); err != nil {
+	// Renew the part's fileset before recording it, so that it doesn't
+	// expire before the pfs master gets to renewing it.
+	if err := pc.RenewFileSet(resp.FileSetId, client.DefaultTTL); err != nil {
 		return "", err
 	}
 
-	fileInfo, err := pc.InspectFile(client.NewCommit(c.repo, "master", ""), path)
+	fileInfo, err := pc.InspectFile(client.NewCommit(client.FileSetsRepoName, "", resp.FileSetId), key)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("%x", fileInfo.Hash), nil
+	etag := fileETag(fileInfo)
+	if ok, err := c.uploads.setPart(r.Context(), uploadID, &multipartPart{
+		PartNumber: partNumber,
+		FileSetID:  resp.FileSetId,
+		ETag:       etag,
+		Size:       fileInfo.SizeBytes,
+	}); err != nil {
+		return "", err
+	} else if !ok {
+		// the upload was completed or aborted while the part was uploaded
+		return "", s2.NoSuchUploadError(r)
+	}
+	return etag, nil
 }
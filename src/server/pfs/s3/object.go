@@ -1,12 +1,13 @@
 package s3
 
 import (
-	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/v2/src/client"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
 	pfsServer "github.com/pachyderm/pachyderm/v2/src/server/pfs"
 	"github.com/pachyderm/s2"
@@ -48,10 +49,21 @@ func (c *controller) GetObject(r *http.Request, bucketName, file, version string
 	if err != nil {
 		return nil, maybeNotFoundError(r, err)
 	}
+	// The conditional headers of a copy apply to its destination, the source
+	// is checked by s2 with the x-amz-copy-source-if-* headers.
+	if r.Header.Get("x-amz-copy-source") == "" {
+		if err := checkReadPreconditions(r, fileInfo); err != nil {
+			return nil, err
+		}
+	}
 
-	modTime, err := types.TimestampFromProto(fileInfo.Committed)
-	if err != nil {
-		return nil, err
+	// Files in open commits don't have a modification time yet.
+	var modTime time.Time
+	if fileInfo.Committed != nil {
+		modTime, err = types.TimestampFromProto(fileInfo.Committed)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	content, err := pc.GetFileReadSeeker(bucket.Commit, file)
@@ -62,7 +74,7 @@ func (c *controller) GetObject(r *http.Request, bucketName, file, version string
 	result := s2.GetObjectResult{
 		ModTime:      modTime,
 		Content:      content,
-		ETag:         fmt.Sprintf("%x", fileInfo.Hash),
+		ETag:         fileETag(fileInfo),
 		Version:      commitID,
 		DeleteMarker: false,
 	}
@@ -101,7 +113,17 @@ func (c *controller) CopyObject(r *http.Request, srcBucketName, srcFile string,
 		return "", s2.NotImplementedError(r)
 	}
 
-	if err = pc.CopyFile(destBucket.Commit, destFile, srcBucket.Commit, srcFile); err != nil {
+	unlock := c.locks.lock(destBucket, destFile)
+	defer unlock()
+	destCommit, done, err := c.writeCommit(pc, r, destBucket)
+	if err != nil {
+		return "", err
+	}
+	defer done()
+	if err := c.checkPreconditions(pc, r, destCommit, destFile); err != nil {
+		return "", err
+	}
+	if err = pc.CopyFile(destCommit, destFile, srcBucket.Commit, srcFile); err != nil {
 		if errutil.IsWriteToOutputBranchError(err) {
 			return "", writeToOutputBranchError(r)
 		} else if errutil.IsNotADirectoryError(err) {
@@ -112,7 +134,7 @@ func (c *controller) CopyObject(r *http.Request, srcBucketName, srcFile string,
 		return "", err
 	}
 
-	fileInfo, err := pc.InspectFile(destBucket.Commit, destFile)
+	fileInfo, err := pc.InspectFile(destCommit, destFile)
 	if err != nil && !pfsServer.IsOutputCommitNotFinishedErr(err) {
 		return "", err
 	}
@@ -148,8 +170,30 @@ func (c *controller) PutObject(r *http.Request, bucketName, file string, reader
 		return nil, s2.NotImplementedError(r)
 	}
 
-	bucketCommit := bucket.Commit
-	if err := pc.PutFile(bucketCommit, file, reader); err != nil {
+	tags, err := requestTags(r)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := c.locks.lock(bucket, file)
+	defer unlock()
+	bucketCommit, done, err := c.writeCommit(pc, r, bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	if err := c.checkPreconditions(pc, r, bucketCommit, file); err != nil {
+		return nil, err
+	}
+	if err := pc.WithModifyFileClient(bucketCommit, func(mf client.ModifyFile) error {
+		if err := mf.PutFile(file, reader); err != nil {
+			return err
+		}
+		if tags == nil {
+			return nil
+		}
+		return mf.SetFileMetadata(file, tagsMetadata(nil, tags))
+	}); err != nil {
 		if errutil.IsWriteToOutputBranchError(err) {
 			return nil, writeToOutputBranchError(r)
 		} else if errutil.IsNotADirectoryError(err) {
@@ -167,7 +211,7 @@ func (c *controller) PutObject(r *http.Request, bucketName, file string, reader
 
 	result := s2.PutObjectResult{}
 	if fileInfo != nil {
-		result.ETag = fmt.Sprintf("%x", fileInfo.Hash)
+		result.ETag = fileETag(fileInfo)
 		result.Version = fileInfo.File.Commit.ID
 	}
 
@@ -201,7 +245,14 @@ func (c *controller) DeleteObject(r *http.Request, bucketName, file, version str
 		return nil, s2.NotImplementedError(r)
 	}
 
-	if err = pc.DeleteFile(bucket.Commit, file); err != nil {
+	unlock := c.locks.lock(bucket, file)
+	defer unlock()
+	bucketCommit, done, err := c.writeCommit(pc, r, bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	if err = pc.DeleteFile(bucketCommit, file); err != nil {
 		if errutil.IsWriteToOutputBranchError(err) {
 			return nil, writeToOutputBranchError(r)
 		}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 
 	"github.com/pachyderm/s2"
 	"github.com/sirupsen/logrus"
@@ -20,7 +21,6 @@ import (
 type ClientFactory = func() (*client.APIClient, error)
 
 const (
-	multipartRepo        = "_s3gateway_multipart_"
 	maxAllowedParts      = 10000
 	maxRequestBodyLength = 128 * 1024 * 1024 //128mb
 	requestTimeout       = 10 * time.Second
@@ -39,9 +39,6 @@ var defaultUser = s2.User{ID: "00000000000000000000000000000000", DisplayName: "
 type controller struct {
 	logger *logrus.Entry
 
-	// Name of the PFS repo holding multipart content
-	repo string
-
 	// the maximum number of allowed parts that can be associated with any
 	// given file
 	maxAllowedParts int
@@ -49,6 +46,27 @@ type controller struct {
 	driver Driver
 
 	clientFactory ClientFactory
+
+	// credentials resolves S3 credentials issued by the auth service, it is
+	// nil if only auth tokens are accepted as access keys.
+	credentials CredentialLookup
+
+	sessions *writeSessions
+
+	uploads *multipartUploads
+
+	locks *objectLocks
+}
+
+// RouterOption configures the router returned by Router.
+type RouterOption func(*controller)
+
+// WithCredentialLookup makes the router accept the S3 credentials resolved by
+// `credentials`, in addition to auth tokens used as access keys.
+func WithCredentialLookup(credentials CredentialLookup) RouterOption {
+	return func(c *controller) {
+		c.credentials = credentials
+	}
 }
 
 // requestPachClient uses the clientFactory to construct a request-scoped
@@ -61,8 +79,9 @@ func (c *controller) requestClient(r *http.Request) (*client.APIClient, error) {
 
 	vars := mux.Vars(r)
 	if vars["s3gAuth"] != "disabled" {
-		accessKey := vars["authAccessKey"]
-		if accessKey != "" {
+		if token := vars["s3gToken"]; token != "" {
+			pc.SetAuthToken(token)
+		} else if accessKey := vars["authAccessKey"]; accessKey != "" {
 			pc.SetAuthToken(accessKey)
 		}
 	}
@@ -79,6 +98,10 @@ func (c *controller) requestClient(r *http.Request) (*client.APIClient, error) {
 // enabled when all PFS branches are served as well; e.g. we add support for
 // some s3 versioning functionality.
 //
+// `db` records the state which must be shared by every replica of the
+// gateway, such as the multipart uploads in progress and the commits of write
+// sessions.
+//
 // This returns an `mux.Router` instance. It is the responsibility of the
 // caller to configure a server to use this Router.
 //
@@ -91,17 +114,22 @@ func (c *controller) requestClient(r *http.Request) (*client.APIClient, error) {
 // Note: In `s3cmd`, you must set the access key and secret key, even though
 // this API will ignore them - otherwise, you'll get an opaque config error:
 // https://github.com/s3tools/s3cmd/issues/845#issuecomment-464885959
-func Router(driver Driver, clientFactory ClientFactory) *mux.Router {
+func Router(driver Driver, clientFactory ClientFactory, db *pachsql.DB, opts ...RouterOption) *mux.Router {
 	logger := logrus.WithFields(logrus.Fields{
 		"source": "s3gateway",
 	})
 
 	c := &controller{
 		logger:          logger,
-		repo:            multipartRepo,
 		maxAllowedParts: maxAllowedParts,
 		driver:          driver,
 		clientFactory:   clientFactory,
+		sessions:        newWriteSessions(logger, clientFactory, db),
+		uploads:         newMultipartUploads(db),
+		locks:           newObjectLocks(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
 
 	s3Server := s2.NewS2(logger, maxRequestBodyLength, readBodyTimeout)
@@ -110,7 +138,12 @@ func Router(driver Driver, clientFactory ClientFactory) *mux.Router {
 	s3Server.Bucket = c
 	s3Server.Object = c
 	s3Server.Multipart = c
-	return s3Server.Router()
+	router := s3Server.Router()
+	router.Use(c.scopeMiddleware)
+	router.Use(c.selectMiddleware)
+	router.Use(c.sessionMiddleware)
+	router.Use(c.taggingMiddleware)
+	return router
 }
 
 // S3Server wraps an HTTP server with an S3-like API for PFS. This allows you to
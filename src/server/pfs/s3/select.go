@@ -0,0 +1,262 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/xml"
+	"hash/crc32"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/s3select"
+	"github.com/pachyderm/s2"
+)
+
+// maxRecordsPayload is the size at which selected records are flushed to the
+// client in a Records event.
+const maxRecordsPayload = 128 * 1024
+
+// selectObjectContentRequest is the body of a SelectObjectContent request,
+// the serialization elements of which share their names with the fields of
+// s3select.Input and s3select.Output.
+type selectObjectContentRequest struct {
+	XMLName             xml.Name `xml:"SelectObjectContentRequest"`
+	Expression          string
+	ExpressionType      string
+	InputSerialization  s3select.Input
+	OutputSerialization s3select.Output
+}
+
+type selectStats struct {
+	XMLName        xml.Name `xml:"Stats"`
+	BytesScanned   int64
+	BytesProcessed int64
+	BytesReturned  int64
+}
+
+// isSelectRequest returns whether a request is a SelectObjectContent
+// request, which is a read even though it is a POST.
+func isSelectRequest(r *http.Request) bool {
+	_, ok := r.URL.Query()["select"]
+	return ok && r.Method == http.MethodPost && mux.Vars(r)["key"] != ""
+}
+
+// selectMiddleware serves SelectObjectContent requests, which s2 doesn't
+// implement.
+func (c *controller) selectMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isSelectRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		c.selectObjectContent(w, r)
+	})
+}
+
+// selectObjectContent runs an S3 Select query over an object, and streams
+// the records it selects back in the event stream format.
+//
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/API_SelectObjectContent.html
+func (c *controller) selectObjectContent(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bucketName, file := vars["bucket"], vars["key"]
+	c.logger.Debugf("SelectObjectContent: bucketName=%+v, file=%+v", bucketName, file)
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, s2.InternalError(r, err))
+		return
+	}
+	var req selectObjectContentRequest
+	if err := xml.Unmarshal(body, &req); err != nil {
+		s2.WriteError(c.logger, w, r, s2.MalformedXMLError(r))
+		return
+	}
+	if req.ExpressionType != "SQL" {
+		s2.WriteError(c.logger, w, r, invalidExpressionTypeError(r))
+		return
+	}
+	query, err := s3select.Parse(req.Expression)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, selectError(r, err))
+		return
+	}
+
+	pc, err := c.requestClient(r)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+	// Stop reading the object if the query is done with it early, e.g.
+	// because of a LIMIT.
+	ctx, cancel := context.WithCancel(pc.Ctx())
+	defer cancel()
+	pc = pc.WithCtx(ctx)
+	if strings.HasSuffix(file, "/") {
+		s2.WriteError(c.logger, w, r, invalidFilePathError(r))
+		return
+	}
+	bucket, err := c.driver.bucket(pc, r, bucketName)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+	bucketCaps, err := c.driver.bucketCapabilities(pc, r, bucket)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+	if !bucketCaps.readable {
+		s2.WriteError(c.logger, w, r, s2.NoSuchKeyError(r))
+		return
+	}
+	if _, err := pc.InspectFile(bucket.Commit, file); err != nil {
+		s2.WriteError(c.logger, w, r, maybeNotFoundError(r, err))
+		return
+	}
+	content, err := pc.GetFileReader(bucket.Commit, file)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, maybeNotFoundError(r, err))
+		return
+	}
+
+	events := &eventStreamWriter{w: w, requestID: vars["requestID"]}
+	stats, err := query.Run(content, req.InputSerialization, req.OutputSerialization, events)
+	if err == nil {
+		err = events.finish(stats)
+	}
+	if err != nil {
+		c.logger.Errorf("SelectObjectContent: error running query %q over %s: %v", req.Expression, file, err)
+		s3Err := selectError(r, err)
+		if !events.started {
+			s2.WriteError(c.logger, w, r, s3Err)
+			return
+		}
+		// The response is already being streamed, so the error is sent as an
+		// event.
+		if err := events.error(s3Err); err != nil {
+			c.logger.Errorf("SelectObjectContent: could not send error: %v", err)
+		}
+	}
+}
+
+func selectError(r *http.Request, err error) *s2.Error {
+	var queryErr *s3select.Error
+	if errors.As(err, &queryErr) {
+		return s2.InvalidRequestError(r, queryErr.Error())
+	}
+	var s3Err *s2.Error
+	if errors.As(err, &s3Err) {
+		return s3Err
+	}
+	return s2.InternalError(r, err)
+}
+
+// eventStreamWriter writes the records selected by a query as Records events,
+// in the event stream format: a sequence of messages made of headers and a
+// payload, prefixed by their lengths and checksummed.
+type eventStreamWriter struct {
+	w         http.ResponseWriter
+	requestID string
+	buf       bytes.Buffer
+	// started is set once the response has started being written, after
+	// which errors must be sent as events.
+	started bool
+}
+
+func (e *eventStreamWriter) Write(p []byte) (int, error) {
+	n, _ := e.buf.Write(p)
+	if e.buf.Len() >= maxRecordsPayload {
+		if err := e.records(); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// records sends the buffered records, if any.
+func (e *eventStreamWriter) records() error {
+	if e.buf.Len() == 0 {
+		return nil
+	}
+	defer e.buf.Reset()
+	return e.message([][2]string{
+		{":event-type", "Records"},
+		{":content-type", "application/octet-stream"},
+		{":message-type", "event"},
+	}, e.buf.Bytes())
+}
+
+// finish sends the remaining records, the stats of the query and the End
+// event.
+func (e *eventStreamWriter) finish(stats *s3select.Stats) error {
+	if err := e.records(); err != nil {
+		return err
+	}
+	payload, err := xml.Marshal(selectStats{
+		BytesScanned:   stats.BytesScanned,
+		BytesProcessed: stats.BytesProcessed,
+		BytesReturned:  stats.BytesReturned,
+	})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := e.message([][2]string{
+		{":event-type", "Stats"},
+		{":content-type", "text/xml"},
+		{":message-type", "event"},
+	}, payload); err != nil {
+		return err
+	}
+	return e.message([][2]string{
+		{":event-type", "End"},
+		{":message-type", "event"},
+	}, nil)
+}
+
+func (e *eventStreamWriter) error(s3Err *s2.Error) error {
+	return e.message([][2]string{
+		{":error-code", s3Err.Code},
+		{":error-message", s3Err.Message},
+		{":message-type", "error"},
+	}, nil)
+}
+
+func (e *eventStreamWriter) message(headers [][2]string, payload []byte) error {
+	if !e.started {
+		e.w.Header().Set("Content-Type", "application/octet-stream")
+		e.w.Header().Set("x-amz-id-2", e.requestID)
+		e.w.Header().Set("x-amz-request-id", e.requestID)
+		e.w.WriteHeader(http.StatusOK)
+		e.started = true
+	}
+	var hdrs bytes.Buffer
+	for _, h := range headers {
+		hdrs.WriteByte(byte(len(h[0])))
+		hdrs.WriteString(h[0])
+		// 7 is the type of string header values.
+		hdrs.WriteByte(7)
+		binary.Write(&hdrs, binary.BigEndian, uint16(len(h[1])))
+		hdrs.WriteString(h[1])
+	}
+	var msg bytes.Buffer
+	// The prelude holds the total length of the message, including the
+	// prelude and trailing checksums, and the length of the headers.
+	binary.Write(&msg, binary.BigEndian, uint32(12+hdrs.Len()+len(payload)+4))
+	binary.Write(&msg, binary.BigEndian, uint32(hdrs.Len()))
+	binary.Write(&msg, binary.BigEndian, crc32.ChecksumIEEE(msg.Bytes()))
+	msg.Write(hdrs.Bytes())
+	msg.Write(payload)
+	binary.Write(&msg, binary.BigEndian, crc32.ChecksumIEEE(msg.Bytes()))
+	if _, err := e.w.Write(msg.Bytes()); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if f, ok := e.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package s3
 import (
 	"net/http"
 
+	"github.com/gorilla/mux"
 	"github.com/pachyderm/s2"
 )
 
@@ -21,6 +22,14 @@ func (c *controller) ListBuckets(r *http.Request) (*s2.ListBucketsResult, error)
 	if err = c.driver.listBuckets(pc, r, &result.Buckets); err != nil {
 		return nil, err
 	}
+	vars := mux.Vars(r)
+	buckets := result.Buckets[:0]
+	for _, bucket := range result.Buckets {
+		if inBucketScope(vars, bucket.Name) {
+			buckets = append(buckets, bucket)
+		}
+	}
+	result.Buckets = buckets
 
 	return &result, nil
 }
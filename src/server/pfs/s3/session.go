@@ -0,0 +1,312 @@
+package s3
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsServer "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// writeSessionHeader names the write session the writes of a request are
+	// accumulated in.
+	writeSessionHeader = "X-Pach-Write-Session"
+	// writeSessionFinishHeader finishes the write session of a request once
+	// the request has been served.
+	writeSessionFinishHeader = "X-Pach-Write-Session-Finish"
+
+	defaultWriteSessionIdleTimeout = 30 * time.Second
+
+	// batchSessionPrefix starts the keys of the implicit sessions which batch
+	// the writes of clients within the batch window.
+	batchSessionPrefix = "batch/"
+
+	// sessionCommitRecoveryDelay is how long after a session's timeout the
+	// pfs master finishes its commits, if the gateway hasn't, so that only
+	// the commits of sessions lost to a restart are finished by the master.
+	sessionCommitRecoveryDelay = 10 * time.Minute
+)
+
+// writeSessions accumulates the writes made through the S3 gateway into one
+// open commit per branch, rather than one commit per write, so that syncing
+// many objects triggers downstream pipelines once. A session is finished, and
+// its commits with it, when it is explicitly finished or has been idle for
+// its timeout.
+//
+// Requests join a session by naming it in the X-Pach-Write-Session header. If
+// a batch window is set, requests without the header join an implicit
+// session of their client, which finishes after being idle for the window.
+// Multi-object deletes and multipart upload completions which aren't in a
+// session are made in a session of their own, which finishes with the
+// request.
+//
+// A session can only start a commit on a branch whose head is finished. If
+// the head is open, such as when another session has started a commit on the
+// branch, the session's writes to the branch are made to the branch without
+// a session, which lands them in the open head.
+//
+// The commits started by sessions are recorded in postgres, so that the pfs
+// master finishes the commits of the sessions lost to a restart.
+type writeSessions struct {
+	logger        *logrus.Entry
+	clientFactory ClientFactory
+	db            *pachsql.DB
+	idleTimeout   time.Duration
+	batchWindow   time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*writeSession
+}
+
+type writeSession struct {
+	key string
+	// token is the auth token the session's commits are finished with
+	token string
+	// commits are the commits the session started, by branch
+	commits map[string]*pfs.Commit
+	// inflight counts the writes in progress, a session isn't finished while
+	// it has writes in progress
+	inflight int
+	// finishing is set when a session is finished while it has writes in
+	// progress, in which case the last of them finishes it
+	finishing bool
+	timer     *time.Timer
+}
+
+func newWriteSessions(logger *logrus.Entry, clientFactory ClientFactory, db *pachsql.DB) *writeSessions {
+	return &writeSessions{
+		logger:        logger,
+		clientFactory: clientFactory,
+		db:            db,
+		idleTimeout:   defaultWriteSessionIdleTimeout,
+		sessions:      make(map[string]*writeSession),
+	}
+}
+
+// WithWriteSessions sets how long a write session named by a request header
+// can be idle before it is finished, and the idle window of the implicit
+// sessions which batch the writes without a session header. A zero batch
+// window disables implicit sessions.
+func WithWriteSessions(idleTimeout, batchWindow time.Duration) RouterOption {
+	return func(c *controller) {
+		if idleTimeout > 0 {
+			c.sessions.idleTimeout = idleTimeout
+		}
+		c.sessions.batchWindow = batchWindow
+	}
+}
+
+// requestIdentity returns the auth token a request is made with.
+func requestIdentity(r *http.Request) string {
+	vars := mux.Vars(r)
+	if vars["s3gAuth"] == "disabled" {
+		return ""
+	}
+	if token := vars["s3gToken"]; token != "" {
+		return token
+	}
+	return vars["authAccessKey"]
+}
+
+// sessionMiddleware selects the write session of a request, and finishes the
+// session once the request is served if it is request scoped or the request
+// asks for it.
+func (c *controller) sessionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		identity := requestIdentity(r)
+		finish := strings.EqualFold(r.Header.Get(writeSessionFinishHeader), "true")
+		if name := r.Header.Get(writeSessionHeader); name != "" {
+			vars["s3gSession"] = "named/" + identity + "/" + name
+		} else if requestScopedWrite(r) && c.sessions.batchWindow == 0 {
+			vars["s3gSession"] = "request/" + identity + "/" + uuid.NewWithoutDashes()
+			finish = true
+		} else if c.sessions.batchWindow > 0 {
+			vars["s3gSession"] = batchSessionPrefix + identity
+		}
+		next.ServeHTTP(w, r)
+		if key := vars["s3gSession"]; finish && key != "" {
+			c.sessions.finish(key)
+		}
+	})
+}
+
+// requestScopedWrite returns whether a request makes several writes which
+// must land in one commit, which are multi-object deletes and the completion
+// of multipart uploads.
+func requestScopedWrite(r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		return false
+	}
+	query := r.URL.Query()
+	_, isDelete := query["delete"]
+	_, isCompleteMultipart := query["uploadId"]
+	return isDelete || isCompleteMultipart
+}
+
+// writeCommit returns the commit a request writes to a bucket with, which is
+// an open commit of the request's write session if it has one. The returned
+// function must be called once the write is done.
+func (c *controller) writeCommit(pc *client.APIClient, r *http.Request, bucket *Bucket) (*pfs.Commit, func(), error) {
+	key := mux.Vars(r)["s3gSession"]
+	// Writes to a specific commit, such as the output commit of a job, are
+	// already made in an open commit.
+	if key == "" || bucket.Commit.ID != "" {
+		return bucket.Commit, func() {}, nil
+	}
+	commit, done, err := c.sessions.commit(pc, key, requestIdentity(r), bucket.Commit.Branch)
+	if err != nil {
+		if pfsServer.IsCommitOnOutputBranchErr(err) {
+			return nil, nil, writeToOutputBranchError(r)
+		}
+		return nil, nil, err
+	}
+	if commit == nil {
+		return bucket.Commit, func() {}, nil
+	}
+	return commit, done, nil
+}
+
+// commit returns the commit a session writes to a branch with, starting it if
+// the session hasn't yet. It returns a nil commit if the branch's head is an
+// open commit the session didn't start, in which case the write is made
+// without the session.
+func (s *writeSessions) commit(pc *client.APIClient, key, token string, branch *pfs.Branch) (*pfs.Commit, func(), error) {
+	s.mu.Lock()
+	session, ok := s.sessions[key]
+	if !ok {
+		session = &writeSession{
+			key:     key,
+			token:   token,
+			commits: make(map[string]*pfs.Commit),
+		}
+		session.timer = time.AfterFunc(s.timeout(key), func() { s.expire(session) })
+		s.sessions[key] = session
+	}
+	// The write is counted as in progress before the session's lock is
+	// released, so that the session isn't finished while its commit is
+	// being started.
+	session.inflight++
+	session.timer.Reset(s.timeout(key))
+	commit, ok := session.commits[pfsdb.BranchKey(branch)]
+	s.mu.Unlock()
+	done := func() {
+		s.mu.Lock()
+		session.inflight--
+		finish := session.finishing && session.inflight == 0
+		s.mu.Unlock()
+		if finish {
+			s.finishCommits(session)
+		}
+	}
+	if !ok {
+		// The session only writes to the commits it starts, so that it can't
+		// finish an open commit it doesn't own, such as the output commit of
+		// a running job.
+		var err error
+		commit, err = s.startCommit(pc, session, branch)
+		if err != nil || commit == nil {
+			done()
+			return nil, nil, err
+		}
+	}
+	ttl := s.timeout(key) + sessionCommitRecoveryDelay
+	if err := pfsdb.PutSessionCommit(pc.Ctx(), s.db, commit, ttl); err != nil {
+		done()
+		return nil, nil, err
+	}
+	return commit, done, nil
+}
+
+// startCommit starts the commit of a session on a branch, or returns the
+// commit a concurrent write of the session started. It returns a nil commit
+// if the branch's head is an open commit the session didn't start.
+func (s *writeSessions) startCommit(pc *client.APIClient, session *writeSession, branch *pfs.Branch) (*pfs.Commit, error) {
+	commit, err := pc.StartCommit(branch.Repo.Name, branch.Name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		if pfsServer.IsParentCommitNotFinishedErr(err) {
+			return session.commits[pfsdb.BranchKey(branch)], nil
+		}
+		return nil, err
+	}
+	session.commits[pfsdb.BranchKey(branch)] = commit
+	return commit, nil
+}
+
+func (s *writeSessions) timeout(key string) time.Duration {
+	if strings.HasPrefix(key, batchSessionPrefix) {
+		return s.batchWindow
+	}
+	return s.idleTimeout
+}
+
+// expire finishes a session which has been idle for its timeout.
+func (s *writeSessions) expire(session *writeSession) {
+	s.mu.Lock()
+	if s.sessions[session.key] != session {
+		s.mu.Unlock()
+		return
+	}
+	if session.inflight > 0 {
+		session.timer.Reset(s.timeout(session.key))
+		s.mu.Unlock()
+		return
+	}
+	delete(s.sessions, session.key)
+	s.mu.Unlock()
+	s.finishCommits(session)
+}
+
+// finish finishes a session and the commits it started, or leaves finishing
+// it to the last of its writes in progress.
+func (s *writeSessions) finish(key string) {
+	s.mu.Lock()
+	session, ok := s.sessions[key]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	session.timer.Stop()
+	delete(s.sessions, key)
+	if session.inflight > 0 {
+		session.finishing = true
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	s.finishCommits(session)
+}
+
+func (s *writeSessions) finishCommits(session *writeSession) {
+	pc, err := s.clientFactory()
+	if err != nil {
+		s.logger.Errorf("could not create a pach client to finish write session: %v", err)
+		return
+	}
+	pc = pc.WithCtx(context.Background())
+	if session.token != "" {
+		pc.SetAuthToken(session.token)
+	}
+	for _, commit := range session.commits {
+		if err := pc.FinishCommit(commit.Branch.Repo.Name, commit.Branch.Name, commit.ID); err != nil && !pfsServer.IsCommitFinishedErr(err) {
+			// the commit is left to the pfs master to finish
+			s.logger.Errorf("could not finish commit %s of write session: %v", commit, err)
+			continue
+		}
+		if err := pfsdb.DeleteSessionCommit(pc.Ctx(), s.db, commit); err != nil {
+			s.logger.Errorf("could not delete the record of commit %s of write session: %v", commit, err)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/s2"
+)
+
+const (
+	// tagMetadataPrefix starts the keys of the file metadata which hold the
+	// tags of an object, the rest of the file metadata isn't exposed as tags.
+	tagMetadataPrefix = "s3/tag/"
+
+	maxTags           = 10
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+type tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	TagSet  []tag    `xml:"TagSet>Tag"`
+}
+
+type tag struct {
+	Key   string
+	Value string
+}
+
+// isTaggingRequest returns whether a request gets, puts or deletes the tags
+// of an object.
+func isTaggingRequest(r *http.Request) bool {
+	_, ok := r.URL.Query()["tagging"]
+	return ok && mux.Vars(r)["key"] != ""
+}
+
+// taggingMiddleware serves the object tagging requests, which s2 doesn't
+// implement. Tags are stored in the metadata of the object's file.
+func (c *controller) taggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isTaggingRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			c.getObjectTagging(w, r)
+		case http.MethodPut:
+			c.putObjectTagging(w, r)
+		case http.MethodDelete:
+			c.deleteObjectTagging(w, r)
+		default:
+			s2.WriteError(c.logger, w, r, s2.MethodNotAllowedError(r))
+		}
+	})
+}
+
+func (c *controller) getObjectTagging(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bucketName, file := vars["bucket"], vars["key"]
+	c.logger.Debugf("GetObjectTagging: bucketName=%+v, file=%+v", bucketName, file)
+
+	pc, err := c.requestClient(r)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+	bucket, err := c.driver.bucket(pc, r, bucketName)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+	bucketCaps, err := c.driver.bucketCapabilities(pc, r, bucket)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+	if !bucketCaps.readable {
+		s2.WriteError(c.logger, w, r, s2.NoSuchKeyError(r))
+		return
+	}
+	fileInfo, err := pc.InspectFile(bucket.Commit, file)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, maybeNotFoundError(r, err))
+		return
+	}
+
+	result := tagging{TagSet: []tag{}}
+	for key, value := range fileInfo.Metadata {
+		if strings.HasPrefix(key, tagMetadataPrefix) {
+			result.TagSet = append(result.TagSet, tag{Key: strings.TrimPrefix(key, tagMetadataPrefix), Value: value})
+		}
+	}
+	sort.Slice(result.TagSet, func(i, j int) bool {
+		return result.TagSet[i].Key < result.TagSet[j].Key
+	})
+	writeResponseHeaders(w, r, http.StatusOK)
+	fmt.Fprint(w, xml.Header)
+	if err := xml.NewEncoder(w).Encode(result); err != nil {
+		c.logger.Errorf("could not encode xml response: %v", err)
+	}
+}
+
+func (c *controller) putObjectTagging(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	c.logger.Debugf("PutObjectTagging: bucketName=%+v, file=%+v", vars["bucket"], vars["key"])
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, s2.InternalError(r, err))
+		return
+	}
+	var req tagging
+	if err := xml.Unmarshal(body, &req); err != nil {
+		s2.WriteError(c.logger, w, r, s2.MalformedXMLError(r))
+		return
+	}
+	tags := make(map[string]string)
+	for _, t := range req.TagSet {
+		if _, ok := tags[t.Key]; ok {
+			s2.WriteError(c.logger, w, r, invalidTagError(r, "Cannot provide multiple Tags with the same key"))
+			return
+		}
+		tags[t.Key] = t.Value
+	}
+	if err := validateTags(r, tags); err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+	if err := c.setObjectTags(r, tags); err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+	writeResponseHeaders(w, r, http.StatusOK)
+}
+
+func (c *controller) deleteObjectTagging(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	c.logger.Debugf("DeleteObjectTagging: bucketName=%+v, file=%+v", vars["bucket"], vars["key"])
+
+	if err := c.setObjectTags(r, nil); err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+	writeResponseHeaders(w, r, http.StatusNoContent)
+}
+
+// setObjectTags replaces the tags of the object of a request, keeping the
+// rest of its file's metadata.
+func (c *controller) setObjectTags(r *http.Request, tags map[string]string) error {
+	vars := mux.Vars(r)
+	bucketName, file := vars["bucket"], vars["key"]
+
+	pc, err := c.requestClient(r)
+	if err != nil {
+		return err
+	}
+	bucket, err := c.driver.bucket(pc, r, bucketName)
+	if err != nil {
+		return err
+	}
+	bucketCaps, err := c.driver.bucketCapabilities(pc, r, bucket)
+	if err != nil {
+		return err
+	}
+	if !bucketCaps.writable {
+		return s2.NotImplementedError(r)
+	}
+
+	unlock := c.locks.lock(bucket, file)
+	defer unlock()
+	bucketCommit, done, err := c.writeCommit(pc, r, bucket)
+	if err != nil {
+		return err
+	}
+	defer done()
+	// Setting the metadata of a file which doesn't exist would create it.
+	fileInfo, err := pc.InspectFile(bucketCommit, file)
+	if err != nil {
+		return maybeNotFoundError(r, err)
+	}
+	if err := pc.SetFileMetadata(bucketCommit, file, tagsMetadata(fileInfo.Metadata, tags)); err != nil {
+		if errutil.IsWriteToOutputBranchError(err) {
+			return writeToOutputBranchError(r)
+		}
+		return err
+	}
+	return nil
+}
+
+// requestTags returns the tags an object is put with in the x-amz-tagging
+// header, which is nil if the header is unset.
+func requestTags(r *http.Request) (map[string]string, error) {
+	header := r.Header.Get("x-amz-tagging")
+	if header == "" {
+		return nil, nil
+	}
+	values, err := url.ParseQuery(header)
+	if err != nil {
+		return nil, invalidTagError(r, "The header 'x-amz-tagging' shall be encoded as UTF-8 then URLEncoded URL query parameters without tag name duplicates.")
+	}
+	tags := make(map[string]string)
+	for key, value := range values {
+		if len(value) > 1 {
+			return nil, invalidTagError(r, "Cannot provide multiple Tags with the same key")
+		}
+		tags[key] = value[0]
+	}
+	if err := validateTags(r, tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func validateTags(r *http.Request, tags map[string]string) error {
+	if len(tags) > maxTags {
+		return invalidTagError(r, fmt.Sprintf("Object tags cannot be greater than %d", maxTags))
+	}
+	for key, value := range tags {
+		if key == "" || len(key) > maxTagKeyLength {
+			return invalidTagError(r, "The TagKey you have provided is invalid")
+		}
+		if len(value) > maxTagValueLength {
+			return invalidTagError(r, "The TagValue you have provided is invalid")
+		}
+	}
+	return nil
+}
+
+// tagsMetadata returns the file metadata md with its tags replaced by tags.
+func tagsMetadata(md, tags map[string]string) map[string]string {
+	result := make(map[string]string)
+	for key, value := range md {
+		if !strings.HasPrefix(key, tagMetadataPrefix) {
+			result[key] = value
+		}
+	}
+	for key, value := range tags {
+		result[tagMetadataPrefix+key] = value
+	}
+	return result
+}
+
+func writeResponseHeaders(w http.ResponseWriter, r *http.Request, code int) {
+	requestID := mux.Vars(r)["requestID"]
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("x-amz-id-2", requestID)
+	w.Header().Set("x-amz-request-id", requestID)
+	w.WriteHeader(code)
+}
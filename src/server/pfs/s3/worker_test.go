@@ -285,7 +285,7 @@ func TestWorkerDriver(t *testing.T) {
 		},
 	)
 
-	testRunner(t, pachClient, "worker", driver, func(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	testRunner(t, pachClient, env.ServiceEnv.GetDBClient(), "worker", driver, func(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
 		s := &workerTestState{
 			pachClient:         pachClient,
 			minioClient:        minioClient,
@@ -21,6 +21,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/log"
 	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pfsload"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/chunk"
 	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
@@ -85,7 +86,7 @@ func (a *apiServer) CreateRepoInTransaction(txnCtx *txncontext.TransactionContex
 	if repo := request.GetRepo(); repo != nil && repo.Name == fileSetsRepo {
 		return errors.Errorf("%s is a reserved name", fileSetsRepo)
 	}
-	return a.driver.createRepo(txnCtx, request.Repo, request.Description, request.Update)
+	return a.driver.createRepo(txnCtx, request.Repo, request.Description, request.Update, request.Retention)
 }
 
 // CreateRepo implements the protobuf pfs.CreateRepo RPC
@@ -135,7 +136,16 @@ func (a *apiServer) InspectRepo(ctx context.Context, request *pfs.InspectRepoReq
 func (a *apiServer) ListRepo(request *pfs.ListRepoRequest, srv pfs.API_ListRepoServer) (retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
-	return a.driver.listRepo(srv.Context(), true, request.Type, srv.Send)
+	p, err := newPager(request.PageSize, request.PageToken)
+	if err != nil {
+		return err
+	}
+	return p.finish(a.driver.listRepo(srv.Context(), true, request.Type, p.token.After, func(repoInfo *pfs.RepoInfo) error {
+		return p.add(pfsdb.RepoKey(repoInfo.Repo), func(nextPageToken string) error {
+			repoInfo.NextPageToken = nextPageToken
+			return srv.Send(repoInfo)
+		})
+	}))
 }
 
 // DeleteRepoInTransaction is identical to DeleteRepo except that it can run
@@ -208,7 +218,23 @@ func (a *apiServer) InspectCommitInTransaction(txnCtx *txncontext.TransactionCon
 func (a *apiServer) InspectCommit(ctx context.Context, request *pfs.InspectCommitRequest) (response *pfs.CommitInfo, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
-	return a.driver.inspectCommit(ctx, request.Commit, request.Wait)
+	commitInfo, err := a.driver.inspectCommit(ctx, request.Commit, request.Wait)
+	if err != nil {
+		return nil, err
+	}
+	if request.Details {
+		stats, err := a.driver.fileSetStats(ctx, commitInfo.Commit)
+		if err != nil {
+			return nil, err
+		}
+		if commitInfo.Details == nil {
+			commitInfo.Details = &pfs.CommitInfo_Details{}
+		}
+		commitInfo.Details.NumLayers = stats.Layers
+		commitInfo.Details.IndexSizeBytes = stats.IndexSizeBytes
+		commitInfo.Details.ReadAmplification = stats.ReadAmplification
+	}
+	return commitInfo, nil
 }
 
 // ListCommit implements the protobuf pfs.ListCommit RPC
@@ -218,10 +244,25 @@ func (a *apiServer) ListCommit(request *pfs.ListCommitRequest, respServer pfs.AP
 	defer func(start time.Time) {
 		a.Log(request, fmt.Sprintf("stream containing %d commits", sent), retErr, time.Since(start))
 	}(time.Now())
-	return a.driver.listCommit(respServer.Context(), request.Repo, request.To, request.From, request.Number, request.Reverse, request.All, request.OriginKind, func(ci *pfs.CommitInfo) error {
-		sent++
-		return respServer.Send(ci)
-	})
+	p, err := newPager(request.PageSize, request.PageToken)
+	if err != nil {
+		return err
+	}
+	// The number of commits is across all of the pages.
+	number := request.Number
+	if number > 0 {
+		if number <= p.token.Offset {
+			return nil
+		}
+		number -= p.token.Offset
+	}
+	return p.finish(a.driver.listCommit(respServer.Context(), request.Repo, request.To, request.From, number, request.Reverse, request.All, request.OriginKind, p.token.After, func(ci *pfs.CommitInfo) error {
+		return p.add(pfsdb.CommitKey(ci.Commit), func(nextPageToken string) error {
+			ci.NextPageToken = nextPageToken
+			sent++
+			return respServer.Send(ci)
+		})
+	}))
 }
 
 // InspectCommitSetInTransaction performs the same job as InspectCommitSet
@@ -288,6 +329,27 @@ func (a *apiServer) SubscribeCommit(request *pfs.SubscribeCommitRequest, stream
 	return a.driver.subscribeCommit(stream.Context(), request.Repo, request.Branch, request.From, request.State, request.All, request.OriginKind, stream.Send)
 }
 
+// SubscribeFile implements the protobuf pfs.SubscribeFile RPC
+func (a *apiServer) SubscribeFile(request *pfs.SubscribeFileRequest, stream pfs.API_SubscribeFileServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
+	return a.driver.subscribeFile(stream.Context(), request.Branch, request.Pattern, request.From, stream.Send)
+}
+
+// VerifyCommit implements the protobuf pfs.VerifyCommit RPC
+func (a *apiServer) VerifyCommit(request *pfs.VerifyCommitRequest, server pfs.API_VerifyCommitServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
+	return a.driver.verifyCommit(server.Context(), request.Commit, request.Ancestors, server.Send)
+}
+
+// AttestCommit implements the protobuf pfs.AttestCommit RPC
+func (a *apiServer) AttestCommit(ctx context.Context, request *pfs.AttestCommitRequest) (response *pfs.CommitAttestation, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	return a.driver.attestCommit(ctx, request.Commit)
+}
+
 // ClearCommit deletes all data in the commit.
 func (a *apiServer) ClearCommit(ctx context.Context, request *pfs.ClearCommitRequest) (_ *types.Empty, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
@@ -295,10 +357,27 @@ func (a *apiServer) ClearCommit(ctx context.Context, request *pfs.ClearCommitReq
 	return &types.Empty{}, a.driver.clearCommit(ctx, request.Commit)
 }
 
+// CompactCommit implements the protobuf pfs.CompactCommit RPC
+func (a *apiServer) CompactCommit(ctx context.Context, request *pfs.CompactCommitRequest) (_ *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
+	return &types.Empty{}, a.driver.compactCommit(ctx, request.Commit)
+}
+
+// MaterializeCommit implements the protobuf pfs.MaterializeCommit RPC
+func (a *apiServer) MaterializeCommit(ctx context.Context, request *pfs.MaterializeCommitRequest) (_ *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
+	if request.Background {
+		return &types.Empty{}, a.driver.queueMaterialize(ctx, request.Commit)
+	}
+	return &types.Empty{}, a.driver.materializeCommit(ctx, request.Commit)
+}
+
 // CreateBranchInTransaction is identical to CreateBranch except that it can run
 // inside an existing postgres transaction.  This is not an RPC.
 func (a *apiServer) CreateBranchInTransaction(txnCtx *txncontext.TransactionContext, request *pfs.CreateBranchRequest) error {
-	return a.driver.createBranch(txnCtx, request.Branch, request.Head, request.Provenance, request.Trigger)
+	return a.driver.createBranch(txnCtx, request.Branch, request.Head, request.Provenance, request.Trigger, userReason(txnCtx))
 }
 
 // CreateBranch implements the protobuf pfs.CreateBranch RPC
@@ -381,6 +460,25 @@ func (a *apiServer) DeleteBranch(ctx context.Context, request *pfs.DeleteBranchR
 	return &types.Empty{}, nil
 }
 
+// ListReflog implements the protobuf pfs.ListReflog RPC
+func (a *apiServer) ListReflog(request *pfs.ListReflogRequest, server pfs.API_ListReflogServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
+	return a.driver.listReflog(server.Context(), request.Branch, request.Number, server.Send)
+}
+
+// ResetBranch implements the protobuf pfs.ResetBranch RPC
+func (a *apiServer) ResetBranch(ctx context.Context, request *pfs.ResetBranchRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	if err := a.env.TxnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		return a.driver.resetBranch(txnCtx, request.Branch, request.ReflogIndex)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
 func (a *apiServer) ModifyFile(server pfs.API_ModifyFileServer) (retErr error) {
 	commit, err := readCommit(server)
 	if err != nil {
@@ -443,6 +541,11 @@ func (a *apiServer) modifyFile(ctx context.Context, uw *fileset.UnorderedWriter,
 			if err := deleteFile(uw, mod.DeleteFile); err != nil {
 				return bytesRead, err
 			}
+		case *pfs.ModifyFileRequest_SetFileMetadata:
+			sfm := mod.SetFileMetadata
+			if err := uw.SetMetadata(sfm.Path, sfm.Datum, sfm.Metadata); err != nil {
+				return bytesRead, err
+			}
 		case *pfs.ModifyFileRequest_CopyFile:
 			cf := mod.CopyFile
 			if err := func() (retErr error) {
@@ -477,6 +580,9 @@ func putFileURL(ctx context.Context, uw *fileset.UnorderedWriter, dstPath, tag s
 	case "http":
 		fallthrough
 	case "https":
+		if src.ByReference {
+			return 0, errors.Errorf("cannot add %v by reference, only object storage URLs can be added by reference", src.URL)
+		}
 		resp, err := http.Get(src.URL)
 		if err != nil {
 			return 0, err
@@ -498,9 +604,19 @@ func putFileURL(ctx context.Context, uw *fileset.UnorderedWriter, dstPath, tag s
 		if err != nil {
 			return 0, err
 		}
+		putRef := func(p, name string) error {
+			ref, err := fileset.NewExternalRef(ctx, objClient, name)
+			if err != nil {
+				return err
+			}
+			return uw.PutExternal(p, tag, ref)
+		}
 		if src.Recursive {
 			path := strings.TrimPrefix(url.Object, "/")
 			return 0, objClient.Walk(ctx, path, func(name string) error {
+				if src.ByReference {
+					return putRef(filepath.Join(dstPath, strings.TrimPrefix(name, path)), name)
+				}
 				return miscutil.WithPipe(func(w io.Writer) error {
 					return objClient.Get(ctx, name, w)
 				}, func(r io.Reader) error {
@@ -508,6 +624,9 @@ func putFileURL(ctx context.Context, uw *fileset.UnorderedWriter, dstPath, tag s
 				})
 			})
 		}
+		if src.ByReference {
+			return 0, putRef(dstPath, url.Object)
+		}
 		return 0, miscutil.WithPipe(func(w io.Writer) error {
 			return objClient.Get(ctx, url.Object, w)
 		}, func(r io.Reader) error {
@@ -576,7 +695,7 @@ func (a *apiServer) GetFile(request *pfs.GetFileRequest, server pfs.API_GetFileS
 }
 
 // TODO: Parallelize and decide on appropriate config.
-func getFileURL(ctx context.Context, URL string, src Source) (int64, error) {
+func getFileURL(ctx context.Context, URL string, src Source) (_ int64, retErr error) {
 	parsedURL, err := obj.ParseURL(URL)
 	if err != nil {
 		return 0, err
@@ -585,6 +704,14 @@ func getFileURL(ctx context.Context, URL string, src Source) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	// A replicated client finishes replicating the objects when it's closed.
+	if closer, ok := objClient.(io.Closer); ok {
+		defer func() {
+			if err := closer.Close(); retErr == nil {
+				retErr = errors.EnsureStack(err)
+			}
+		}()
+	}
 	var bytesWritten int64
 	err = src.Iterate(ctx, func(fi *pfs.FileInfo, file fileset.File) (retErr error) {
 		if fi.FileType != pfs.FileType_FILE {
@@ -651,10 +778,95 @@ func (a *apiServer) ListFile(request *pfs.ListFileRequest, server pfs.API_ListFi
 	defer func(start time.Time) {
 		a.Log(request, fmt.Sprintf("response stream with %d objects", sent), retErr, time.Since(start))
 	}(time.Now())
-	return a.driver.listFile(server.Context(), request.File, func(fi *pfs.FileInfo) error {
-		sent++
-		return server.Send(fi)
-	})
+	p, err := newPager(request.PageSize, request.PageToken)
+	if err != nil {
+		return err
+	}
+	add := func(fi *pfs.FileInfo) error {
+		return p.add(fi.File.Path, func(nextPageToken string) error {
+			fi.NextPageToken = nextPageToken
+			sent++
+			return server.Send(fi)
+		})
+	}
+	// Files are stored in path order, so a path ordered listing can start
+	// after the last path of the previous page.
+	if request.Sort == pfs.FileSort_FILE_SORT_PATH && !request.Reverse {
+		after := absolutePath(request.StartAfter)
+		if p.token.After > after {
+			after = p.token.After
+		}
+		return p.finish(a.driver.listFile(server.Context(), request.File, after, add))
+	}
+	// Other orders are found by offset, so only the files up to the end of
+	// the page are kept.
+	var times map[string]*types.Timestamp
+	if request.Sort == pfs.FileSort_FILE_SORT_COMMITTED {
+		paths := make(map[string]bool)
+		if err := a.driver.listFile(server.Context(), request.File, absolutePath(request.StartAfter), func(fi *pfs.FileInfo) error {
+			paths[fi.File.Path] = true
+			return nil
+		}); err != nil {
+			return err
+		}
+		if times, err = a.driver.fileCommitTimes(server.Context(), request.File, paths); err != nil {
+			return err
+		}
+	}
+	var limit int64
+	if request.PageSize > 0 {
+		// one more file than the page shows whether there is a next page
+		limit = p.token.Offset + request.PageSize + 1
+	}
+	h := newFileInfoHeap(fileInfoLess(request.Sort, request.Reverse), limit)
+	if err := a.driver.listFile(server.Context(), request.File, absolutePath(request.StartAfter), func(fi *pfs.FileInfo) error {
+		if times != nil {
+			fi.Committed = times[fi.File.Path]
+		}
+		h.add(fi)
+		return nil
+	}); err != nil {
+		return err
+	}
+	fis := h.sorted()
+	if p.token.Offset > int64(len(fis)) {
+		return errors.Errorf("page token is no longer valid, offset %d is past the end of the listing", p.token.Offset)
+	}
+	for _, fi := range fis[p.token.Offset:] {
+		if err := add(fi); err != nil {
+			return p.finish(err)
+		}
+	}
+	return p.finish(nil)
+}
+
+// fileInfoLess returns the order of a file listing, with path as the tie
+// breaker. Files in open commits have no committed time, and are the most
+// recently committed.
+func fileInfoLess(by pfs.FileSort, reverse bool) func(a, b *pfs.FileInfo) bool {
+	less := func(a, b *pfs.FileInfo) bool {
+		if by == pfs.FileSort_FILE_SORT_SIZE && a.SizeBytes != b.SizeBytes {
+			return a.SizeBytes < b.SizeBytes
+		}
+		if by == pfs.FileSort_FILE_SORT_COMMITTED {
+			ta, tb := a.Committed, b.Committed
+			switch {
+			case ta == nil && tb != nil:
+				return false
+			case ta != nil && tb == nil:
+				return true
+			case ta != nil && tb != nil:
+				if c := ta.Compare(tb); c != 0 {
+					return c < 0
+				}
+			}
+		}
+		return a.File.Path < b.File.Path
+	}
+	if reverse {
+		return func(a, b *pfs.FileInfo) bool { return less(b, a) }
+	}
+	return less
 }
 
 // WalkFile implements the protobuf pfs.WalkFile RPC
@@ -670,19 +882,50 @@ func (a *apiServer) WalkFile(request *pfs.WalkFileRequest, server pfs.API_WalkFi
 	})
 }
 
-// GlobFile implements the protobuf pfs.GlobFile RPC
-func (a *apiServer) GlobFile(request *pfs.GlobFileRequest, respServer pfs.API_GlobFileServer) (retErr error) {
+// DiskUsage implements the protobuf pfs.DiskUsage RPC
+func (a *apiServer) DiskUsage(request *pfs.DiskUsageRequest, server pfs.API_DiskUsageServer) (retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	var sent int
 	defer func(start time.Time) {
 		a.Log(request, fmt.Sprintf("response stream with %d objects", sent), retErr, time.Since(start))
 	}(time.Now())
-	return a.driver.globFile(respServer.Context(), request.Commit, request.Pattern, func(fi *pfs.FileInfo) error {
+	return a.driver.diskUsage(server.Context(), request.File, request.Depth, func(info *pfs.DiskUsageInfo) error {
 		sent++
-		return respServer.Send(fi)
+		return server.Send(info)
 	})
 }
 
+// RepoDiskUsage implements the protobuf pfs.RepoDiskUsage RPC
+func (a *apiServer) RepoDiskUsage(ctx context.Context, request *pfs.RepoDiskUsageRequest) (response *pfs.RepoDiskUsageResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	return a.driver.repoDiskUsage(ctx, request.Repo)
+}
+
+// GlobFile implements the protobuf pfs.GlobFile RPC
+func (a *apiServer) GlobFile(request *pfs.GlobFileRequest, respServer pfs.API_GlobFileServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	var sent int
+	defer func(start time.Time) {
+		a.Log(request, fmt.Sprintf("response stream with %d objects", sent), retErr, time.Since(start))
+	}(time.Now())
+	p, err := newPager(request.PageSize, request.PageToken)
+	if err != nil {
+		return err
+	}
+	after := absolutePath(request.StartAfter)
+	if p.token.After > after {
+		after = p.token.After
+	}
+	return p.finish(a.driver.globFile(respServer.Context(), request.Commit, request.Pattern, after, func(fi *pfs.FileInfo) error {
+		return p.add(fi.File.Path, func(nextPageToken string) error {
+			fi.NextPageToken = nextPageToken
+			sent++
+			return respServer.Send(fi)
+		})
+	}))
+}
+
 // DiffFile implements the protobuf pfs.DiffFile RPC
 func (a *apiServer) DiffFile(request *pfs.DiffFileRequest, server pfs.API_DiffFileServer) (retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
@@ -709,6 +952,16 @@ func (a *apiServer) DeleteAll(ctx context.Context, request *types.Empty) (respon
 	return &types.Empty{}, nil
 }
 
+// CheckDeleteAll implements the protobuf pfs.CheckDeleteAll RPC
+func (a *apiServer) CheckDeleteAll(ctx context.Context, request *types.Empty) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	if err := a.driver.checkDeleteAll(ctx); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
 // Fsckimplements the protobuf pfs.Fsck RPC
 func (a *apiServer) Fsck(request *pfs.FsckRequest, fsckServer pfs.API_FsckServer) (retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
@@ -716,7 +969,7 @@ func (a *apiServer) Fsck(request *pfs.FsckRequest, fsckServer pfs.API_FsckServer
 	defer func(start time.Time) {
 		a.Log(request, fmt.Sprintf("stream containing %d messages", sent), retErr, time.Since(start))
 	}(time.Now())
-	if err := a.driver.fsck(fsckServer.Context(), request.Fix, func(resp *pfs.FsckResponse) error {
+	if err := a.driver.fsck(fsckServer.Context(), request, func(resp *pfs.FsckResponse) error {
 		sent++
 		return fsckServer.Send(resp)
 	}); err != nil {
@@ -821,6 +1074,35 @@ func (a *apiServer) CheckStorage(ctx context.Context, req *pfs.CheckStorageReque
 	}, nil
 }
 
+// ReclaimOrphanedObjects implements the pfs.ReclaimOrphanedObjects RPC
+func (a *apiServer) ReclaimOrphanedObjects(req *pfs.ReclaimOrphanedObjectsRequest, server pfs.API_ReclaimOrphanedObjectsServer) (retErr error) {
+	func() { a.Log(req, nil, nil, 0) }()
+	sent := 0
+	defer func(start time.Time) {
+		a.Log(req, fmt.Sprintf("stream containing %d messages", sent), retErr, time.Since(start))
+	}(time.Now())
+	minAge := defaultOrphanMinAge
+	if req.MinAge != nil {
+		var err error
+		minAge, err = types.DurationFromProto(req.MinAge)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	od := chunk.NewOrphanDetector(a.driver.storage.ChunkStorage(), minAge, req.DryRun)
+	return od.RunOnce(server.Context(), func(o *chunk.Orphan) error {
+		sent++
+		return server.Send(&pfs.OrphanedObject{
+			Key:         o.Key,
+			SizeBytes:   o.SizeBytes,
+			Age:         types.DurationProto(o.Age),
+			Reason:      o.Reason,
+			Reclaimable: o.Reclaimable,
+			Reclaimed:   o.Reclaimed,
+		})
+	})
+}
+
 // RunLoadTest implements the pfs.RunLoadTest RPC
 func (a *apiServer) RunLoadTest(ctx context.Context, req *pfs.RunLoadTestRequest) (_ *pfs.RunLoadTestResponse, retErr error) {
 	func() { a.Log(nil, nil, nil, 0) }()
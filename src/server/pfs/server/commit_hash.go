@@ -0,0 +1,240 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// attestationKey derives the key of the cluster from its attestation secret,
+// so that the key is never stored. It returns nil if there is no secret.
+func attestationKey(secret string) ed25519.PrivateKey {
+	if secret == "" {
+		return nil
+	}
+	seed := sha256.Sum256([]byte("pachyderm commit attestation\x00" + secret))
+	return ed25519.NewKeyFromSeed(seed[:])
+}
+
+// contentHash hashes the paths and hashes of the files in a file set, in path
+// order. Directories are skipped since their hashes are derived from the
+// files in them.
+func (d *driver) contentHash(ctx context.Context, commitInfo *pfs.CommitInfo, id *fileset.ID) ([]byte, error) {
+	fs, err := d.storage.Open(ctx, []fileset.ID{*id})
+	if err != nil {
+		return nil, err
+	}
+	h := pfs.NewHash()
+	buf := make([]byte, binary.MaxVarintLen64)
+	if err := NewSource(commitInfo, fs).Iterate(ctx, func(fi *pfs.FileInfo, _ fileset.File) error {
+		if fi.FileType != pfs.FileType_FILE {
+			return nil
+		}
+		h.Write(buf[:binary.PutUvarint(buf, uint64(len(fi.File.Path)))])
+		h.Write([]byte(fi.File.Path))
+		h.Write(fi.Hash)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// linkCommitHash sets the hash of a commit being finished from the hash of
+// its content and the root hash of its parent.
+func (d *driver) linkCommitHash(txnCtx *txncontext.TransactionContext, commitInfo *pfs.CommitInfo, content []byte) error {
+	parent, err := d.parentRootHash(txnCtx.SqlTx, commitInfo)
+	if err != nil {
+		return err
+	}
+	commitInfo.Hash = &pfs.CommitHash{
+		Content: content,
+		Parent:  parent,
+		Root:    pfs.ChainHash(parent, content),
+	}
+	return nil
+}
+
+// parentRootHash returns the root hash of the parent of a commit, which is
+// empty if the commit has no parent or the parent has no hash.
+func (d *driver) parentRootHash(tx *pachsql.Tx, commitInfo *pfs.CommitInfo) ([]byte, error) {
+	if commitInfo.ParentCommit == nil {
+		return nil, nil
+	}
+	parentInfo := &pfs.CommitInfo{}
+	if err := d.commits.ReadWrite(tx).Get(pfsdb.CommitKey(commitInfo.ParentCommit), parentInfo); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if parentInfo.Hash == nil {
+		return nil, nil
+	}
+	return parentInfo.Hash.Root, nil
+}
+
+// relinkCommitHashes recomputes the parent and root hashes of commits whose
+// content or parent changed, and of their descendants whose hashes are
+// chained from them.
+func (d *driver) relinkCommitHashes(txnCtx *txncontext.TransactionContext, commits ...*pfs.Commit) error {
+	for len(commits) > 0 {
+		commit := commits[0]
+		commits = commits[1:]
+		commitInfo := &pfs.CommitInfo{}
+		if err := d.commits.ReadWrite(txnCtx.SqlTx).Get(pfsdb.CommitKey(commit), commitInfo); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if commitInfo.Hash == nil {
+			continue
+		}
+		parent, err := d.parentRootHash(txnCtx.SqlTx, commitInfo)
+		if err != nil {
+			return err
+		}
+		root := pfs.ChainHash(parent, commitInfo.Hash.Content)
+		if bytes.Equal(commitInfo.Hash.Parent, parent) && bytes.Equal(commitInfo.Hash.Root, root) {
+			continue
+		}
+		commitInfo.Hash.Parent = parent
+		commitInfo.Hash.Root = root
+		if err := d.commits.ReadWrite(txnCtx.SqlTx).Put(pfsdb.CommitKey(commit), commitInfo); err != nil {
+			return errors.EnsureStack(err)
+		}
+		commits = append(commits, commitInfo.ChildCommits...)
+	}
+	return nil
+}
+
+// aliasHash returns the hash of an alias of a commit. An alias has the same
+// content as the commit it aliases, which is its parent.
+func aliasHash(hash *pfs.CommitHash) *pfs.CommitHash {
+	if hash == nil {
+		return nil
+	}
+	return &pfs.CommitHash{
+		Content: hash.Content,
+		Parent:  hash.Root,
+		Root:    pfs.ChainHash(hash.Root, hash.Content),
+	}
+}
+
+// verifyCommit recomputes the hash of a commit, and of its ancestors if
+// requested, and compares it with the hash stored when the commit was
+// finished. A commit which fails verification is reported with an error in
+// its response rather than by failing the call.
+func (d *driver) verifyCommit(ctx context.Context, commit *pfs.Commit, ancestors bool, cb func(*pfs.VerifyCommitResponse) error) error {
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, commit.Branch.Repo, auth.Permission_REPO_READ); err != nil {
+		return errors.EnsureStack(err)
+	}
+	commitInfo, err := d.inspectCommit(ctx, commit, pfs.CommitState_STARTED)
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := d.verifyCommitInfo(ctx, commitInfo)
+		if err != nil {
+			return err
+		}
+		if err := cb(resp); err != nil {
+			return err
+		}
+		if !ancestors || commitInfo.ParentCommit == nil {
+			return nil
+		}
+		commitInfo, err = d.inspectCommit(ctx, commitInfo.ParentCommit, pfs.CommitState_STARTED)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (d *driver) verifyCommitInfo(ctx context.Context, commitInfo *pfs.CommitInfo) (*pfs.VerifyCommitResponse, error) {
+	resp := &pfs.VerifyCommitResponse{
+		Commit: commitInfo.Commit,
+		Hash:   commitInfo.Hash,
+	}
+	if commitInfo.Finished == nil {
+		resp.Error = "commit is not finished"
+		return resp, nil
+	}
+	if commitInfo.Hash == nil {
+		resp.Error = "commit has no hash, it was finished before commit hashes were computed"
+		return resp, nil
+	}
+	id, err := d.getFileSet(ctx, commitInfo.Commit)
+	if err != nil {
+		return nil, err
+	}
+	content, err := d.contentHash(ctx, commitInfo, id)
+	if err != nil {
+		return nil, err
+	}
+	var parent []byte
+	if err := d.txnEnv.WithReadContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		var err error
+		parent, err = d.parentRootHash(txnCtx.SqlTx, commitInfo)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	resp.Computed = &pfs.CommitHash{
+		Content: content,
+		Parent:  parent,
+		Root:    pfs.ChainHash(parent, content),
+	}
+	switch {
+	case !bytes.Equal(commitInfo.Hash.Content, content):
+		resp.Error = "content hash does not match the content of the commit"
+	case !bytes.Equal(commitInfo.Hash.Parent, parent):
+		resp.Error = "parent root hash does not match the root hash of the parent commit"
+	case !bytes.Equal(commitInfo.Hash.Root, pfs.ChainHash(commitInfo.Hash.Parent, commitInfo.Hash.Content)):
+		resp.Error = "root hash does not match the parent and content hashes"
+	}
+	return resp, nil
+}
+
+// attestCommit verifies a commit and signs an attestation of its hash with
+// the key of the cluster.
+func (d *driver) attestCommit(ctx context.Context, commit *pfs.Commit) (*pfs.CommitAttestation, error) {
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, commit.Branch.Repo, auth.Permission_REPO_READ); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if d.attestationKey == nil {
+		return nil, errors.Errorf("commits can't be attested, the cluster has no attestation secret (PFS_ATTESTATION_SECRET)")
+	}
+	commitInfo, err := d.inspectCommit(ctx, commit, pfs.CommitState_FINISHED)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.verifyCommitInfo(ctx, commitInfo)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.Errorf("cannot attest commit %v: %s", commitInfo.Commit, resp.Error)
+	}
+	key := d.attestationKey
+	attestation := &pfs.CommitAttestation{
+		Commit:    commitInfo.Commit,
+		Hash:      commitInfo.Hash,
+		Finished:  commitInfo.Finished,
+		Attested:  types.TimestampNow(),
+		PublicKey: key.Public().(ed25519.PublicKey),
+	}
+	data, err := attestation.SignedBytes()
+	if err != nil {
+		return nil, err
+	}
+	attestation.Signature = ed25519.Sign(key, data)
+	return attestation, nil
+}
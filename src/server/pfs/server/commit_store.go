@@ -32,6 +32,9 @@ type commitStore interface {
 	GetTotalFileSetTx(tx *pachsql.Tx, commit *pfs.Commit) (*fileset.ID, error)
 	// GetDiffFileSet returns the diff fileset for a commit
 	GetDiffFileSet(ctx context.Context, commit *pfs.Commit) (*fileset.ID, error)
+	// CompactDiff replaces the diff filesets of a commit with the fileset returned by compact.
+	// The diff is left unchanged if it is modified while compact is running.
+	CompactDiff(ctx context.Context, commit *pfs.Commit, compact func(context.Context, fileset.ID) (*fileset.ID, error)) error
 	// DropFileSets clears the diff and total filesets for the commit.
 	DropFileSets(ctx context.Context, commit *pfs.Commit) error
 	// DropFileSetsTx is identical to DropFileSets except it runs in the provided transaction.
@@ -123,6 +126,57 @@ func (cs *postgresCommitStore) GetDiffFileSet(ctx context.Context, commit *pfs.C
 	return cs.s.Compose(ctx, ids, defaultTTL)
 }
 
+func (cs *postgresCommitStore) CompactDiff(ctx context.Context, commit *pfs.Commit, compact func(context.Context, fileset.ID) (*fileset.ID, error)) error {
+	var ids []fileset.ID
+	var diffId *fileset.ID
+	if err := dbutil.WithTx(ctx, cs.db, func(tx *pachsql.Tx) error {
+		var err error
+		ids, err = getDiff(tx, commit)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		diffId, err = cs.s.ComposeTx(tx, ids, defaultTTL)
+		return err
+	}); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	// The composed diff and its compaction are renewed until the compaction
+	// replaces the diff, since compacting a large diff can outlast their TTL.
+	return cs.s.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *fileset.Renewer) error {
+		if err := renewer.Add(ctx, *diffId); err != nil {
+			return err
+		}
+		id, err := compact(ctx, *diffId)
+		if err != nil {
+			return err
+		}
+		if err := renewer.Add(ctx, *id); err != nil {
+			return err
+		}
+		return dbutil.WithTx(ctx, cs.db, func(tx *pachsql.Tx) error {
+			ids2, err := getDiff(tx, commit)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			if len(ids2) != len(ids) {
+				return errors.Errorf("commit %v was modified while it was being compacted", commit)
+			}
+			for i := range ids {
+				if ids[i] != ids2[i] {
+					return errors.Errorf("commit %v was modified while it was being compacted", commit)
+				}
+			}
+			if err := cs.dropDiff(tx, commit); err != nil {
+				return err
+			}
+			return cs.AddFileSetTx(tx, commit, *id)
+		})
+	})
+}
+
 func (cs *postgresCommitStore) SetTotalFileSet(ctx context.Context, commit *pfs.Commit, id fileset.ID) error {
 	return dbutil.WithTx(ctx, cs.db, func(tx *pachsql.Tx) error {
 		return cs.SetTotalFileSetTx(tx, commit, id)
@@ -30,11 +30,23 @@ func newCompactor(storage *fileset.Storage, maxFanIn int) *compactor {
 
 func (c *compactor) Compact(ctx context.Context, taskDoer task.Doer, ids []fileset.ID, ttl time.Duration) (*fileset.ID, error) {
 	return c.storage.CompactLevelBased(ctx, ids, defaultTTL, func(ctx context.Context, ids []fileset.ID, ttl time.Duration) (*fileset.ID, error) {
-		return c.compact(ctx, taskDoer, ids, ttl)
+		return c.compact(ctx, taskDoer, ids, ttl, false)
 	})
 }
 
-func (c *compactor) compact(ctx context.Context, taskDoer task.Doer, ids []fileset.ID, ttl time.Duration) (*fileset.ID, error) {
+// CompactFull compacts the file sets into a single file set, regardless of
+// the compaction levels of the file sets.
+func (c *compactor) CompactFull(ctx context.Context, taskDoer task.Doer, ids []fileset.ID, ttl time.Duration) (*fileset.ID, error) {
+	return c.compact(ctx, taskDoer, ids, ttl, false)
+}
+
+// Materialize compacts the file sets into a single file set, copying the
+// content of files which reference external objects into the result.
+func (c *compactor) Materialize(ctx context.Context, taskDoer task.Doer, ids []fileset.ID, ttl time.Duration) (*fileset.ID, error) {
+	return c.compact(ctx, taskDoer, ids, ttl, true)
+}
+
+func (c *compactor) compact(ctx context.Context, taskDoer task.Doer, ids []fileset.ID, ttl time.Duration, materialize bool) (*fileset.ID, error) {
 	ids, err := c.storage.Flatten(ctx, ids)
 	if err != nil {
 		return nil, err
@@ -53,7 +65,7 @@ func (c *compactor) compact(ctx context.Context, taskDoer task.Doer, ids []files
 		var compactResults []fileset.ID
 		if err := miscutil.LogStep(fmt.Sprintf("compacting %v tasks", len(tasks)), func() error {
 			var err error
-			compactResults, err = c.processCompactTasks(ctx, taskDoer, renewer, tasks)
+			compactResults, err = c.processCompactTasks(ctx, taskDoer, renewer, tasks, materialize)
 			return err
 		}); err != nil {
 			return err
@@ -70,7 +82,7 @@ func (c *compactor) compact(ctx context.Context, taskDoer task.Doer, ids []files
 			id = &concatResults[0]
 			return nil
 		}
-		id, err = c.compact(ctx, taskDoer, concatResults, ttl)
+		id, err = c.compact(ctx, taskDoer, concatResults, ttl, false)
 		return err
 	}); err != nil {
 		return nil, err
@@ -118,10 +130,11 @@ func (c *compactor) createCompactTasks(ctx context.Context, taskDoer task.Doer,
 	return tasks, taskLens, nil
 }
 
-func (c *compactor) processCompactTasks(ctx context.Context, taskDoer task.Doer, renewer *fileset.Renewer, tasks []*CompactTask) ([]fileset.ID, error) {
+func (c *compactor) processCompactTasks(ctx context.Context, taskDoer task.Doer, renewer *fileset.Renewer, tasks []*CompactTask, materialize bool) ([]fileset.ID, error) {
 	inputs := make([]*types.Any, len(tasks))
 	for i, task := range tasks {
 		task := proto.Clone(task).(*CompactTask)
+		task.Materialize = materialize
 		input, err := serializeCompactTask(task)
 		if err != nil {
 			return nil, err
@@ -214,6 +227,12 @@ func compactionWorker(ctx context.Context, taskSource task.Source, storage *file
 					return nil, err
 				}
 				return processConcatTask(ctx, storage, concatTask)
+			case types.Is(input, &FsckTask{}):
+				fsckTask, err := deserializeFsckTask(input)
+				if err != nil {
+					return nil, err
+				}
+				return processFsckTask(ctx, storage, fsckTask)
 			default:
 				return nil, errors.Errorf("unrecognized any type (%v) in compaction worker", input.TypeUrl)
 			}
@@ -258,7 +277,11 @@ func processCompactTask(ctx context.Context, storage *fileset.Storage, task *Com
 			Lower: task.PathRange.Lower,
 			Upper: task.PathRange.Upper,
 		}
-		id, err := storage.Compact(ctx, ids, defaultTTL, index.WithRange(pathRange))
+		compact := storage.Compact
+		if task.Materialize {
+			compact = storage.Materialize
+		}
+		id, err := compact(ctx, ids, defaultTTL, index.WithRange(pathRange))
 		if err != nil {
 			return err
 		}
@@ -2,8 +2,10 @@ package server
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/rand"
 	"database/sql"
+	"fmt"
 	"math"
 	"os"
 	"sort"
@@ -17,6 +19,7 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/obj"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
@@ -80,6 +83,10 @@ type driver struct {
 
 	storage     *fileset.Storage
 	commitStore commitStore
+
+	// attestationKey signs commit attestations, it is nil if the cluster has
+	// no attestation secret
+	attestationKey ed25519.PrivateKey
 }
 
 func newDriver(env Env) (*driver, error) {
@@ -99,13 +106,14 @@ func newDriver(env Env) (*driver, error) {
 
 	// Setup driver struct.
 	d := &driver{
-		txnEnv:     env.TxnEnv,
-		etcdClient: env.EtcdClient,
-		prefix:     env.EtcdPrefix,
-		repos:      repos,
-		commits:    commits,
-		branches:   branches,
-		env:        env,
+		txnEnv:         env.TxnEnv,
+		etcdClient:     env.EtcdClient,
+		prefix:         env.EtcdPrefix,
+		repos:          repos,
+		commits:        commits,
+		branches:       branches,
+		env:            env,
+		attestationKey: attestationKey(env.AttestationSecret),
 	}
 	// Setup tracker and chunk / fileset storage.
 	tracker := track.NewPostgresTracker(env.DB)
@@ -129,7 +137,7 @@ func newDriver(env Env) (*driver, error) {
 	return d, nil
 }
 
-func (d *driver) createRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, description string, update bool) error {
+func (d *driver) createRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Repo, description string, update bool, retention *types.Duration) error {
 	// Validate arguments
 	if repo == nil {
 		return errors.New("repo cannot be nil")
@@ -168,7 +176,11 @@ func (d *driver) createRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Rep
 			}
 		}
 
-		if existingRepoInfo.Description == description {
+		newRetention, err := updateRetention(existingRepoInfo.Retention, retention)
+		if err != nil {
+			return errors.Wrapf(err, "could not update retention of %q", repo)
+		}
+		if existingRepoInfo.Description == description && proto.Equal(existingRepoInfo.Retention, newRetention) {
 			// Don't overwrite the stored proto with an identical value. This
 			// optimization is impactful because pps will frequently update the spec
 			// repo to make sure it exists.
@@ -185,6 +197,7 @@ func (d *driver) createRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Rep
 			return errors.Wrapf(err, "could not update description of %q", repo)
 		}
 		existingRepoInfo.Description = description
+		existingRepoInfo.Retention = newRetention
 		return repos.Put(repo, &existingRepoInfo)
 	} else {
 		// if this is a system repo, make sure the corresponding user repo already exists
@@ -213,10 +226,15 @@ func (d *driver) createRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Rep
 				return errors.Wrapf(grpcutil.ScrubGRPC(err), "could not create role binding for new repo %q", repo)
 			}
 		}
+		newRetention, err := updateRetention(nil, retention)
+		if err != nil {
+			return err
+		}
 		return repos.Create(repo, &pfs.RepoInfo{
 			Repo:        repo,
 			Created:     txnCtx.Timestamp,
 			Description: description,
+			Retention:   newRetention,
 		})
 	}
 }
@@ -256,11 +274,20 @@ func (d *driver) getPermissions(ctx context.Context, repo *pfs.Repo) ([]auth.Per
 	return resp.Permissions, resp.Roles, nil
 }
 
-func (d *driver) listRepo(ctx context.Context, includeAuth bool, repoType string, cb func(*pfs.RepoInfo) error) error {
+// listRepo lists the repos of a type, or all repos if repoType is blank. If
+// after is set, the listing resumes after the repo with that key.
+func (d *driver) listRepo(ctx context.Context, includeAuth bool, repoType string, after string, cb func(*pfs.RepoInfo) error) error {
 	authSeemsActive := true
 	repoInfo := &pfs.RepoInfo{}
+	opts := col.DefaultOptions()
+	opts.After = after
+	seeking := after != ""
 
-	processFunc := func(string) error {
+	processFunc := func(key string) error {
+		if seeking {
+			seeking = key != after
+			return nil
+		}
 		size, err := d.repoSize(ctx, repoInfo.Repo)
 		if err != nil {
 			return err
@@ -279,12 +306,17 @@ func (d *driver) listRepo(ctx context.Context, includeAuth bool, repoType string
 		return cb(proto.Clone(repoInfo).(*pfs.RepoInfo))
 	}
 
+	var err error
 	if repoType == "" {
 		// blank type means return all
-		return d.repos.ReadOnly(ctx).List(repoInfo, col.DefaultOptions(), processFunc)
+		err = d.repos.ReadOnly(ctx).List(repoInfo, opts, processFunc)
 	} else {
-		return d.repos.ReadOnly(ctx).GetByIndex(pfsdb.ReposTypeIndex, repoType, repoInfo, col.DefaultOptions(), processFunc)
+		err = d.repos.ReadOnly(ctx).GetByIndex(pfsdb.ReposTypeIndex, repoType, repoInfo, opts, processFunc)
+	}
+	if err == nil && seeking {
+		return errTokenNotFound(after)
 	}
+	return err
 }
 
 func (d *driver) deleteAllBranchesFromRepos(txnCtx *txncontext.TransactionContext, repos []pfs.RepoInfo, force bool) error {
@@ -331,6 +363,11 @@ func (d *driver) deleteRepo(txnCtx *txncontext.TransactionContext, repo *pfs.Rep
 		return err
 	}
 
+	// The retention lock holds even when forcing the deletion.
+	if err := d.checkBranchRetention(txnCtx, repo.NewBranch("")); err != nil {
+		return errors.Wrapf(err, "cannot delete repo %q", repo)
+	}
+
 	if !force {
 		if _, err := d.env.GetPPSServer().InspectPipelineInTransaction(txnCtx, repo.Name); err == nil {
 			return errors.Errorf("cannot delete a repo associated with a pipeline - delete the pipeline instead")
@@ -471,6 +508,9 @@ func (d *driver) startCommit(
 			parent = branchInfo.Head
 		}
 		// Point 'branch' at the new commit
+		if err := logBranchHead(txnCtx, branch, branchInfo.Head, newCommit, userReason(txnCtx)); err != nil {
+			return err
+		}
 		branchInfo.Head = newCommit
 		return nil
 	}); err != nil {
@@ -487,7 +527,7 @@ func (d *driver) startCommit(
 		specBranch := client.NewSystemRepo(spoutName, pfs.SpecRepoType).NewBranch("master")
 		specCommit := specBranch.NewCommit(spoutCommit)
 		log.Infof("Adding spout spec commit to current commitset: %s", specCommit)
-		if _, err := d.aliasCommit(txnCtx, specCommit, specBranch); err != nil {
+		if _, err := d.aliasCommit(txnCtx, specCommit, specBranch, pfs.ReflogReason_REFLOG_PIPELINE); err != nil {
 			return nil, err
 		}
 	} else if len(branchInfo.Provenance) > 0 {
@@ -509,7 +549,7 @@ func (d *driver) startCommit(
 		}
 		// fail if the parent commit has not been finished
 		if parentCommitInfo.Finishing == nil {
-			return nil, errors.Errorf("parent commit %s has not been finished", parent)
+			return nil, pfsserver.ErrParentCommitNotFinished{Commit: parent}
 		}
 
 		newCommitInfo.ParentCommit = parentCommitInfo.Commit
@@ -583,7 +623,7 @@ func (d *driver) resolveAlias(txnCtx *txncontext.TransactionContext, source *pfs
 	return baseInfo, nil
 }
 
-func (d *driver) aliasCommit(txnCtx *txncontext.TransactionContext, parent *pfs.Commit, branch *pfs.Branch) (*pfs.CommitInfo, error) {
+func (d *driver) aliasCommit(txnCtx *txncontext.TransactionContext, parent *pfs.Commit, branch *pfs.Branch, reason pfs.ReflogReason) (*pfs.CommitInfo, error) {
 	// It is considered an error if the CommitSet attempts to use two different
 	// commits from the same branch.  Therefore, if there is already a row for the
 	// given branch and it doesn't reference the same parent commit, we fail.  In
@@ -637,7 +677,8 @@ func (d *driver) aliasCommit(txnCtx *txncontext.TransactionContext, parent *pfs.
 			commitInfo.Finishing = txnCtx.Timestamp
 			if parentCommitInfo.Finished != nil {
 				commitInfo.Finished = txnCtx.Timestamp
-				commitInfo.Details = parentCommitInfo.Details
+				commitInfo.Details = aliasDetails(parentCommitInfo.Details)
+				commitInfo.Hash = aliasHash(parentCommitInfo.Hash)
 				// if the parent is already finished we can just use its total fileset.
 				total, err := d.commitStore.GetTotalFileSetTx(txnCtx.SqlTx, parentCommitInfo.Commit)
 				if err != nil {
@@ -668,6 +709,9 @@ func (d *driver) aliasCommit(txnCtx *txncontext.TransactionContext, parent *pfs.
 	}
 
 	// Update the branch head
+	if err := logBranchHead(txnCtx, branch, branchInfo.Head, commit, reason); err != nil {
+		return nil, err
+	}
 	branchInfo.Head = commit
 	if err := d.branches.ReadWrite(txnCtx.SqlTx).Put(branch, branchInfo); err != nil {
 		return nil, err
@@ -796,7 +840,7 @@ func (d *driver) propagateBranches(txnCtx *txncontext.TransactionContext, branch
 				return err
 			}
 			if provOfSubvBI.Head.ID != txnCtx.CommitSetID {
-				if _, err := d.aliasCommit(txnCtx, provOfSubvBI.Head, provOfSubvBI.Head.Branch); err != nil {
+				if _, err := d.aliasCommit(txnCtx, provOfSubvBI.Head, provOfSubvBI.Head.Branch, pfs.ReflogReason_REFLOG_ALIAS); err != nil {
 					return err
 				}
 				// Update the cached branch head
@@ -826,6 +870,9 @@ func (d *driver) propagateBranches(txnCtx *txncontext.TransactionContext, branch
 			// the old commit is compatible with the current provenance, so use it.
 			// This will reuse the old data and not create a job, meaning if the reprocess spec is "every job",
 			// moving a branch head back is different from doing the inverse changes in PFS
+			if err := logBranchHead(txnCtx, subvBI.Branch, subvBI.Head, oldCommit.Commit, pfs.ReflogReason_REFLOG_PIPELINE); err != nil {
+				return err
+			}
 			subvBI.Head = oldCommit.Commit
 			if err := d.branches.ReadWrite(txnCtx.SqlTx).Put(subvBI.Branch, subvBI); err != nil {
 				return err
@@ -847,6 +894,9 @@ func (d *driver) propagateBranches(txnCtx *txncontext.TransactionContext, branch
 
 			// Set 'newCommit's ParentCommit, 'branch.Head's ChildCommits and 'branch.Head'
 			newCommitInfo.ParentCommit = subvBI.Head
+			if err := logBranchHead(txnCtx, subvBI.Branch, subvBI.Head, newCommit, pfs.ReflogReason_REFLOG_PIPELINE); err != nil {
+				return err
+			}
 			subvBI.Head = newCommit
 			if newCommitInfo.ParentCommit != nil {
 				parentCommitInfo := &pfs.CommitInfo{}
@@ -1102,12 +1152,16 @@ func (d *driver) listCommit(
 	reverse bool,
 	all bool,
 	originKind pfs.OriginKind,
+	after string,
 	cb func(*pfs.CommitInfo) error,
 ) error {
 	// Validate arguments
 	if repo == nil {
 		return errors.New("repo cannot be nil")
 	}
+	// seeking is set while skipping the commits up to and including the
+	// commit with the key after, which were listed by the previous pages
+	seeking := after != ""
 
 	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_LIST_COMMIT); err != nil {
 		return err
@@ -1153,14 +1207,19 @@ func (d *driver) listCommit(
 			// We don't sort these because there is no provenance between commits
 			// within a repo, so there is no topological sort necessary.
 			for i, ci := range cis {
+				if reverse {
+					ci = cis[len(cis)-1-i]
+				}
+				if seeking {
+					seeking = pfsdb.CommitKey(ci.Commit) != after
+					continue
+				}
+
 				if number == 0 {
 					return errutil.ErrBreak
 				}
 				number--
 
-				if reverse {
-					ci = cis[len(cis)-1-i]
-				}
 				var err error
 				ci.SizeBytesUpperBound, err = d.commitSizeUpperBound(ctx, ci.Commit)
 				if err != nil {
@@ -1193,7 +1252,7 @@ func (d *driver) listCommit(
 
 		// if neither from and to is given, we list all commits in
 		// the repo, sorted by revision timestamp (or reversed if so requested.)
-		opts := &col.Options{Target: col.SortByCreateRevision, Order: col.SortDescend}
+		opts := &col.Options{Target: col.SortByCreateRevision, Order: col.SortDescend, After: after}
 		if reverse {
 			opts.Order = col.SortAscend
 		}
@@ -1217,6 +1276,17 @@ func (d *driver) listCommit(
 			return errors.Errorf("cannot use 'Reverse' while also using 'From' or 'To'")
 		}
 		cursor := to
+		if seeking {
+			afterInfo := &pfs.CommitInfo{}
+			if err := d.commits.ReadOnly(ctx).Get(after, afterInfo); err != nil {
+				if col.IsErrNotFound(err) {
+					return errTokenNotFound(after)
+				}
+				return errors.EnsureStack(err)
+			}
+			cursor = afterInfo.ParentCommit
+			seeking = false
+		}
 		for number != 0 && cursor != nil && (from == nil || cursor.ID != from.ID) {
 			commitInfo := &pfs.CommitInfo{}
 			if err := d.commits.ReadOnly(ctx).Get(cursor, commitInfo); err != nil {
@@ -1234,6 +1304,9 @@ func (d *driver) listCommit(
 			cursor = commitInfo.ParentCommit
 		}
 	}
+	if seeking {
+		return errTokenNotFound(after)
+	}
 	return nil
 }
 
@@ -1402,6 +1475,7 @@ func (d *driver) squashCommitSetInternal(txnCtx *txncontext.TransactionContext,
 		branchInfo := &pfs.BranchInfo{}
 		if err := d.branches.ReadWrite(txnCtx.SqlTx).Update(commitInfo.Commit.Branch, branchInfo, func() error {
 			if branchInfo.Head.ID == commitInfo.Commit.ID {
+				oldHead := branchInfo.Head
 				if commitInfo.ParentCommit == nil || !proto.Equal(commitInfo.ParentCommit.Branch, commitInfo.Commit.Branch) {
 					// Create a new empty commit for the branch head
 					var err error
@@ -1412,6 +1486,9 @@ func (d *driver) squashCommitSetInternal(txnCtx *txncontext.TransactionContext,
 				} else {
 					branchInfo.Head = commitInfo.ParentCommit
 				}
+				if err := logBranchHead(txnCtx, branchInfo.Branch, oldHead, branchInfo.Head, userReason(txnCtx)); err != nil {
+					return err
+				}
 				affectedBranches = append(affectedBranches, commitInfo.Commit.Branch)
 			}
 			return nil
@@ -1425,6 +1502,7 @@ func (d *driver) squashCommitSetInternal(txnCtx *txncontext.TransactionContext,
 	// 2) Rewrite ParentCommit of deleted commits' children, and
 	// ChildCommits of deleted commits' parents
 	visited := make(map[string]struct{}) // visited child/parent commits
+	var reparented []*pfs.Commit         // live children with a new parent
 	for _, deletedInfo := range deleted {
 		if _, ok := visited[pfsdb.CommitKey(deletedInfo.Commit)]; ok {
 			continue
@@ -1472,6 +1550,7 @@ func (d *driver) squashCommitSetInternal(txnCtx *txncontext.TransactionContext,
 			}); err != nil {
 				return errors.Wrapf(err, "err updating child commit %s", oldestCommitInfo.Commit)
 			}
+			reparented = append(reparented, commit)
 		}
 		if parent != nil {
 			commitInfo := &pfs.CommitInfo{}
@@ -1496,6 +1575,12 @@ func (d *driver) squashCommitSetInternal(txnCtx *txncontext.TransactionContext,
 		}
 	}
 
+	// The hashes of the children are chained from the root hashes of their
+	// old parents, so relink them to their new parents.
+	if err := d.relinkCommitHashes(txnCtx, reparented...); err != nil {
+		return err
+	}
+
 	// 4) propagate the changes to 'branch' and its subvenance. This may start
 	// new HEAD commits downstream, if the new branch heads haven't been
 	// processed yet
@@ -1522,6 +1607,9 @@ func (d *driver) dropCommitSet(txnCtx *txncontext.TransactionContext, commitset
 			return &pfsserver.ErrDropWithChildren{Commit: ci.Commit}
 		}
 	}
+	if err := d.checkRetention(txnCtx, commitInfos); err != nil {
+		return err
+	}
 
 	// While this is a 'drop' operation and not a 'squash', proper drop semantics
 	// aren't implemented at the moment.  Squashing the head of a branch is
@@ -1553,6 +1641,9 @@ func (d *driver) squashCommitSet(txnCtx *txncontext.TransactionContext, commitse
 			return &pfsserver.ErrSquashWithoutChildren{Commit: ci.Commit}
 		}
 	}
+	if err := d.checkRetention(txnCtx, commitInfos); err != nil {
+		return err
+	}
 
 	if err := d.squashCommitSetInternal(txnCtx, commitInfos); err != nil {
 		return err
@@ -1581,9 +1672,29 @@ func (d *driver) subscribeCommit(
 	if from != nil && !proto.Equal(from.Branch.Repo, repo) {
 		return errors.Errorf("the `from` commit needs to be from repo %s", repo)
 	}
+	// `from` may be a branch or an ancestry reference, so it is resolved to
+	// the commit it names. A `from` with no ID is the head of its branch,
+	// which subscribes to the new commits, so if the branch doesn't exist yet
+	// every commit is new.
+	var fromInfo *pfs.CommitInfo
+	if from != nil {
+		var err error
+		fromInfo, err = d.inspectCommit(ctx, from, pfs.CommitState_STARTED)
+		if err != nil {
+			if from.ID != "" || !pfsserver.IsBranchNotFoundErr(err) {
+				return err
+			}
+			fromInfo = nil
+		}
+	}
 
-	// keep track of the commits that have been sent
+	// keep track of the commits that have been sent, or skipped because they
+	// were created before `from`
 	seen := make(map[string]bool)
+	// Commits are watched in the order they were created, so the commits
+	// before `from` are skipped until it, or a commit started after it in
+	// case it was deleted, is reached.
+	fromReached := fromInfo == nil
 
 	// Note that this watch may leave events unread for a long amount of time
 	// while waiting for the commit state - if the watch channel fills up, it will
@@ -1595,6 +1706,15 @@ func (d *driver) subscribeCommit(
 			return errors.Wrapf(err, "unmarshal")
 		}
 
+		if !fromReached {
+			if commitInfo.Started.Compare(fromInfo.Started) <= 0 || commitInfo.Commit.ID == fromInfo.Commit.ID {
+				seen[commitInfo.Commit.ID] = true
+				fromReached = commitInfo.Commit.ID == fromInfo.Commit.ID
+				return nil
+			}
+			fromReached = true
+		}
+
 		// if branch is provided, make sure the commit was created on that branch
 		if branch != "" && commitInfo.Commit.Branch.Name != branch {
 			return nil
@@ -1606,7 +1726,7 @@ func (d *driver) subscribeCommit(
 		}
 
 		// We don't want to include the `from` commit itself
-		if !(seen[commitInfo.Commit.ID] || (from != nil && from.ID == commitInfo.Commit.ID)) {
+		if !seen[commitInfo.Commit.ID] {
 			// Wait for the commit to enter the right state
 			commitInfo, err := d.inspectCommit(ctx, proto.Clone(commitInfo.Commit).(*pfs.Commit), state)
 			if err != nil {
@@ -1632,6 +1752,148 @@ func (d *driver) clearCommit(ctx context.Context, commit *pfs.Commit) error {
 	return d.commitStore.DropFileSets(ctx, commit)
 }
 
+// materializeCommit copies the content of the external objects referenced by a
+// finished commit into the commit, so that the commit no longer depends on
+// them.
+func (d *driver) materializeCommit(ctx context.Context, commit *pfs.Commit) error {
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, commit.Branch.Repo, auth.Permission_REPO_WRITE); err != nil {
+		return errors.EnsureStack(err)
+	}
+	commitInfo, err := d.inspectCommit(ctx, commit, pfs.CommitState_FINISHED)
+	if err != nil {
+		return err
+	}
+	compactor := newCompactor(d.storage, d.env.StorageConfig.StorageCompactionMaxFanIn)
+	taskDoer := d.env.TaskService.NewDoer(storageTaskNamespace, "materialize-"+uuid.NewWithoutDashes())
+	return miscutil.LogStep(fmt.Sprintf("materializing commit %v", commitInfo.Commit), func() error {
+		return d.rewriteTotalFileSet(ctx, commitInfo.Commit, func(ctx context.Context, id fileset.ID) (*fileset.ID, error) {
+			return compactor.Materialize(ctx, taskDoer, []fileset.ID{id}, defaultTTL)
+		})
+	})
+}
+
+// compactCommit fully compacts the file set layers of a commit. The diff of
+// an open commit is compacted, and the total file set of a finished commit is
+// compacted.
+func (d *driver) compactCommit(ctx context.Context, commit *pfs.Commit) error {
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, commit.Branch.Repo, auth.Permission_REPO_WRITE); err != nil {
+		return errors.EnsureStack(err)
+	}
+	commitInfo, err := d.inspectCommit(ctx, commit, pfs.CommitState_STARTED)
+	if err != nil {
+		return err
+	}
+	compactor := newCompactor(d.storage, d.env.StorageConfig.StorageCompactionMaxFanIn)
+	taskDoer := d.env.TaskService.NewDoer(storageTaskNamespace, "compact-"+uuid.NewWithoutDashes())
+	compact := func(ctx context.Context, id fileset.ID) (*fileset.ID, error) {
+		ids, err := d.storage.Flatten(ctx, []fileset.ID{id})
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) <= 1 {
+			return &id, nil
+		}
+		return compactor.CompactFull(ctx, taskDoer, ids, defaultTTL)
+	}
+	return miscutil.LogStep(fmt.Sprintf("compacting commit %v", commitInfo.Commit), func() error {
+		if commitInfo.Finishing == nil {
+			return d.commitStore.CompactDiff(ctx, commitInfo.Commit, compact)
+		}
+		// The commit is compacted when it is finished, so wait for that first.
+		commitInfo, err := d.inspectCommit(ctx, commitInfo.Commit, pfs.CommitState_FINISHED)
+		if err != nil {
+			return err
+		}
+		return d.rewriteTotalFileSet(ctx, commitInfo.Commit, compact)
+	})
+}
+
+// rewriteTotalFileSet replaces the total file set of a finished commit, and of
+// the alias commits which share it, with the file set returned by rewrite.
+// The rewritten file set must have the same content as the original, but the
+// hashes of its files may differ, so the commit hashes are recomputed.
+func (d *driver) rewriteTotalFileSet(ctx context.Context, commit *pfs.Commit, rewrite func(context.Context, fileset.ID) (*fileset.ID, error)) error {
+	if err := d.checkCommitRetention(ctx, commit); err != nil {
+		return err
+	}
+	prevId, err := d.commitStore.GetTotalFileSet(ctx, commit)
+	if err != nil {
+		return err
+	}
+	return d.storage.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *fileset.Renewer) error {
+		id, err := rewrite(ctx, *prevId)
+		if err != nil {
+			return err
+		}
+		if *id == *prevId {
+			return nil
+		}
+		if err := renewer.Add(ctx, *id); err != nil {
+			return err
+		}
+		commitInfo, err := d.inspectCommit(ctx, commit, pfs.CommitState_FINISHED)
+		if err != nil {
+			return err
+		}
+		content, err := d.contentHash(ctx, commitInfo, id)
+		if err != nil {
+			return err
+		}
+		return d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+			// Alias descendents share the total file set of the commit.
+			var rewritten []*pfs.Commit
+			commits := []*pfs.Commit{commit}
+			for len(commits) > 0 {
+				c := commits[0]
+				commits = commits[1:]
+				totalId, err := d.commitStore.GetTotalFileSetTx(txnCtx.SqlTx, c)
+				if err != nil {
+					return err
+				}
+				if *totalId != *prevId {
+					if c == commit {
+						return errors.Errorf("commit %v was modified while it was being rewritten", commit)
+					}
+					continue
+				}
+				if err := d.commitStore.SetTotalFileSetTx(txnCtx.SqlTx, c, *id); err != nil {
+					return err
+				}
+				commitInfo := &pfs.CommitInfo{}
+				if err := d.commits.ReadWrite(txnCtx.SqlTx).Get(pfsdb.CommitKey(c), commitInfo); err != nil {
+					return err
+				}
+				if commitInfo.Hash != nil {
+					commitInfo.Hash.Content = content
+					if err := d.commits.ReadWrite(txnCtx.SqlTx).Put(pfsdb.CommitKey(c), commitInfo); err != nil {
+						return errors.EnsureStack(err)
+					}
+				}
+				rewritten = append(rewritten, c)
+				for _, child := range commitInfo.ChildCommits {
+					childInfo := &pfs.CommitInfo{}
+					if err := d.commits.ReadWrite(txnCtx.SqlTx).Get(pfsdb.CommitKey(child), childInfo); err != nil {
+						return err
+					}
+					if childInfo.Origin.Kind == pfs.OriginKind_ALIAS && childInfo.Finished != nil {
+						commits = append(commits, child)
+					}
+				}
+			}
+			return d.relinkCommitHashes(txnCtx, rewritten...)
+		})
+	})
+}
+
+// fileSetStats computes the statistics of the file set layers of a commit.
+func (d *driver) fileSetStats(ctx context.Context, commit *pfs.Commit) (*fileset.Stats, error) {
+	id, err := d.getFileSet(ctx, commit)
+	if err != nil {
+		return nil, err
+	}
+	return d.storage.Stats(ctx, *id)
+}
+
 // createBranch creates a new branch or updates an existing branch (must be one
 // or the other). Most importantly, it sets 'branch.DirectProvenance' to
 // 'provenance' and then for all (downstream) branches, restores the invariant:
@@ -1639,7 +1901,7 @@ func (d *driver) clearCommit(ctx context.Context, commit *pfs.Commit) error {
 //
 // This invariant is assumed to hold for all branches upstream of 'branch', but not
 // for 'branch' itself once 'b.Provenance' has been set.
-func (d *driver) createBranch(txnCtx *txncontext.TransactionContext, branch *pfs.Branch, commit *pfs.Commit, provenance []*pfs.Branch, trigger *pfs.Trigger) error {
+func (d *driver) createBranch(txnCtx *txncontext.TransactionContext, branch *pfs.Branch, commit *pfs.Commit, provenance []*pfs.Branch, trigger *pfs.Trigger, reason pfs.ReflogReason) error {
 	// Validate arguments
 	if branch == nil {
 		return errors.New("branch cannot be nil")
@@ -1693,7 +1955,7 @@ func (d *driver) createBranch(txnCtx *txncontext.TransactionContext, branch *pfs
 		// Verify the provenance of the new branch head and lock in its upstream commits
 		for _, provBranch := range provenance {
 			// Check that the CommitSet for the given commit has values for every branch in provenance and alias them
-			if _, err := d.aliasCommit(txnCtx, provBranch.NewCommit(ci.Commit.ID), provBranch); err != nil {
+			if _, err := d.aliasCommit(txnCtx, provBranch.NewCommit(ci.Commit.ID), provBranch, pfs.ReflogReason_REFLOG_ALIAS); err != nil {
 				if pfsserver.IsCommitNotFoundErr(err) {
 					return errors.Errorf("cannot create branch %s with commit %s as head because it does not have provenance in the %s branch", branch, ci.Commit, provBranch)
 				}
@@ -1702,11 +1964,14 @@ func (d *driver) createBranch(txnCtx *txncontext.TransactionContext, branch *pfs
 
 		if commit.ID == txnCtx.CommitSetID && proto.Equal(commit.Branch, branchInfo.Branch) {
 			// We can reuse the existing commit only if it is already on this branch
+			if err := logBranchHead(txnCtx, branch, branchInfo.Head, commit, reason); err != nil {
+				return err
+			}
 			branchInfo.Head = commit
 		} else if branchInfo.Head == nil || branchInfo.Head.ID != commit.ID {
 			// Create an alias of the head commit onto this branch - this will move the
 			// head of the branch and update the repo size if necessary
-			aliasCommitInfo, err := d.aliasCommit(txnCtx, commit, branch)
+			aliasCommitInfo, err := d.aliasCommit(txnCtx, commit, branch, reason)
 			if err != nil {
 				return err
 			}
@@ -1722,6 +1987,9 @@ func (d *driver) createBranch(txnCtx *txncontext.TransactionContext, branch *pfs
 		if err != nil {
 			return err
 		}
+		if err := logBranchHead(txnCtx, branch, nil, branchInfo.Head, reason); err != nil {
+			return err
+		}
 	}
 
 	// Update (or create)
@@ -1913,6 +2181,9 @@ func (d *driver) deleteBranch(txnCtx *txncontext.TransactionContext, branch *pfs
 	if err := d.env.AuthServer.CheckRepoIsAuthorizedInTransaction(txnCtx, branch.Repo, auth.Permission_REPO_DELETE_BRANCH); err != nil {
 		return err
 	}
+	if err := d.checkBranchRetention(txnCtx, branch); err != nil {
+		return errors.Wrapf(err, "cannot delete branch %q", branch)
+	}
 
 	branchInfo := &pfs.BranchInfo{}
 	if err := d.branches.ReadWrite(txnCtx.SqlTx).Get(branch, branchInfo); err != nil {
@@ -1946,7 +2217,7 @@ func (d *driver) deleteBranch(txnCtx *txncontext.TransactionContext, branch *pfs
 				return err
 			}
 			del(&subvBranchInfo.DirectProvenance, branch)
-			if err := d.createBranch(txnCtx, subvBranch, nil, subvBranchInfo.DirectProvenance, nil); err != nil {
+			if err := d.createBranch(txnCtx, subvBranch, nil, subvBranchInfo.DirectProvenance, nil, pfs.ReflogReason_REFLOG_PIPELINE); err != nil {
 				return err
 			}
 		}
@@ -1954,6 +2225,9 @@ func (d *driver) deleteBranch(txnCtx *txncontext.TransactionContext, branch *pfs
 		if err := d.branches.ReadWrite(txnCtx.SqlTx).Delete(branch); err != nil {
 			return errors.Wrapf(err, "branches.Delete")
 		}
+		if err := deleteReflog(txnCtx.SqlTx, branch); err != nil {
+			return err
+		}
 	}
 	repoInfo := &pfs.RepoInfo{}
 	if err := d.repos.ReadWrite(txnCtx.SqlTx).Update(branch.Repo, repoInfo, func() error {
@@ -1983,6 +2257,9 @@ func (d *driver) addBranchProvenance(txnCtx *txncontext.TransactionContext, bran
 			if err != nil {
 				return err
 			}
+			if err := logBranchHead(txnCtx, provBranch, nil, head, userReason(txnCtx)); err != nil {
+				return err
+			}
 			provBranchInfo.Head = head
 		}
 		add(&provBranchInfo.Subvenance, branchInfo.Branch)
@@ -1997,9 +2274,40 @@ func (d *driver) addBranchProvenance(txnCtx *txncontext.TransactionContext, bran
 	})
 }
 
+// checkDeleteAll returns an error if any of the repos deleteAll would delete
+// is under a retention lock, so that clients can check before deleting the
+// state of the other services.
+func (d *driver) checkDeleteAll(ctx context.Context) error {
+	var repoInfos []*pfs.RepoInfo
+	if err := d.listRepo(ctx, !includeAuth, "", "", func(repoInfo *pfs.RepoInfo) error {
+		repoInfos = append(repoInfos, repoInfo)
+		return nil
+	}); err != nil {
+		return err
+	}
+	return d.txnEnv.WithReadContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		for _, repoInfo := range repoInfos {
+			// deleteAll skips the repos the caller can't delete
+			if err := d.env.AuthServer.CheckRepoIsAuthorizedInTransaction(txnCtx, repoInfo.Repo, auth.Permission_REPO_DELETE); err != nil {
+				if auth.IsErrNotAuthorized(err) {
+					continue
+				}
+				return err
+			}
+			if err := d.checkBranchRetention(txnCtx, repoInfo.Repo.NewBranch("")); err != nil {
+				return errors.Wrapf(err, "cannot delete repo %q", repoInfo.Repo)
+			}
+		}
+		return nil
+	})
+}
+
 func (d *driver) deleteAll(ctx context.Context) error {
+	if err := d.checkDeleteAll(ctx); err != nil {
+		return err
+	}
 	var repoInfos []*pfs.RepoInfo
-	if err := d.listRepo(ctx, !includeAuth, "", func(repoInfo *pfs.RepoInfo) error {
+	if err := d.listRepo(ctx, !includeAuth, "", "", func(repoInfo *pfs.RepoInfo) error {
 		repoInfos = append(repoInfos, repoInfo)
 		return nil
 	}); err != nil {
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset/index"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// diskUsage aggregates the sizes and file counts of the files under a path by
+// directory, down to depth directory levels below the path. Only the index is
+// read, not the file content.
+func (d *driver) diskUsage(ctx context.Context, file *pfs.File, depth int64, cb func(*pfs.DiskUsageInfo) error) error {
+	if depth < 0 {
+		return errors.Errorf("depth must be non-negative")
+	}
+	p := cleanPath(file.Path)
+	if p == "/" {
+		p = ""
+	}
+	commitInfo, fs, err := d.openCommit(ctx, file.Commit, index.WithPrefix(p))
+	if err != nil {
+		return err
+	}
+	usages := make(map[string]*pfs.DiskUsageInfo)
+	add := func(path string, size int64, newFile bool) {
+		usage, ok := usages[path]
+		if !ok {
+			usage = &pfs.DiskUsageInfo{Path: path}
+			usages[path] = usage
+		}
+		usage.SizeBytes += size
+		if newFile {
+			usage.FileCount++
+		}
+	}
+	var lastPath string
+	if err := fs.Iterate(ctx, func(f fileset.File) error {
+		idx := f.Index()
+		if idx.Path != p && !strings.HasPrefix(idx.Path, p+"/") {
+			return nil
+		}
+		size := index.SizeBytes(idx)
+		newFile := idx.Path != lastPath
+		lastPath = idx.Path
+		if idx.Path == p {
+			add(p, size, newFile)
+			return nil
+		}
+		// The directories which contain the file, relative to p.
+		dirs := strings.Split(strings.TrimPrefix(idx.Path, p+"/"), "/")
+		dirs = dirs[:len(dirs)-1]
+		dir := p + "/"
+		add(dir, size, newFile)
+		for i := 0; i < len(dirs) && int64(i) < depth; i++ {
+			dir += dirs[i] + "/"
+			add(dir, size, newFile)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(usages) == 0 {
+		if p == "" {
+			return cb(&pfs.DiskUsageInfo{Path: "/"})
+		}
+		return newFileNotFound(commitInfo.Commit.ID, p)
+	}
+	var paths []string
+	for path := range usages {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := cb(usages[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// repoDiskUsage compares the logical size of the finished commits in a repo
+// with the size of the distinct data chunks that they reference.
+func (d *driver) repoDiskUsage(ctx context.Context, repo *pfs.Repo) (*pfs.RepoDiskUsageResponse, error) {
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, repo, auth.Permission_REPO_READ); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var commitInfos []*pfs.CommitInfo
+	if err := d.listCommit(ctx, repo, nil, nil, 0, false, false, pfs.OriginKind_ORIGIN_KIND_UNKNOWN, "", func(ci *pfs.CommitInfo) error {
+		if ci.Finished != nil {
+			commitInfos = append(commitInfos, ci)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	resp := &pfs.RepoDiskUsageResponse{}
+	chunks := make(map[string]struct{})
+	for _, ci := range commitInfos {
+		resp.CommitCount++
+		if ci.Details != nil {
+			resp.LogicalSizeBytes += ci.Details.SizeBytes
+		}
+		id, err := d.getFileSet(ctx, ci.Commit)
+		if err != nil {
+			return nil, err
+		}
+		fs, err := d.storage.Open(ctx, []fileset.ID{*id})
+		if err != nil {
+			return nil, err
+		}
+		if err := fs.Iterate(ctx, func(f fileset.File) error {
+			for _, dataRef := range f.Index().File.DataRefs {
+				key := string(dataRef.Ref.Id)
+				if _, ok := chunks[key]; ok {
+					continue
+				}
+				chunks[key] = struct{}{}
+				resp.UniqueSizeBytes += dataRef.Ref.SizeBytes
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	resp.ChunkCount = int64(len(chunks))
+	return resp, nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/v2/src/auth"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
@@ -230,9 +231,11 @@ func (d *driver) inspectFile(ctx context.Context, file *pfs.File) (*pfs.FileInfo
 	return ret, nil
 }
 
-func (d *driver) listFile(ctx context.Context, file *pfs.File, cb func(*pfs.FileInfo) error) error {
+// listFile lists the children of a directory in path order, starting after
+// the path after if it is set.
+func (d *driver) listFile(ctx context.Context, file *pfs.File, after string, cb func(*pfs.FileInfo) error) error {
 	name := cleanPath(file.Path)
-	commitInfo, fs, err := d.openCommit(ctx, file.Commit, index.WithPrefix(name), index.WithDatum(file.Datum))
+	commitInfo, fs, err := d.openCommit(ctx, file.Commit, index.WithPrefix(name), index.WithLowerBound(lowerBoundAfter(after)), index.WithDatum(file.Datum))
 	if err != nil {
 		return err
 	}
@@ -254,13 +257,71 @@ func (d *driver) listFile(ctx context.Context, file *pfs.File, cb func(*pfs.File
 	}
 	s := NewSource(commitInfo, fs, opts...)
 	return s.Iterate(ctx, func(fi *pfs.FileInfo, _ fileset.File) error {
-		if pathIsChild(name, cleanPath(fi.File.Path)) {
+		if fi.File.Path > after && pathIsChild(name, cleanPath(fi.File.Path)) {
 			return cb(fi)
 		}
 		return nil
 	})
 }
 
+// fileCommitTimes finds the finishing time of the last commit which changed
+// each of the paths in a listing of file, by diffing the commit and its
+// ancestors with their parents until every path is found. An open commit has
+// a nil time.
+func (d *driver) fileCommitTimes(ctx context.Context, file *pfs.File, paths map[string]bool) (map[string]*types.Timestamp, error) {
+	name := cleanPath(file.Path)
+	times := make(map[string]*types.Timestamp)
+	commit := file.Commit
+	for commit != nil && len(times) < len(paths) {
+		commitInfo, err := d.inspectCommit(ctx, commit, pfs.CommitState_STARTED)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.diffFile(ctx, nil, commitInfo.Commit.NewFile(name), func(oldFi, newFi *pfs.FileInfo) error {
+			fi := newFi
+			if fi == nil {
+				fi = oldFi
+			}
+			entry := listingEntry(name, fi.File.Path)
+			if _, ok := times[entry]; !ok && paths[entry] {
+				times[entry] = commitInfo.Finishing
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		commit = commitInfo.ParentCommit
+	}
+	return times, nil
+}
+
+// listingEntry returns the path in a listing of name which contains p, or ""
+// if p isn't in the listing.
+func listingEntry(name, p string) string {
+	if p == name {
+		return p
+	}
+	prefix := fileset.Clean(name, true)
+	if !strings.HasPrefix(p, prefix) || p == prefix {
+		return ""
+	}
+	rel := p[len(prefix):]
+	if i := strings.Index(rel, "/"); i >= 0 {
+		return prefix + rel[:i+1]
+	}
+	return p
+}
+
+// lowerBoundAfter returns an index lower bound for the paths which follow the
+// path after in a listing. The contents of after are skipped when it is a
+// directory. The bound is inclusive, so after itself must be filtered out.
+func lowerBoundAfter(after string) string {
+	if strings.HasSuffix(after, "/") {
+		return after[:len(after)-1] + string('/'+1)
+	}
+	return after
+}
+
 func (d *driver) walkFile(ctx context.Context, file *pfs.File, cb func(*pfs.FileInfo) error) (retErr error) {
 	p := cleanPath(file.Path)
 	if p == "/" {
@@ -288,9 +349,11 @@ func (d *driver) walkFile(ctx context.Context, file *pfs.File, cb func(*pfs.File
 	return err
 }
 
-func (d *driver) globFile(ctx context.Context, commit *pfs.Commit, glob string, cb func(*pfs.FileInfo) error) error {
+// globFile lists the files which match a glob pattern in path order, starting
+// after the path after if it is set.
+func (d *driver) globFile(ctx context.Context, commit *pfs.Commit, glob string, after string, cb func(*pfs.FileInfo) error) error {
 	glob = cleanPath(glob)
-	commitInfo, fs, err := d.openCommit(ctx, commit, index.WithPrefix(globLiteralPrefix(glob)))
+	commitInfo, fs, err := d.openCommit(ctx, commit, index.WithPrefix(globLiteralPrefix(glob)), index.WithLowerBound(after))
 	if err != nil {
 		return err
 	}
@@ -307,7 +370,7 @@ func (d *driver) globFile(ctx context.Context, commit *pfs.Commit, glob string,
 	}
 	s := NewSource(commitInfo, fs, opts...)
 	return s.Iterate(ctx, func(fi *pfs.FileInfo, _ fileset.File) error {
-		if mf(fi.File.Path) {
+		if fi.File.Path > after && mf(fi.File.Path) {
 			return cb(fi)
 		}
 		return nil
@@ -391,6 +454,56 @@ func (d *driver) diffFile(ctx context.Context, oldFile, newFile *pfs.File, cb fu
 	return diff.Iterate(ctx, cb)
 }
 
+// subscribeFile calls cb for each finished commit on a branch which changed
+// files that match a glob pattern, with the changes to the matching files.
+// Directories are not included in the changes. If from is set, it must be a
+// commit on the branch, and only the commits created after it are considered.
+func (d *driver) subscribeFile(ctx context.Context, branch *pfs.Branch, glob string, from *pfs.Commit, cb func(*pfs.SubscribeFileEvent) error) error {
+	if branch == nil || branch.Repo == nil {
+		return errors.New("branch cannot be nil")
+	}
+	glob = cleanPath(glob)
+	mf, err := globMatchFunction(glob)
+	if err != nil {
+		return err
+	}
+	if from != nil {
+		fromInfo, err := d.inspectCommit(ctx, from, pfs.CommitState_STARTED)
+		if err != nil {
+			return err
+		}
+		if !proto.Equal(fromInfo.Commit.Branch, branch) {
+			return errors.Errorf("the `from` commit %v is not on branch %v", fromInfo.Commit, branch)
+		}
+		from = fromInfo.Commit
+	}
+	// Only the directory which contains the literal prefix of the pattern
+	// needs to be diffed.
+	dir := path.Dir(globLiteralPrefix(glob))
+	matches := func(fi *pfs.FileInfo) bool {
+		return fi != nil && fi.FileType == pfs.FileType_FILE && mf(fi.File.Path)
+	}
+	return d.subscribeCommit(ctx, branch.Repo, branch.Name, from, pfs.CommitState_FINISHED, false, pfs.OriginKind_ORIGIN_KIND_UNKNOWN, func(commitInfo *pfs.CommitInfo) error {
+		event := &pfs.SubscribeFileEvent{CommitInfo: commitInfo}
+		if err := d.diffFile(ctx, nil, commitInfo.Commit.NewFile(dir), func(oldFi, newFi *pfs.FileInfo) error {
+			if !matches(oldFi) && !matches(newFi) {
+				return nil
+			}
+			event.Changes = append(event.Changes, &pfs.DiffFileResponse{
+				OldFile: oldFi,
+				NewFile: newFi,
+			})
+			return nil
+		}); err != nil {
+			return err
+		}
+		if len(event.Changes) == 0 {
+			return nil
+		}
+		return cb(event)
+	})
+}
+
 // createFileSet creates a new temporary fileset and returns it.
 func (d *driver) createFileSet(ctx context.Context, cb func(*fileset.UnorderedWriter) error) (*fileset.ID, error) {
 	var id *fileset.ID
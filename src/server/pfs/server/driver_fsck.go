@@ -3,17 +3,36 @@ package server
 import (
 	"context"
 	"fmt"
+	"path"
+	"sort"
 	"strings"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	log "github.com/sirupsen/logrus"
 
 	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
 	"github.com/pachyderm/pachyderm/v2/src/internal/dbutil"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+	"github.com/pachyderm/pachyderm/v2/src/internal/task"
+	"github.com/pachyderm/pachyderm/v2/src/internal/uuid"
 	"github.com/pachyderm/pachyderm/v2/src/pfs"
 )
 
+const (
+	// fsckCheckpointPrefix is the prefix of the deep fsck checkpoints, which
+	// are kept for each set of options.
+	fsckCheckpointPrefix = "pfs-fsck-checkpoint"
+	// fsckBatchSize is the number of commits checked between checkpoints.
+	fsckBatchSize = 100
+	// maxFsckTaskErrors bounds the number of paths reported for a file set.
+	maxFsckTaskErrors = 1000
+)
+
 func equalBranches(a, b []*pfs.Branch) bool {
 	aMap := make(map[string]bool)
 	bMap := make(map[string]bool)
@@ -125,11 +144,29 @@ func (e ErrMissingBranchHead) Error() string {
 	return fmt.Sprintf("consistency error: branch %s does not have a head commit", e.Branch)
 }
 
+// ErrCommitDataUnreadable indicates that a chunk referenced by a commit is
+// missing from object storage or is corrupt.
+type ErrCommitDataUnreadable struct {
+	Commit *pfs.Commit
+	Path   string
+	Reason string
+}
+
+func (e ErrCommitDataUnreadable) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("consistency error: data for commit %s is unreadable: %s", e.Commit, e.Reason)
+	}
+	return fmt.Sprintf("consistency error: data for path %s in commit %s is unreadable: %s", e.Path, e.Commit, e.Reason)
+}
+
 // fsck verifies that pfs satisfies the following invariants:
 // 1. Branch provenance is transitive
 // 2. Head commit provenance has heads of branch's branch provenance
 // If fix is true it will attempt to fix as many of these issues as it can.
-func (d *driver) fsck(ctx context.Context, fix bool, cb func(*pfs.FsckResponse) error) error {
+// If deep is true it will also check that the chunks referenced by each
+// commit exist in object storage, see fsckChunks.
+func (d *driver) fsck(ctx context.Context, req *pfs.FsckRequest, cb func(*pfs.FsckResponse) error) error {
+	fix := req.Fix
 	onError := func(err error) error { return cb(&pfs.FsckResponse{Error: err.Error()}) }
 
 	// TODO(global ids): no fixable fsck issues?
@@ -285,6 +322,12 @@ func (d *driver) fsck(ctx context.Context, fix bool, cb func(*pfs.FsckResponse)
 
 	// TODO(global ids): is there any verification we can do for commitsets?
 
+	if req.Deep {
+		if err := d.fsckChunks(ctx, commitInfos, req.ReadChunkData, req.Resume, cb); err != nil {
+			return err
+		}
+	}
+
 	if fix {
 		return dbutil.WithTx(ctx, d.env.DB, func(sqlTx *pachsql.Tx) error {
 			for _, ci := range newCommitInfos {
@@ -300,3 +343,190 @@ func (d *driver) fsck(ctx context.Context, fix bool, cb func(*pfs.FsckResponse)
 	}
 	return nil
 }
+
+// fsckChunks checks that all of the index and data chunks referenced by each
+// commit's file set exist in object storage, and if readChunkData is true that
+// they are intact. The commits are checked in batches by the storage task
+// workers, and a checkpoint is written after each batch so that an
+// interrupted check can be resumed. Errors reported before the checkpoint are
+// not reported again when resuming.
+func (d *driver) fsckChunks(ctx context.Context, commitInfos map[string]*pfs.CommitInfo, readChunkData, resume bool, cb func(*pfs.FsckResponse) error) error {
+	var keys []string
+	for key := range commitInfos {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// A check which only finds the chunks doesn't cover a check which reads
+	// them, so each kind of check is resumed from its own checkpoint.
+	checkpointKey := path.Join(d.prefix, fsckCheckpointPrefix, fmt.Sprintf("read-chunk-data-%t", readChunkData))
+	if resume {
+		resp, err := d.etcdClient.Get(ctx, checkpointKey)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if len(resp.Kvs) > 0 {
+			checkpoint := string(resp.Kvs[0].Value)
+			log.Infof("resuming deep fsck after commit %v", checkpoint)
+			keys = keys[sort.Search(len(keys), func(i int) bool { return keys[i] > checkpoint }):]
+		}
+	}
+	taskDoer := d.env.TaskService.NewDoer(storageTaskNamespace, "fsck-"+uuid.NewWithoutDashes())
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > fsckBatchSize {
+			batch = batch[:fsckBatchSize]
+		}
+		var commits []*pfs.Commit
+		for _, key := range batch {
+			commits = append(commits, commitInfos[key].Commit)
+		}
+		if err := d.fsckChunksBatch(ctx, taskDoer, commits, readChunkData, cb); err != nil {
+			return err
+		}
+		if _, err := d.etcdClient.Put(ctx, checkpointKey, batch[len(batch)-1]); err != nil {
+			return errors.EnsureStack(err)
+		}
+		keys = keys[len(batch):]
+	}
+	_, err := d.etcdClient.Delete(ctx, checkpointKey)
+	return errors.EnsureStack(err)
+}
+
+func (d *driver) fsckChunksBatch(ctx context.Context, taskDoer task.Doer, commits []*pfs.Commit, readChunkData bool, cb func(*pfs.FsckResponse) error) error {
+	onError := func(commit *pfs.Commit, path, reason string) error {
+		return cb(&pfs.FsckResponse{
+			Error: ErrCommitDataUnreadable{
+				Commit: commit,
+				Path:   path,
+				Reason: reason,
+			}.Error(),
+			Commit: commit,
+			Path:   path,
+		})
+	}
+	return d.storage.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *fileset.Renewer) error {
+		// Commits can share file sets, so each file set is only checked once.
+		var ids []string
+		idCommits := make(map[string][]*pfs.Commit)
+		for _, commit := range commits {
+			id, err := d.getFileSet(ctx, commit)
+			if err != nil {
+				if err := onError(commit, "", err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := renewer.Add(ctx, *id); err != nil {
+				return err
+			}
+			if _, ok := idCommits[id.HexString()]; !ok {
+				ids = append(ids, id.HexString())
+			}
+			idCommits[id.HexString()] = append(idCommits[id.HexString()], commit)
+		}
+		var inputs []*types.Any
+		for _, id := range ids {
+			input, err := serializeFsckTask(&FsckTask{
+				Id:            id,
+				ReadChunkData: readChunkData,
+			})
+			if err != nil {
+				return err
+			}
+			inputs = append(inputs, input)
+		}
+		return task.DoBatch(ctx, taskDoer, inputs, func(i int64, output *types.Any, err error) error {
+			commits := idCommits[ids[i]]
+			if err != nil {
+				for _, commit := range commits {
+					if err := onError(commit, "", err.Error()); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			result, err := deserializeFsckTaskResult(output)
+			if err != nil {
+				return err
+			}
+			for _, commit := range commits {
+				for _, fsckErr := range result.Errors {
+					if err := onError(commit, fsckErr.Path, fsckErr.Error); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func processFsckTask(ctx context.Context, storage *fileset.Storage, task *FsckTask) (*types.Any, error) {
+	result := &FsckTaskResult{}
+	if err := miscutil.LogStep("processing fsck task", func() error {
+		id, err := fileset.ParseID(task.Id)
+		if err != nil {
+			return err
+		}
+		var skipped int
+		n, err := storage.Check(ctx, *id, task.ReadChunkData, func(path string, err error) error {
+			if len(result.Errors) >= maxFsckTaskErrors {
+				skipped++
+				return nil
+			}
+			result.Errors = append(result.Errors, &FsckTaskError{
+				Path:  path,
+				Error: err.Error(),
+			})
+			return nil
+		})
+		if skipped > 0 {
+			result.Errors = append(result.Errors, &FsckTaskError{
+				Error: fmt.Sprintf("%d more paths are unreadable", skipped),
+			})
+		}
+		result.ChunkCount = int64(n)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return serializeFsckTaskResult(result)
+}
+
+func serializeFsckTask(task *FsckTask) (*types.Any, error) {
+	data, err := proto.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Any{
+		TypeUrl: "/" + proto.MessageName(task),
+		Value:   data,
+	}, nil
+}
+
+func deserializeFsckTask(taskAny *types.Any) (*FsckTask, error) {
+	task := &FsckTask{}
+	if err := types.UnmarshalAny(taskAny, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func serializeFsckTaskResult(res *FsckTaskResult) (*types.Any, error) {
+	data, err := proto.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Any{
+		TypeUrl: "/" + proto.MessageName(res),
+		Value:   data,
+	}, nil
+}
+
+func deserializeFsckTaskResult(any *types.Any) (*FsckTaskResult, error) {
+	res := &FsckTaskResult{}
+	if err := types.UnmarshalAny(any, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -37,6 +37,9 @@ type Env struct {
 	BackgroundContext context.Context
 	Logger            *logrus.Logger
 	StorageConfig     serviceenv.StorageConfiguration
+	// AttestationSecret is the secret the key which signs commit
+	// attestations is derived from
+	AttestationSecret string
 }
 
 func EnvFromServiceEnv(env serviceenv.ServiceEnv, txnEnv *txnenv.TransactionEnv) (*Env, error) {
@@ -64,6 +67,7 @@ func EnvFromServiceEnv(env serviceenv.ServiceEnv, txnEnv *txnenv.TransactionEnv)
 
 		BackgroundContext: env.Context(),
 		StorageConfig:     env.Config().StorageConfiguration,
+		AttestationSecret: env.Config().PFSAttestationSecret,
 		Logger:            env.Logger(),
 	}, nil
 }
@@ -9,7 +9,7 @@ import (
 
 	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
 	"github.com/pachyderm/pachyderm/v2/src/internal/dlock"
-	_ "github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
 	"github.com/pachyderm/pachyderm/v2/src/internal/middleware/auth"
 	"github.com/pachyderm/pachyderm/v2/src/internal/miscutil"
 	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
@@ -21,13 +21,19 @@ import (
 	"github.com/pachyderm/pachyderm/v2/src/pfs"
 	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
-	masterLockPath = "pfs-master-lock"
+	masterLockPath      = "pfs-master-lock"
+	orphanReclaimPeriod = time.Hour
+	// defaultOrphanMinAge is the minimum age of the orphaned objects reclaimed
+	// by a request which doesn't set one, so that objects which are being
+	// uploaded aren't mistaken for orphans.
+	defaultOrphanMinAge = 24 * time.Hour
 )
 
 func (d *driver) master(ctx context.Context) {
@@ -47,9 +53,28 @@ func (d *driver) master(ctx context.Context) {
 			gc := chunk.NewGC(d.storage.ChunkStorage())
 			return gc.RunForever(ctx)
 		})
+		if age := d.env.StorageConfig.StorageOrphanReclaimAge; age != "" {
+			minAge, err := time.ParseDuration(age)
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			eg.Go(func() error {
+				od := chunk.NewOrphanDetector(d.storage.ChunkStorage(), minAge, false)
+				return od.RunForever(ctx, orphanReclaimPeriod)
+			})
+		}
 		eg.Go(func() error {
 			return d.finishCommits(ctx)
 		})
+		eg.Go(func() error {
+			return d.materializeQueued(ctx)
+		})
+		eg.Go(func() error {
+			return d.renewMultipartUploads(ctx)
+		})
+		eg.Go(func() error {
+			return d.finishSessionCommits(ctx)
+		})
 		return eg.Wait()
 	}, backoff.NewInfiniteBackOff(), func(err error, _ time.Duration) error {
 		log.Errorf("error in pfs master: %v", err)
@@ -137,9 +162,19 @@ func (d *driver) finishRepoCommits(ctx context.Context, compactor *compactor, re
 				start = time.Now()
 				var size int64
 				var validationError string
+				var changeSummary *pfs.ChangeSummary
+				var contentHash []byte
 				if err := miscutil.LogStep(fmt.Sprintf("validating commit %v", commit), func() error {
 					var err error
 					size, validationError, err = d.validate(ctx, totalId)
+					if err != nil {
+						return err
+					}
+					changeSummary, err = d.changeSummary(ctx, commitInfo, totalId)
+					if err != nil {
+						return err
+					}
+					contentHash, err = d.contentHash(ctx, commitInfo, totalId)
 					return err
 				}); err != nil {
 					return err
@@ -156,12 +191,13 @@ func (d *driver) finishRepoCommits(ctx context.Context, compactor *compactor, re
 								commitInfo.Details = &pfs.CommitInfo_Details{}
 							}
 							commitInfo.Details.SizeBytes = size
+							commitInfo.Details.ChangeSummary = changeSummary
 							if commitInfo.Error == "" {
 								commitInfo.Error = validationError
 							}
 							commitInfo.Details.CompactingTime = types.DurationProto(compactingDuration)
 							commitInfo.Details.ValidatingTime = types.DurationProto(validatingDuration)
-							return nil
+							return d.linkCommitHash(txnCtx, commitInfo, contentHash)
 						}); err != nil {
 							return err
 						}
@@ -214,15 +250,82 @@ func (d *driver) validate(ctx context.Context, id *fileset.ID) (int64, string, e
 	return size, validationError, nil
 }
 
+// changeSummary counts the files which changed between a commit, with the
+// file set id, and its parent.
+func (d *driver) changeSummary(ctx context.Context, commitInfo *pfs.CommitInfo, id *fileset.ID) (*pfs.ChangeSummary, error) {
+	var parent Source = emptySource{}
+	if commitInfo.ParentCommit != nil {
+		parentId, err := d.getFileSet(ctx, commitInfo.ParentCommit)
+		if err != nil {
+			return nil, err
+		}
+		fs, err := d.storage.Open(ctx, []fileset.ID{*parentId})
+		if err != nil {
+			return nil, err
+		}
+		parent = NewSource(&pfs.CommitInfo{Commit: commitInfo.ParentCommit}, fs)
+	}
+	fs, err := d.storage.Open(ctx, []fileset.ID{*id})
+	if err != nil {
+		return nil, err
+	}
+	// Directories are skipped, the files in them are counted instead.
+	summary := &pfs.ChangeSummary{}
+	isFile := func(fi *pfs.FileInfo) bool {
+		return fi != nil && fi.FileType == pfs.FileType_FILE
+	}
+	if err := NewDiffer(parent, NewSource(commitInfo, fs)).Iterate(ctx, func(oldFi, newFi *pfs.FileInfo) error {
+		switch {
+		case isFile(oldFi) && isFile(newFi):
+			summary.FilesModified++
+			if delta := newFi.SizeBytes - oldFi.SizeBytes; delta > 0 {
+				summary.BytesAdded += delta
+			} else {
+				summary.BytesRemoved -= delta
+			}
+		case isFile(oldFi):
+			summary.FilesDeleted++
+			summary.BytesRemoved += oldFi.SizeBytes
+		case isFile(newFi):
+			summary.FilesAdded++
+			summary.BytesAdded += newFi.SizeBytes
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// aliasDetails returns the details for an alias of a commit. An alias has the
+// same files as the commit it aliases, so nothing changed in it.
+func aliasDetails(details *pfs.CommitInfo_Details) *pfs.CommitInfo_Details {
+	if details == nil || details.ChangeSummary == nil {
+		return details
+	}
+	details = proto.Clone(details).(*pfs.CommitInfo_Details)
+	details.ChangeSummary = &pfs.ChangeSummary{}
+	return details
+}
+
 // finishAliasDescendents will traverse the given commit's descendents, finding all
 // contiguous aliases and finishing them.
 func (d *driver) finishAliasDescendents(txnCtx *txncontext.TransactionContext, parentCommitInfo *pfs.CommitInfo, id fileset.ID) error {
-	// Build the starting set of commits to consider
-	descendents := append([]*pfs.Commit{}, parentCommitInfo.ChildCommits...)
+	// Build the starting set of commits to consider, with the hashes of their
+	// parents
+	type descendent struct {
+		commit     *pfs.Commit
+		parentHash *pfs.CommitHash
+	}
+	var descendents []descendent
+	for _, child := range parentCommitInfo.ChildCommits {
+		descendents = append(descendents, descendent{child, parentCommitInfo.Hash})
+	}
 
 	// A commit cannot have more than one parent, so no need to track visited nodes
 	for len(descendents) > 0 {
-		commit := descendents[0]
+		commit := descendents[0].commit
+		parentHash := descendents[0].parentHash
 		descendents = descendents[1:]
 		commitInfo := &pfs.CommitInfo{}
 		if err := d.commits.ReadWrite(txnCtx.SqlTx).Get(pfsdb.CommitKey(commit), commitInfo); err != nil {
@@ -234,7 +337,8 @@ func (d *driver) finishAliasDescendents(txnCtx *txncontext.TransactionContext, p
 				commitInfo.Finishing = txnCtx.Timestamp
 			}
 			commitInfo.Finished = txnCtx.Timestamp
-			commitInfo.Details = parentCommitInfo.Details
+			commitInfo.Details = aliasDetails(parentCommitInfo.Details)
+			commitInfo.Hash = aliasHash(parentHash)
 			commitInfo.Error = parentCommitInfo.Error
 			if err := d.commits.ReadWrite(txnCtx.SqlTx).Put(pfsdb.CommitKey(commit), commitInfo); err != nil {
 				return err
@@ -243,7 +347,9 @@ func (d *driver) finishAliasDescendents(txnCtx *txncontext.TransactionContext, p
 				return err
 			}
 
-			descendents = append(descendents, commitInfo.ChildCommits...)
+			for _, child := range commitInfo.ChildCommits {
+				descendents = append(descendents, descendent{child, commitInfo.Hash})
+			}
 		}
 	}
 	return nil
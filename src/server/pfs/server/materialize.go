@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+)
+
+// materializePeriod is how often the pfs master materializes the commits
+// queued for background materialization.
+const materializePeriod = 10 * time.Second
+
+// SetupPostgresMaterializeQueueV0 creates the table of the commits queued for
+// background materialization.
+func SetupPostgresMaterializeQueueV0(ctx context.Context, tx *pachsql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE pfs.materialize_queue (
+			commit_id TEXT PRIMARY KEY,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	return errors.EnsureStack(err)
+}
+
+// queueMaterialize queues a commit to be materialized by the pfs master once
+// it is finished.
+func (d *driver) queueMaterialize(ctx context.Context, commit *pfs.Commit) error {
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, commit.Branch.Repo, auth.Permission_REPO_WRITE); err != nil {
+		return errors.EnsureStack(err)
+	}
+	commitInfo, err := d.inspectCommit(ctx, commit, pfs.CommitState_STARTED)
+	if err != nil {
+		return err
+	}
+	_, err = d.env.DB.ExecContext(ctx, `INSERT INTO pfs.materialize_queue (commit_id) VALUES ($1) ON CONFLICT DO NOTHING`,
+		pfsdb.CommitKey(commitInfo.Commit))
+	return errors.EnsureStack(err)
+}
+
+// materializeQueued periodically materializes the finished commits in the
+// materialize queue. Commits stay queued until they are finished, and are
+// retried if materializing them fails, unless they were deleted or are
+// locked by the retention of their repo.
+func (d *driver) materializeQueued(ctx context.Context) error {
+	ticker := time.NewTicker(materializePeriod)
+	defer ticker.Stop()
+	for {
+		var keys []string
+		if err := d.env.DB.SelectContext(ctx, &keys, `SELECT commit_id FROM pfs.materialize_queue ORDER BY created_at`); err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, key := range keys {
+			commitInfo := &pfs.CommitInfo{}
+			err := d.commits.ReadOnly(ctx).Get(key, commitInfo)
+			if err != nil && !col.IsErrNotFound(err) {
+				return errors.EnsureStack(err)
+			}
+			if err == nil {
+				if commitInfo.Finished == nil {
+					continue
+				}
+				err = d.materializeCommit(ctx, commitInfo.Commit)
+				if err != nil && !pfsserver.IsRetentionLockedErr(err) {
+					log.Errorf("error materializing commit %v: %v", commitInfo.Commit, err)
+					continue
+				}
+			}
+			if _, err := d.env.DB.ExecContext(ctx, `DELETE FROM pfs.materialize_queue WHERE commit_id = $1`, key); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/storage/fileset"
+)
+
+// multipartRenewPeriod is how often the pfs master renews the filesets of
+// the parts of the S3 gateway's multipart uploads, it must be well within
+// defaultTTL.
+const multipartRenewPeriod = time.Minute
+
+// SetupPostgresMultipartUploadsV0 creates the tables of the multipart uploads
+// in progress through the S3 gateway, and of the filesets their parts are
+// staged in.
+func SetupPostgresMultipartUploadsV0(ctx context.Context, tx *pachsql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE pfs.multipart_uploads (
+			upload_id TEXT PRIMARY KEY,
+			bucket TEXT NOT NULL,
+			key TEXT NOT NULL,
+			initiated TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			expires_at TIMESTAMP NOT NULL
+		);
+		CREATE INDEX multipart_uploads_bucket_key ON pfs.multipart_uploads (bucket, key, upload_id);
+
+		CREATE TABLE pfs.multipart_upload_parts (
+			upload_id TEXT NOT NULL REFERENCES pfs.multipart_uploads (upload_id) ON DELETE CASCADE,
+			part_number INT NOT NULL,
+			fileset_id TEXT NOT NULL,
+			etag TEXT NOT NULL,
+			size_bytes BIGINT NOT NULL,
+			PRIMARY KEY (upload_id, part_number)
+		);
+	`)
+	return errors.EnsureStack(err)
+}
+
+// renewMultipartUploads periodically drops the multipart uploads which have
+// been idle past their expiry, and renews the filesets of the parts of the
+// others. The filesets of dropped uploads expire once they're no longer
+// renewed.
+func (d *driver) renewMultipartUploads(ctx context.Context) error {
+	ticker := time.NewTicker(multipartRenewPeriod)
+	defer ticker.Stop()
+	for {
+		if _, err := d.env.DB.ExecContext(ctx, `DELETE FROM pfs.multipart_uploads WHERE expires_at < CURRENT_TIMESTAMP`); err != nil {
+			return errors.EnsureStack(err)
+		}
+		var ids []string
+		if err := d.env.DB.SelectContext(ctx, &ids, `SELECT fileset_id FROM pfs.multipart_upload_parts`); err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, id := range ids {
+			fsid, err := fileset.ParseID(id)
+			if err == nil {
+				_, err = d.storage.SetTTL(ctx, *fsid, defaultTTL)
+			}
+			if err != nil {
+				log.Errorf("error renewing fileset %v of multipart upload part: %v", id, err)
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"container/heap"
+	"encoding/base64"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// errPageFull stops a listing once a page is full and there is at least one
+// more result.
+var errPageFull = errors.New("page full")
+
+func encodePageToken(token *PageToken) (string, error) {
+	data, err := proto.Marshal(token)
+	if err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageToken(s string) (*PageToken, error) {
+	token := &PageToken{}
+	if s == "" {
+		return token, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Errorf("invalid page token: %v", err)
+	}
+	if err := proto.Unmarshal(data, token); err != nil {
+		return nil, errors.Errorf("invalid page token: %v", err)
+	}
+	return token, nil
+}
+
+// absolutePath makes a start after path absolute, like the paths of the file
+// infos that it is compared with.
+func absolutePath(p string) string {
+	if p != "" && !strings.HasPrefix(p, "/") {
+		return "/" + p
+	}
+	return p
+}
+
+// errTokenNotFound is returned when the last result of the previous page is
+// no longer in the listing, so the listing can't be resumed after it.
+func errTokenNotFound(after string) error {
+	return errors.Errorf("page token is no longer valid, %q was not found", after)
+}
+
+// pager splits the results of a listing into pages.
+// Each result is held until the next one arrives, so that the last result of
+// a page can carry the token for the next page.
+type pager struct {
+	size    int64
+	token   *PageToken
+	sent    int64
+	heldKey string
+	held    func(nextPageToken string) error
+}
+
+func newPager(size int64, token string) (*pager, error) {
+	if size < 0 {
+		return nil, errors.Errorf("page size must be non-negative")
+	}
+	t, err := decodePageToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return &pager{
+		size:  size,
+		token: t,
+	}, nil
+}
+
+// add must be called with the key of each result, and a function which sends
+// it with a next page token. It returns errPageFull when the result belongs
+// to the next page.
+func (p *pager) add(key string, send func(nextPageToken string) error) error {
+	if p.size > 0 && p.sent == p.size {
+		return errPageFull
+	}
+	if p.held != nil {
+		if err := p.held(""); err != nil {
+			return err
+		}
+	}
+	p.sent++
+	p.heldKey = key
+	p.held = send
+	return nil
+}
+
+// finish sends the held result, with the next page token if the listing
+// stopped at the end of a page.
+func (p *pager) finish(err error) error {
+	if err != nil && !errors.Is(err, errPageFull) {
+		return err
+	}
+	if p.held == nil {
+		return nil
+	}
+	if err == nil {
+		return p.held("")
+	}
+	token, err := encodePageToken(&PageToken{
+		After:  p.heldKey,
+		Offset: p.token.Offset + p.sent,
+	})
+	if err != nil {
+		return err
+	}
+	return p.held(token)
+}
+
+// fileInfoHeap keeps the first files of a listing in an order, up to a
+// limit, so that a page of a listing which is not in path order doesn't
+// require holding the whole listing.
+type fileInfoHeap struct {
+	fis   []*pfs.FileInfo
+	less  func(a, b *pfs.FileInfo) bool
+	limit int64
+}
+
+// newFileInfoHeap returns a heap which keeps the first limit files, or every
+// file if limit is 0.
+func newFileInfoHeap(less func(a, b *pfs.FileInfo) bool, limit int64) *fileInfoHeap {
+	return &fileInfoHeap{less: less, limit: limit}
+}
+
+// Len, Less, Swap, Push and Pop implement heap.Interface, with the last file
+// in the order at the top so that it can be dropped.
+func (h *fileInfoHeap) Len() int           { return len(h.fis) }
+func (h *fileInfoHeap) Less(i, j int) bool { return h.less(h.fis[j], h.fis[i]) }
+func (h *fileInfoHeap) Swap(i, j int)      { h.fis[i], h.fis[j] = h.fis[j], h.fis[i] }
+func (h *fileInfoHeap) Push(x interface{}) { h.fis = append(h.fis, x.(*pfs.FileInfo)) }
+func (h *fileInfoHeap) Pop() interface{} {
+	fi := h.fis[len(h.fis)-1]
+	h.fis = h.fis[:len(h.fis)-1]
+	return fi
+}
+
+func (h *fileInfoHeap) add(fi *pfs.FileInfo) {
+	heap.Push(h, fi)
+	if h.limit > 0 && int64(h.Len()) > h.limit {
+		heap.Pop(h)
+	}
+}
+
+// sorted empties the heap, and returns its files in order.
+func (h *fileInfoHeap) sorted() []*pfs.FileInfo {
+	fis := make([]*pfs.FileInfo, h.Len())
+	for i := len(fis) - 1; i >= 0; i-- {
+		fis[i] = heap.Pop(h).(*pfs.FileInfo)
+	}
+	return fis
+}
@@ -172,11 +172,13 @@ func (m *PathRange) GetUpper() string {
 }
 
 type CompactTask struct {
-	Inputs               []string   `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
-	PathRange            *PathRange `protobuf:"bytes,2,opt,name=path_range,json=pathRange,proto3" json:"path_range,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Inputs    []string   `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	PathRange *PathRange `protobuf:"bytes,2,opt,name=path_range,json=pathRange,proto3" json:"path_range,omitempty"`
+	// materialize copies the content of external objects into the output.
+	Materialize          bool     `protobuf:"varint,3,opt,name=materialize,proto3" json:"materialize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CompactTask) Reset()         { *m = CompactTask{} }
@@ -226,6 +228,13 @@ func (m *CompactTask) GetPathRange() *PathRange {
 	return nil
 }
 
+func (m *CompactTask) GetMaterialize() bool {
+	if m != nil {
+		return m.Materialize
+	}
+	return false
+}
+
 type CompactTaskResult struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -367,6 +376,232 @@ func (m *ConcatTaskResult) GetId() string {
 	return ""
 }
 
+type FsckTask struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReadChunkData        bool     `protobuf:"varint,2,opt,name=read_chunk_data,json=readChunkData,proto3" json:"read_chunk_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FsckTask) Reset()         { *m = FsckTask{} }
+func (m *FsckTask) String() string { return proto.CompactTextString(m) }
+func (*FsckTask) ProtoMessage()    {}
+func (*FsckTask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5a92e512e703e9c, []int{7}
+}
+func (m *FsckTask) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FsckTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FsckTask.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FsckTask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckTask.Merge(m, src)
+}
+func (m *FsckTask) XXX_Size() int {
+	return m.Size()
+}
+func (m *FsckTask) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckTask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FsckTask proto.InternalMessageInfo
+
+func (m *FsckTask) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *FsckTask) GetReadChunkData() bool {
+	if m != nil {
+		return m.ReadChunkData
+	}
+	return false
+}
+
+type FsckTaskResult struct {
+	Errors               []*FsckTaskError `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	ChunkCount           int64            `protobuf:"varint,2,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FsckTaskResult) Reset()         { *m = FsckTaskResult{} }
+func (m *FsckTaskResult) String() string { return proto.CompactTextString(m) }
+func (*FsckTaskResult) ProtoMessage()    {}
+func (*FsckTaskResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5a92e512e703e9c, []int{8}
+}
+func (m *FsckTaskResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FsckTaskResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FsckTaskResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FsckTaskResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckTaskResult.Merge(m, src)
+}
+func (m *FsckTaskResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *FsckTaskResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckTaskResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FsckTaskResult proto.InternalMessageInfo
+
+func (m *FsckTaskResult) GetErrors() []*FsckTaskError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+func (m *FsckTaskResult) GetChunkCount() int64 {
+	if m != nil {
+		return m.ChunkCount
+	}
+	return 0
+}
+
+type FsckTaskError struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FsckTaskError) Reset()         { *m = FsckTaskError{} }
+func (m *FsckTaskError) String() string { return proto.CompactTextString(m) }
+func (*FsckTaskError) ProtoMessage()    {}
+func (*FsckTaskError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5a92e512e703e9c, []int{9}
+}
+func (m *FsckTaskError) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FsckTaskError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FsckTaskError.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FsckTaskError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckTaskError.Merge(m, src)
+}
+func (m *FsckTaskError) XXX_Size() int {
+	return m.Size()
+}
+func (m *FsckTaskError) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckTaskError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FsckTaskError proto.InternalMessageInfo
+
+func (m *FsckTaskError) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FsckTaskError) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// PageToken is the content of the opaque tokens which resume paginated
+// listings.
+type PageToken struct {
+	// after is the key of the last result of the previous pages, which is a
+	// path, commit or repo depending on the listing.
+	After string `protobuf:"bytes,1,opt,name=after,proto3" json:"after,omitempty"`
+	// offset is the number of results in the previous pages, which is used for
+	// listings which are not in key order.
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PageToken) Reset()         { *m = PageToken{} }
+func (m *PageToken) String() string { return proto.CompactTextString(m) }
+func (*PageToken) ProtoMessage()    {}
+func (*PageToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5a92e512e703e9c, []int{10}
+}
+func (m *PageToken) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PageToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PageToken.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PageToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PageToken.Merge(m, src)
+}
+func (m *PageToken) XXX_Size() int {
+	return m.Size()
+}
+func (m *PageToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_PageToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PageToken proto.InternalMessageInfo
+
+func (m *PageToken) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+func (m *PageToken) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ShardTask)(nil), "pfsserver.ShardTask")
 	proto.RegisterType((*ShardTaskResult)(nil), "pfsserver.ShardTaskResult")
@@ -375,31 +610,44 @@ func init() {
 	proto.RegisterType((*CompactTaskResult)(nil), "pfsserver.CompactTaskResult")
 	proto.RegisterType((*ConcatTask)(nil), "pfsserver.ConcatTask")
 	proto.RegisterType((*ConcatTaskResult)(nil), "pfsserver.ConcatTaskResult")
+	proto.RegisterType((*FsckTask)(nil), "pfsserver.FsckTask")
+	proto.RegisterType((*FsckTaskResult)(nil), "pfsserver.FsckTaskResult")
+	proto.RegisterType((*FsckTaskError)(nil), "pfsserver.FsckTaskError")
+	proto.RegisterType((*PageToken)(nil), "pfsserver.PageToken")
 }
 
 func init() { proto.RegisterFile("server/pfs/server/pfsserver.proto", fileDescriptor_a5a92e512e703e9c) }
 
 var fileDescriptor_a5a92e512e703e9c = []byte{
-	// 291 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x51, 0x3d, 0x4f, 0xc3, 0x30,
-	0x14, 0x94, 0x83, 0xa8, 0xe4, 0x17, 0x3e, 0xa3, 0xaa, 0xca, 0x14, 0x15, 0x97, 0xa1, 0x53, 0x22,
-	0xa5, 0x43, 0x07, 0x36, 0x2a, 0x56, 0x84, 0x02, 0x53, 0x97, 0xca, 0x75, 0x4c, 0x13, 0xb5, 0x8d,
-	0x2d, 0xdb, 0x29, 0xe2, 0x1f, 0x32, 0xf2, 0x13, 0x50, 0x7e, 0x09, 0x6a, 0x5c, 0x92, 0x48, 0x40,
-	0xb7, 0xbb, 0xf3, 0x7b, 0x77, 0x67, 0x3d, 0xb8, 0xd1, 0x5c, 0xed, 0xb8, 0x8a, 0xe4, 0xab, 0x8e,
-	0x5a, 0x68, 0x51, 0x28, 0x95, 0x30, 0xc2, 0xc3, 0x8d, 0x40, 0x46, 0x80, 0x9f, 0x33, 0xaa, 0xd2,
-	0x17, 0xaa, 0xd7, 0xde, 0x00, 0x7a, 0x79, 0x21, 0x4b, 0xa3, 0x7d, 0x34, 0x3c, 0x19, 0xe3, 0xe4,
-	0xc0, 0xc8, 0x23, 0x5c, 0x36, 0x43, 0x09, 0xd7, 0xe5, 0xc6, 0x78, 0x77, 0x70, 0xce, 0xc4, 0x56,
-	0x52, 0x66, 0x16, 0x86, 0xea, 0xb5, 0xdd, 0x70, 0xe3, 0x41, 0xd8, 0x66, 0xcd, 0xec, 0x7b, 0xbd,
-	0x74, 0xc6, 0x5a, 0xa2, 0xc9, 0x14, 0xf0, 0x13, 0x35, 0x59, 0x42, 0x8b, 0x15, 0xf7, 0xfa, 0x70,
-	0xba, 0x11, 0x6f, 0x5c, 0xf9, 0x68, 0x88, 0xc6, 0x38, 0xb1, 0x64, 0xaf, 0x96, 0x52, 0x72, 0xe5,
-	0x3b, 0x56, 0xad, 0x09, 0x99, 0x83, 0xdb, 0x71, 0xfd, 0xaf, 0xaf, 0x37, 0x01, 0x90, 0xd4, 0x64,
-	0x0b, 0xb5, 0x0f, 0xa8, 0x1d, 0xdc, 0xb8, 0xdf, 0x69, 0xd6, 0x84, 0x27, 0x58, 0xfe, 0x40, 0x32,
-	0x82, 0xeb, 0x6e, 0x63, 0xfb, 0xcd, 0x0b, 0x70, 0xf2, 0xf4, 0xd0, 0xcc, 0xc9, 0x53, 0x72, 0x0b,
-	0x30, 0x13, 0x05, 0xa3, 0x47, 0xf3, 0x09, 0x81, 0xab, 0x76, 0xea, 0x6f, 0xa7, 0xfb, 0x87, 0x8f,
-	0x2a, 0x40, 0x9f, 0x55, 0x80, 0xbe, 0xaa, 0x00, 0xcd, 0xa7, 0xab, 0xdc, 0x64, 0xe5, 0x32, 0x64,
-	0x62, 0x1b, 0x49, 0xca, 0xb2, 0xf7, 0x94, 0xab, 0x2e, 0xda, 0xc5, 0x91, 0x56, 0x2c, 0xfa, 0x75,
-	0xda, 0x65, 0xaf, 0xbe, 0xe8, 0xe4, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xc9, 0x1e, 0x8f, 0x6c, 0xf6,
-	0x01, 0x00, 0x00,
+	// 438 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0x51, 0x6f, 0xd3, 0x3c,
+	0x14, 0x55, 0xda, 0xef, 0xab, 0x9a, 0x1b, 0xba, 0x81, 0x35, 0x4d, 0x79, 0x2a, 0xc5, 0x43, 0xa8,
+	0x4f, 0x0d, 0xea, 0x1e, 0xa6, 0x89, 0xb7, 0x95, 0xf1, 0x88, 0x90, 0xd9, 0x13, 0x2f, 0xd5, 0x9d,
+	0xe3, 0x36, 0x51, 0xda, 0xd8, 0xb2, 0x9d, 0x21, 0x10, 0x3f, 0x90, 0x47, 0x7e, 0x02, 0xea, 0x2f,
+	0x41, 0x76, 0x92, 0x26, 0x80, 0xc6, 0xdb, 0x3d, 0xc7, 0xd7, 0xe7, 0x1c, 0x5f, 0xdb, 0xf0, 0xc2,
+	0x08, 0xfd, 0x20, 0x74, 0xa2, 0x36, 0x26, 0xe9, 0xca, 0xba, 0x5a, 0x28, 0x2d, 0xad, 0x24, 0xe1,
+	0x91, 0xa0, 0x17, 0x10, 0x7e, 0xcc, 0x50, 0xa7, 0x77, 0x68, 0x0a, 0x72, 0x0e, 0xa3, 0xbc, 0x54,
+	0x95, 0x35, 0x71, 0x30, 0x1b, 0xce, 0x43, 0xd6, 0x20, 0xfa, 0x1e, 0x4e, 0x8f, 0x4d, 0x4c, 0x98,
+	0x6a, 0x67, 0xc9, 0x1b, 0x98, 0x70, 0xb9, 0x57, 0xc8, 0xed, 0xda, 0xa2, 0x29, 0xea, 0x1d, 0xd1,
+	0xf2, 0x7c, 0xd1, 0x79, 0xad, 0xea, 0x75, 0xbf, 0xe9, 0x09, 0xef, 0x80, 0xa1, 0x57, 0x10, 0x7e,
+	0x40, 0x9b, 0x31, 0x2c, 0xb7, 0x82, 0x9c, 0xc1, 0xff, 0x3b, 0xf9, 0x59, 0xe8, 0x38, 0x98, 0x05,
+	0xf3, 0x90, 0xd5, 0xc0, 0xb1, 0x95, 0x52, 0x42, 0xc7, 0x83, 0x9a, 0xf5, 0x80, 0x7e, 0x83, 0xa8,
+	0xa7, 0xfa, 0x58, 0x5e, 0x72, 0x09, 0xa0, 0xd0, 0x66, 0x6b, 0xed, 0x0c, 0xbc, 0x42, 0xb4, 0x3c,
+	0xeb, 0x25, 0x3b, 0x9a, 0xb3, 0x50, 0x1d, 0x73, 0xcc, 0x20, 0xda, 0xa3, 0x15, 0x3a, 0xc7, 0x5d,
+	0xfe, 0x55, 0xc4, 0xc3, 0x59, 0x30, 0x1f, 0xb3, 0x3e, 0x45, 0x2f, 0xe0, 0x59, 0xff, 0x4c, 0xf5,
+	0x20, 0x4e, 0x60, 0x90, 0xa7, 0x4d, 0xf6, 0x41, 0x9e, 0xd2, 0x97, 0x00, 0x2b, 0x59, 0x72, 0xfc,
+	0x67, 0x42, 0x4a, 0xe1, 0x69, 0xd7, 0xf5, 0x88, 0xd2, 0x0d, 0x8c, 0xdf, 0x19, 0x5e, 0x78, 0x9d,
+	0x3f, 0xd6, 0xc8, 0x2b, 0x38, 0xd5, 0x02, 0xd3, 0x35, 0xcf, 0xaa, 0xb2, 0x58, 0xa7, 0x68, 0xd1,
+	0x1f, 0x73, 0xcc, 0x26, 0x8e, 0x5e, 0x39, 0xf6, 0x2d, 0x5a, 0xa4, 0x1c, 0x4e, 0x5a, 0x8d, 0xc6,
+	0xe5, 0x35, 0x8c, 0x84, 0xd6, 0x52, 0xb7, 0x37, 0x16, 0xf7, 0xe6, 0xd2, 0xb6, 0xde, 0xba, 0x06,
+	0xd6, 0xf4, 0x91, 0xe7, 0x10, 0xd5, 0x36, 0x5c, 0x56, 0xa5, 0xf5, 0x3e, 0x43, 0x06, 0x9e, 0x5a,
+	0x39, 0x86, 0x5e, 0xc3, 0xe4, 0xb7, 0x9d, 0x84, 0xc0, 0x7f, 0x6e, 0xae, 0x4d, 0x5e, 0x5f, 0xbb,
+	0x0b, 0xf5, 0x7a, 0xed, 0x85, 0x7a, 0x40, 0xaf, 0xdd, 0x4b, 0xd8, 0x8a, 0x3b, 0x59, 0x88, 0xd2,
+	0xb5, 0xe0, 0xc6, 0x76, 0x2f, 0xc1, 0x03, 0x37, 0x42, 0xb9, 0xd9, 0x18, 0xd1, 0x3a, 0x37, 0xe8,
+	0xe6, 0xf6, 0xfb, 0x61, 0x1a, 0xfc, 0x38, 0x4c, 0x83, 0x9f, 0x87, 0x69, 0xf0, 0xe9, 0x6a, 0x9b,
+	0xdb, 0xac, 0xba, 0x5f, 0x70, 0xb9, 0x4f, 0x14, 0xf2, 0xec, 0x4b, 0x2a, 0x74, 0xbf, 0x7a, 0x58,
+	0x26, 0x46, 0xf3, 0xe4, 0xaf, 0xbf, 0x71, 0x3f, 0xf2, 0x5f, 0xe2, 0xf2, 0xd7, 0x00, 0x89, 0xd7,
+	0x01, 0x85, 0x37, 0x03, 0x00, 0x00,
 }
 
 func (m *ShardTask) Marshal() (dAtA []byte, err error) {
@@ -544,6 +792,16 @@ func (m *CompactTask) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Materialize {
+		i--
+		if m.Materialize {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.PathRange != nil {
 		{
 			size, err := m.PathRange.MarshalToSizedBuffer(dAtA[:i])
@@ -672,81 +930,251 @@ func (m *ConcatTaskResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintPfsserver(dAtA []byte, offset int, v uint64) int {
-	offset -= sovPfsserver(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *FsckTask) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *ShardTask) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Inputs) > 0 {
-		for _, s := range m.Inputs {
-			l = len(s)
-			n += 1 + l + sovPfsserver(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+
+func (m *FsckTask) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ShardTaskResult) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *FsckTask) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.CompactTasks) > 0 {
-		for _, e := range m.CompactTasks {
-			l = e.Size()
-			n += 1 + l + sovPfsserver(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ReadChunkData {
+		i--
+		if m.ReadChunkData {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintPfsserver(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *PathRange) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *FsckTaskResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *FsckTaskResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FsckTaskResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Lower)
-	if l > 0 {
-		n += 1 + l + sovPfsserver(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Upper)
-	if l > 0 {
-		n += 1 + l + sovPfsserver(uint64(l))
+	if m.ChunkCount != 0 {
+		i = encodeVarintPfsserver(dAtA, i, uint64(m.ChunkCount))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Errors) > 0 {
+		for iNdEx := len(m.Errors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Errors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPfsserver(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *CompactTask) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *FsckTaskError) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.Inputs) > 0 {
-		for _, s := range m.Inputs {
+	return dAtA[:n], nil
+}
+
+func (m *FsckTaskError) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FsckTaskError) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintPfsserver(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintPfsserver(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PageToken) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PageToken) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PageToken) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Offset != 0 {
+		i = encodeVarintPfsserver(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.After) > 0 {
+		i -= len(m.After)
+		copy(dAtA[i:], m.After)
+		i = encodeVarintPfsserver(dAtA, i, uint64(len(m.After)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintPfsserver(dAtA []byte, offset int, v uint64) int {
+	offset -= sovPfsserver(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ShardTask) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Inputs) > 0 {
+		for _, s := range m.Inputs {
+			l = len(s)
+			n += 1 + l + sovPfsserver(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardTaskResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.CompactTasks) > 0 {
+		for _, e := range m.CompactTasks {
+			l = e.Size()
+			n += 1 + l + sovPfsserver(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PathRange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Lower)
+	if l > 0 {
+		n += 1 + l + sovPfsserver(uint64(l))
+	}
+	l = len(m.Upper)
+	if l > 0 {
+		n += 1 + l + sovPfsserver(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CompactTask) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Inputs) > 0 {
+		for _, s := range m.Inputs {
 			l = len(s)
 			n += 1 + l + sovPfsserver(uint64(l))
 		}
@@ -755,6 +1183,9 @@ func (m *CompactTask) Size() (n int) {
 		l = m.PathRange.Size()
 		n += 1 + l + sovPfsserver(uint64(l))
 	}
+	if m.Materialize {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -811,6 +1242,85 @@ func (m *ConcatTaskResult) Size() (n int) {
 	return n
 }
 
+func (m *FsckTask) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovPfsserver(uint64(l))
+	}
+	if m.ReadChunkData {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FsckTaskResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, e := range m.Errors {
+			l = e.Size()
+			n += 1 + l + sovPfsserver(uint64(l))
+		}
+	}
+	if m.ChunkCount != 0 {
+		n += 1 + sovPfsserver(uint64(m.ChunkCount))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FsckTaskError) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovPfsserver(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovPfsserver(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PageToken) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.After)
+	if l > 0 {
+		n += 1 + l + sovPfsserver(uint64(l))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovPfsserver(uint64(m.Offset))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovPfsserver(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -843,7 +1353,290 @@ func (m *ShardTask) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: ShardTask: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardTask: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ShardTask: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inputs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfsserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Inputs = append(m.Inputs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfsserver(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardTaskResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfsserver
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardTaskResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardTaskResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactTasks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfsserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompactTasks = append(m.CompactTasks, &CompactTask{})
+			if err := m.CompactTasks[len(m.CompactTasks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfsserver(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PathRange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfsserver
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PathRange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PathRange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lower", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfsserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Lower = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upper", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfsserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Upper = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfsserver(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompactTask) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfsserver
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompactTask: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompactTask: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -878,6 +1671,62 @@ func (m *ShardTask) Unmarshal(dAtA []byte) error {
 			}
 			m.Inputs = append(m.Inputs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PathRange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfsserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PathRange == nil {
+				m.PathRange = &PathRange{}
+			}
+			if err := m.PathRange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Materialize", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfsserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Materialize = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfsserver(dAtA[iNdEx:])
@@ -900,7 +1749,7 @@ func (m *ShardTask) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardTaskResult) Unmarshal(dAtA []byte) error {
+func (m *CompactTaskResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -923,17 +1772,17 @@ func (m *ShardTaskResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardTaskResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompactTaskResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardTaskResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompactTaskResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CompactTasks", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfsserver
@@ -943,25 +1792,23 @@ func (m *ShardTaskResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPfsserver
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPfsserver
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CompactTasks = append(m.CompactTasks, &CompactTask{})
-			if err := m.CompactTasks[len(m.CompactTasks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -985,7 +1832,7 @@ func (m *ShardTaskResult) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PathRange) Unmarshal(dAtA []byte) error {
+func (m *ConcatTask) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1008,15 +1855,15 @@ func (m *PathRange) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PathRange: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConcatTask: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PathRange: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConcatTask: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lower", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Inputs", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1044,11 +1891,62 @@ func (m *PathRange) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Lower = string(dAtA[iNdEx:postIndex])
+			m.Inputs = append(m.Inputs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfsserver(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConcatTaskResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfsserver
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConcatTaskResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConcatTaskResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Upper", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1076,7 +1974,7 @@ func (m *PathRange) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Upper = string(dAtA[iNdEx:postIndex])
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1100,7 +1998,7 @@ func (m *PathRange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CompactTask) Unmarshal(dAtA []byte) error {
+func (m *FsckTask) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1123,15 +2021,15 @@ func (m *CompactTask) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CompactTask: wiretype end group for non-group")
+			return fmt.Errorf("proto: FsckTask: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CompactTask: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FsckTask: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Inputs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1159,13 +2057,13 @@ func (m *CompactTask) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Inputs = append(m.Inputs, string(dAtA[iNdEx:postIndex]))
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PathRange", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadChunkData", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfsserver
@@ -1175,28 +2073,12 @@ func (m *CompactTask) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfsserver
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPfsserver
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.PathRange == nil {
-				m.PathRange = &PathRange{}
-			}
-			if err := m.PathRange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.ReadChunkData = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfsserver(dAtA[iNdEx:])
@@ -1219,7 +2101,7 @@ func (m *CompactTask) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CompactTaskResult) Unmarshal(dAtA []byte) error {
+func (m *FsckTaskResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1242,17 +2124,17 @@ func (m *CompactTaskResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CompactTaskResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: FsckTaskResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CompactTaskResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FsckTaskResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfsserver
@@ -1262,24 +2144,45 @@ func (m *CompactTaskResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfsserver
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPfsserver
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.Errors = append(m.Errors, &FsckTaskError{})
+			if err := m.Errors[len(m.Errors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkCount", wireType)
+			}
+			m.ChunkCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfsserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfsserver(dAtA[iNdEx:])
@@ -1302,7 +2205,7 @@ func (m *CompactTaskResult) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConcatTask) Unmarshal(dAtA []byte) error {
+func (m *FsckTaskError) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1325,15 +2228,15 @@ func (m *ConcatTask) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConcatTask: wiretype end group for non-group")
+			return fmt.Errorf("proto: FsckTaskError: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConcatTask: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FsckTaskError: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Inputs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1361,7 +2264,39 @@ func (m *ConcatTask) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Inputs = append(m.Inputs, string(dAtA[iNdEx:postIndex]))
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfsserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfsserver
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1385,7 +2320,7 @@ func (m *ConcatTask) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConcatTaskResult) Unmarshal(dAtA []byte) error {
+func (m *PageToken) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1408,15 +2343,15 @@ func (m *ConcatTaskResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConcatTaskResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: PageToken: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConcatTaskResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PageToken: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1444,8 +2379,27 @@ func (m *ConcatTaskResult) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.After = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfsserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfsserver(dAtA[iNdEx:])
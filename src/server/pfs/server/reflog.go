@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+)
+
+// SetupPostgresReflogV0 creates the table which records the moves of branch
+// heads.
+func SetupPostgresReflogV0(ctx context.Context, tx *pachsql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE pfs.branch_reflog (
+			branch TEXT NOT NULL,
+			num BIGSERIAL NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			principal TEXT NOT NULL,
+			reason INT NOT NULL,
+			old_head TEXT NOT NULL,
+			new_head TEXT NOT NULL,
+			PRIMARY KEY(branch, num)
+		);
+	`)
+	return errors.EnsureStack(err)
+}
+
+type reflogRow struct {
+	CreatedAt time.Time `db:"created_at"`
+	Principal string    `db:"principal"`
+	Reason    int32     `db:"reason"`
+	OldHead   string    `db:"old_head"`
+	NewHead   string    `db:"new_head"`
+}
+
+// logBranchHead appends a move of a branch head to the branch's reflog.
+// Heads are always commits on the branch, so only their IDs are stored.
+func logBranchHead(txnCtx *txncontext.TransactionContext, branch *pfs.Branch, oldHead, newHead *pfs.Commit, reason pfs.ReflogReason) error {
+	if newHead == nil || (oldHead != nil && oldHead.ID == newHead.ID) {
+		return nil
+	}
+	var oldID string
+	if oldHead != nil {
+		oldID = oldHead.ID
+	}
+	var principal string
+	if me, err := txnCtx.WhoAmI(); err == nil {
+		principal = me.Username
+	}
+	ts, err := types.TimestampFromProto(txnCtx.Timestamp)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	_, err = txnCtx.SqlTx.Exec(`INSERT INTO pfs.branch_reflog (branch, created_at, principal, reason, old_head, new_head)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		pfsdb.BranchKey(branch), ts, principal, int32(reason), oldID, newHead.ID)
+	return errors.EnsureStack(err)
+}
+
+// userReason returns the reflog reason for a head move requested by the
+// caller of a transaction, which is a pipeline when its principal is one.
+func userReason(txnCtx *txncontext.TransactionContext) pfs.ReflogReason {
+	if me, err := txnCtx.WhoAmI(); err == nil && strings.HasPrefix(me.Username, auth.PipelinePrefix) {
+		return pfs.ReflogReason_REFLOG_PIPELINE
+	}
+	return pfs.ReflogReason_REFLOG_USER
+}
+
+func deleteReflog(tx *pachsql.Tx, branch *pfs.Branch) error {
+	_, err := tx.Exec(`DELETE FROM pfs.branch_reflog WHERE branch = $1`, pfsdb.BranchKey(branch))
+	return errors.EnsureStack(err)
+}
+
+func getReflog(tx *pachsql.Tx, branch *pfs.Branch, number int64) ([]*pfs.ReflogEntry, error) {
+	query := `SELECT created_at, principal, reason, old_head, new_head FROM pfs.branch_reflog
+		WHERE branch = $1
+		ORDER BY num DESC`
+	args := []interface{}{pfsdb.BranchKey(branch)}
+	if number > 0 {
+		query += ` LIMIT $2`
+		args = append(args, number)
+	}
+	var rows []reflogRow
+	if err := tx.Select(&rows, query, args...); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var entries []*pfs.ReflogEntry
+	for i, row := range rows {
+		ts, err := types.TimestampProto(row.CreatedAt)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		entry := &pfs.ReflogEntry{
+			Branch:    branch,
+			Index:     int64(i),
+			Timestamp: ts,
+			Principal: row.Principal,
+			Reason:    pfs.ReflogReason(row.Reason),
+			NewHead:   branch.NewCommit(row.NewHead),
+		}
+		if row.OldHead != "" {
+			entry.OldHead = branch.NewCommit(row.OldHead)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (d *driver) listReflog(ctx context.Context, branch *pfs.Branch, number int64, cb func(*pfs.ReflogEntry) error) error {
+	if branch == nil || branch.Repo == nil {
+		return errors.New("branch cannot be nil")
+	}
+	if err := d.env.AuthServer.CheckRepoIsAuthorized(ctx, branch.Repo, auth.Permission_REPO_READ); err != nil {
+		return errors.EnsureStack(err)
+	}
+	var entries []*pfs.ReflogEntry
+	if err := d.txnEnv.WithReadContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		var err error
+		entries, err = getReflog(txnCtx.SqlTx, branch, number)
+		return err
+	}); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := cb(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetBranch moves a branch head to the new head of an entry in its reflog.
+// The branch keeps its provenance and trigger, and the reset is itself
+// recorded in the reflog. Authorization is checked by createBranch.
+func (d *driver) resetBranch(txnCtx *txncontext.TransactionContext, branch *pfs.Branch, index int64) error {
+	if branch == nil || branch.Repo == nil {
+		return errors.New("branch cannot be nil")
+	}
+	if index < 0 {
+		return errors.Errorf("reflog index must be non-negative")
+	}
+	entries, err := getReflog(txnCtx.SqlTx, branch, index+1)
+	if err != nil {
+		return err
+	}
+	if int64(len(entries)) <= index {
+		return errors.Errorf("branch %s has only %d reflog entries", branch, len(entries))
+	}
+	branchInfo := &pfs.BranchInfo{}
+	if err := d.branches.ReadWrite(txnCtx.SqlTx).Get(branch, branchInfo); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return d.createBranch(txnCtx, branch, entries[index].NewHead, branchInfo.DirectProvenance, branchInfo.Trigger, pfs.ReflogReason_REFLOG_USER)
+}
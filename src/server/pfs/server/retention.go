@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+)
+
+// updateRetention validates a change of the retention period of a repo,
+// which can be extended but never shortened, and returns the new period.
+func updateRetention(existing, retention *types.Duration) (*types.Duration, error) {
+	if retention == nil {
+		return existing, nil
+	}
+	period, err := types.DurationFromProto(retention)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if period < 0 {
+		return nil, errors.Errorf("retention period cannot be negative")
+	}
+	if existing != nil {
+		existingPeriod, err := types.DurationFromProto(existing)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if period < existingPeriod {
+			return nil, errors.Errorf("retention period cannot be shortened from %v to %v", existingPeriod, period)
+		}
+	}
+	if period == 0 {
+		return nil, nil
+	}
+	return retention, nil
+}
+
+// retentionPeriod returns the retention period of a repo, which is zero if
+// the repo has none or does not exist.
+func (d *driver) retentionPeriod(txnCtx *txncontext.TransactionContext, repo *pfs.Repo) (time.Duration, error) {
+	repoInfo := &pfs.RepoInfo{}
+	if err := d.repos.ReadWrite(txnCtx.SqlTx).Get(repo, repoInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return 0, nil
+		}
+		return 0, errors.EnsureStack(err)
+	}
+	if repoInfo.Retention == nil {
+		return 0, nil
+	}
+	period, err := types.DurationFromProto(repoInfo.Retention)
+	return period, errors.EnsureStack(err)
+}
+
+// checkRetention returns an ErrRetentionLocked error if any of the commits
+// is finished and still within the retention period of its repo. Alias
+// commits are skipped, since their content is retained by the commits they
+// alias.
+func (d *driver) checkRetention(txnCtx *txncontext.TransactionContext, commitInfos []*pfs.CommitInfo) error {
+	periods := make(map[string]time.Duration)
+	now, err := types.TimestampFromProto(txnCtx.Timestamp)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, ci := range commitInfos {
+		if ci.Finished == nil || (ci.Origin != nil && ci.Origin.Kind == pfs.OriginKind_ALIAS) {
+			continue
+		}
+		key := pfsdb.RepoKey(ci.Commit.Branch.Repo)
+		period, ok := periods[key]
+		if !ok {
+			period, err = d.retentionPeriod(txnCtx, ci.Commit.Branch.Repo)
+			if err != nil {
+				return err
+			}
+			periods[key] = period
+		}
+		if period == 0 {
+			continue
+		}
+		finished, err := types.TimestampFromProto(ci.Finished)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if until := finished.Add(period); now.Before(until) {
+			return pfsserver.ErrRetentionLocked{Commit: ci.Commit, Until: until}
+		}
+	}
+	return nil
+}
+
+// checkBranchRetention checks the retention of the commits which were made
+// on a branch, or of all the commits in the repo if branch name is empty.
+func (d *driver) checkBranchRetention(txnCtx *txncontext.TransactionContext, branch *pfs.Branch) error {
+	period, err := d.retentionPeriod(txnCtx, branch.Repo)
+	if err != nil || period == 0 {
+		return err
+	}
+	var commitInfos []*pfs.CommitInfo
+	commitInfo := &pfs.CommitInfo{}
+	if err := d.commits.ReadWrite(txnCtx.SqlTx).GetByIndex(pfsdb.CommitsRepoIndex, pfsdb.RepoKey(branch.Repo), commitInfo, col.DefaultOptions(), func(string) error {
+		if branch.Name == "" || commitInfo.Commit.Branch.Name == branch.Name {
+			commitInfos = append(commitInfos, &pfs.CommitInfo{Commit: commitInfo.Commit, Origin: commitInfo.Origin, Finished: commitInfo.Finished})
+		}
+		return nil
+	}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return d.checkRetention(txnCtx, commitInfos)
+}
+
+// checkCommitRetention checks the retention of a commit which is about to be
+// rewritten, which a locked commit can't be, even with the same content.
+func (d *driver) checkCommitRetention(ctx context.Context, commit *pfs.Commit) error {
+	return d.txnEnv.WithReadContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+		commitInfo := &pfs.CommitInfo{}
+		if err := d.commits.ReadWrite(txnCtx.SqlTx).Get(pfsdb.CommitKey(commit), commitInfo); err != nil {
+			return errors.EnsureStack(err)
+		}
+		return d.checkRetention(txnCtx, []*pfs.CommitInfo{commitInfo})
+	})
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	col "github.com/pachyderm/pachyderm/v2/src/internal/collection"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/transactionenv/txncontext"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsserver "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+)
+
+// sessionCommitsPeriod is how often the pfs master finishes the expired
+// commits of the gateways' write sessions.
+const sessionCommitsPeriod = time.Minute
+
+// SetupPostgresSessionCommitsV0 creates the table of the commits started by
+// the write sessions of the S3 and WebDAV gateways.
+func SetupPostgresSessionCommitsV0(ctx context.Context, tx *pachsql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE pfs.session_commits (
+			commit_id TEXT PRIMARY KEY,
+			expires_at TIMESTAMP NOT NULL
+		);
+	`)
+	return errors.EnsureStack(err)
+}
+
+// finishSessionCommits periodically finishes the commits of write sessions
+// which have expired without being finished by their gateway, such as the
+// commits of the sessions in progress when a gateway was restarted.
+func (d *driver) finishSessionCommits(ctx context.Context) error {
+	ticker := time.NewTicker(sessionCommitsPeriod)
+	defer ticker.Stop()
+	for {
+		var keys []string
+		if err := d.env.DB.SelectContext(ctx, &keys, `SELECT commit_id FROM pfs.session_commits WHERE expires_at < CURRENT_TIMESTAMP`); err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, key := range keys {
+			commitInfo := &pfs.CommitInfo{}
+			err := d.commits.ReadOnly(ctx).Get(key, commitInfo)
+			if err != nil && !col.IsErrNotFound(err) {
+				return errors.EnsureStack(err)
+			}
+			if err == nil && commitInfo.Finishing == nil {
+				err = d.txnEnv.WithWriteContext(ctx, func(txnCtx *txncontext.TransactionContext) error {
+					return d.finishCommit(txnCtx, commitInfo.Commit, "", "", false)
+				})
+				if err != nil && !pfsserver.IsCommitFinishedErr(err) && !pfsserver.IsCommitNotFoundErr(err) {
+					log.Errorf("error finishing commit %v of write session: %v", commitInfo.Commit, err)
+					continue
+				}
+			}
+			if _, err := d.env.DB.ExecContext(ctx, `DELETE FROM pfs.session_commits WHERE commit_id = $1`, key); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		}
+	}
+}
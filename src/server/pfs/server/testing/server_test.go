@@ -6,8 +6,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"math/rand"
+	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -17,6 +19,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	units "github.com/docker/go-units"
@@ -2430,6 +2433,81 @@ func TestPFS(suite *testing.T) {
 		})
 	})
 
+	suite.Run("SubscribeCommitFrom", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		var commits []*pfs.Commit
+		for i := 0; i < 3; i++ {
+			commit, err := env.PachClient.StartCommit(repo, "master")
+			require.NoError(t, err)
+			require.NoError(t, finishCommit(env.PachClient, repo, commit.Branch.Name, commit.ID))
+			commits = append(commits, commit)
+		}
+		// first returns the first commit sent by a subscription from a commit
+		first := func(from *pfs.Commit) (*pfs.Commit, error) {
+			ctx, cancel := context.WithCancel(env.PachClient.Ctx())
+			defer cancel()
+			sub, err := env.PachClient.PfsAPIClient.SubscribeCommit(ctx, &pfs.SubscribeCommitRequest{
+				Repo:   client.NewRepo(repo),
+				Branch: "master",
+				From:   from,
+				State:  pfs.CommitState_STARTED,
+			})
+			if err != nil {
+				return nil, err
+			}
+			ci, err := sub.Recv()
+			if err != nil {
+				return nil, err
+			}
+			return ci.Commit, nil
+		}
+
+		// A `from` with no ID, which `pachctl subscribe commit --new` sends,
+		// only sees the commits after the head of the branch.
+		newCommits := make(chan *pfs.Commit, 1)
+		subErr := make(chan error, 1)
+		go func() {
+			commit, err := first(client.NewCommit(repo, "master", ""))
+			if err != nil {
+				subErr <- err
+				return
+			}
+			newCommits <- commit
+		}()
+		require.NoErrorWithinT(t, 60*time.Second, func() error {
+			for {
+				commit, err := env.PachClient.StartCommit(repo, "master")
+				require.NoError(t, err)
+				require.NoError(t, finishCommit(env.PachClient, repo, commit.Branch.Name, commit.ID))
+				select {
+				case received := <-newCommits:
+					for _, old := range commits {
+						require.NotEqual(t, old.ID, received.ID)
+					}
+					return nil
+				case err := <-subErr:
+					return err
+				case <-time.After(time.Second):
+				}
+			}
+		})
+
+		// Ancestry references are resolved to the commit they name.
+		commit, err := first(client.NewCommit(repo, "master", commits[2].ID+"^"))
+		require.NoError(t, err)
+		require.Equal(t, commits[2].ID, commit.ID)
+		// References which don't resolve are errors, rather than waiting
+		// forever for the commit.
+		_, err = first(client.NewCommit(repo, "master", uuid.NewWithoutDashes()))
+		require.YesError(t, err)
+		_, err = first(client.NewCommit(repo, "master", commits[2].ID+"~10"))
+		require.YesError(t, err)
+	})
+
 	suite.Run("InspectRepoSimple", func(t *testing.T) {
 		t.Parallel()
 		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
@@ -4506,6 +4584,38 @@ func TestPFS(suite *testing.T) {
 		assert.Len(t, walkFile("/"), 7)
 	})
 
+	suite.Run("FS", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		commit, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit, "dir/bar", strings.NewReader("bar")))
+		require.NoError(t, env.PachClient.PutFile(commit, "dir/dir2/buzz", strings.NewReader("buzz")))
+		require.NoError(t, env.PachClient.PutFile(commit, "foo", strings.NewReader("0123456789")))
+		require.NoError(t, finishCommit(env.PachClient, repo, commit.Branch.Name, commit.ID))
+
+		fsys := env.PachClient.FS(commit)
+		require.NoError(t, fstest.TestFS(fsys, "dir/bar", "dir/dir2/buzz", "foo"))
+
+		f, err := fsys.Open("foo")
+		require.NoError(t, err)
+		_, err = f.(io.Seeker).Seek(-4, io.SeekEnd)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(f)
+		require.NoError(t, err)
+		require.Equal(t, "6789", string(data))
+		require.NoError(t, f.Close())
+
+		matches, err := fs.Glob(fsys, "*/b*")
+		require.NoError(t, err)
+		require.ElementsEqual(t, []string{"dir/bar"}, matches)
+		_, err = fs.Stat(fsys, "baz")
+		require.True(t, errors.Is(err, fs.ErrNotExist))
+	})
+
 	suite.Run("WalkFileEmpty", func(t *testing.T) {
 		t.Parallel()
 		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
@@ -4656,6 +4766,66 @@ func TestPFS(suite *testing.T) {
 		check()
 	})
 
+	suite.Run("MaterializeByReference", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+
+		repo := "repo"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		objC := dockertestenv.NewTestObjClient(t)
+		paths := []string{"files/foo", "files/bar"}
+		for _, path := range paths {
+			writeObj(t, objC, path, path)
+		}
+		bucketURL := objC.BucketURL().String()
+		var commits []*pfs.Commit
+		for _, p := range paths {
+			commit, err := env.PachClient.StartCommit(repo, "master")
+			require.NoError(t, err)
+			require.NoError(t, env.PachClient.PutFileURL(commit, p, bucketURL+"/"+p, false, client.WithByReferencePutFile()))
+			require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+			commits = append(commits, commit)
+		}
+		getFile := func(commit *pfs.Commit, path string) (string, error) {
+			var b bytes.Buffer
+			err := env.PachClient.GetFile(commit, path, &b)
+			return b.String(), err
+		}
+		content, err := getFile(commits[0], paths[0])
+		require.NoError(t, err)
+		require.Equal(t, paths[0], content)
+
+		// The files are read through until the commits are materialized, and
+		// their hashes change when they are.
+		fileInfo, err := env.PachClient.InspectFile(commits[1], paths[1])
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.MaterializeCommit(repo, "master", commits[0].ID))
+		require.NoError(t, env.PachClient.MaterializeCommitInBackground(repo, "master", commits[1].ID))
+		require.NoErrorWithinTRetry(t, time.Minute, func() error {
+			materialized, err := env.PachClient.InspectFile(commits[1], paths[1])
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(fileInfo.Hash, materialized.Hash) {
+				return errors.Errorf("commit %v is not materialized", commits[1].ID)
+			}
+			return nil
+		})
+		for _, path := range paths {
+			writeObj(t, objC, path, "changed")
+		}
+		for i, path := range paths {
+			content, err := getFile(commits[i], path)
+			require.NoError(t, err)
+			require.Equal(t, path, content)
+		}
+		resps, err := env.PachClient.VerifyCommit(repo, "master", commits[1].ID, true)
+		require.NoError(t, err)
+		for _, resp := range resps {
+			require.Equal(t, "", resp.Error)
+		}
+	})
+
 	suite.Run("GetFilesObjURL", func(t *testing.T) {
 		t.Parallel()
 		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
@@ -4973,6 +5143,51 @@ func TestPFS(suite *testing.T) {
 		}
 	})
 
+	suite.Run("FsckDeep", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "input"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		for i := 0; i < 3; i++ {
+			require.NoError(t, env.PachClient.PutFile(client.NewCommit(repo, "master", ""), fmt.Sprintf("file%d", i), strings.NewReader("foo")))
+		}
+		for _, readChunkData := range []bool{false, true} {
+			require.NoError(t, env.PachClient.FsckDeep(false, readChunkData, false, func(resp *pfs.FsckResponse) error {
+				return errors.Errorf("unexpected fsck error: %v", resp.Error)
+			}))
+		}
+		// Resuming a finished check checks everything again.
+		require.NoError(t, env.PachClient.FsckDeep(false, false, true, func(resp *pfs.FsckResponse) error {
+			return errors.Errorf("unexpected fsck error: %v", resp.Error)
+		}))
+		// Corrupt chunks are found by reading them, and missing chunks are
+		// found either way.
+		fsckErrors := func(readChunkData bool) int {
+			var n int
+			require.NoError(t, env.PachClient.FsckDeep(false, readChunkData, false, func(resp *pfs.FsckResponse) error {
+				require.NotEqual(t, "", resp.Error)
+				n++
+				return nil
+			}))
+			return n
+		}
+		chunkDir := filepath.Join(env.Directory, "localStorage", "chunk")
+		forEachChunk := func(f func(string) error) {
+			require.NoError(t, filepath.Walk(chunkDir, func(p string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				return f(p)
+			}))
+		}
+		forEachChunk(func(p string) error {
+			return ioutil.WriteFile(p, []byte("corrupt"), 0644)
+		})
+		require.True(t, fsckErrors(true) > 0)
+		forEachChunk(os.Remove)
+		require.True(t, fsckErrors(false) > 0)
+	})
+
 	suite.Run("FsckFix", func(t *testing.T) {
 		// TODO(optional 2.0): force-deleting the repo no longer creates dangling references
 		t.Skip("this test no longer creates invalid metadata")
@@ -6136,6 +6351,487 @@ func TestPFS(suite *testing.T) {
 		require.True(t, errutil.IsNotFoundError(err))
 		require.False(t, strings.Contains(err.Error(), pfs.UserRepoType))
 	})
+
+	suite.Run("DiskUsage", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		commit, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit, "/a/b/1", strings.NewReader("foo")))
+		require.NoError(t, env.PachClient.PutFile(commit, "/a/c", strings.NewReader("ba")))
+		require.NoError(t, env.PachClient.PutFile(commit, "/d", strings.NewReader("z")))
+		require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+		diskUsage := func(path string, depth int64) []*pfs.DiskUsageInfo {
+			var infos []*pfs.DiskUsageInfo
+			require.NoError(t, env.PachClient.DiskUsage(commit, path, depth, func(info *pfs.DiskUsageInfo) error {
+				infos = append(infos, info)
+				return nil
+			}))
+			return infos
+		}
+		infos := diskUsage("/", 1)
+		require.Equal(t, 2, len(infos))
+		require.Equal(t, "/", infos[0].Path)
+		require.Equal(t, int64(6), infos[0].SizeBytes)
+		require.Equal(t, int64(3), infos[0].FileCount)
+		require.Equal(t, "/a/", infos[1].Path)
+		require.Equal(t, int64(5), infos[1].SizeBytes)
+		require.Equal(t, int64(2), infos[1].FileCount)
+		infos = diskUsage("/a", 2)
+		require.Equal(t, 2, len(infos))
+		require.Equal(t, "/a/", infos[0].Path)
+		require.Equal(t, "/a/b/", infos[1].Path)
+		require.Equal(t, int64(3), infos[1].SizeBytes)
+		infos = diskUsage("/d", 1)
+		require.Equal(t, 1, len(infos))
+		require.Equal(t, "/d", infos[0].Path)
+		require.Equal(t, int64(1), infos[0].SizeBytes)
+		require.YesError(t, env.PachClient.DiskUsage(commit, "/missing", 1, func(*pfs.DiskUsageInfo) error { return nil }))
+
+		// An empty commit on top of the first one doesn't add any chunks.
+		commit2, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		require.NoError(t, finishCommit(env.PachClient, repo, "master", commit2.ID))
+		resp, err := env.PachClient.RepoDiskUsage(repo)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), resp.CommitCount)
+		require.Equal(t, int64(12), resp.LogicalSizeBytes)
+		require.True(t, resp.ChunkCount > 0)
+		require.True(t, resp.UniqueSizeBytes > 0)
+	})
+
+	suite.Run("ChangeSummary", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		commit1, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit1, "/a", strings.NewReader("foo")))
+		require.NoError(t, env.PachClient.PutFile(commit1, "/b", strings.NewReader("bar")))
+		require.NoError(t, env.PachClient.PutFile(commit1, "/dir/c", strings.NewReader("baz")))
+		require.NoError(t, finishCommit(env.PachClient, repo, "master", commit1.ID))
+		commitInfo, err := env.PachClient.InspectCommit(repo, "master", commit1.ID)
+		require.NoError(t, err)
+		require.Equal(t, &pfs.ChangeSummary{FilesAdded: 3, BytesAdded: 9}, commitInfo.Details.ChangeSummary)
+
+		commit2, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit2, "/a", strings.NewReader("foobar")))
+		require.NoError(t, env.PachClient.DeleteFile(commit2, "/dir/c"))
+		require.NoError(t, env.PachClient.PutFile(commit2, "/d", strings.NewReader("q")))
+		require.NoError(t, finishCommit(env.PachClient, repo, "master", commit2.ID))
+		commitInfo, err = env.PachClient.InspectCommit(repo, "master", commit2.ID)
+		require.NoError(t, err)
+		require.Equal(t, &pfs.ChangeSummary{
+			FilesAdded:    1,
+			FilesModified: 1,
+			FilesDeleted:  1,
+			BytesAdded:    4,
+			BytesRemoved:  3,
+		}, commitInfo.Details.ChangeSummary)
+		commitInfos, err := env.PachClient.ListCommit(client.NewRepo(repo), nil, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(commitInfos))
+		require.Equal(t, int64(1), commitInfos[0].Details.ChangeSummary.FilesDeleted)
+	})
+
+	suite.Run("SubscribeFile", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		var commits []*pfs.Commit
+		for _, p := range []string{"/a/x", "/b/y", "/a/z"} {
+			commit, err := env.PachClient.StartCommit(repo, "master")
+			require.NoError(t, err)
+			require.NoError(t, env.PachClient.PutFile(commit, p, strings.NewReader("foo")))
+			require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+			commits = append(commits, commit)
+		}
+		branch := client.NewBranch(repo, "master")
+		var events []*pfs.SubscribeFileEvent
+		require.NoError(t, env.PachClient.SubscribeFile(branch, "/a/*", nil, func(event *pfs.SubscribeFileEvent) error {
+			events = append(events, event)
+			if len(events) == 2 {
+				return errutil.ErrBreak
+			}
+			return nil
+		}))
+		require.Equal(t, commits[0].ID, events[0].CommitInfo.Commit.ID)
+		require.Equal(t, 1, len(events[0].Changes))
+		require.Equal(t, "/a/x", events[0].Changes[0].NewFile.File.Path)
+		require.Nil(t, events[0].Changes[0].OldFile)
+		require.Equal(t, commits[2].ID, events[1].CommitInfo.Commit.ID)
+		require.Equal(t, "/a/z", events[1].Changes[0].NewFile.File.Path)
+
+		// Resume after the first event.
+		require.NoError(t, env.PachClient.SubscribeFile(branch, "/a/*", events[0].CommitInfo.Commit, func(event *pfs.SubscribeFileEvent) error {
+			require.Equal(t, commits[2].ID, event.CommitInfo.Commit.ID)
+			return errutil.ErrBreak
+		}))
+
+		// A commit on another branch can't be resumed from.
+		require.NoError(t, env.PachClient.CreateBranch(repo, "other", "master", "", nil))
+		require.YesError(t, env.PachClient.SubscribeFile(branch, "/a/*", client.NewCommit(repo, "other", ""), func(event *pfs.SubscribeFileEvent) error {
+			return errutil.ErrBreak
+		}))
+	})
+
+	suite.Run("RetentionLock", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "worm"
+		require.NoError(t, env.PachClient.CreateRepoWithRetention(repo, time.Hour))
+		var commits []*pfs.Commit
+		for i := 0; i < 2; i++ {
+			commit, err := env.PachClient.StartCommit(repo, "master")
+			require.NoError(t, err)
+			require.NoError(t, env.PachClient.PutFile(commit, "/file", strings.NewReader(fmt.Sprint(i))))
+			require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+			commits = append(commits, commit)
+		}
+		err := env.PachClient.SquashCommitSet(commits[0].ID)
+		require.True(t, pfsserver.IsRetentionLockedErr(err), err)
+		err = env.PachClient.DropCommitSet(commits[1].ID)
+		require.True(t, pfsserver.IsRetentionLockedErr(err), err)
+		err = env.PachClient.DeleteBranch(repo, "master", true)
+		require.True(t, pfsserver.IsRetentionLockedErr(err), err)
+		err = env.PachClient.DeleteRepo(repo, true)
+		require.True(t, pfsserver.IsRetentionLockedErr(err), err)
+		_, err = env.PachClient.PfsAPIClient.DeleteAll(env.PachClient.Ctx(), &types.Empty{})
+		require.True(t, pfsserver.IsRetentionLockedErr(err), err)
+		err = env.PachClient.CompactCommit(repo, "master", commits[1].ID)
+		require.True(t, pfsserver.IsRetentionLockedErr(err), err)
+		err = env.PachClient.MaterializeCommit(repo, "master", commits[1].ID)
+		require.True(t, pfsserver.IsRetentionLockedErr(err), err)
+
+		// A branch without locked commits can be deleted, including one whose
+		// commits alias locked commits.
+		require.NoError(t, env.PachClient.CreateBranch(repo, "other", "master", "", nil))
+		require.NoError(t, env.PachClient.DeleteBranch(repo, "other", false))
+
+		// The retention period can be extended but not shortened.
+		require.YesError(t, env.PachClient.ExtendRepoRetention(repo, time.Minute))
+		require.NoError(t, env.PachClient.ExtendRepoRetention(repo, 2*time.Hour))
+		repoInfo, err := env.PachClient.InspectRepo(repo)
+		require.NoError(t, err)
+		require.Equal(t, types.DurationProto(2*time.Hour), repoInfo.Retention)
+
+		// The repo can be deleted once the retention period has expired.
+		short := "short"
+		require.NoError(t, env.PachClient.CreateRepoWithRetention(short, time.Second))
+		commit, err := env.PachClient.StartCommit(short, "master")
+		require.NoError(t, err)
+		require.NoError(t, finishCommit(env.PachClient, short, "master", commit.ID))
+		time.Sleep(2 * time.Second)
+		require.NoError(t, env.PachClient.DeleteRepo(short, false))
+	})
+
+	suite.Run("CommitHash", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		var commitInfos []*pfs.CommitInfo
+		for i := 0; i < 2; i++ {
+			commit, err := env.PachClient.StartCommit(repo, "master")
+			require.NoError(t, err)
+			require.NoError(t, env.PachClient.PutFile(commit, fmt.Sprintf("/dir/file%d", i), strings.NewReader(fmt.Sprint(i))))
+			require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+			commitInfo, err := env.PachClient.WaitCommit(repo, "master", commit.ID)
+			require.NoError(t, err)
+			require.NotNil(t, commitInfo.Hash)
+			commitInfos = append(commitInfos, commitInfo)
+		}
+		require.Equal(t, commitInfos[0].Hash.Root, commitInfos[1].Hash.Parent)
+		require.Equal(t, pfs.ChainHash(commitInfos[1].Hash.Parent, commitInfos[1].Hash.Content), commitInfos[1].Hash.Root)
+
+		resps, err := env.PachClient.VerifyCommit(repo, "master", "", true)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(resps))
+		for _, resp := range resps {
+			require.Equal(t, "", resp.Error)
+			require.Equal(t, resp.Hash.Root, resp.Computed.Root)
+		}
+
+		attestation, err := env.PachClient.AttestCommit(repo, "master", "")
+		require.NoError(t, err)
+		require.Equal(t, commitInfos[1].Hash.Root, attestation.Hash.Root)
+		require.NoError(t, attestation.Verify())
+		attestation.Hash.Content = commitInfos[0].Hash.Content
+		attestation.Hash.Root = pfs.ChainHash(attestation.Hash.Parent, attestation.Hash.Content)
+		require.YesError(t, attestation.Verify())
+	})
+
+	suite.Run("CommitHashRewrite", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		var commits []*pfs.Commit
+		for i := 0; i < 3; i++ {
+			commit, err := env.PachClient.StartCommit(repo, "master")
+			require.NoError(t, err)
+			require.NoError(t, env.PachClient.PutFile(commit, fmt.Sprintf("/file%d", i), strings.NewReader(fmt.Sprint(i))))
+			require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+			commits = append(commits, commit)
+		}
+		verify := func() {
+			resps, err := env.PachClient.VerifyCommit(repo, "master", "", true)
+			require.NoError(t, err)
+			for _, resp := range resps {
+				require.Equal(t, "", resp.Error)
+				require.Equal(t, resp.Hash.Root, resp.Computed.Root)
+			}
+		}
+		// Materializing and compacting rewrite the hashes of the files.
+		require.NoError(t, env.PachClient.MaterializeCommit(repo, "master", commits[1].ID))
+		verify()
+		require.NoError(t, env.PachClient.CompactCommit(repo, "master", commits[1].ID))
+		verify()
+		// Squashing a commit re-points its child at its parent.
+		require.NoError(t, env.PachClient.SquashCommitSet(commits[1].ID))
+		verify()
+		commitInfo, err := env.PachClient.InspectCommit(repo, "master", commits[2].ID)
+		require.NoError(t, err)
+		parentInfo, err := env.PachClient.InspectCommit(repo, "master", commits[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, parentInfo.Hash.Root, commitInfo.Hash.Parent)
+	})
+
+	suite.Run("BranchReflog", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		var commits []*pfs.Commit
+		for i := 0; i < 2; i++ {
+			commit, err := env.PachClient.StartCommit(repo, "master")
+			require.NoError(t, err)
+			require.NoError(t, env.PachClient.PutFile(commit, "/file", strings.NewReader(fmt.Sprint(i))))
+			require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+			commits = append(commits, commit)
+		}
+		entries, err := env.PachClient.ListReflog(repo, "master", 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(entries))
+		require.Equal(t, int64(0), entries[0].Index)
+		require.Equal(t, pfs.ReflogReason_REFLOG_USER, entries[0].Reason)
+		require.Equal(t, commits[0].ID, entries[0].OldHead.ID)
+		require.Equal(t, commits[1].ID, entries[0].NewHead.ID)
+		require.Nil(t, entries[1].OldHead)
+
+		// Undo the last commit.
+		require.NoError(t, env.PachClient.ResetBranch(repo, "master", 1))
+		var buf bytes.Buffer
+		require.NoError(t, env.PachClient.GetFile(client.NewCommit(repo, "master", ""), "/file", &buf))
+		require.Equal(t, "0", buf.String())
+		entries, err = env.PachClient.ListReflog(repo, "master", 1)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(entries))
+		require.Equal(t, commits[1].ID, entries[0].OldHead.ID)
+		require.YesError(t, env.PachClient.ResetBranch(repo, "master", 10))
+
+		// Triggered head moves are recorded with the trigger reason.
+		require.NoError(t, env.PachClient.CreateBranchTrigger(repo, "staging", "", "", &pfs.Trigger{Branch: "master"}))
+		commit, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+		_, err = env.PachClient.WaitCommit(repo, "staging", commit.ID)
+		require.NoError(t, err)
+		entries, err = env.PachClient.ListReflog(repo, "staging", 1)
+		require.NoError(t, err)
+		require.Equal(t, pfs.ReflogReason_REFLOG_TRIGGER, entries[0].Reason)
+	})
+
+	suite.Run("Pagination", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		commit, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			require.NoError(t, env.PachClient.PutFile(commit, fmt.Sprintf("/f%d", i), strings.NewReader(strings.Repeat("a", 5-i))))
+		}
+		require.NoError(t, env.PachClient.PutFile(commit, "/dir/a", strings.NewReader("foo")))
+		require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+
+		listFile := func(req *pfs.ListFileRequest) ([]string, string) {
+			var paths []string
+			token, err := env.PachClient.ListFilePage(req, func(fi *pfs.FileInfo) error {
+				paths = append(paths, fi.File.Path)
+				return nil
+			})
+			require.NoError(t, err)
+			return paths, token
+		}
+		var paths []string
+		var token string
+		for {
+			page, next := listFile(&pfs.ListFileRequest{File: commit.NewFile("/"), PageSize: 2, PageToken: token})
+			require.True(t, len(page) <= 2)
+			paths = append(paths, page...)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		require.Equal(t, []string{"/dir/", "/f0", "/f1", "/f2", "/f3", "/f4"}, paths)
+		paths, _ = listFile(&pfs.ListFileRequest{File: commit.NewFile("/"), StartAfter: "/dir/"})
+		require.Equal(t, []string{"/f0", "/f1", "/f2", "/f3", "/f4"}, paths)
+		paths, token = listFile(&pfs.ListFileRequest{File: commit.NewFile("/"), Sort: pfs.FileSort_FILE_SORT_SIZE, PageSize: 3})
+		require.Equal(t, []string{"/f4", "/f3", "/dir/"}, paths)
+		paths, token = listFile(&pfs.ListFileRequest{File: commit.NewFile("/"), Sort: pfs.FileSort_FILE_SORT_SIZE, PageSize: 3, PageToken: token})
+		require.Equal(t, []string{"/f2", "/f1", "/f0"}, paths)
+		require.Equal(t, "", token)
+
+		var globbed []string
+		token, err = env.PachClient.GlobFilePage(&pfs.GlobFileRequest{Commit: commit, Pattern: "/f*", PageSize: 3}, func(fi *pfs.FileInfo) error {
+			globbed = append(globbed, fi.File.Path)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"/f0", "/f1", "/f2"}, globbed)
+		require.NotEqual(t, "", token)
+
+		for i := 0; i < 2; i++ {
+			commit, err := env.PachClient.StartCommit(repo, "master")
+			require.NoError(t, err)
+			require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+		}
+		var commits []string
+		token = ""
+		for {
+			next, err := env.PachClient.ListCommitPage(&pfs.ListCommitRequest{Repo: client.NewRepo(repo), PageSize: 1, PageToken: token}, func(ci *pfs.CommitInfo) error {
+				commits = append(commits, ci.Commit.ID)
+				return nil
+			})
+			require.NoError(t, err)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		require.Equal(t, 3, len(commits))
+		require.Equal(t, commit.ID, commits[2])
+		// the number of commits is across all of the pages
+		var lastToken string
+		token, err = env.PachClient.ListCommitPage(&pfs.ListCommitRequest{Repo: client.NewRepo(repo), Number: 2, PageSize: 1}, func(ci *pfs.CommitInfo) error {
+			lastToken = ci.NextPageToken
+			return nil
+		})
+		require.NoError(t, err)
+		require.NotEqual(t, "", token)
+		require.Equal(t, token, lastToken)
+		var numbered int
+		token, err = env.PachClient.ListCommitPage(&pfs.ListCommitRequest{Repo: client.NewRepo(repo), Number: 2, PageSize: 1, PageToken: token}, func(ci *pfs.CommitInfo) error {
+			numbered++
+			require.Equal(t, "", ci.NextPageToken)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, numbered)
+		require.Equal(t, "", token)
+
+		commit, err = env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit, "/f0", strings.NewReader("b")))
+		require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+		commit, err = env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		require.NoError(t, env.PachClient.PutFile(commit, "/f5", strings.NewReader("c")))
+		require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+		paths, token = listFile(&pfs.ListFileRequest{File: commit.NewFile("/"), Sort: pfs.FileSort_FILE_SORT_COMMITTED, PageSize: 4})
+		require.Equal(t, []string{"/dir/", "/f1", "/f2", "/f3"}, paths)
+		paths, token = listFile(&pfs.ListFileRequest{File: commit.NewFile("/"), Sort: pfs.FileSort_FILE_SORT_COMMITTED, PageSize: 4, PageToken: token})
+		require.Equal(t, []string{"/f4", "/f0", "/f5"}, paths)
+		require.Equal(t, "", token)
+		paths, _ = listFile(&pfs.ListFileRequest{File: commit.NewFile("/"), Sort: pfs.FileSort_FILE_SORT_COMMITTED, Reverse: true, PageSize: 2})
+		require.Equal(t, []string{"/f5", "/f0"}, paths)
+	})
+
+	suite.Run("CompactCommit", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		commit, err := env.PachClient.StartCommit(repo, "master")
+		require.NoError(t, err)
+		numFiles := 10
+		for i := 0; i < numFiles; i++ {
+			require.NoError(t, env.PachClient.PutFile(commit, fmt.Sprintf("file%d", i), strings.NewReader(fmt.Sprintf("data%d", i))))
+		}
+		inspectDetails := func() *pfs.CommitInfo_Details {
+			commitInfo, err := env.PachClient.PfsAPIClient.InspectCommit(env.PachClient.Ctx(), &pfs.InspectCommitRequest{
+				Commit:  commit,
+				Details: true,
+			})
+			require.NoError(t, err)
+			return commitInfo.Details
+		}
+		details := inspectDetails()
+		require.True(t, details.NumLayers >= int64(numFiles))
+		require.True(t, details.ReadAmplification >= int64(numFiles))
+		require.True(t, details.IndexSizeBytes > 0)
+		require.NoError(t, env.PachClient.CompactCommit(repo, "master", commit.ID))
+		details = inspectDetails()
+		require.Equal(t, int64(1), details.NumLayers)
+		checkFiles := func() {
+			for i := 0; i < numFiles; i++ {
+				buf := &bytes.Buffer{}
+				require.NoError(t, env.PachClient.GetFile(commit, fmt.Sprintf("file%d", i), buf))
+				require.Equal(t, fmt.Sprintf("data%d", i), buf.String())
+			}
+		}
+		checkFiles()
+		require.NoError(t, finishCommit(env.PachClient, repo, "master", commit.ID))
+		require.NoError(t, env.PachClient.CompactCommit(repo, "master", ""))
+		require.Equal(t, int64(1), inspectDetails().NumLayers)
+		checkFiles()
+	})
+
+	suite.Run("FileMetadata", func(t *testing.T) {
+		t.Parallel()
+		env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+		repo := "test"
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		master := client.NewCommit(repo, "master", "")
+		checkMetadata := func(expected map[string]string) {
+			fileInfo, err := env.PachClient.InspectFile(master, "file")
+			require.NoError(t, err)
+			require.Equal(t, expected, fileInfo.Metadata)
+			fileInfos, err := env.PachClient.ListFileAll(master, "")
+			require.NoError(t, err)
+			require.Equal(t, 1, len(fileInfos))
+			require.Equal(t, expected, fileInfos[0].Metadata)
+		}
+		require.NoError(t, env.PachClient.PutFile(master, "file", strings.NewReader("foo")))
+		hash := func() []byte {
+			fileInfo, err := env.PachClient.InspectFile(master, "file")
+			require.NoError(t, err)
+			return fileInfo.Hash
+		}
+		before := hash()
+		require.NoError(t, env.PachClient.SetFileMetadata(master, "file", map[string]string{"a": "1", "b": "2"}))
+		checkMetadata(map[string]string{"a": "1", "b": "2"})
+		// Metadata doesn't change the content of the file.
+		require.Equal(t, before, hash())
+		// Appending keeps the metadata, setting it replaces it.
+		require.NoError(t, env.PachClient.PutFile(master, "file", strings.NewReader("bar"), client.WithAppendPutFile()))
+		checkMetadata(map[string]string{"a": "1", "b": "2"})
+		require.NoError(t, env.PachClient.SetFileMetadata(master, "file", map[string]string{"c": "3"}))
+		checkMetadata(map[string]string{"c": "3"})
+		buf := &bytes.Buffer{}
+		require.NoError(t, env.PachClient.GetFile(master, "file", buf))
+		require.Equal(t, "foobar", buf.String())
+		// Overwriting the file drops its metadata.
+		require.NoError(t, env.PachClient.PutFile(master, "file", strings.NewReader("baz")))
+		checkMetadata(nil)
+	})
 }
 
 var (
@@ -63,7 +63,7 @@ func (d *driver) triggerCommit(
 				}
 
 				if triggered {
-					aliasCommit, err := d.aliasCommit(txnCtx, newHead.Commit, bi.Branch)
+					aliasCommit, err := d.aliasCommit(txnCtx, newHead.Commit, bi.Branch, pfs.ReflogReason_REFLOG_TRIGGER)
 					if err != nil {
 						return nil, err
 					}
@@ -0,0 +1,163 @@
+package webdav
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pfsdb"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsServer "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+	"github.com/sirupsen/logrus"
+)
+
+// batchCommitRecoveryDelay is how long after a batch's window the pfs master
+// finishes its commit, if the gateway hasn't, so that only the commits of
+// batches lost to a restart are finished by the master.
+const batchCommitRecoveryDelay = 10 * time.Minute
+
+// writeBatches accumulates the writes each client makes to a branch into one
+// open commit, rather than one commit per write, the way a FUSE mount in
+// Write mode makes one commit per branch when it is unmounted. A batch's
+// commit is finished once the client has made no writes to the branch for
+// the batch window.
+//
+// A batch can only start a commit on a branch whose head is finished. If the
+// head is open, such as when another client's batch has started a commit on
+// the branch, the batch's writes are made to the branch, which lands them in
+// the open head.
+//
+// The commits started by batches are recorded in postgres, so that the pfs
+// master finishes the commits of the batches lost to a restart.
+type writeBatches struct {
+	logger        *logrus.Entry
+	clientFactory ClientFactory
+	db            *pachsql.DB
+	window        time.Duration
+
+	mu      sync.Mutex
+	batches map[string]*writeBatch
+}
+
+type writeBatch struct {
+	key string
+	// token is the auth token the batch's commit is finished with
+	token string
+	// commit is the commit the batch started, if it has started one
+	commit *pfs.Commit
+	// inflight counts the writes in progress, a batch isn't finished while
+	// it has writes in progress
+	inflight int
+	timer    *time.Timer
+}
+
+func newWriteBatches(logger *logrus.Entry, clientFactory ClientFactory, db *pachsql.DB, window time.Duration) *writeBatches {
+	return &writeBatches{
+		logger:        logger,
+		clientFactory: clientFactory,
+		db:            db,
+		window:        window,
+		batches:       make(map[string]*writeBatch),
+	}
+}
+
+// commit returns the commit a client with the auth token `token` writes to a
+// branch with. The returned function must be called once the write is done.
+func (b *writeBatches) commit(pc *client.APIClient, token string, branch *pfs.Branch) (*pfs.Commit, func(), error) {
+	if b.window == 0 {
+		return branch.NewCommit(""), func() {}, nil
+	}
+	key := token + "/" + pfsdb.BranchKey(branch)
+	b.mu.Lock()
+	batch, ok := b.batches[key]
+	if !ok {
+		batch = &writeBatch{key: key, token: token}
+		batch.timer = time.AfterFunc(b.window, func() { b.expire(batch) })
+		b.batches[key] = batch
+	}
+	// The write is counted as in progress before the lock is released, so
+	// that the batch isn't finished while its commit is being started.
+	batch.inflight++
+	batch.timer.Reset(b.window)
+	commit := batch.commit
+	b.mu.Unlock()
+	done := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		batch.inflight--
+	}
+	if commit == nil {
+		// The batch only writes to a commit it starts, so that it can't
+		// finish an open commit it doesn't own, such as the output commit of
+		// a running job.
+		var err error
+		commit, err = b.startCommit(pc, batch, branch)
+		if err != nil {
+			done()
+			return nil, nil, err
+		}
+		if commit == nil {
+			return branch.NewCommit(""), done, nil
+		}
+	}
+	if err := pfsdb.PutSessionCommit(pc.Ctx(), b.db, commit, b.window+batchCommitRecoveryDelay); err != nil {
+		done()
+		return nil, nil, err
+	}
+	return commit, done, nil
+}
+
+// startCommit starts the commit of a batch, or returns the commit a
+// concurrent write of the batch started. It returns a nil commit if the
+// branch's head is an open commit the batch didn't start.
+func (b *writeBatches) startCommit(pc *client.APIClient, batch *writeBatch, branch *pfs.Branch) (*pfs.Commit, error) {
+	commit, err := pc.StartCommit(branch.Repo.Name, branch.Name)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		if pfsServer.IsParentCommitNotFinishedErr(err) {
+			return batch.commit, nil
+		}
+		return nil, err
+	}
+	batch.commit = commit
+	return commit, nil
+}
+
+// expire finishes a batch which has been idle for the batch window.
+func (b *writeBatches) expire(batch *writeBatch) {
+	b.mu.Lock()
+	if b.batches[batch.key] != batch {
+		b.mu.Unlock()
+		return
+	}
+	if batch.inflight > 0 {
+		batch.timer.Reset(b.window)
+		b.mu.Unlock()
+		return
+	}
+	delete(b.batches, batch.key)
+	b.mu.Unlock()
+	if batch.commit == nil {
+		return
+	}
+	pc, err := b.clientFactory()
+	if err != nil {
+		b.logger.Errorf("could not create a pach client to finish write batch: %v", err)
+		return
+	}
+	pc = pc.WithCtx(context.Background())
+	if batch.token != "" {
+		pc.SetAuthToken(batch.token)
+	}
+	if err := pc.FinishCommit(batch.commit.Branch.Repo.Name, batch.commit.Branch.Name, batch.commit.ID); err != nil && !pfsServer.IsCommitFinishedErr(err) {
+		// the commit is left to the pfs master to finish
+		b.logger.Errorf("could not finish commit %s of write batch: %v", batch.commit, err)
+		return
+	}
+	if err := pfsdb.DeleteSessionCommit(pc.Ctx(), b.db, batch.commit); err != nil {
+		b.logger.Errorf("could not delete the record of commit %s of write batch: %v", batch.commit, err)
+	}
+}
@@ -0,0 +1,336 @@
+package webdav
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/grpcutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	"golang.org/x/net/webdav"
+)
+
+// fileInfo is the os.FileInfo of the folder hierarchy. It implements
+// webdav.ETager and webdav.ContentTyper, so that listing a directory doesn't
+// read its files.
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+	hash    string
+}
+
+func newFileInfo(fi *pfs.FileInfo) *fileInfo {
+	info := &fileInfo{
+		name: path.Base(fi.File.Path),
+		size: fi.SizeBytes,
+		dir:  fi.FileType == pfs.FileType_DIR,
+		hash: fmt.Sprintf("%x", fi.Hash),
+	}
+	if fi.Committed != nil {
+		info.modTime, _ = types.TimestampFromProto(fi.Committed)
+	}
+	return info
+}
+
+func dirInfo(name string, modTime *types.Timestamp) *fileInfo {
+	info := &fileInfo{name: name, dir: true}
+	if modTime != nil {
+		info.modTime, _ = types.TimestampFromProto(modTime)
+	}
+	return info
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *fileInfo) IsDir() bool        { return fi.dir }
+func (fi *fileInfo) Sys() interface{}   { return nil }
+
+func (fi *fileInfo) Mode() os.FileMode {
+	if fi.dir {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
+
+func (fi *fileInfo) ETag(ctx context.Context) (string, error) {
+	if fi.hash == "" {
+		return "", webdav.ErrNotImplemented
+	}
+	return fmt.Sprintf("%q", fi.hash), nil
+}
+
+func (fi *fileInfo) ContentType(ctx context.Context) (string, error) {
+	if contentType := mime.TypeByExtension(path.Ext(fi.name)); contentType != "" {
+		return contentType, nil
+	}
+	return "application/octet-stream", nil
+}
+
+// dirFile is a directory of the folder hierarchy, which is listed when it is
+// first read.
+type dirFile struct {
+	fs      *filesystem
+	ctx     context.Context
+	name    string
+	loc     *location
+	info    *fileInfo
+	entries []os.FileInfo
+	listed  bool
+}
+
+func (f *dirFile) Readdir(count int) ([]os.FileInfo, error) {
+	if !f.listed {
+		entries, err := f.list()
+		if err != nil {
+			return nil, err
+		}
+		f.entries = entries
+		f.listed = true
+	}
+	if count <= 0 {
+		entries := f.entries
+		f.entries = nil
+		return entries, nil
+	}
+	if len(f.entries) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(f.entries) {
+		count = len(f.entries)
+	}
+	entries := f.entries[:count]
+	f.entries = f.entries[count:]
+	return entries, nil
+}
+
+func (f *dirFile) list() ([]os.FileInfo, error) {
+	pc := requestFrom(f.ctx).pc
+	var entries []os.FileInfo
+	switch f.loc.depth {
+	case 0:
+		repoInfos, err := pc.ListRepo()
+		if err != nil {
+			return nil, fsError("readdir", f.name, err)
+		}
+		for _, repoInfo := range repoInfos {
+			entries = append(entries, dirInfo(repoInfo.Repo.Name, repoInfo.Created))
+		}
+	case 1:
+		entries = append(entries, dirInfo(branchesDir, nil), dirInfo(commitsDir, nil))
+	case 2:
+		if f.loc.kind == branchesDir {
+			branchInfos, err := pc.ListBranch(f.loc.repo)
+			if err != nil {
+				return nil, fsError("readdir", f.name, err)
+			}
+			for _, branchInfo := range branchInfos {
+				entries = append(entries, dirInfo(branchInfo.Branch.Name, nil))
+			}
+		} else {
+			commitInfos, err := pc.ListCommitByRepo(client.NewRepo(f.loc.repo))
+			if err != nil {
+				return nil, fsError("readdir", f.name, err)
+			}
+			for _, commitInfo := range commitInfos {
+				entries = append(entries, dirInfo(commitInfo.Commit.ID, commitInfo.Started))
+			}
+		}
+	default:
+		p := f.loc.path
+		if p == "" {
+			p = "/"
+		}
+		seen := make(map[string]bool)
+		if err := pc.ListFile(f.loc.commit(), p, func(fi *pfs.FileInfo) error {
+			info := newFileInfo(fi)
+			seen[info.name] = true
+			entries = append(entries, info)
+			return nil
+		}); err != nil {
+			// A branch without a head commit, or a directory which was only
+			// made by the filesystem, is empty.
+			if err := fsError("readdir", f.name, err); !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+		for _, name := range f.fs.childDirs(f.name) {
+			if !seen[name] {
+				entries = append(entries, dirInfo(name, nil))
+			}
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Name() < entries[j].Name()
+		})
+	}
+	return entries, nil
+}
+
+func (f *dirFile) Stat() (os.FileInfo, error) { return f.info, nil }
+func (f *dirFile) Close() error               { return nil }
+
+func (f *dirFile) Read(p []byte) (int, error) {
+	return 0, &os.PathError{Op: "read", Path: f.name, Err: errors.New("is a directory")}
+}
+
+func (f *dirFile) Seek(offset int64, whence int) (int64, error) {
+	return 0, &os.PathError{Op: "seek", Path: f.name, Err: errors.New("is a directory")}
+}
+
+func (f *dirFile) Write(p []byte) (int, error) {
+	return 0, &os.PathError{Op: "write", Path: f.name, Err: os.ErrPermission}
+}
+
+// readFile is a file of a commit opened for reading. It is read from its
+// offset when it is first read after being opened or seeked, so that range
+// requests only read the range.
+type readFile struct {
+	req    *request
+	file   *pfs.File
+	info   *fileInfo
+	offset int64
+	r      io.ReadCloser
+}
+
+func (f *readFile) Read(p []byte) (int, error) {
+	if f.offset >= f.info.size {
+		return 0, io.EOF
+	}
+	if f.r == nil {
+		ctx, cancel := context.WithCancel(f.req.pc.Ctx())
+		getFileClient, err := f.req.pc.PfsAPIClient.GetFile(ctx, &pfs.GetFileRequest{
+			File:   f.file,
+			Offset: f.offset,
+		})
+		if err != nil {
+			cancel()
+			err = grpcutil.ScrubGRPC(err)
+			f.req.failRead(err)
+			return 0, err
+		}
+		f.r = grpcutil.NewStreamingBytesReader(getFileClient, cancel)
+	}
+	n, err := f.r.Read(p)
+	f.offset += int64(n)
+	f.req.failRead(err)
+	return n, err
+}
+
+func (f *readFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.info.size
+	}
+	if offset < 0 {
+		return f.offset, &os.PathError{Op: "seek", Path: f.file.Path, Err: os.ErrInvalid}
+	}
+	if offset != f.offset {
+		if err := f.closeReader(); err != nil {
+			return f.offset, err
+		}
+		f.offset = offset
+	}
+	return f.offset, nil
+}
+
+func (f *readFile) closeReader() error {
+	if f.r == nil {
+		return nil
+	}
+	err := f.r.Close()
+	f.r = nil
+	return errors.EnsureStack(err)
+}
+
+func (f *readFile) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, &os.PathError{Op: "readdir", Path: f.file.Path, Err: errors.New("not a directory")}
+}
+
+func (f *readFile) Write(p []byte) (int, error) {
+	return 0, &os.PathError{Op: "write", Path: f.file.Path, Err: os.ErrPermission}
+}
+
+func (f *readFile) Stat() (os.FileInfo, error) { return f.info, nil }
+func (f *readFile) Close() error               { return f.closeReader() }
+
+// writeFile is a file of a branch opened for writing. What is written to it
+// is streamed into the commit of the branch's write batch, and the write is
+// done when it is closed, or aborted if what its request writes to it
+// couldn't be read.
+type writeFile struct {
+	req     *request
+	name    string
+	size    int64
+	modTime time.Time
+	w       *io.PipeWriter
+	errC    chan error
+	done    func()
+}
+
+func newWriteFile(batches *writeBatches, req *request, loc *location, opts ...client.PutFileOption) (*writeFile, error) {
+	commit, done, err := batches.commit(req.pc, req.token, loc.commit().Branch)
+	if err != nil {
+		return nil, fsError("open", loc.path, err)
+	}
+	r, w := io.Pipe()
+	f := &writeFile{
+		req:     req,
+		name:    path.Base(loc.path),
+		modTime: time.Now(),
+		w:       w,
+		errC:    make(chan error, 1),
+		done:    done,
+	}
+	go func() {
+		err := req.pc.PutFile(commit, loc.path, r, opts...)
+		r.CloseWithError(err)
+		f.errC <- err
+	}()
+	return f, nil
+}
+
+func (f *writeFile) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.size += int64(n)
+	return n, errors.EnsureStack(err)
+}
+
+func (f *writeFile) Close() error {
+	defer f.done()
+	if err := f.req.readError(); err != nil {
+		// Failing the put aborts it, rather than writing a truncated file.
+		f.w.CloseWithError(err)
+		<-f.errC
+		return &os.PathError{Op: "write", Path: f.name, Err: err}
+	}
+	f.w.Close()
+	return fsError("write", f.name, <-f.errC)
+}
+
+func (f *writeFile) Stat() (os.FileInfo, error) {
+	return &fileInfo{name: f.name, size: f.size, modTime: f.modTime}, nil
+}
+
+func (f *writeFile) Read(p []byte) (int, error) {
+	return 0, &os.PathError{Op: "read", Path: f.name, Err: os.ErrPermission}
+}
+
+func (f *writeFile) Seek(offset int64, whence int) (int64, error) {
+	return 0, &os.PathError{Op: "seek", Path: f.name, Err: os.ErrInvalid}
+}
+
+func (f *writeFile) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, &os.PathError{Op: "readdir", Path: f.name, Err: errors.New("not a directory")}
+}
@@ -0,0 +1,336 @@
+package webdav
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errutil"
+	"github.com/pachyderm/pachyderm/v2/src/pfs"
+	pfsServer "github.com/pachyderm/pachyderm/v2/src/server/pfs"
+	"golang.org/x/net/webdav"
+)
+
+const (
+	branchesDir = "branches"
+	commitsDir  = "commits"
+)
+
+// location is what a path of the WebDAV folder hierarchy refers to.
+type location struct {
+	// depth is the number of elements of the path, 0 for the root
+	depth int
+	repo  string
+	// kind is branchesDir or commitsDir
+	kind string
+	// ref is a branch name or commit ID
+	ref string
+	// path is the path of a file in the commit, it is set if depth > 3
+	path string
+}
+
+func parseLocation(name string) (*location, error) {
+	name = path.Clean("/" + name)
+	if name == "/" {
+		return &location{}, nil
+	}
+	parts := strings.Split(name[1:], "/")
+	loc := &location{depth: len(parts), repo: parts[0]}
+	if loc.depth > 1 {
+		loc.kind = parts[1]
+		if loc.kind != branchesDir && loc.kind != commitsDir {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+	}
+	if loc.depth > 2 {
+		loc.ref = parts[2]
+	}
+	if loc.depth > 3 {
+		loc.path = "/" + strings.Join(parts[3:], "/")
+	}
+	return loc, nil
+}
+
+// commit returns the commit a location at or below a branch or commit
+// directory reads from.
+func (loc *location) commit() *pfs.Commit {
+	if loc.kind == branchesDir {
+		return client.NewCommit(loc.repo, loc.ref, "")
+	}
+	return client.NewCommit(loc.repo, "", loc.ref)
+}
+
+// writable returns whether a location is a file path in a branch.
+func (loc *location) writable() bool {
+	return loc.depth > 3 && loc.kind == branchesDir
+}
+
+// filesystem is the webdav.FileSystem of PFS. PFS has no empty directories,
+// so the directories made in branches are only remembered by the filesystem
+// until files are written in them.
+type filesystem struct {
+	batches *writeBatches
+
+	mu   sync.Mutex
+	dirs map[string]struct{}
+}
+
+func newFilesystem(batches *writeBatches) *filesystem {
+	return &filesystem{
+		batches: batches,
+		dirs:    make(map[string]struct{}),
+	}
+}
+
+func (fs *filesystem) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	loc, err := parseLocation(name)
+	if err != nil {
+		return err
+	}
+	pc := requestFrom(ctx).pc
+	switch {
+	case loc.depth == 1:
+		return fsError("mkdir", name, pc.CreateRepo(loc.repo))
+	case loc.depth == 3 && loc.kind == branchesDir:
+		if _, err := pc.InspectBranch(loc.repo, loc.ref); err == nil {
+			return &os.PathError{Op: "mkdir", Path: name, Err: os.ErrExist}
+		}
+		return fsError("mkdir", name, pc.CreateBranch(loc.repo, loc.ref, "", "", nil))
+	case loc.writable():
+		if _, err := fs.Stat(ctx, path.Dir(name)); err != nil {
+			return err
+		}
+		if _, err := fs.Stat(ctx, name); err == nil {
+			return &os.PathError{Op: "mkdir", Path: name, Err: os.ErrExist}
+		}
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		fs.dirs[path.Clean(name)] = struct{}{}
+		return nil
+	default:
+		return &os.PathError{Op: "mkdir", Path: name, Err: os.ErrPermission}
+	}
+}
+
+func (fs *filesystem) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	loc, err := parseLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	req := requestFrom(ctx)
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		if !loc.writable() {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrPermission}
+		}
+		var opts []client.PutFileOption
+		if flag&os.O_APPEND != 0 {
+			opts = append(opts, client.WithAppendPutFile())
+		}
+		return newWriteFile(fs.batches, req, loc, opts...)
+	}
+	info, fileInfo, err := fs.stat(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if info.dir {
+		return &dirFile{fs: fs, ctx: ctx, name: path.Clean(name), loc: loc, info: info}, nil
+	}
+	return &readFile{req: req, file: fileInfo.File, info: info}, nil
+}
+
+func (fs *filesystem) RemoveAll(ctx context.Context, name string) error {
+	loc, err := parseLocation(name)
+	if err != nil {
+		return err
+	}
+	if !loc.writable() {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrPermission}
+	}
+	req := requestFrom(ctx)
+	info, fileInfo, err := fs.stat(ctx, name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer fs.forgetDirs(name)
+	if fileInfo == nil {
+		// The directory was only made by the filesystem.
+		return nil
+	}
+	commit, done, err := fs.batches.commit(req.pc, req.token, loc.commit().Branch)
+	if err != nil {
+		return fsError("remove", name, err)
+	}
+	defer done()
+	var opts []client.DeleteFileOption
+	if info.dir {
+		opts = append(opts, client.WithRecursiveDeleteFile())
+	}
+	return fsError("remove", name, req.pc.DeleteFile(commit, loc.path, opts...))
+}
+
+// Rename moves a file or directory by copying it to its new path and deleting
+// it from its old path, which are made in one commit if both paths are in the
+// same branch.
+func (fs *filesystem) Rename(ctx context.Context, oldName, newName string) error {
+	oldLoc, err := parseLocation(oldName)
+	if err != nil {
+		return err
+	}
+	newLoc, err := parseLocation(newName)
+	if err != nil {
+		return err
+	}
+	if !oldLoc.writable() || !newLoc.writable() {
+		return &os.PathError{Op: "rename", Path: oldName, Err: os.ErrPermission}
+	}
+	req := requestFrom(ctx)
+	info, fileInfo, err := fs.stat(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	if fileInfo == nil {
+		// The directory was only made by the filesystem.
+		fs.forgetDirs(oldName)
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		fs.dirs[path.Clean(newName)] = struct{}{}
+		return nil
+	}
+	defer fs.forgetDirs(oldName)
+	var deleteOpts []client.DeleteFileOption
+	if info.dir {
+		deleteOpts = append(deleteOpts, client.WithRecursiveDeleteFile())
+	}
+	oldBranch, newBranch := oldLoc.commit().Branch, newLoc.commit().Branch
+	newCommit, newDone, err := fs.batches.commit(req.pc, req.token, newBranch)
+	if err != nil {
+		return fsError("rename", newName, err)
+	}
+	defer newDone()
+	if oldBranch.Repo.Name == newBranch.Repo.Name && oldBranch.Name == newBranch.Name {
+		return fsError("rename", oldName, req.pc.WithModifyFileClient(newCommit, func(mf client.ModifyFile) error {
+			if err := mf.CopyFile(newLoc.path, fileInfo.File); err != nil {
+				return err
+			}
+			return mf.DeleteFile(oldLoc.path, deleteOpts...)
+		}))
+	}
+	if err := req.pc.CopyFile(newCommit, newLoc.path, fileInfo.File.Commit, fileInfo.File.Path); err != nil {
+		return fsError("rename", newName, err)
+	}
+	oldCommit, oldDone, err := fs.batches.commit(req.pc, req.token, oldBranch)
+	if err != nil {
+		return fsError("rename", oldName, err)
+	}
+	defer oldDone()
+	return fsError("rename", oldName, req.pc.DeleteFile(oldCommit, oldLoc.path, deleteOpts...))
+}
+
+func (fs *filesystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	info, _, err := fs.stat(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// stat returns the info of a path, along with the info of its file if it is
+// a path in a commit. The file info is nil for directories which were only
+// made by the filesystem.
+func (fs *filesystem) stat(ctx context.Context, name string) (*fileInfo, *pfs.FileInfo, error) {
+	loc, err := parseLocation(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	pc := requestFrom(ctx).pc
+	switch loc.depth {
+	case 0:
+		return dirInfo("/", nil), nil, nil
+	case 1, 2:
+		repoInfo, err := pc.InspectRepo(loc.repo)
+		if err != nil {
+			return nil, nil, fsError("stat", name, err)
+		}
+		return dirInfo(path.Base(name), repoInfo.Created), nil, nil
+	case 3:
+		if loc.kind == branchesDir {
+			if _, err := pc.InspectBranch(loc.repo, loc.ref); err != nil {
+				return nil, nil, fsError("stat", name, err)
+			}
+			return dirInfo(loc.ref, nil), nil, nil
+		}
+		commitInfo, err := pc.InspectCommit(loc.repo, "", loc.ref)
+		if err != nil {
+			return nil, nil, fsError("stat", name, err)
+		}
+		return dirInfo(loc.ref, commitInfo.Started), nil, nil
+	}
+	fileInfo, err := pc.InspectFile(loc.commit(), loc.path)
+	if err != nil {
+		err = fsError("stat", name, err)
+		if os.IsNotExist(err) && fs.isDir(name) {
+			return dirInfo(path.Base(name), nil), nil, nil
+		}
+		return nil, nil, err
+	}
+	return newFileInfo(fileInfo), fileInfo, nil
+}
+
+// isDir returns whether a directory was made by the filesystem.
+func (fs *filesystem) isDir(name string) bool {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	_, ok := fs.dirs[path.Clean(name)]
+	return ok
+}
+
+// childDirs returns the directories made by the filesystem in a directory.
+func (fs *filesystem) childDirs(name string) []string {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	var children []string
+	for dir := range fs.dirs {
+		if path.Dir(dir) == name {
+			children = append(children, path.Base(dir))
+		}
+	}
+	return children
+}
+
+// forgetDirs forgets the directories made by the filesystem at or below a
+// path.
+func (fs *filesystem) forgetDirs(name string) {
+	name = path.Clean(name)
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	for dir := range fs.dirs {
+		if dir == name || strings.HasPrefix(dir, name+"/") {
+			delete(fs.dirs, dir)
+		}
+	}
+}
+
+// fsError converts the PFS errors of an operation on a path to the os errors
+// the webdav package maps to status codes.
+func fsError(op, name string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case pfsServer.IsRepoNotFoundErr(err), pfsServer.IsBranchNotFoundErr(err),
+		pfsServer.IsCommitNotFoundErr(err), pfsServer.IsFileNotFoundErr(err):
+		return &os.PathError{Op: op, Path: name, Err: os.ErrNotExist}
+	case pfsServer.IsRepoExistsErr(err):
+		return &os.PathError{Op: op, Path: name, Err: os.ErrExist}
+	case auth.IsErrNotAuthorized(err), errutil.IsWriteToOutputBranchError(err),
+		pfsServer.IsCommitOnOutputBranchErr(err):
+		return &os.PathError{Op: op, Path: name, Err: os.ErrPermission}
+	}
+	return err
+}
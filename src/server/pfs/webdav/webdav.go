@@ -0,0 +1,235 @@
+// Package webdav serves PFS over WebDAV, so that it can be browsed and edited
+// with file managers and mounted by the WebDAV clients built into most
+// operating systems.
+//
+// Repos, branches and commits are mapped to a folder hierarchy:
+//
+//	/<repo>/branches/<branch>/<path>
+//	/<repo>/commits/<commit ID>/<path>
+//
+// Commits are read-only. Branches are writable, and the writes made to a
+// branch are batched into one commit, like the writes made to a FUSE mount
+// in Write mode.
+package webdav
+
+import (
+	"context"
+	"fmt"
+	"io"
+	stdlog "log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pachyderm/pachyderm/v2/src/auth"
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/pachsql"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/webdav"
+)
+
+// ClientFactory is a function called by the WebDAV server to create
+// request-scoped pachyderm clients
+type ClientFactory = func() (*client.APIClient, error)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+
+	defaultBatchWindow = 10 * time.Second
+
+	// realm is the realm clients are challenged to authenticate in.
+	realm = "pachyderm"
+)
+
+type controller struct {
+	logger        *logrus.Entry
+	clientFactory ClientFactory
+	batches       *writeBatches
+}
+
+// RouterOption configures the router returned by Router.
+type RouterOption func(*controller)
+
+// WithBatchWindow sets how long the writes made to a branch are batched into
+// one commit after the last of them. A zero window makes a commit per write.
+func WithBatchWindow(window time.Duration) RouterOption {
+	return func(c *controller) {
+		c.batches.window = window
+	}
+}
+
+// Router creates an http handler which serves PFS over WebDAV. Requests are
+// authenticated with a pachyderm auth token, passed as a bearer token or as
+// the password (or the user name, if the password is empty) of HTTP basic
+// auth.
+//
+// This returns an `mux.Router` instance. It is the responsibility of the
+// caller to configure a server to use this Router.
+func Router(clientFactory ClientFactory, db *pachsql.DB, opts ...RouterOption) *mux.Router {
+	logger := logrus.WithFields(logrus.Fields{
+		"source": "webdav",
+	})
+
+	c := &controller{
+		logger:        logger,
+		clientFactory: clientFactory,
+		batches:       newWriteBatches(logger, clientFactory, db, defaultBatchWindow),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	handler := &webdav.Handler{
+		FileSystem: newFilesystem(c.batches),
+		LockSystem: webdav.NewMemLS(),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				logger.Debugf("%s %s: %v", r.Method, r.URL.Path, err)
+			}
+		},
+	}
+	router := mux.NewRouter()
+	router.PathPrefix("/").Handler(handler)
+	router.Use(c.authMiddleware)
+	router.Use(readOnlyMiddleware)
+	return router
+}
+
+// Server runs an HTTP server which serves PFS over WebDAV.
+func Server(port uint16, router *mux.Router) *http.Server {
+	logger := logrus.WithFields(logrus.Fields{
+		"source": "webdav",
+	})
+	return &http.Server{
+		Addr: fmt.Sprintf(":%d", port),
+		// Reads and writes are streamed, so only reading the headers of a
+		// request is bounded.
+		ReadHeaderTimeout: readHeaderTimeout,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger.Infof("http request: %s %s", r.Method, r.RequestURI)
+			router.ServeHTTP(w, r)
+		}),
+		// NOTE: this is not closed. If the standard logger gets customized, this will need to be fixed
+		ErrorLog: stdlog.New(logger.Writer(), "", 0),
+	}
+}
+
+type requestKey struct{}
+
+// request is the pachyderm client and auth token a request is served with.
+type request struct {
+	pc *client.APIClient
+	// token is the auth token of the request, which is empty if auth isn't
+	// activated
+	token string
+
+	mu sync.Mutex
+	// readErr is the first error reading the request body or a file which
+	// the request copies. The webdav package closes the files it writes even
+	// if what it writes to them couldn't be read, so the files check it to
+	// abort their writes instead of truncating.
+	readErr error
+}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// failRead records an error reading what a request writes.
+func (req *request) failRead(err error) {
+	if err == nil || errors.Is(err, io.EOF) {
+		return
+	}
+	req.mu.Lock()
+	defer req.mu.Unlock()
+	if req.readErr == nil {
+		req.readErr = err
+	}
+}
+
+func (req *request) readError() error {
+	req.mu.Lock()
+	defer req.mu.Unlock()
+	return req.readErr
+}
+
+// requestBody records the errors reading a request body in its request.
+type requestBody struct {
+	io.ReadCloser
+	req *request
+}
+
+func (b *requestBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.req.failRead(err)
+	return n, err
+}
+
+// authMiddleware authenticates a request, and challenges the client to
+// authenticate if it can't be.
+func (c *controller) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pc, err := c.clientFactory()
+		if err != nil {
+			c.logger.Errorf("could not create a pach client: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		pc = pc.WithCtx(r.Context())
+		token := requestToken(r)
+		if token != "" {
+			pc.SetAuthToken(token)
+		}
+
+		// WhoAmI will simultaneously check that auth is enabled, and that the
+		// user is who they say they are
+		if _, err := pc.WhoAmI(pc.Ctx(), &auth.WhoAmIRequest{}); err != nil {
+			if !auth.IsErrNotActivated(err) {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q", realm))
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			token = ""
+		}
+		req := &request{pc: pc, token: token}
+		r = r.WithContext(context.WithValue(r.Context(), requestKey{}, req))
+		if r.Body != nil {
+			r.Body = &requestBody{ReadCloser: r.Body, req: req}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readOnlyMiddleware rejects uploads to paths outside of branches, which the
+// webdav package would otherwise report as not found.
+func readOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			if loc, err := parseLocation(r.URL.Path); err == nil && !loc.writable() {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestToken returns the auth token of a request, from its bearer token or
+// its basic auth credentials. Clients which can't leave the user name empty
+// can use any user name and pass the token as the password, and clients which
+// can't leave the password empty can pass the token as the user name.
+func requestToken(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); len(header) > len("Bearer ") && strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+		return strings.TrimSpace(header[len("Bearer "):])
+	}
+	if username, password, ok := r.BasicAuth(); ok {
+		if password != "" {
+			return password
+		}
+		return username
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+package webdav
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/pachyderm/pachyderm/v2/src/client"
+	"github.com/pachyderm/pachyderm/v2/src/internal/backoff"
+	"github.com/pachyderm/pachyderm/v2/src/internal/dockertestenv"
+	"github.com/pachyderm/pachyderm/v2/src/internal/errors"
+	"github.com/pachyderm/pachyderm/v2/src/internal/require"
+	"github.com/pachyderm/pachyderm/v2/src/internal/testpachd"
+	"github.com/pachyderm/pachyderm/v2/src/internal/testutil"
+)
+
+func TestRequestToken(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		setup    func(r *http.Request)
+		expected string
+	}{
+		{"None", func(r *http.Request) {}, ""},
+		{"Bearer", func(r *http.Request) { r.Header.Set("Authorization", "Bearer abc") }, "abc"},
+		{"BasicPassword", func(r *http.Request) { r.SetBasicAuth("user", "abc") }, "abc"},
+		{"BasicUsername", func(r *http.Request) { r.SetBasicAuth("abc", "") }, "abc"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			test.setup(r)
+			require.Equal(t, test.expected, requestToken(r))
+		})
+	}
+}
+
+func TestRequestBody(t *testing.T) {
+	req := &request{}
+	body := &requestBody{ReadCloser: ioutil.NopCloser(strings.NewReader("foo")), req: req}
+	_, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, req.readError())
+
+	// A body which is cut short fails the writes of the request.
+	body = &requestBody{ReadCloser: ioutil.NopCloser(iotest.TimeoutReader(strings.NewReader("foo"))), req: req}
+	_, err = ioutil.ReadAll(body)
+	require.YesError(t, err)
+	require.True(t, errors.Is(req.readError(), iotest.ErrTimeout))
+}
+
+func TestParseLocation(t *testing.T) {
+	loc, err := parseLocation("/repo/branches/master/dir/file")
+	require.NoError(t, err)
+	require.Equal(t, &location{depth: 5, repo: "repo", kind: branchesDir, ref: "master", path: "/dir/file"}, loc)
+	require.True(t, loc.writable())
+
+	loc, err = parseLocation("/repo/commits/abc/")
+	require.NoError(t, err)
+	require.Equal(t, &location{depth: 3, repo: "repo", kind: commitsDir, ref: "abc"}, loc)
+	require.False(t, loc.writable())
+
+	_, err = parseLocation("/repo/tags")
+	require.YesError(t, err)
+}
+
+func TestWebDAV(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	t.Parallel()
+	env := testpachd.NewRealEnv(t, dockertestenv.NewTestDBConfig(t))
+	pachClient := env.PachClient
+	router := Router(func() (*client.APIClient, error) {
+		return pachClient.WithCtx(context.Background()), nil
+	}, env.ServiceEnv.GetDBClient(), WithBatchWindow(time.Second))
+	server := Server(0, router)
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	go func() {
+		server.Serve(listener)
+	}()
+	defer func() {
+		require.NoError(t, server.Shutdown(context.Background()))
+	}()
+	url := fmt.Sprintf("http://127.0.0.1:%d", listener.Addr().(*net.TCPAddr).Port)
+
+	do := func(method, path string, body string, headers ...string) (int, string) {
+		req, err := http.NewRequest(method, url+path, strings.NewReader(body))
+		require.NoError(t, err)
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		content, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(content)
+	}
+
+	repo := testutil.UniqueString("webdav")
+	code, _ := do("MKCOL", "/"+repo, "")
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = do("MKCOL", "/"+repo+"/branches/master", "")
+	require.Equal(t, http.StatusCreated, code)
+	commitInfos, err := pachClient.ListCommitByRepo(client.NewRepo(repo))
+	require.NoError(t, err)
+	initialCommits := len(commitInfos)
+
+	// Writes within the batch window are made in one commit.
+	code, _ = do(http.MethodPut, "/"+repo+"/branches/master/a", "0123456789")
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = do(http.MethodPut, "/"+repo+"/branches/master/dir/b", "bar")
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = do("MOVE", "/"+repo+"/branches/master/dir/b", "", "Destination", url+"/"+repo+"/branches/master/c")
+	require.Equal(t, http.StatusCreated, code)
+	require.NoError(t, backoff.Retry(func() error {
+		commitInfo, err := pachClient.InspectCommit(repo, "master", "")
+		if err != nil {
+			return err
+		}
+		if commitInfo.Finished == nil {
+			return errors.Errorf("commit %s isn't finished", commitInfo.Commit.ID)
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+	commitInfos, err = pachClient.ListCommitByRepo(client.NewRepo(repo))
+	require.NoError(t, err)
+	require.Equal(t, initialCommits+1, len(commitInfos))
+	commitID := commitInfos[0].Commit.ID
+
+	// Files are served from branches and commits, with range reads.
+	code, content := do(http.MethodGet, "/"+repo+"/branches/master/a", "", "Range", "bytes=2-4")
+	require.Equal(t, http.StatusPartialContent, code)
+	require.Equal(t, "234", content)
+	code, content = do(http.MethodGet, "/"+repo+"/commits/"+commitID+"/c", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "bar", content)
+	code, _ = do(http.MethodGet, "/"+repo+"/branches/master/dir/b", "")
+	require.Equal(t, http.StatusNotFound, code)
+
+	code, content = do("PROPFIND", "/"+repo+"/branches/master/", "", "Depth", "1")
+	require.Equal(t, http.StatusMultiStatus, code)
+	require.True(t, strings.Contains(content, "/"+repo+"/branches/master/a"))
+	require.True(t, strings.Contains(content, "/"+repo+"/branches/master/c"))
+
+	// Commits are read-only.
+	code, _ = do(http.MethodPut, "/"+repo+"/commits/"+commitID+"/d", "baz")
+	require.Equal(t, http.StatusForbidden, code)
+
+	code, _ = do(http.MethodDelete, "/"+repo+"/branches/master/a", "")
+	require.Equal(t, http.StatusNoContent, code)
+	code, _ = do(http.MethodGet, "/"+repo+"/branches/master/a", "")
+	require.Equal(t, http.StatusNotFound, code)
+}
@@ -42,6 +42,13 @@ const (
 	// UploadConcurrencyLimitEnvVar is the environment variable for the upload concurrency limit.
 	// EnvVar defined in src/internal/serviceenv/config.go
 	UploadConcurrencyLimitEnvVar = "STORAGE_UPLOAD_CONCURRENCY_LIMIT"
+	// UploadBytesPerSecondEnvVar, DownloadBytesPerSecondEnvVar and
+	// BandwidthBurstBytesEnvVar are the environment variables for the
+	// storage bandwidth limits.
+	// EnvVars defined in src/internal/serviceenv/config.go
+	UploadBytesPerSecondEnvVar   = "STORAGE_UPLOAD_BYTES_PER_SECOND"
+	DownloadBytesPerSecondEnvVar = "STORAGE_DOWNLOAD_BYTES_PER_SECOND"
+	BandwidthBurstBytesEnvVar    = "STORAGE_BANDWIDTH_BURST_BYTES"
 )
 
 // Parameters used when creating the kubernetes replication controller in charge
@@ -463,6 +470,9 @@ func (pc *pipelineController) workerPodSpec(options *workerOptions, pipelineInfo
 func (pc *pipelineController) getStorageEnvVars(pipelineInfo *pps.PipelineInfo) []v1.EnvVar {
 	vars := []v1.EnvVar{
 		{Name: UploadConcurrencyLimitEnvVar, Value: strconv.Itoa(pc.env.Config.StorageUploadConcurrencyLimit)},
+		{Name: UploadBytesPerSecondEnvVar, Value: strconv.FormatInt(pc.env.Config.StorageUploadBytesPerSecond, 10)},
+		{Name: DownloadBytesPerSecondEnvVar, Value: strconv.FormatInt(pc.env.Config.StorageDownloadBytesPerSecond, 10)},
+		{Name: BandwidthBurstBytesEnvVar, Value: strconv.FormatInt(pc.env.Config.StorageBandwidthBurstBytes, 10)},
 		{Name: client.PPSPipelineNameEnv, Value: pipelineInfo.Pipeline.Name},
 	}
 	return vars